@@ -23,6 +23,7 @@ var (
 	activityIssue     string
 	activityTo        string
 	activityCount     int
+	activityPatrolID  string
 )
 
 var activityCmd = &cobra.Command{
@@ -60,12 +61,16 @@ Common options:
   --rig      Which rig the event is about
   --message  Human-readable message
 
+Give patrol_started and patrol_complete the same --patrol-id so
+'gt feed --spans' can pair them when one actor runs overlapping patrols.
+
 Examples:
   gt activity emit patrol_started --rig greenplace --count 3
   gt activity emit polecat_checked --rig greenplace --polecat Toast --status working --issue gp-xyz
   gt activity emit polecat_nudged --rig greenplace --polecat Toast --reason "idle for 10 minutes"
   gt activity emit escalation_sent --rig greenplace --target Toast --to mayor --reason "unresponsive"
-  gt activity emit patrol_complete --rig greenplace --count 3 --message "All polecats healthy"`,
+  gt activity emit patrol_complete --rig greenplace --count 3 --message "All polecats healthy"
+  gt activity emit patrol_started --rig greenplace --patrol-id p-1742`,
 	Args: cobra.ExactArgs(1),
 	RunE: runActivityEmit,
 }
@@ -82,6 +87,7 @@ func init() {
 	activityEmitCmd.Flags().StringVar(&activityIssue, "issue", "", "Issue ID (for polecat_checked)")
 	activityEmitCmd.Flags().StringVar(&activityTo, "to", "", "Escalation target (for escalation_sent: mayor, deacon)")
 	activityEmitCmd.Flags().IntVar(&activityCount, "count", 0, "Polecat count (for patrol events)")
+	activityEmitCmd.Flags().StringVar(&activityPatrolID, "patrol-id", "", "ID pairing patrol_started with its patrol_complete (for patrol events)")
 
 	activityCmd.AddCommand(activityEmitCmd)
 	rootCmd.AddCommand(activityCmd)
@@ -111,6 +117,9 @@ func runActivityEmit(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("--rig is required for %s events", eventType)
 		}
 		payload = events.PatrolPayload(activityRig, activityCount, activityMessage)
+		if activityPatrolID != "" {
+			payload["patrol_id"] = activityPatrolID
+		}
 
 	case events.TypePolecatChecked:
 		if activityRig == "" || activityPolecat == "" {
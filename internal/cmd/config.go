@@ -647,6 +647,10 @@ Supported keys:
   lifecycle.backup.enabled     Enable/disable JSONL + Dolt backups (true/false)
   lifecycle.backup.interval    Backup interval (default: 15m)
 
+  Operational thresholds:
+  operational.<subsystem>.<field>  Any duration or numeric threshold listed by
+                              gt config schema (e.g. operational.daemon.max_dog_pool_size)
+
 Examples:
   gt config set convoy.notify_on_complete true
   gt config set cli_theme dark
@@ -656,7 +660,8 @@ Examples:
   gt config set maintenance.window 03:00
   gt config set maintenance.interval daily
   gt config set lifecycle.reaper.delete_age 336h
-  gt config set lifecycle.compactor.threshold 1000`,
+  gt config set lifecycle.compactor.threshold 1000
+  gt config set operational.session.claude_start_timeout 90s`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -812,7 +817,10 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return setLifecycleConfig(townRoot, key, value)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  polecat.target_clean_policy\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if path, ok := strings.CutPrefix(key, "operational."); ok {
+			return setOperationalConfig(townRoot, townSettings.Operational, path, value)
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  polecat.target_clean_policy\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*\n  operational.*", key)
 	}
 
 	if err := config.SaveTownSettings(settingsPath, townSettings); err != nil {
@@ -911,6 +919,22 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// setOperationalConfig sets one operational threshold (path below
+// "operational", e.g. "daemon.max_dog_pool_size") in settings/config.json.
+func setOperationalConfig(townRoot string, c *config.OperationalConfig, path, value string) error {
+	if c == nil {
+		c = &config.OperationalConfig{}
+	}
+	if err := config.SetOperationalField(c, path, value); err != nil {
+		return err
+	}
+	if err := config.SaveOperationalConfig(townRoot, c); err != nil {
+		return fmt.Errorf("saving town settings: %w", err)
+	}
+	fmt.Printf("Set %s = %s\n", style.Bold.Render("operational."+path), value)
+	return nil
+}
+
 // setMaintenanceConfig sets a maintenance.* key in daemon.json (patrol config).
 func setMaintenanceConfig(townRoot, key, value string) error {
 	patrolConfig := daemon.LoadPatrolConfig(townRoot)
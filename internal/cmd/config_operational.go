@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	configEnvJSON   bool
+	configDumpJSON  bool
+	configDriftJSON bool
+)
+
+// configEnvCmd lists the environment variables that override operational thresholds.
+var configEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "List environment overrides for operational thresholds",
+	Long: `List the environment variables that override operational thresholds.
+
+Each threshold under "operational" in settings/config.json can be overridden
+by an environment variable named GT_<SUBSYSTEM>_<FIELD>. The environment
+value takes precedence over the file value; a malformed value is ignored.
+
+The list is generated from the config struct tags, so it always matches
+the thresholds this binary understands.
+
+Examples:
+  gt config env
+  gt config env --json
+  GT_SESSION_CLAUDE_START_TIMEOUT=120s gt daemon start`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEnv,
+}
+
+func runConfigEnv(cmd *cobra.Command, args []string) error {
+	vars := config.OperationalEnvVars()
+
+	if configEnvJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(vars)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Operational environment overrides"))
+	for _, v := range vars {
+		marker := ""
+		if val, ok := os.LookupEnv(v.Name); ok {
+			marker = style.Bold.Render(" = " + val)
+		}
+		fmt.Printf("  %-50s %s%s\n", v.Name, style.Dim.Render(v.Accessor), marker)
+	}
+	return nil
+}
+
+// configSchemaCmd prints every operational threshold with its kind and default.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the operational threshold schema as JSON",
+	Long: `Print a machine-readable schema of every operational threshold.
+
+Each entry names the subsystem and JSON field under "operational" in
+settings/config.json, its kind (duration, count, or float), the compiled-in
+default, and the environment variable that overrides it.
+
+Examples:
+  gt config schema
+  gt config schema | jq '.[] | select(.subsystem == "daemon")'`,
+	Args: cobra.NoArgs,
+	RunE: runConfigSchema,
+}
+
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(config.OperationalSchema())
+}
+
+// configDumpCmd prints the fully-resolved operational thresholds.
+var configDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the effective operational configuration",
+	Long: `Print every operational threshold as it is actually resolved.
+
+Each value is annotated with its source:
+  env      GT_<SUBSYSTEM>_<FIELD> environment variable
+  file     settings/config.json (under "operational", plus the
+           GT_PROFILE entry from "profiles" if set), layered over
+           the machine-wide ~/.config/gastown/config.json
+  default  compiled-in default
+
+Malformed file or environment values are ignored, so they show up with
+the source that actually supplied the value. Paste the output into bug
+reports to show exactly which thresholds were in effect.
+
+Examples:
+  gt config dump
+  gt config dump --json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDump,
+}
+
+func runConfigDump(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	values := config.EffectiveOperationalValues(config.LoadOperationalConfigLayered(townRoot))
+
+	if configDumpJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(values)
+	}
+
+	fmt.Printf("%s %s\n", style.Bold.Render("Effective operational config"),
+		style.Dim.Render("("+config.TownSettingsPath(townRoot)+")"))
+	if profile := os.Getenv(config.ProfileEnvVar); profile != "" {
+		fmt.Printf("Profile: %s\n", profile)
+	}
+	fmt.Println()
+	for _, v := range values {
+		source := style.Dim.Render("(" + string(v.Source) + ")")
+		if v.Source != config.SourceDefault {
+			source = style.Bold.Render("(" + string(v.Source) + ")")
+		}
+		fmt.Printf("  %-50s %-12s %s\n", v.Path, v.Value, source)
+	}
+	return nil
+}
+
+// configDriftCmd compares the daemon's startup config with the files on disk.
+var configDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Show startup-only thresholds the running daemon has not picked up",
+	Long: `Compare the operational config the running daemon loaded at startup
+with the current settings files, and list the thresholds the daemon only
+reads at startup (the Dolt pool settings) whose effective value differs.
+Restart the daemon to apply them.
+
+The daemon re-reads every other threshold when it uses it, so changing
+one is not drift. Values are compared after defaults are applied, so
+setting a threshold to its default is not drift either. Environment
+overrides in this shell apply to both sides.
+
+Exits 1 if any threshold has drifted.
+
+Examples:
+  gt config drift
+  gt config drift --json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDrift,
+}
+
+func runConfigDrift(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+
+	state, err := daemon.LoadState(townRoot)
+	if err != nil {
+		return fmt.Errorf("loading daemon state: %w", err)
+	}
+	if !state.Running || state.Operational == nil {
+		return fmt.Errorf("no running daemon config recorded in %s (start the daemon with 'gt daemon start')", daemon.StateFile(townRoot))
+	}
+
+	diffs := daemon.StartupDrift(state.Operational, config.LoadOperationalConfigLayered(townRoot))
+
+	out := cmd.OutOrStdout()
+	if configDriftJSON {
+		if diffs == nil {
+			diffs = []config.ConfigFieldDiff{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diffs); err != nil {
+			return err
+		}
+	} else if len(diffs) == 0 {
+		fmt.Fprintf(out, "%s Daemon config matches %s\n", style.Bold.Render("✓"), config.TownSettingsPath(townRoot))
+	} else {
+		fmt.Fprintf(out, "%s %d startup-only thresholds changed since the daemon started %s\n\n",
+			style.Warning.Render("⚠"), len(diffs), style.Dim.Render("("+state.StartedAt.Format(time.RFC3339)+")"))
+		for _, d := range diffs {
+			fmt.Fprintf(out, "  %-50s %s → %s\n", d.Path, d.Old, d.New)
+		}
+		fmt.Fprintf(out, "\n  %s\n", style.Dim.Render("Restart the daemon to apply them: gt daemon restart"))
+	}
+
+	if len(diffs) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// configValidateCmd checks the operational config for bad values and unknown keys.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check operational thresholds for bad values and unknown keys",
+	Long: `Check the "operational" section of settings/config.json.
+
+Reports every threshold with a malformed or negative value, and every key
+the config does not recognize, such as a misspelled field name. Normal
+loading ignores both and falls back to defaults, so a typo otherwise goes
+unnoticed.
+
+Exits 1 if any problem is found.
+
+Examples:
+  gt config validate`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil {
+		return fmt.Errorf("finding town root: %w", err)
+	}
+	settingsPath := config.TownSettingsPath(townRoot)
+
+	_, problems, err := config.LoadOperationalConfigStrict(townRoot)
+	if err != nil {
+		return err
+	}
+	unknown, err := config.UnknownOperationalKeys(settingsPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", settingsPath, err)
+	}
+	problems = append(problems, unknown...)
+
+	out := cmd.OutOrStdout()
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "%s Operational config in %s is valid\n", style.Bold.Render("✓"), settingsPath)
+		return nil
+	}
+	fmt.Fprintf(out, "%s %d problems in %s (defaults are used instead):\n\n",
+		style.Warning.Render("⚠"), len(problems), settingsPath)
+	for _, p := range problems {
+		fmt.Fprintf(out, "  operational.%v\n", p)
+	}
+	return NewSilentExit(1)
+}
+
+func init() {
+	configEnvCmd.Flags().BoolVar(&configEnvJSON, "json", false, "Output as JSON")
+	configCmd.AddCommand(configEnvCmd)
+	configCmd.AddCommand(configSchemaCmd)
+
+	configDumpCmd.Flags().BoolVar(&configDumpJSON, "json", false, "Output as JSON")
+	configCmd.AddCommand(configDumpCmd)
+
+	configDriftCmd.Flags().BoolVar(&configDriftJSON, "json", false, "Output as JSON")
+	configCmd.AddCommand(configDriftCmd)
+
+	configCmd.AddCommand(configValidateCmd)
+}
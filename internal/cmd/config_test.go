@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -797,6 +798,27 @@ func TestConfigSetGet(t *testing.T) {
 		}
 	})
 
+	t.Run("set operational threshold", func(t *testing.T) {
+		townRoot := setupTestTownForConfig(t)
+
+		originalWd, _ := os.Getwd()
+		defer os.Chdir(originalWd)
+		if err := os.Chdir(townRoot); err != nil {
+			t.Fatalf("chdir: %v", err)
+		}
+
+		cmd := &cobra.Command{}
+		if err := runConfigSet(cmd, []string{"operational.daemon.max_dog_pool_size", "6"}); err != nil {
+			t.Fatalf("runConfigSet failed: %v", err)
+		}
+		if got := config.LoadOperationalConfig(townRoot).GetDaemonConfig().MaxDogPoolSizeV(); got != 6 {
+			t.Errorf("max_dog_pool_size = %d, want 6", got)
+		}
+		if err := runConfigSet(cmd, []string{"operational.daemon.max_dog_pool_size", "30s"}); err == nil {
+			t.Error("expected error for a duration on an integer threshold")
+		}
+	})
+
 	t.Run("set cli_theme rejects invalid value", func(t *testing.T) {
 		townRoot := setupTestTownForConfig(t)
 
@@ -1002,3 +1024,48 @@ func TestParseBool(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	townRoot := setupTestTownForConfig(t)
+	settingsPath := config.TownSettingsPath(townRoot)
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	run := func(settings string) (string, error) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(settingsPath, []byte(settings), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&out)
+		err := runConfigValidate(cmd, nil)
+		return out.String(), err
+	}
+
+	out, err := run(`{"operational": {"daemon": {"max_dog_pool_size": 6, "max_dog_pools": 7}}}`)
+	if code, ok := IsSilentExit(err); !ok || code != 1 {
+		t.Fatalf("err = %v, want silent exit 1", err)
+	}
+	if !strings.Contains(out, "operational.daemon.max_dog_pools") {
+		t.Errorf("output missing the misspelled key:\n%s", out)
+	}
+	if strings.Contains(out, "max_dog_pool_size") {
+		t.Errorf("output reports the valid key:\n%s", out)
+	}
+
+	out, err = run(`{"operational": {"daemon": {"max_dog_pool_size": 6}}}`)
+	if err != nil {
+		t.Fatalf("valid config: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "is valid") {
+		t.Errorf("output = %q, want a valid message", out)
+	}
+}
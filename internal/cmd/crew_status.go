@@ -164,7 +164,7 @@ func buildCrewStatusItems(r *rig.Rig, workers []*crew.CrewWorker, t *tmux.Tmux)
 		// Git status
 		crewGit := git.NewGit(w.ClonePath)
 		gitStatus, _ := crewGit.Status()
-		branch, _ := crewGit.CurrentBranch()
+		branch, _, _ := crewGit.CurrentBranch()
 
 		gitClean := true
 		var modified, untracked []string
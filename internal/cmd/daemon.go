@@ -144,11 +144,38 @@ Examples:
 	RunE: runDaemonClearBackoff,
 }
 
+var daemonReapWispsCmd = &cobra.Command{
+	Use:   "reap-wisps",
+	Short: "Run one wisp_reaper cycle now",
+	Long: `Run a single wisp_reaper cycle synchronously and print the results.
+
+The cycle runs in this process with the wisp_reaper settings from
+mayor/daemon.json (max ages, reap status, alert threshold); the daemon
+does not need to be running. Databases are discovered from the Dolt
+server unless --database is given, which overrides both discovery and
+the configured list.
+
+Exits non-zero if the open wisp count after the cycle exceeds the
+alert threshold.
+
+Examples:
+  gt daemon reap-wisps                       # Reap all databases
+  gt daemon reap-wisps --dry-run             # Report only, change nothing
+  gt daemon reap-wisps --database gastown    # Reap one database`,
+	RunE: runDaemonReapWisps,
+}
+
 var (
 	daemonLogLines  int
 	daemonLogFollow bool
+
+	daemonReapWispsDryRun    bool
+	daemonReapWispsDatabases []string
 )
 
+// runWispReaperOnce is replaced in tests.
+var runWispReaperOnce = daemon.RunWispReaperOnce
+
 func init() {
 	daemonCmd.AddCommand(daemonStartCmd)
 	daemonCmd.AddCommand(daemonStopCmd)
@@ -158,10 +185,13 @@ func init() {
 	daemonCmd.AddCommand(daemonEnableSupervisorCmd)
 	daemonCmd.AddCommand(daemonClearBackoffCmd)
 	daemonCmd.AddCommand(daemonRotateLogsCmd)
+	daemonCmd.AddCommand(daemonReapWispsCmd)
 
 	daemonLogsCmd.Flags().IntVarP(&daemonLogLines, "lines", "n", 50, "Number of lines to show")
 	daemonLogsCmd.Flags().BoolVarP(&daemonLogFollow, "follow", "f", false, "Follow log output")
 	daemonRotateLogsCmd.Flags().BoolVar(&daemonRotateLogsForce, "force", false, "Rotate all logs regardless of size")
+	daemonReapWispsCmd.Flags().BoolVar(&daemonReapWispsDryRun, "dry-run", false, "Report what would be reaped without changing anything")
+	daemonReapWispsCmd.Flags().StringSliceVar(&daemonReapWispsDatabases, "database", nil, "Database to reap (repeatable or comma-separated; default: discover)")
 
 	rootCmd.AddCommand(daemonCmd)
 }
@@ -293,6 +323,13 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 						style.Dim.Render("gt daemon stop && gt daemon start"))
 				}
 			}
+
+			if len(state.Patrols) > 0 {
+				fmt.Printf("\n  Patrols (as of last heartbeat):\n")
+				for _, p := range state.Patrols {
+					fmt.Printf("    %s\n", formatPatrolStatus(p))
+				}
+			}
 		}
 	} else {
 		fmt.Printf("%s Daemon is %s\n",
@@ -304,6 +341,25 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatPatrolStatus renders one patrol's line for gt daemon status.
+func formatPatrolStatus(p daemon.PatrolStatus) string {
+	if !p.Enabled {
+		return fmt.Sprintf("%-22s %s", p.Name, style.Dim.Render("disabled"))
+	}
+	line := fmt.Sprintf("%-22s every %-8s", p.Name, p.Interval)
+	if p.NeverRun() {
+		return line + " " + style.Dim.Render("never run")
+	}
+	line += " last run " + p.LastRun.Format("15:04:05")
+	if p.LastError != "" {
+		return line + " " + style.Bold.Render("⚠ "+p.LastError)
+	}
+	if p.LastResult != "" {
+		line += " — " + p.LastResult
+	}
+	return line
+}
+
 // getBinaryModTime returns the modification time of the current executable
 func getBinaryModTime() (time.Time, error) {
 	exePath, err := os.Executable()
@@ -476,3 +532,42 @@ func runDaemonRotateLogs(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runDaemonReapWisps(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	res, err := runWispReaperOnce(townRoot, daemon.WispReaperOnceOptions{
+		DryRun:    daemonReapWispsDryRun,
+		Databases: daemonReapWispsDatabases,
+	})
+	if err != nil {
+		return fmt.Errorf("wisp_reaper: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	verb := "reaped"
+	if res.DryRun {
+		verb = "would reap"
+		fmt.Fprintf(out, "%s\n", style.Dim.Render("DRY RUN — no changes made"))
+	}
+	for _, db := range res.Databases {
+		fmt.Fprintf(out, "  %s: %s %d, purged %d, auto-closed %d, %d open\n",
+			db.Database, verb, db.Reaped, db.Purged+db.MailPurged, db.AutoClosed+db.PluginClosed+db.DispatchClosed, db.Open)
+		for _, e := range db.Errors {
+			fmt.Fprintf(out, "    %s %s\n", style.Warning.Render("⚠"), e)
+		}
+	}
+	t := res.Totals
+	fmt.Fprintf(out, "%s %d databases: %s %d, purged %d, auto-closed %d, %d open\n",
+		style.Bold.Render("✓"), len(res.Databases), verb, t.Reaped, t.Purged+t.MailPurged, t.AutoClosed+t.PluginClosed+t.DispatchClosed, t.Open)
+
+	if res.Alert {
+		fmt.Fprintf(out, "%s %d open wisps exceed alert threshold %d\n",
+			style.Warning.Render("⚠"), t.Open, res.AlertThreshold)
+		return NewSilentExit(1)
+	}
+	return nil
+}
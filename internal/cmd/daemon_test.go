@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/daemon"
 )
 
 func TestReadDaemonStartupFailure(t *testing.T) {
@@ -41,3 +46,87 @@ func TestReadDaemonStartupFailure_MissingPIDReturnsEmpty(t *testing.T) {
 		t.Fatalf("readDaemonStartupFailure() = %q, want empty string", got)
 	}
 }
+
+func TestFormatPatrolStatus(t *testing.T) {
+	ran := time.Date(2026, 3, 28, 22, 5, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		status daemon.PatrolStatus
+		want   string
+	}{
+		{"disabled", daemon.PatrolStatus{Name: "dolt_backup"}, "disabled"},
+		{"never run", daemon.PatrolStatus{Name: "wisp_reaper", Enabled: true, Interval: 30 * time.Minute}, "never run"},
+		{"result", daemon.PatrolStatus{Name: "wisp_reaper", Enabled: true, Interval: 30 * time.Minute, LastRun: ran, LastResult: "reaped=2"}, "last run 22:05:00 — reaped=2"},
+		{"error", daemon.PatrolStatus{Name: "wisp_reaper", Enabled: true, Interval: 30 * time.Minute, LastRun: ran, LastResult: "reaped=0", LastError: "hq: timeout"}, "hq: timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatPatrolStatus(tt.status)
+			if !strings.HasPrefix(got, tt.status.Name) || !strings.Contains(got, tt.want) {
+				t.Errorf("formatPatrolStatus() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDaemonReapWisps(t *testing.T) {
+	townRoot := setupTestTownForConfig(t)
+	t.Chdir(townRoot)
+
+	origRun, origDryRun, origDBs := runWispReaperOnce, daemonReapWispsDryRun, daemonReapWispsDatabases
+	t.Cleanup(func() {
+		runWispReaperOnce, daemonReapWispsDryRun, daemonReapWispsDatabases = origRun, origDryRun, origDBs
+	})
+
+	var gotOpts daemon.WispReaperOnceOptions
+	stub := func(open, threshold int) func(string, daemon.WispReaperOnceOptions) (*daemon.WispReaperOnceResult, error) {
+		return func(root string, opts daemon.WispReaperOnceOptions) (*daemon.WispReaperOnceResult, error) {
+			gotOpts = opts
+			res := &daemon.WispReaperResult{
+				DryRun: opts.DryRun,
+				Databases: []daemon.WispReaperDBResult{
+					{Database: "beads", WispReaperCounts: daemon.WispReaperCounts{Reaped: 2, Open: open - 1}},
+					{Database: "gastown", WispReaperCounts: daemon.WispReaperCounts{Reaped: 3, Open: 1}, Errors: []string{"purge: boom"}},
+				},
+				Totals: daemon.WispReaperCounts{Reaped: 5, Open: open},
+			}
+			return &daemon.WispReaperOnceResult{WispReaperResult: res, AlertThreshold: threshold, Alert: open > threshold}, nil
+		}
+	}
+
+	t.Run("prints per-database and total results", func(t *testing.T) {
+		runWispReaperOnce = stub(10, 500)
+		daemonReapWispsDryRun = true
+		daemonReapWispsDatabases = []string{"beads", "gastown"}
+
+		var out bytes.Buffer
+		daemonReapWispsCmd.SetOut(&out)
+		if err := runDaemonReapWisps(daemonReapWispsCmd, nil); err != nil {
+			t.Fatalf("runDaemonReapWisps: %v", err)
+		}
+		if !gotOpts.DryRun || strings.Join(gotOpts.Databases, ",") != "beads,gastown" {
+			t.Errorf("options = %+v, want dry run with the --database filter", gotOpts)
+		}
+		for _, want := range []string{"beads: would reap 2", "gastown: would reap 3", "purge: boom", "2 databases: would reap 5", "10 open"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("output missing %q:\n%s", want, out.String())
+			}
+		}
+	})
+
+	t.Run("exits non-zero above the alert threshold", func(t *testing.T) {
+		runWispReaperOnce = stub(600, 500)
+		daemonReapWispsDryRun = false
+		daemonReapWispsDatabases = nil
+
+		var out bytes.Buffer
+		daemonReapWispsCmd.SetOut(&out)
+		err := runDaemonReapWisps(daemonReapWispsCmd, nil)
+		if code, ok := IsSilentExit(err); !ok || code != 1 {
+			t.Fatalf("err = %v, want silent exit 1", err)
+		}
+		if !strings.Contains(out.String(), "600 open wisps exceed alert threshold 500") {
+			t.Errorf("output missing alert:\n%s", out.String())
+		}
+	})
+}
@@ -90,7 +90,8 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 			fmt.Fprintf(cmd.ErrOrStderr(), "warning: loading town settings: %v (using defaults)\n", loadErr)
 		}
 
-		handler, err = web.NewDashboardMux(fetcher, webCfg)
+		webThresholds := config.LoadOperationalConfigLayered(townRoot).GetWebConfig()
+		handler, err = web.NewDashboardMux(fetcher, webCfg, webThresholds)
 		if err != nil {
 			return fmt.Errorf("creating dashboard handler: %w", err)
 		}
@@ -568,7 +568,8 @@ func startDeaconSession(t *tmux.Tmux, sessionName, agentOverride string) error {
 	_ = t.ConfigureGasTownSession(sessionName, theme, "", "Deacon", "health-check")
 
 	// Wait for Claude to start
-	if err := t.WaitForCommand(sessionName, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
+	startTimeout := config.LoadOperationalConfigLayered(townRoot).SessionConfigForRole("deacon").ClaudeStartTimeoutD()
+	if err := t.WaitForCommand(sessionName, constants.SupportedShells, startTimeout); err != nil {
 		return fmt.Errorf("waiting for deacon to start: %w", err)
 	}
 
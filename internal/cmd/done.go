@@ -249,7 +249,11 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			return fmt.Errorf("cannot determine branch: GT_BRANCH not set and working directory unavailable")
 		}
 		var err error
-		branch, err = g.CurrentBranch()
+		var onBranch bool
+		branch, onBranch, err = g.CurrentBranch()
+		if err == nil && !onBranch {
+			return fmt.Errorf("cannot determine branch: %w at %s; check out the polecat branch or set GT_BRANCH", git.ErrDetachedHead, branch)
+		}
 		if err != nil {
 			// Last resort: try to extract from polecat name (polecat/<name>-<suffix>)
 			if polecatName := os.Getenv("GT_POLECAT"); polecatName != "" {
@@ -1540,7 +1544,7 @@ notifyWitness:
 	// per task, reduces token waste, and eliminates stale state bugs.
 	// Must be the LAST thing gt done does — everything above must complete first.
 	if isPolecat {
-		daemonCfg := config.LoadOperationalConfig(townRoot).GetDaemonConfig()
+		daemonCfg := config.LoadOperationalConfigLayered(townRoot).GetDaemonConfig()
 		if daemonCfg.PolecatSelfTerminate != nil && *daemonCfg.PolecatSelfTerminate {
 			fmt.Printf("%s Self-terminating session (polecat_self_terminate=true)\n", style.Bold.Render("✓"))
 			sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
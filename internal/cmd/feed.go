@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/tui/feed"
+	"github.com/steveyegge/gastown/internal/ui"
 	"github.com/steveyegge/gastown/internal/workspace"
 	"golang.org/x/term"
 )
@@ -18,12 +20,21 @@ var (
 	feedFollow   bool
 	feedLimit    int
 	feedSince    string
+	feedUntil    string
 	feedMol      string
-	feedType     string
+	feedTypes    []string
+	feedActors   []string
 	feedRig      string
 	feedNoFollow bool
 	feedWindow   bool
 	feedPlain    bool
+	feedJSON     bool
+	feedNoColor  bool
+	feedRotated  bool
+	feedCollapse bool
+	feedSpans    bool
+	feedStdin    bool
+	feedTheme    string
 	feedProblems bool
 )
 
@@ -33,12 +44,21 @@ func init() {
 	feedCmd.Flags().BoolVarP(&feedFollow, "follow", "f", false, "Stream events in real-time (default when no other flags)")
 	feedCmd.Flags().BoolVar(&feedNoFollow, "no-follow", false, "Show events once and exit")
 	feedCmd.Flags().IntVarP(&feedLimit, "limit", "n", 100, "Maximum number of events to show")
-	feedCmd.Flags().StringVar(&feedSince, "since", "", "Show events since duration (e.g., 5m, 1h, 30s)")
+	feedCmd.Flags().StringVar(&feedSince, "since", "", "Show events since a duration ago (e.g., 5m, 1h) or RFC3339 time")
+	feedCmd.Flags().StringVar(&feedUntil, "until", "", "Show events until a duration ago (e.g., 30m) or RFC3339 time")
 	feedCmd.Flags().StringVar(&feedMol, "mol", "", "Filter by molecule/issue ID prefix")
-	feedCmd.Flags().StringVar(&feedType, "type", "", "Filter by event type (create, update, delete, comment)")
+	feedCmd.Flags().StringSliceVar(&feedTypes, "type", nil, "Filter by event type; repeatable or comma-separated (e.g. patrol_complete,sling)")
+	feedCmd.Flags().StringSliceVar(&feedActors, "actor", nil, "Filter by actor; repeatable or comma-separated (e.g. hq-deacon)")
 	feedCmd.Flags().StringVar(&feedRig, "rig", "", "Filter events by rig name")
 	feedCmd.Flags().BoolVarP(&feedWindow, "window", "w", false, "Open in dedicated tmux window (creates 'feed' window)")
 	feedCmd.Flags().BoolVar(&feedPlain, "plain", false, "Use plain text output (bd activity) instead of TUI")
+	feedCmd.Flags().BoolVar(&feedJSON, "json", false, "Print events as JSON lines (implies --plain)")
+	feedCmd.Flags().BoolVar(&feedNoColor, "no-color", false, "Disable colored plain output (also honors NO_COLOR)")
+	feedCmd.Flags().BoolVar(&feedRotated, "include-rotated", false, "Also read rotated logs (.events.jsonl.1, .2, ...)")
+	feedCmd.Flags().BoolVar(&feedStdin, "stdin", false, "Read events from stdin instead of .events.jsonl (implies --plain --no-follow)")
+	feedCmd.Flags().BoolVar(&feedCollapse, "collapse", false, "Merge runs of identical events into one line with a count (plain output)")
+	feedCmd.Flags().BoolVar(&feedSpans, "spans", false, "Print each patrol's start and completion as one line with its duration (plain output)")
+	feedCmd.Flags().StringVar(&feedTheme, "theme", "emoji", "Event symbols for plain output: emoji or ascii")
 	feedCmd.Flags().BoolVarP(&feedProblems, "problems", "p", false, "Start in problems view (shows stuck agents)")
 }
 
@@ -66,13 +86,20 @@ The feed combines multiple event sources:
   - Beads activity: Issue creates, updates, completions (from bd activity, when available)
   - Convoy status: In-progress and recently-landed convoys (refreshes every 10s)
 
-Use --plain for simple text output (reads .events.jsonl directly).
+Use --plain for simple text output (reads .events.jsonl directly), or
+--json for one JSON object per event (time, type, actor, message, payload).
+With --plain, --collapse merges runs of identical events (e.g. repeated
+nudges) into one line ending in "(xN, first-last)". --stdin prints events
+piped in instead, e.g. cat old.jsonl | gt feed --stdin. --spans pairs
+each patrol_started with its patrol_complete (same actor and patrol_id) and
+prints one line with the elapsed time, or "(incomplete)" if it hasn't
+finished.
 
 Tmux Integration:
   Use --window to open the feed in a dedicated tmux window named 'feed'.
   This creates a persistent window you can cycle to with C-b n/p.
 
-Event symbols:
+Event symbols (use --theme ascii in terminals without emoji):
   +  created/bonded    - New issue or molecule created
   →  in_progress       - Work started on an issue
   ✓  completed         - Issue closed or step completed
@@ -103,11 +130,22 @@ Examples:
   gt feed --plain               # Plain text output (bd activity)
   gt feed --window              # Open in dedicated tmux window
   gt feed --since 1h            # Events from last hour
+  gt feed --plain --since 2026-05-01T12:00:00Z --until 2026-05-01T13:00:00Z
+  gt feed --plain --type patrol_complete --actor hq-deacon   # Deacon patrol completions
+  gt feed --json --no-follow | jq .type                        # JSON lines for tooling
   gt feed --rig greenplace      # Use gastown rig's beads`,
 	RunE: runFeed,
 }
 
 func runFeed(cmd *cobra.Command, args []string) error {
+	// Piped events need no workspace, and there is no file to follow.
+	if feedStdin {
+		if feedFollow || feedWindow {
+			return fmt.Errorf("--stdin cannot be combined with --follow or --window")
+		}
+		return runFeedDirect("")
+	}
+
 	// Must be in a Gas Town workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -127,7 +165,7 @@ func runFeed(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use TUI by default if running in a terminal and not --plain
-	useTUI := !feedPlain && term.IsTerminal(int(os.Stdout.Fd()))
+	useTUI := !feedPlain && !feedJSON && term.IsTerminal(int(os.Stdout.Fd()))
 
 	if useTUI {
 		// TUI mode: resolve --rig to a beads directory for BdActivitySource
@@ -188,12 +226,20 @@ func buildFeedArgs() []string {
 		args = append(args, "--since", feedSince)
 	}
 
+	if feedUntil != "" {
+		args = append(args, "--until", feedUntil)
+	}
+
 	if feedMol != "" {
 		args = append(args, "--mol", feedMol)
 	}
 
-	if feedType != "" {
-		args = append(args, "--type", feedType)
+	for _, t := range feedTypes {
+		args = append(args, "--type", t)
+	}
+
+	for _, a := range feedActors {
+		args = append(args, "--actor", a)
 	}
 
 	if feedRig != "" {
@@ -204,7 +250,7 @@ func buildFeedArgs() []string {
 }
 
 // runFeedDirect prints events from .events.jsonl to stdout.
-// Supports --follow for tailing, and --since/--mol/--type for filtering.
+// Supports --follow for tailing, and --since/--until/--mol/--type/--actor for filtering.
 // townRoot is the resolved workspace root (incorporates --rig if set).
 func runFeedDirect(townRoot string) error {
 	// Determine follow behavior:
@@ -217,18 +263,63 @@ func runFeedDirect(townRoot string) error {
 		shouldFollow = term.IsTerminal(int(os.Stdout.Fd()))
 	}
 
+	now := time.Now()
+	since, err := parseFeedTime("--since", feedSince, now)
+	if err != nil {
+		return err
+	}
+	until, err := parseFeedTime("--until", feedUntil, now)
+	if err != nil {
+		return err
+	}
+
+	theme, err := feed.LookupSymbolTheme(feedTheme)
+	if err != nil {
+		return err
+	}
+
 	opts := feed.PrintOptions{
 		Limit:  feedLimit,
 		Follow: shouldFollow,
-		Since:  feedSince,
+		Since:  since,
+		Until:  until,
 		Mol:    feedMol,
-		Type:   feedType,
+		Types:  feedTypes,
+		Actors: feedActors,
 		Rig:    feedRig,
+		// Color only when stdout is a TTY and NO_COLOR/CLICOLOR allow it.
+		Color:          !feedNoColor && ui.ShouldUseColor(),
+		IncludeRotated: feedRotated,
+		Collapse:       feedCollapse,
+		Spans:          feedSpans,
+		Theme:          theme,
+	}
+	if feedJSON {
+		opts.Format = feed.FormatJSON
 	}
 
+	if feedStdin {
+		opts.Follow = false
+		return feed.PrintGtEventsFromReader(os.Stdin, opts)
+	}
 	return feed.PrintGtEvents(townRoot, opts)
 }
 
+// parseFeedTime parses a --since/--until value: either a duration meaning
+// that long before now ("2h"), or an RFC3339 timestamp. Empty means unbounded.
+func parseFeedTime(flag, value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q: want a duration (e.g. 2h) or RFC3339 time", flag, value)
+}
+
 // runFeedTUI runs the interactive TUI feed.
 func runFeedTUI(workDir string, problemsView bool) error {
 	// Must be in a Gas Town workspace
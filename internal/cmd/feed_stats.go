@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/tui/feed"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	feedStatsSince  string
+	feedStatsUntil  string
+	feedStatsTypes  []string
+	feedStatsActors []string
+	feedStatsRig    string
+	feedStatsJSON   bool
+)
+
+var feedStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Count feed events by type and actor",
+	Long: `Count events in .events.jsonl, grouped by event type and then actor.
+
+Takes the same filters as gt feed. Events without an actor are counted
+under "system".
+
+Examples:
+  gt feed stats --since 1h                       # Everything in the last hour
+  gt feed stats --since 1h --type merge_failed   # Merge failures by actor
+  gt feed stats --json | jq '.merge_failed'`,
+	RunE: runFeedStats,
+}
+
+func init() {
+	feedCmd.AddCommand(feedStatsCmd)
+
+	feedStatsCmd.Flags().StringVar(&feedStatsSince, "since", "", "Count events since a duration ago (e.g., 1h) or RFC3339 time")
+	feedStatsCmd.Flags().StringVar(&feedStatsUntil, "until", "", "Count events until a duration ago (e.g., 30m) or RFC3339 time")
+	feedStatsCmd.Flags().StringSliceVar(&feedStatsTypes, "type", nil, "Filter by event type; repeatable or comma-separated")
+	feedStatsCmd.Flags().StringSliceVar(&feedStatsActors, "actor", nil, "Filter by actor; repeatable or comma-separated")
+	feedStatsCmd.Flags().StringVar(&feedStatsRig, "rig", "", "Filter events by rig name")
+	feedStatsCmd.Flags().BoolVar(&feedStatsJSON, "json", false, "Output counts as JSON ({type: {actor: count}})")
+}
+
+func runFeedStats(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace (run from ~/gt or a rig directory)")
+	}
+
+	now := time.Now()
+	since, err := parseFeedTime("--since", feedStatsSince, now)
+	if err != nil {
+		return err
+	}
+	until, err := parseFeedTime("--until", feedStatsUntil, now)
+	if err != nil {
+		return err
+	}
+
+	counts, err := feed.SummarizeGtEvents(townRoot, feed.SummaryOptions{
+		Since:  since,
+		Until:  until,
+		Types:  feedStatsTypes,
+		Actors: feedStatsActors,
+		Rig:    feedStatsRig,
+	})
+	if err != nil {
+		return err
+	}
+
+	if feedStatsJSON {
+		data, err := json.MarshalIndent(counts, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Print(formatFeedStats(counts))
+	return nil
+}
+
+// formatFeedStats renders counts as one block per event type (alphabetical)
+// with its actors by descending count.
+func formatFeedStats(counts map[string]map[string]int) string {
+	if len(counts) == 0 {
+		return "No matching events\n"
+	}
+
+	types := make([]string, 0, len(counts))
+	width := 0
+	for typ, actors := range counts {
+		types = append(types, typ)
+		for actor := range actors {
+			width = max(width, len(actor))
+		}
+	}
+	sort.Strings(types)
+
+	var b strings.Builder
+	for _, typ := range types {
+		actors := counts[typ]
+		names := make([]string, 0, len(actors))
+		total := 0
+		for actor, n := range actors {
+			names = append(names, actor)
+			total += n
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if actors[names[i]] != actors[names[j]] {
+				return actors[names[i]] > actors[names[j]]
+			}
+			return names[i] < names[j]
+		})
+
+		fmt.Fprintf(&b, "%s (%d)\n", typ, total)
+		for _, actor := range names {
+			fmt.Fprintf(&b, "  %-*s  %d\n", width, actor, actors[actor])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFeedTime(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"", time.Time{}},
+		{"2h", now.Add(-2 * time.Hour)},
+		{"90s", now.Add(-90 * time.Second)},
+		{"2026-04-30T08:15:00Z", time.Date(2026, 4, 30, 8, 15, 0, 0, time.UTC)},
+	}
+	for _, tc := range tests {
+		got, err := parseFeedTime("--since", tc.value, now)
+		if err != nil {
+			t.Errorf("parseFeedTime(%q) error: %v", tc.value, err)
+			continue
+		}
+		if !got.Equal(tc.want) {
+			t.Errorf("parseFeedTime(%q) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+
+	_, err := parseFeedTime("--until", "yesterday", now)
+	if err == nil || !strings.Contains(err.Error(), "invalid --until") {
+		t.Errorf("parseFeedTime(yesterday) error = %v, want invalid --until", err)
+	}
+}
+
+func TestFormatFeedStats(t *testing.T) {
+	got := formatFeedStats(map[string]map[string]int{
+		"sling":        {"mayor": 1},
+		"merge_failed": {"system": 1, "gastown/refinery": 3},
+	})
+	want := "merge_failed (4)\n" +
+		"  gastown/refinery  3\n" +
+		"  system            1\n" +
+		"sling (1)\n" +
+		"  mayor             1\n"
+	if got != want {
+		t.Errorf("formatFeedStats =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := formatFeedStats(nil); got != "No matching events\n" {
+		t.Errorf("formatFeedStats(nil) = %q", got)
+	}
+}
+
+func TestRunFeed_JSONFlagPrintsJSONLines(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"ts":"2026-05-01T12:00:00Z","source":"gt","type":"sling","actor":"mayor","payload":{"bead":"gt-abc"},"visibility":"feed"}`
+	if err := os.WriteFile(filepath.Join(townRoot, ".events.jsonl"), []byte(line+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	oldJSON, oldNoFollow := feedJSON, feedNoFollow
+	t.Cleanup(func() { feedJSON, feedNoFollow = oldJSON, oldNoFollow })
+	feedJSON, feedNoFollow = true, true
+
+	var runErr error
+	out := captureStdout(t, func() { runErr = runFeed(feedCmd, nil) })
+	if runErr != nil {
+		t.Fatalf("runFeed: %v", runErr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want 1:\n%s", len(lines), out)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if got["type"] != "sling" || got["actor"] != "mayor" {
+		t.Errorf("JSON event = %v, want type sling from mayor", got)
+	}
+}
@@ -1541,7 +1541,7 @@ func collectGitState() string {
 	var lines []string
 
 	// Branch
-	if branch, err := g.CurrentBranch(); err == nil && branch != "" {
+	if branch, _, err := g.CurrentBranch(); err == nil && branch != "" {
 		lines = append(lines, "Branch: "+branch)
 	}
 
@@ -124,11 +124,15 @@ func isShellCommand(cmd string) bool {
 func ensureDefaultBranch(dir, roleName, rigPath string) error {
 	g := git.NewGit(dir)
 
-	branch, err := g.CurrentBranch()
+	branch, onBranch, err := g.CurrentBranch()
 	if err != nil {
 		// Not a git repo or other error, skip check
 		return fmt.Errorf("could not determine current branch: %w", err)
 	}
+	if !onBranch {
+		// Commits made on a detached HEAD would be lost by switching away.
+		return fmt.Errorf("%s: %w at %s; check out a branch first", roleName, git.ErrDetachedHead, branch)
+	}
 
 	// Get configured default branch for this rig
 	defaultBranch := "main" // fallback
@@ -166,7 +170,7 @@ func ensureDefaultBranch(dir, roleName, rigPath string) error {
 func warnIfNotDefaultBranch(dir, roleName, rigPath string) {
 	g := git.NewGit(dir)
 
-	branch, err := g.CurrentBranch()
+	branch, onBranch, err := g.CurrentBranch()
 	if err != nil {
 		return
 	}
@@ -176,7 +180,17 @@ func warnIfNotDefaultBranch(dir, roleName, rigPath string) {
 		defaultBranch = rigCfg.DefaultBranch
 	}
 
-	if branch == defaultBranch {
+	if onBranch && branch == defaultBranch {
+		return
+	}
+
+	if !onBranch {
+		fmt.Printf("\n%s %s has a detached HEAD at %s, not branch '%s'.\n",
+			style.Warning.Render("⚠"),
+			roleName,
+			branch,
+			defaultBranch)
+		fmt.Printf("  Check out %s before continuing; --reset will not switch away from a detached HEAD.\n\n", defaultBranch)
 		return
 	}
 
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
 )
 
 func TestParseRigSlashName(t *testing.T) {
@@ -104,3 +108,23 @@ func TestIsShellCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureDefaultBranch_DetachedHead(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"checkout", "-q", "--detach"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	err := ensureDefaultBranch(dir, "crew", t.TempDir())
+	if !errors.Is(err, git.ErrDetachedHead) {
+		t.Errorf("ensureDefaultBranch on detached HEAD = %v, want ErrDetachedHead", err)
+	}
+}
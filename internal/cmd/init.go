@@ -45,7 +45,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// Check if it's a git repository
 	g := git.NewGit(cwd)
-	if _, err := g.CurrentBranch(); err != nil {
+	if _, _, err := g.CurrentBranch(); err != nil {
 		return fmt.Errorf("not a git repository (run 'git init' first)")
 	}
 
@@ -27,6 +27,9 @@ var (
 	crashAgent    string
 	crashSession  string
 	crashExitCode int
+
+	// log respawn flags
+	respawnSession string
 )
 
 var logCmd = &cobra.Command{
@@ -71,6 +74,21 @@ Examples:
 	RunE: runLogCrash,
 }
 
+var logRespawnCmd = &cobra.Command{
+	Use:    "respawn",
+	Short:  "Record a respawn event (called by tmux auto-respawn hook)",
+	Hidden: true,
+	Long: `Record a respawn event to the activity feed.
+
+This command is called by the tmux auto-respawn hook after it restarts a
+dead pane, so hook-driven respawns show up in the feed alongside
+daemon-driven restarts. It's not typically run manually.
+
+Examples:
+  gt log respawn --session hq-deacon`,
+	RunE: runLogRespawn,
+}
+
 func init() {
 	logCmd.Flags().IntVarP(&logTail, "tail", "n", 20, "Number of events to show")
 	logCmd.Flags().StringVarP(&logType, "type", "t", "", "Filter by event type (spawn,wake,nudge,handoff,done,crash,kill)")
@@ -85,7 +103,12 @@ func init() {
 	logCrashCmd.Flags().IntVar(&crashExitCode, "exit-code", -1, "Exit code from pane")
 	_ = logCrashCmd.MarkFlagRequired("agent")
 
+	// respawn subcommand flags
+	logRespawnCmd.Flags().StringVar(&respawnSession, "session", "", "Tmux target that was respawned")
+	_ = logRespawnCmd.MarkFlagRequired("session")
+
 	logCmd.AddCommand(logCrashCmd)
+	logCmd.AddCommand(logRespawnCmd)
 	rootCmd.AddCommand(logCmd)
 }
 
@@ -355,8 +378,9 @@ func truncateStr(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-// runLogCrash handles the "gt log crash" command from tmux pane-died hooks.
-func runLogCrash(cmd *cobra.Command, args []string) error {
+// hookTownRoot finds the town root for commands run from tmux hooks, which
+// may not have a cwd inside the town: cwd first, then the conventional ~/gt.
+func hookTownRoot() (string, error) {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil || townRoot == "" {
 		// Try to find town root from conventional location
@@ -367,9 +391,18 @@ func runLogCrash(cmd *cobra.Command, args []string) error {
 			townRoot = defaultRoot
 		}
 		if townRoot == "" {
-			return fmt.Errorf("cannot find town root (tried cwd and ~/gt)")
+			return "", fmt.Errorf("cannot find town root (tried cwd and ~/gt)")
 		}
 	}
+	return townRoot, nil
+}
+
+// runLogCrash handles the "gt log crash" command from tmux pane-died hooks.
+func runLogCrash(cmd *cobra.Command, args []string) error {
+	townRoot, err := hookTownRoot()
+	if err != nil {
+		return err
+	}
 
 	// Determine event type based on exit code
 	var eventType townlog.EventType
@@ -429,6 +462,26 @@ func logCrashFeedEvent(townRoot, agent, session string, exitCode int) {
 	_ = events.LogFeed(events.TypeSessionDeath, agent, payload)
 }
 
+// runLogRespawn handles the hidden "gt log respawn" command run by the tmux
+// auto-respawn hook. The events file is created on first write.
+func runLogRespawn(cmd *cobra.Command, args []string) error {
+	townRoot, err := hookTownRoot()
+	if err != nil {
+		return err
+	}
+
+	// events.LogFeed locates the town from cwd.
+	origDir, getwdErr := os.Getwd()
+	if err := os.Chdir(townRoot); err != nil {
+		return fmt.Errorf("changing to town root: %w", err)
+	}
+	if getwdErr == nil {
+		defer func() { _ = os.Chdir(origDir) }()
+	}
+
+	return events.LogFeed(events.TypeRespawn, respawnSession, events.RespawnPayload(respawnSession, "pane-died hook"))
+}
+
 // LogEvent is a helper that logs an event from anywhere in the codebase.
 // It finds the town root and logs the event.
 func LogEvent(eventType townlog.EventType, agent, context string) error {
@@ -81,6 +81,62 @@ func TestRunLogCrashEmitsFeedSessionDeath(t *testing.T) {
 	}
 }
 
+func TestRunLogRespawnEmitsFeedEvent(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	origSession := respawnSession
+	t.Cleanup(func() { respawnSession = origSession })
+	respawnSession = "hq-deacon"
+
+	// No events file yet: the first respawn must create it.
+	if _, err := os.Stat(filepath.Join(townRoot, gtevents.EventsFile)); !os.IsNotExist(err) {
+		t.Fatalf("events file should not exist yet: %v", err)
+	}
+	if err := runLogRespawn(nil, nil); err != nil {
+		t.Fatalf("runLogRespawn: %v", err)
+	}
+
+	rawEvents, err := os.ReadFile(filepath.Join(townRoot, gtevents.EventsFile))
+	if err != nil {
+		t.Fatalf("read events log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(rawEvents)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("event count = %d, want 1: %s", len(lines), rawEvents)
+	}
+
+	var event gtevents.Event
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatalf("unmarshal event: %v", err)
+	}
+	if event.Type != gtevents.TypeRespawn {
+		t.Fatalf("event type = %q, want %q", event.Type, gtevents.TypeRespawn)
+	}
+	if event.Actor != "hq-deacon" {
+		t.Fatalf("actor = %q", event.Actor)
+	}
+	if event.Visibility != gtevents.VisibilityFeed {
+		t.Fatalf("visibility = %q", event.Visibility)
+	}
+	assertPayloadString(t, event.Payload, "session", "hq-deacon")
+	assertPayloadString(t, event.Payload, "trigger", "pane-died hook")
+}
+
 func assertPayloadString(t *testing.T, payload map[string]interface{}, key, want string) {
 	t.Helper()
 	if got, ok := payload[key].(string); !ok || got != want {
@@ -832,8 +832,8 @@ func showGitDivergenceWarning() {
 		return
 	}
 
-	branch, err := g.CurrentBranch()
-	if err != nil || branch == "" {
+	branch, onBranch, err := g.CurrentBranch()
+	if err != nil || !onBranch || branch == "" {
 		return
 	}
 
@@ -128,10 +128,14 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	// Get current branch
 	branch := mqSubmitBranch
 	if branch == "" {
-		branch, err = g.CurrentBranch()
+		var onBranch bool
+		branch, onBranch, err = g.CurrentBranch()
 		if err != nil {
 			return fmt.Errorf("getting current branch: %w", err)
 		}
+		if !onBranch {
+			return fmt.Errorf("%w at %s; check out a branch or use --branch", git.ErrDetachedHead, branch)
+		}
 	}
 
 	// Get configured default branch for this rig
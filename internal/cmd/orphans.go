@@ -362,13 +362,13 @@ func findOrphanPolecatBranches(rigPath, rigName, defaultBranch string) ([]Orphan
 		g := gitpkg.NewGit(worktreePath)
 
 		// Get current branch
-		branch, err := g.CurrentBranch()
+		branch, onBranch, err := g.CurrentBranch()
 		if err != nil {
 			skipped = append(skipped, skippedPolecat{polecatName, fmt.Sprintf("cannot determine branch: %v", err)})
 			continue
 		}
 		branch = strings.TrimSpace(branch)
-		if branch == "" || branch == "HEAD" || branch == defaultBranch {
+		if branch == "" || !onBranch || branch == defaultBranch {
 			continue // On default branch or detached HEAD — nothing unmerged
 		}
 
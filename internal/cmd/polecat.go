@@ -973,7 +973,10 @@ func getGitStateWithTargets(worktreePath string, targets []string) (*GitState, e
 		state.Clean = false
 	}
 
-	branch, _ := worktreeGit.CurrentBranch()
+	branch, onBranch, branchErr := worktreeGit.CurrentBranch()
+	if branchErr != nil || !onBranch {
+		branch = ""
+	}
 	if preservation, preserveErr := worktreeGit.BranchPreservationStatus(branch, "origin", targets); preserveErr == nil {
 		state.ComparisonBase = preservation.ComparisonBase
 		state.UnpreservedPatchCount = preservation.UnpreservedPatchCount
@@ -1309,8 +1312,8 @@ func staleCleanupStatusCanBeIgnoredForRecovery(status polecat.CleanupStatus, wor
 
 func activeMRGitSafeForWorktree(worktreePath string) bool {
 	g := git.NewGit(worktreePath)
-	branch, err := g.CurrentBranch()
-	if err != nil || branch == "" {
+	branch, onBranch, err := g.CurrentBranch()
+	if err != nil || !onBranch || branch == "" {
 		return false
 	}
 	status, err := g.CheckUncommittedWork()
@@ -1448,11 +1451,14 @@ func activeMRBlocker(bd issueShower, mrID, sourceHint string, requireGitSafe, gi
 
 func hasSubmittableWorkForRecovery(worktreePath string, targetRefs []string, gitState *GitState, gitErr error) bool {
 	g := git.NewGit(worktreePath)
-	branch, _ := g.CurrentBranch()
+	branch, onBranch, branchErr := g.CurrentBranch()
+	if branchErr != nil || !onBranch {
+		branch = ""
+	}
 	if status, err := g.BranchTargetStatus(branch, "origin", targetRefs); err == nil {
 		return status.UnpreservedPatchCount > 0
 	}
-	if branch, err := g.CurrentBranch(); err == nil && branch != "" && !isRecoveryBaseBranch(branch) {
+	if branch != "" && !isRecoveryBaseBranch(branch) {
 		if pushed, _, err := g.BranchPushedToRemote(branch, "origin"); err == nil && pushed {
 			return true
 		}
@@ -1992,7 +1992,7 @@ func runRigStatus(cmd *cobra.Command, args []string) error {
 				sessionName := crewSessionName(rigName, w.Name)
 				cInfos[idx].hasSession = isAgentSessionHealthy(t, sessionName)
 				crewGit := git.NewGit(w.ClonePath)
-				cInfos[idx].branch, _ = crewGit.CurrentBranch()
+				cInfos[idx].branch, _, _ = crewGit.CurrentBranch()
 				gitStatus, _ := crewGit.Status()
 				if gitStatus != nil && !gitStatus.Clean {
 					cInfos[idx].dirty = true
@@ -95,6 +95,17 @@ var branchCheckExemptCommands = map[string]bool{
 	"scheduler":   true, // Daemon hot path; scheduler handles beads internally
 }
 
+// Commands exempt from operational config validation warnings.
+// These run on hot paths or must produce clean output.
+var configValidationExemptCommands = map[string]bool{
+	"version":     true,
+	"help":        true,
+	"completion":  true,
+	"status-line": true, // tmux hot path; stderr noise would corrupt the status bar
+	"signal":      true, // Hook signal handlers must be fast
+	"heartbeat":   true, // Heartbeat state update — must be fast and dependency-free
+}
+
 // persistentPreRun runs before every command.
 func persistentPreRun(cmd *cobra.Command, args []string) error {
 	// Check if binary was built properly (via make build, not raw go build).
@@ -141,6 +152,11 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 		warnIfTownRootOffMain()
 	}
 
+	// Surface malformed operational thresholds (warning only, non-blocking)
+	if !isCommandOrAncestorExempt(cmd, configValidationExemptCommands) {
+		warnInvalidOperationalConfig()
+	}
+
 	// Touch polecat session heartbeat on every gt command (gt-qjtq: ZFC liveness fix).
 	// This is best-effort and non-blocking — the heartbeat file signals that the agent
 	// is alive and actively running gt commands. Used by isSessionProcessDead to
@@ -198,6 +214,27 @@ func initCLITheme() {
 	ui.ApplyThemeMode()
 }
 
+// warnInvalidOperationalConfig prints a warning for each malformed value in the
+// town's operational config. LoadOperationalConfig silently falls back to
+// defaults for bad values, or clamps out-of-range counts, so without this a
+// typo goes unnoticed. Each message says which value applies instead.
+func warnInvalidOperationalConfig() {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return
+	}
+	_, errs, err := config.LoadOperationalConfigStrict(townRoot)
+	if err != nil || len(errs) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n%s Invalid operational config in %s:\n",
+		style.Bold.Render("⚠️  WARNING:"), config.TownSettingsPath(townRoot))
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "   operational.%v\n", e)
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
 // touchPolecatHeartbeat touches the session heartbeat file for polecat agents.
 // Called from persistentPreRun on every gt command. The heartbeat signals that
 // the agent process is alive and actively running gt commands. Used by
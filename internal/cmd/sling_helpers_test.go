@@ -110,6 +110,10 @@ func TestWakeRigAgentsDoesNotNudgeRefinery(t *testing.T) {
 func TestNudgeRefineryNoOpWithoutLog(t *testing.T) {
 	// Ensure test log is NOT set so we exercise the real tmux path
 	t.Setenv("GT_TEST_NUDGE_LOG", "")
+	// nudgeRefinery emits an MQ_SUBMIT event into the town found from cwd.
+	// internal/ has a mayor/ package dir and looks like a town, so run from
+	// an empty directory to keep event files out of the source tree.
+	t.Chdir(t.TempDir())
 
 	// Should not panic even though no tmux session exists
 	nudgeRefinery("nonexistent-rig", "test message")
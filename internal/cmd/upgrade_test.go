@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 func TestGenerateCLAUDEMD(t *testing.T) {
@@ -36,18 +41,14 @@ func TestUpgradeCLAUDEMD_CreatesMissingFile(t *testing.T) {
 	upgradeVerbose = false
 
 	result := upgradeCLAUDEMD(tmpDir)
+	if result.changed != 1 {
+		t.Errorf("expected 1 change for new CLAUDE.md, got %d", result.changed)
+	}
 
-	// 2 changes: CLAUDE.md created + AGENTS.md symlink created
-	if runtime.GOOS == "windows" {
-		// On Windows, symlink creation requires elevated privileges.
-		// Only CLAUDE.md is created; AGENTS.md symlink may fail silently.
-		if result.changed < 1 {
-			t.Errorf("expected at least 1 change for new CLAUDE.md, got %d", result.changed)
-		}
-	} else {
-		if result.changed != 2 {
-			t.Errorf("expected 2 changes for new CLAUDE.md + AGENTS.md, got %d", result.changed)
-		}
+	agentsResult := upgradeAgentsMD(tmpDir)
+	// On Windows, symlink creation requires elevated privileges and may fail.
+	if runtime.GOOS != "windows" && agentsResult.changed != 1 {
+		t.Errorf("expected 1 change for new AGENTS.md, got %d", agentsResult.changed)
 	}
 
 	// Verify file was created
@@ -57,7 +58,7 @@ func TestUpgradeCLAUDEMD_CreatesMissingFile(t *testing.T) {
 		t.Fatalf("CLAUDE.md not created: %v", err)
 	}
 
-	expected := generateCLAUDEMD()
+	expected := managedCLAUDEMDRegion()
 	if string(data) != expected {
 		t.Error("CLAUDE.md content doesn't match expected template")
 	}
@@ -79,7 +80,7 @@ func TestUpgradeCLAUDEMD_UpToDate(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Write the expected content
-	expected := generateCLAUDEMD()
+	expected := managedCLAUDEMDRegion()
 	claudePath := filepath.Join(tmpDir, "CLAUDE.md")
 	if err := os.WriteFile(claudePath, []byte(expected), 0644); err != nil {
 		t.Fatal(err)
@@ -117,6 +118,282 @@ func TestUpgradeCLAUDEMD_DryRun(t *testing.T) {
 	upgradeDryRun = false
 }
 
+func TestUpgradeCLAUDEMD_BacksUpExistingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	claudePath := filepath.Join(tmpDir, "CLAUDE.md")
+	custom := "# My customized CLAUDE.md\n"
+	if err := os.WriteFile(claudePath, []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+	upgradeVerbose = false
+
+	result := upgradeCLAUDEMD(tmpDir)
+
+	if len(result.backups) != 1 {
+		t.Fatalf("expected 1 backup, got %v", result.backups)
+	}
+	backupPath := result.backups[0]
+	if !strings.HasPrefix(filepath.Base(backupPath), "CLAUDE.md.bak-") {
+		t.Errorf("backup path = %q, want CLAUDE.md.bak-<timestamp>", backupPath)
+	}
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if string(data) != custom {
+		t.Errorf("backup content = %q, want %q", data, custom)
+	}
+}
+
+func TestUpgradeCLAUDEMD_NoBackupWhenUpToDate(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "CLAUDE.md"), []byte(managedCLAUDEMDRegion()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+	upgradeVerbose = false
+
+	result := upgradeCLAUDEMD(tmpDir)
+
+	if len(result.backups) != 0 {
+		t.Errorf("expected no backup for up-to-date CLAUDE.md, got %v", result.backups)
+	}
+	matches, _ := filepath.Glob(filepath.Join(tmpDir, "CLAUDE.md.bak-*"))
+	if len(matches) != 0 {
+		t.Errorf("unexpected backup files: %v", matches)
+	}
+}
+
+func TestUpgradeCLAUDEMD_NoBackupFlag(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "CLAUDE.md"), []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+	upgradeVerbose = false
+	upgradeNoBackup = true
+	defer func() { upgradeNoBackup = false }()
+
+	result := upgradeCLAUDEMD(tmpDir)
+
+	if result.changed == 0 {
+		t.Fatal("expected CLAUDE.md to be updated")
+	}
+	if len(result.backups) != 0 {
+		t.Errorf("expected no backup with --no-backup, got %v", result.backups)
+	}
+}
+
+func TestUpgradeCLAUDEMD_PreservesUserSections(t *testing.T) {
+	tmpDir := t.TempDir()
+	claudePath := filepath.Join(tmpDir, "CLAUDE.md")
+	userTop := "# Local notes\n\nKeep this above.\n\n"
+	userBottom := "\n## My Section\n\nAlways run the linters.\n"
+	stale := claudeMDManagedBegin + "\n# Gas Town\n\nOld managed text.\n" + claudeMDManagedEnd + "\n"
+	if err := os.WriteFile(claudePath, []byte(userTop+stale+userBottom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+	upgradeVerbose = false
+
+	result := upgradeCLAUDEMD(tmpDir)
+	if result.changed == 0 {
+		t.Fatal("expected stale managed region to be updated")
+	}
+
+	data, err := os.ReadFile(claudePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := userTop + managedCLAUDEMDRegion() + userBottom
+	if string(data) != want {
+		t.Errorf("CLAUDE.md =\n%s\nwant\n%s", data, want)
+	}
+
+	// A second run finds nothing to do.
+	if result := upgradeCLAUDEMD(tmpDir); result.changed != 0 {
+		t.Errorf("expected 0 changes on second run, got %d", result.changed)
+	}
+}
+
+func TestMergeCLAUDEMD_MigratesUnmarkedFiles(t *testing.T) {
+	region := managedCLAUDEMDRegion()
+	template := generateCLAUDEMD()
+	legacy := "# Gas Town\n\nRun `gt prime` after compaction.\n\n## Dolt\n\nOld norms.\n"
+
+	tests := []struct {
+		name    string
+		current string
+		want    string
+	}{
+		{"empty", "", region},
+		{"unmarked template", template, region},
+		{"unmarked template with user section", template + "\n## Mine\n", region + "\n## Mine\n"},
+		{"fully custom", "# Custom\n", region + "\n# Custom\n"},
+		{"legacy template", legacy, region},
+		{"legacy template with user heading", legacy + "\n# Mine\n", region + "\n# Mine\n"},
+		{"already current", "intro\n" + region, "intro\n" + region},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeCLAUDEMD(tt.current); got != tt.want {
+				t.Errorf("mergeCLAUDEMD(%q) =\n%q\nwant\n%q", tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeDiff(t *testing.T) {
+	current := "# Gas Town\n\nold line\nkept\n"
+	expected := "# Gas Town\n\nnew line\nkept\n"
+
+	diff := upgradeDiff("CLAUDE.md", current, expected)
+	for _, want := range []string{"--- a/CLAUDE.md", "+++ b/CLAUDE.md", "-old line", "+new line", " kept"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q:\n%s", want, diff)
+		}
+	}
+
+	created := upgradeDiff("daemon.json", "", "{\n}\n")
+	for _, want := range []string{"--- /dev/null", "+++ b/daemon.json", "+{", "+}"} {
+		if !strings.Contains(created, want) {
+			t.Errorf("creation diff missing %q:\n%s", want, created)
+		}
+	}
+	if strings.Contains(created, "\n-") {
+		t.Errorf("creation diff should only add lines:\n%s", created)
+	}
+
+	if diff := upgradeDiff("CLAUDE.md", expected, expected); diff != "" {
+		t.Errorf("expected empty diff for identical content, got:\n%s", diff)
+	}
+}
+
+func TestUpgradeCLAUDEMD_DryRunPrintsDiff(t *testing.T) {
+	tmpDir := t.TempDir()
+	claudePath := filepath.Join(tmpDir, "CLAUDE.md")
+	old := claudeMDManagedBegin + "\n# Gas Town\n\nStale managed line.\n" + claudeMDManagedEnd + "\n\n## Mine\n"
+	if err := os.WriteFile(claudePath, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = true
+	defer func() { upgradeDryRun = false }()
+
+	out := captureStdout(t, func() { upgradeCLAUDEMD(tmpDir) })
+
+	if !strings.Contains(out, "-Stale managed line.") {
+		t.Errorf("dry-run output missing removed line:\n%s", out)
+	}
+	if !strings.Contains(out, "+**Do NOT adopt an identity") {
+		t.Errorf("dry-run output missing added line:\n%s", out)
+	}
+	if strings.Contains(out, "-## Mine") || strings.Contains(out, "+## Mine") {
+		t.Errorf("user section should not appear as changed:\n%s", out)
+	}
+	if data, _ := os.ReadFile(claudePath); string(data) != old {
+		t.Error("dry-run modified CLAUDE.md")
+	}
+}
+
+func TestUpgradeAgentsMD_FallsBackWhenSymlinksUnsupported(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	upgradeDryRun = false
+	upgradeVerbose = false
+	upgradeSymlink = func(oldname, newname string) error {
+		return &os.LinkError{Op: "symlink", Old: oldname, New: newname, Err: errors.New("operation not permitted")}
+	}
+	defer func() { upgradeSymlink = os.Symlink }()
+
+	result := upgradeAgentsMD(tmpDir)
+
+	if result.changed != 1 {
+		t.Errorf("expected 1 change, got %d", result.changed)
+	}
+	if !slices.Contains(result.details, "strategy: pointer file") {
+		t.Errorf("details = %v, want pointer file strategy", result.details)
+	}
+	agentsPath := filepath.Join(tmpDir, "AGENTS.md")
+	info, err := os.Lstat(agentsPath)
+	if err != nil {
+		t.Fatalf("AGENTS.md not created: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		t.Error("expected a regular pointer file, got a symlink")
+	}
+	data, _ := os.ReadFile(agentsPath)
+	if !strings.Contains(string(data), "CLAUDE.md") {
+		t.Errorf("pointer file should reference CLAUDE.md, got %q", data)
+	}
+
+	// The pointer file is recognized as current on the next run.
+	if result := upgradeAgentsMD(tmpDir); result.changed != 0 || result.skipped != 0 {
+		t.Errorf("second run: changed=%d skipped=%d, want 0/0", result.changed, result.skipped)
+	}
+}
+
+func TestUpgradeAgentsMD_SymlinkStrategy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require elevated privileges on Windows")
+	}
+	tmpDir := t.TempDir()
+	upgradeDryRun = false
+
+	result := upgradeAgentsMD(tmpDir)
+
+	if result.changed != 1 || !slices.Contains(result.details, "strategy: symlink") {
+		t.Errorf("result = %+v, want symlink strategy", result)
+	}
+}
+
+func TestUpgradeAgentsMD_LeavesExistingRegularFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	agentsPath := filepath.Join(tmpDir, "AGENTS.md")
+	custom := "# My agent notes\n"
+	if err := os.WriteFile(agentsPath, []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+
+	result := upgradeAgentsMD(tmpDir)
+
+	if result.changed != 0 || result.skipped != 1 {
+		t.Errorf("changed=%d skipped=%d, want 0/1", result.changed, result.skipped)
+	}
+	if data, _ := os.ReadFile(agentsPath); string(data) != custom {
+		t.Errorf("AGENTS.md was clobbered: %q", data)
+	}
+}
+
+func TestUpgradeAgentsMD_WarnsOnForeignSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require elevated privileges on Windows")
+	}
+	tmpDir := t.TempDir()
+	agentsPath := filepath.Join(tmpDir, "AGENTS.md")
+	if err := os.Symlink("OTHER.md", agentsPath); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+
+	result := upgradeAgentsMD(tmpDir)
+
+	if result.changed != 0 || result.skipped != 1 {
+		t.Errorf("changed=%d skipped=%d, want 0/1", result.changed, result.skipped)
+	}
+	if target, _ := os.Readlink(agentsPath); target != "OTHER.md" {
+		t.Errorf("symlink target changed to %q", target)
+	}
+}
+
 func TestUpgradeDaemonConfig_CreatesMissing(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -155,7 +432,11 @@ func TestUpgradeDaemonConfig_ExistingValid(t *testing.T) {
 		"type": "daemon-patrol-config",
 		"version": 1,
 		"heartbeat": {"enabled": true, "interval": "3m"},
-		"patrols": {}
+		"patrols": {
+			"deacon": {"enabled": true, "interval": "5m", "agent": "deacon"},
+			"witness": {"enabled": true, "interval": "5m", "agent": "witness"},
+			"refinery": {"enabled": true, "interval": "5m", "agent": "refinery"}
+		}
 	}`
 	if err := os.WriteFile(daemonPath, []byte(content), 0644); err != nil {
 		t.Fatal(err)
@@ -171,6 +452,181 @@ func TestUpgradeDaemonConfig_ExistingValid(t *testing.T) {
 	}
 }
 
+func TestUpgradeDaemonConfig_MergesMissingPatrol(t *testing.T) {
+	tmpDir := t.TempDir()
+	mayorDir := filepath.Join(tmpDir, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// An older daemon.json without the refinery patrol and a custom interval.
+	daemonPath := filepath.Join(mayorDir, "daemon.json")
+	content := `{
+  "type": "daemon-patrol-config",
+  "version": 1,
+  "heartbeat": {"enabled": true, "interval": "3m"},
+  "patrols": {
+    "deacon": {"enabled": true, "interval": "12m", "agent": "deacon"},
+    "witness": {"enabled": true, "interval": "5m", "agent": "witness"}
+  }
+}
+`
+	if err := os.WriteFile(daemonPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = false
+	upgradeVerbose = false
+
+	result := upgradeDaemonConfig(tmpDir)
+	if result.changed != 1 {
+		t.Errorf("expected 1 change, got %d", result.changed)
+	}
+	if len(result.backups) != 1 {
+		t.Errorf("expected a backup of the old daemon.json, got %v", result.backups)
+	}
+
+	cfg, err := config.LoadDaemonPatrolConfig(daemonPath)
+	if err != nil {
+		t.Fatalf("LoadDaemonPatrolConfig: %v", err)
+	}
+	if got := cfg.Patrols["deacon"].Interval; got != "12m" {
+		t.Errorf("deacon interval = %q, want the user's 12m", got)
+	}
+	if r, ok := cfg.Patrols["refinery"]; !ok || !r.Enabled {
+		t.Errorf("refinery patrol not added: %+v", cfg.Patrols)
+	}
+
+	// Nothing left to add on the next run.
+	if result := upgradeDaemonConfig(tmpDir); result.changed != 0 {
+		t.Errorf("expected 0 changes on second run, got %d", result.changed)
+	}
+}
+
+func TestUpgradeDaemonConfig_DryRunLeavesFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	mayorDir := filepath.Join(tmpDir, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	daemonPath := filepath.Join(mayorDir, "daemon.json")
+	content := `{"type": "daemon-patrol-config", "version": 1, "patrols": {}}`
+	if err := os.WriteFile(daemonPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	upgradeDryRun = true
+	defer func() { upgradeDryRun = false }()
+
+	if result := upgradeDaemonConfig(tmpDir); result.changed != 1 {
+		t.Errorf("expected 1 change in dry-run, got %d", result.changed)
+	}
+	data, _ := os.ReadFile(daemonPath)
+	if string(data) != content {
+		t.Errorf("dry-run modified daemon.json:\n%s", data)
+	}
+}
+
+func TestSelectUpgradeSteps(t *testing.T) {
+	names := func(steps []upgradeStep) string {
+		var out []string
+		for _, step := range steps {
+			out = append(out, step.name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name    string
+		only    []string
+		skip    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "all", want: "doctor,claude,agents,daemon,hooks,formulas"},
+		{name: "only daemon", only: []string{"daemon"}, want: "daemon"},
+		{name: "only keeps run order", only: []string{"daemon", "Claude"}, want: "claude,daemon"},
+		{name: "skip", skip: []string{"doctor", "formulas"}, want: "claude,agents,daemon,hooks"},
+		{name: "only and skip", only: []string{"claude", "agents"}, skip: []string{"agents"}, want: "claude"},
+		{name: "unknown", only: []string{"bogus"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := selectUpgradeSteps(tt.only, tt.skip)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectUpgradeSteps: %v", err)
+			}
+			if got := names(steps); got != tt.want {
+				t.Errorf("steps = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunUpgradeSteps_OnlyRunsSelected(t *testing.T) {
+	upgradeDryRun = false
+	upgradeVerbose = false
+
+	t.Run("only daemon leaves CLAUDE.md untouched", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(tmpDir, "mayor"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		steps, err := selectUpgradeSteps([]string{"daemon"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var results []upgradeResult
+		out := captureStdout(t, func() { results = runUpgradeSteps(tmpDir, steps) })
+		if !strings.Contains(out, "1.") || strings.Contains(out, "4.") {
+			t.Errorf("--only daemon should number its single step 1, got:\n%s", out)
+		}
+
+		if len(results) != 1 || results[0].step != "Daemon config" {
+			t.Fatalf("results = %+v, want only the daemon step", results)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "mayor", "daemon.json")); err != nil {
+			t.Errorf("daemon.json not created: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "CLAUDE.md")); !os.IsNotExist(err) {
+			t.Error("--only daemon should not create CLAUDE.md")
+		}
+	})
+
+	t.Run("only claude leaves daemon.json untouched", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(tmpDir, "mayor"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		steps, err := selectUpgradeSteps([]string{"claude"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results := runUpgradeSteps(tmpDir, steps)
+
+		if len(results) != 1 || results[0].step != "CLAUDE.md sync" {
+			t.Fatalf("results = %+v, want only the CLAUDE.md step", results)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "CLAUDE.md")); err != nil {
+			t.Errorf("CLAUDE.md not created: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "mayor", "daemon.json")); !os.IsNotExist(err) {
+			t.Error("--only claude should not create daemon.json")
+		}
+		if _, err := os.Lstat(filepath.Join(tmpDir, "AGENTS.md")); !os.IsNotExist(err) {
+			t.Error("--only claude should not create AGENTS.md")
+		}
+	})
+}
+
 func TestUpgradeCommandRegistered(t *testing.T) {
 	// Verify the upgrade command is registered in rootCmd
 	found := false
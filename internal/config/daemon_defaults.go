@@ -0,0 +1,172 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// MergeDaemonPatrolDefaults adds the top-level keys and patrols from
+// NewDaemonPatrolConfig that are missing in an existing daemon.json, without
+// changing any value the user already set. It returns the updated content and
+// the names of the keys it added (e.g. "heartbeat", "patrols.refinery"); when
+// nothing is missing, data is returned unchanged with no names.
+//
+// New keys are spliced in as text after the last existing key of their
+// object, so the user's key order, formatting, and unknown fields (e.g.
+// dolt_server) are preserved.
+func MergeDaemonPatrolDefaults(data []byte) ([]byte, []string, error) {
+	top, err := scanJSONObject(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing daemon config: %w", err)
+	}
+
+	defaults := NewDaemonPatrolConfig()
+	var added []string
+
+	// Patrols first: the splice is inside the patrols object, which ends
+	// before the top-level insertion point, so offsets stay valid.
+	type splice struct {
+		at   int
+		text []byte
+	}
+	var splices []splice
+
+	if patrolsSpan, ok := top.values["patrols"]; ok {
+		patrols, err := scanJSONObject(data[patrolsSpan.start:patrolsSpan.end])
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing patrols: %w", err)
+		}
+		var names []string
+		for name := range defaults.Patrols {
+			if _, ok := patrols.values[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		var entries []jsonEntry
+		for _, name := range names {
+			entries = append(entries, jsonEntry{name, defaults.Patrols[name]})
+			added = append(added, "patrols."+name)
+		}
+		text, err := objectInsertion(patrols, entries, "    ", "  ")
+		if err != nil {
+			return nil, nil, err
+		}
+		if text != nil {
+			splices = append(splices, splice{patrolsSpan.start + patrols.insertAt, text})
+		}
+	}
+
+	var entries []jsonEntry
+	for _, e := range []jsonEntry{
+		{"type", defaults.Type},
+		{"version", defaults.Version},
+		{"heartbeat", defaults.Heartbeat},
+		{"patrols", defaults.Patrols},
+	} {
+		if _, ok := top.values[e.key]; !ok {
+			entries = append(entries, e)
+			added = append(added, e.key)
+		}
+	}
+	text, err := objectInsertion(top, entries, "  ", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if text != nil {
+		splices = append(splices, splice{top.insertAt, text})
+	}
+
+	if len(splices) == 0 {
+		return data, nil, nil
+	}
+
+	out := append([]byte(nil), data...)
+	for i := len(splices) - 1; i >= 0; i-- {
+		s := splices[i]
+		out = append(out[:s.at], append(s.text, out[s.at:]...)...)
+	}
+	return out, added, nil
+}
+
+// jsonEntry is a key and value to add to a JSON object.
+type jsonEntry struct {
+	key   string
+	value interface{}
+}
+
+// jsonSpan is the byte range of a value within its enclosing document.
+type jsonSpan struct {
+	start, end int
+}
+
+// jsonObjectScan describes a JSON object's keys and where new keys go.
+type jsonObjectScan struct {
+	values   map[string]jsonSpan
+	insertAt int  // offset just after the last value, or after '{' if empty
+	empty    bool // object has no keys
+}
+
+// scanJSONObject records the span of each key's value in a JSON object.
+func scanJSONObject(data []byte) (jsonObjectScan, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return jsonObjectScan{}, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return jsonObjectScan{}, fmt.Errorf("expected JSON object")
+	}
+
+	scan := jsonObjectScan{
+		values:   make(map[string]jsonSpan),
+		insertAt: int(dec.InputOffset()),
+		empty:    true,
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return jsonObjectScan{}, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return jsonObjectScan{}, fmt.Errorf("expected object key")
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return jsonObjectScan{}, err
+		}
+		end := int(dec.InputOffset())
+		scan.values[key] = jsonSpan{start: end - len(raw), end: end}
+		scan.insertAt = end
+		scan.empty = false
+	}
+	return scan, nil
+}
+
+// objectInsertion renders entries as text to splice in at obj.insertAt.
+// indent is the indentation of the object's keys; closeIndent is that of
+// its closing brace, used when the object was empty.
+func objectInsertion(obj jsonObjectScan, entries []jsonEntry, indent, closeIndent string) ([]byte, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	for i, e := range entries {
+		value, err := json.MarshalIndent(e.value, indent, "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", e.key, err)
+		}
+		if i > 0 || !obj.empty {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.key)
+		fmt.Fprintf(&buf, "\n%s%s: %s", indent, key, value)
+	}
+	if obj.empty {
+		buf.WriteString("\n" + closeIndent)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMergeDaemonPatrolDefaults_AddsMissingPatrol(t *testing.T) {
+	existing := `{
+  "type": "daemon-patrol-config",
+  "version": 1,
+  "heartbeat": {"enabled": true, "interval": "10m"},
+  "patrols": {
+    "witness": {"enabled": false, "interval": "15m", "agent": "witness"},
+    "deacon": {"enabled": true, "interval": "5m", "agent": "deacon"}
+  },
+  "dolt_server": {"port": 3307}
+}
+`
+	out, added, err := MergeDaemonPatrolDefaults([]byte(existing))
+	if err != nil {
+		t.Fatalf("MergeDaemonPatrolDefaults: %v", err)
+	}
+	if strings.Join(added, ",") != "patrols.refinery" {
+		t.Errorf("added = %v, want [patrols.refinery]", added)
+	}
+
+	var cfg struct {
+		Heartbeat  HeartbeatConfig         `json:"heartbeat"`
+		Patrols    map[string]PatrolConfig `json:"patrols"`
+		DoltServer map[string]int          `json:"dolt_server"`
+	}
+	if err := json.Unmarshal(out, &cfg); err != nil {
+		t.Fatalf("merged output is not valid JSON: %v\n%s", err, out)
+	}
+	if cfg.Heartbeat.Interval != "10m" {
+		t.Errorf("heartbeat interval = %q, want user's 10m", cfg.Heartbeat.Interval)
+	}
+	if w := cfg.Patrols["witness"]; w.Enabled || w.Interval != "15m" {
+		t.Errorf("witness = %+v, want user's disabled/15m", w)
+	}
+	if r := cfg.Patrols["refinery"]; !r.Enabled || r.Interval != "5m" || r.Agent != "refinery" {
+		t.Errorf("refinery = %+v, want template default", r)
+	}
+	if cfg.DoltServer["port"] != 3307 {
+		t.Errorf("unknown field dolt_server lost: %s", out)
+	}
+
+	// Existing text is kept byte-for-byte up to the insertion point.
+	prefix := existing[:strings.Index(existing, `"agent": "deacon"}`)]
+	if !strings.HasPrefix(string(out), prefix) {
+		t.Errorf("existing formatting changed:\n%s", out)
+	}
+	if strings.Index(string(out), `"patrols"`) > strings.Index(string(out), `"dolt_server"`) {
+		t.Errorf("key order changed:\n%s", out)
+	}
+}
+
+func TestMergeDaemonPatrolDefaults_NothingMissing(t *testing.T) {
+	full, err := json.MarshalIndent(NewDaemonPatrolConfig(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, added, err := MergeDaemonPatrolDefaults(full)
+	if err != nil {
+		t.Fatalf("MergeDaemonPatrolDefaults: %v", err)
+	}
+	if len(added) != 0 || string(out) != string(full) {
+		t.Errorf("expected no change, added %v:\n%s", added, out)
+	}
+}
+
+func TestMergeDaemonPatrolDefaults_MissingSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"empty object", `{}`, "type,version,heartbeat,patrols"},
+		{"empty patrols", `{"type": "daemon-patrol-config", "version": 1, "heartbeat": {"enabled": false}, "patrols": {}}`, "patrols.deacon,patrols.refinery,patrols.witness"},
+		{"no heartbeat", `{"type": "daemon-patrol-config", "version": 1, "patrols": {"deacon": {}, "witness": {}, "refinery": {}}}`, "heartbeat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, added, err := MergeDaemonPatrolDefaults([]byte(tt.existing))
+			if err != nil {
+				t.Fatalf("MergeDaemonPatrolDefaults: %v", err)
+			}
+			if got := strings.Join(added, ","); got != tt.want {
+				t.Errorf("added = %s, want %s", got, tt.want)
+			}
+			var cfg DaemonPatrolConfig
+			if err := json.Unmarshal(out, &cfg); err != nil {
+				t.Fatalf("merged output is not valid JSON: %v\n%s", err, out)
+			}
+			if len(cfg.Patrols) != 3 || cfg.Heartbeat == nil {
+				t.Errorf("merged config incomplete: %s", out)
+			}
+			// Merging again is a no-op.
+			if _, again, _ := MergeDaemonPatrolDefaults(out); len(again) != 0 {
+				t.Errorf("second merge added %v", again)
+			}
+		})
+	}
+}
+
+func TestMergeDaemonPatrolDefaults_InvalidJSON(t *testing.T) {
+	if _, _, err := MergeDaemonPatrolDefaults([]byte(`[1, 2]`)); err == nil {
+		t.Error("expected error for non-object JSON")
+	}
+	if _, _, err := MergeDaemonPatrolDefaults([]byte(`{"patrols": 3}`)); err == nil {
+		t.Error("expected error for non-object patrols")
+	}
+}
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"math/rand"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/state"
 )
 
 // Compiled-in defaults for operational thresholds.
@@ -11,7 +16,7 @@ import (
 
 // Session defaults.
 const (
-	DefaultClaudeStartTimeout      = 60 * time.Second
+	DefaultClaudeStartTimeout      = 180 * time.Second
 	DefaultShellReadyTimeout       = 5 * time.Second
 	DefaultGracefulShutdownTimeout = 3 * time.Second
 	DefaultBdCommandTimeout        = 30 * time.Second
@@ -20,6 +25,10 @@ const (
 	DefaultHungSessionThreshold    = 30 * time.Minute
 	DefaultStartupNudgeVerifyDelay = 25 * time.Second
 	DefaultStartupNudgeMaxRetries  = 2
+	DefaultRespawnHookDelay        = 3 * time.Second
+	DefaultRespawnBaseBackoff      = 2 * time.Second
+	DefaultRespawnBackoffMax       = 1 * time.Minute
+	DefaultRespawnStablePeriod     = 5 * time.Minute
 )
 
 // Nudge defaults.
@@ -30,6 +39,7 @@ const (
 	DefaultNudgeNormalTTL         = 30 * time.Minute
 	DefaultNudgeUrgentTTL         = 2 * time.Hour
 	DefaultNudgeMaxQueueDepth     = 50
+	DefaultNudgeUrgentHeadroom    = 5
 	DefaultNudgeStaleClaimTimeout = 5 * time.Minute
 )
 
@@ -37,6 +47,7 @@ const (
 const (
 	DefaultMassDeathWindow                 = 30 * time.Second
 	DefaultMassDeathThreshold              = 3
+	DefaultMassDeathRespawnPause           = 5 * time.Minute
 	DefaultDogIdleSessionTimeout           = 1 * time.Hour
 	DefaultPolecatIdleSessionTimeout       = 15 * time.Minute
 	DefaultDogIdleRemoveTimeout            = 4 * time.Hour
@@ -49,6 +60,8 @@ const (
 	DefaultBootSpawnCooldown               = 2 * time.Minute
 	DefaultBootIdleSuppression             = 15 * time.Minute
 	DefaultDeaconGracePeriod               = 5 * time.Minute
+	DefaultRespawnMaxAttempts              = 5
+	DefaultRespawnWindow                   = 10 * time.Minute
 
 	// Pressure check defaults — fully opt-in. All zero = disabled.
 	// Configure in settings/config.json under operational.daemon to enable.
@@ -69,6 +82,8 @@ const (
 	DefaultRedispatchCooldown              = 5 * time.Minute
 	DefaultMaxFeedsPerCycle                = 3
 	DefaultFeedCooldown                    = 10 * time.Minute
+	DefaultRedispatchCooldownJitter        = 0 * time.Second
+	DefaultFeedCooldownJitter              = 0 * time.Second
 )
 
 // Polecat defaults.
@@ -79,6 +94,8 @@ const (
 	DefaultPolecatDoltBackoffMax  = 30 * time.Second
 	DefaultPolecatPendingMaxAge   = 5 * time.Minute
 	DefaultPolecatNamepoolSize    = 50
+	DefaultPolecatNamepoolGrowBy  = 0
+	DefaultPolecatNamepoolMaxSize = 200
 )
 
 // Dolt defaults.
@@ -100,9 +117,10 @@ const (
 
 // Web defaults.
 const (
-	DefaultWebMaxConcurrentCmds = 12
-	DefaultWebMaxSubjectLen     = 500
-	DefaultWebMaxBodyLen        = 100_000
+	DefaultWebMaxConcurrentCmds    = 12
+	DefaultWebMaxSubjectLen        = 500
+	DefaultWebMaxBodyLen           = 100_000
+	DefaultWebMaxCommandsPerMinute = 60
 )
 
 // Witness defaults.
@@ -115,7 +133,45 @@ const (
 	DefaultWitnessHeartbeatStartupGrace     = 5 * time.Minute
 )
 
-// LoadOperationalConfig loads operational config from a town root.
+// Bounds for count thresholds. Configured values outside these ranges are
+// clamped by the accessors and reported by ValidateOperationalConfig, so a
+// typo like max_dog_pool_size: 10000 or max_connections: 0 cannot
+// destabilize a town. See operationalIntBounds.
+const (
+	MinNudgeQueueDepth      = 1
+	MaxNudgeQueueDepthLimit = 1000
+
+	MinNudgeUrgentHeadroom      = 0
+	MaxNudgeUrgentHeadroomLimit = 100
+
+	MinMassDeathThreshold      = 1
+	MaxMassDeathThresholdLimit = 100
+
+	MinDogPoolSize      = 1
+	MaxDogPoolSizeLimit = 32
+
+	MinPolecatNamepoolSize      = 1
+	MaxPolecatNamepoolSizeLimit = 1000
+
+	MinPolecatNamepoolGrowBy      = 0
+	MaxPolecatNamepoolGrowByLimit = 100
+
+	MinDoltConnections      = 1
+	MaxDoltConnectionsLimit = 10000
+
+	MaxDoltPort = 65535
+
+	MinMailConcurrentAcks      = 1
+	MaxMailConcurrentAcksLimit = 64
+
+	MinWebConcurrentCmds      = 1
+	MaxWebConcurrentCmdsLimit = 128
+)
+
+// LoadOperationalConfig loads the operational block of a town's own
+// settings/config.json, without the global layer or any profile. It is for
+// code that edits that file; runtime consumers use
+// LoadOperationalConfigLayered so global and GT_PROFILE values apply.
 // Returns a valid (possibly empty) config — never nil, never errors.
 // Callers can use accessor methods that return defaults for nil sub-configs.
 func LoadOperationalConfig(townRoot string) *OperationalConfig {
@@ -127,8 +183,39 @@ func LoadOperationalConfig(townRoot string) *OperationalConfig {
 	return ts.Operational
 }
 
+// GlobalSettingsPath returns the machine-wide settings file shared by every
+// town on this host (~/.config/gastown/config.json, honoring XDG_CONFIG_HOME).
+// It uses the same schema as a town's settings/config.json.
+func GlobalSettingsPath() string {
+	return filepath.Join(state.ConfigDir(), "config.json")
+}
+
+// LoadOperationalConfigLayered loads operational config in three layers:
+// the machine-wide GlobalSettingsPath file, then the town's
+// settings/config.json (with any GT_PROFILE profile applied, see
+// LoadOperationalConfigProfile) merged over it field by field, with anything
+// unset in both resolved to compiled-in defaults by the accessors. An explicit town
+// value always wins, even when it equals the default. Like
+// LoadOperationalConfig, it never returns nil and never errors.
+func LoadOperationalConfigLayered(townRoot string) *OperationalConfig {
+	merged := &OperationalConfig{}
+	if global, err := LoadOrCreateTownSettings(GlobalSettingsPath()); err == nil && global != nil {
+		mergeThresholds(merged, global.Operational)
+	}
+	mergeThresholds(merged, LoadOperationalConfigProfile(townRoot, ""))
+	return merged
+}
+
 // --- Accessor methods ---
-// Each method reads from config with fallback to the compiled-in default.
+// Each method reads from config with fallback to the compiled-in default
+// in operationalDefaults.
+// A GT_<SUBSYSTEM>_<FIELD> environment variable (see OperationalEnvVars)
+// takes precedence over the config file value.
+// Count accessors clamp to operationalIntBounds, or to zero from below when
+// a count has no bounds.
+// Duration accessors return DurationDisabled when the value is "off" or
+// "disabled" and the field is listed in operationalDisableable; elsewhere the
+// keywords are invalid and the default applies.
 // Nil-safe: works when OperationalConfig or any sub-struct is nil.
 
 // GetSessionConfig returns the session thresholds, never nil.
@@ -141,74 +228,192 @@ func (c *OperationalConfig) GetSessionConfig() *SessionThresholds {
 
 // ClaudeStartTimeout returns the configured or default Claude start timeout.
 func (s *SessionThresholds) ClaudeStartTimeoutD() time.Duration {
+	v, _ := s.ClaudeStartTimeoutWithSource()
+	return v
+}
+
+// ClaudeStartTimeoutWithSource returns ClaudeStartTimeoutD and where the value came from.
+func (s *SessionThresholds) ClaudeStartTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.ClaudeStartTimeout, DefaultClaudeStartTimeout)
+		v = s.ClaudeStartTimeout
 	}
-	return DefaultClaudeStartTimeout
+	return durationWithSource("session.claude_start_timeout", v)
 }
 
 // ShellReadyTimeoutD returns the configured or default shell ready timeout.
 func (s *SessionThresholds) ShellReadyTimeoutD() time.Duration {
+	v, _ := s.ShellReadyTimeoutWithSource()
+	return v
+}
+
+// ShellReadyTimeoutWithSource returns ShellReadyTimeoutD and where the value came from.
+func (s *SessionThresholds) ShellReadyTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.ShellReadyTimeout, DefaultShellReadyTimeout)
+		v = s.ShellReadyTimeout
 	}
-	return DefaultShellReadyTimeout
+	return durationWithSource("session.shell_ready_timeout", v)
 }
 
 // GracefulShutdownTimeoutD returns the configured or default graceful shutdown timeout.
 func (s *SessionThresholds) GracefulShutdownTimeoutD() time.Duration {
+	v, _ := s.GracefulShutdownTimeoutWithSource()
+	return v
+}
+
+// GracefulShutdownTimeoutWithSource returns GracefulShutdownTimeoutD and where the value came from.
+func (s *SessionThresholds) GracefulShutdownTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.GracefulShutdownTimeout, DefaultGracefulShutdownTimeout)
+		v = s.GracefulShutdownTimeout
 	}
-	return DefaultGracefulShutdownTimeout
+	return durationWithSource("session.graceful_shutdown_timeout", v)
 }
 
 // BdCommandTimeoutD returns the configured or default bd command timeout.
 func (s *SessionThresholds) BdCommandTimeoutD() time.Duration {
+	v, _ := s.BdCommandTimeoutWithSource()
+	return v
+}
+
+// BdCommandTimeoutWithSource returns BdCommandTimeoutD and where the value came from.
+func (s *SessionThresholds) BdCommandTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.BdCommandTimeout, DefaultBdCommandTimeout)
+		v = s.BdCommandTimeout
 	}
-	return DefaultBdCommandTimeout
+	return durationWithSource("session.bd_command_timeout", v)
 }
 
 // BdSubprocessTimeoutD returns the configured or default bd subprocess timeout.
 func (s *SessionThresholds) BdSubprocessTimeoutD() time.Duration {
+	v, _ := s.BdSubprocessTimeoutWithSource()
+	return v
+}
+
+// BdSubprocessTimeoutWithSource returns BdSubprocessTimeoutD and where the value came from.
+func (s *SessionThresholds) BdSubprocessTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.BdSubprocessTimeout, DefaultBdSubprocessTimeout)
+		v = s.BdSubprocessTimeout
 	}
-	return DefaultBdSubprocessTimeout
+	return durationWithSource("session.bd_subprocess_timeout", v)
 }
 
 // GUPPViolationTimeoutD returns the configured or default GUPP violation timeout.
 func (s *SessionThresholds) GUPPViolationTimeoutD() time.Duration {
+	v, _ := s.GUPPViolationTimeoutWithSource()
+	return v
+}
+
+// GUPPViolationTimeoutWithSource returns GUPPViolationTimeoutD and where the value came from.
+func (s *SessionThresholds) GUPPViolationTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.GUPPViolationTimeout, DefaultGUPPViolationTimeout)
+		v = s.GUPPViolationTimeout
 	}
-	return DefaultGUPPViolationTimeout
+	return durationWithSource("session.gupp_violation_timeout", v)
 }
 
 // HungSessionThresholdD returns the configured or default hung session threshold.
 func (s *SessionThresholds) HungSessionThresholdD() time.Duration {
+	v, _ := s.HungSessionThresholdWithSource()
+	return v
+}
+
+// HungSessionThresholdWithSource returns HungSessionThresholdD and where the value came from.
+func (s *SessionThresholds) HungSessionThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.HungSessionThreshold, DefaultHungSessionThreshold)
+		v = s.HungSessionThreshold
 	}
-	return DefaultHungSessionThreshold
+	return durationWithSource("session.hung_session_threshold", v)
 }
 
 // StartupNudgeVerifyDelayD returns the configured or default startup nudge verify delay.
 func (s *SessionThresholds) StartupNudgeVerifyDelayD() time.Duration {
+	v, _ := s.StartupNudgeVerifyDelayWithSource()
+	return v
+}
+
+// StartupNudgeVerifyDelayWithSource returns StartupNudgeVerifyDelayD and where the value came from.
+func (s *SessionThresholds) StartupNudgeVerifyDelayWithSource() (time.Duration, Source) {
+	var v string
 	if s != nil {
-		return ParseDurationOrDefault(s.StartupNudgeVerifyDelay, DefaultStartupNudgeVerifyDelay)
+		v = s.StartupNudgeVerifyDelay
 	}
-	return DefaultStartupNudgeVerifyDelay
+	return durationWithSource("session.startup_nudge_verify_delay", v)
 }
 
 // StartupNudgeMaxRetriesV returns the configured or default startup nudge max retries.
 func (s *SessionThresholds) StartupNudgeMaxRetriesV() int {
-	if s != nil && s.StartupNudgeMaxRetries != nil {
-		return *s.StartupNudgeMaxRetries
+	var v *int
+	if s != nil {
+		v = s.StartupNudgeMaxRetries
+	}
+	return intSetting("session.startup_nudge_max_retries", v)
+}
+
+// RespawnHookDelayD returns the configured or default auto-respawn hook delay.
+func (s *SessionThresholds) RespawnHookDelayD() time.Duration {
+	v, _ := s.RespawnHookDelayWithSource()
+	return v
+}
+
+// RespawnHookDelayWithSource returns RespawnHookDelayD and where the value came from.
+func (s *SessionThresholds) RespawnHookDelayWithSource() (time.Duration, Source) {
+	var v string
+	if s != nil {
+		v = s.RespawnHookDelay
+	}
+	return durationWithSource("session.respawn_hook_delay", v)
+}
+
+// RespawnBaseBackoffD returns the configured or default respawn base backoff.
+func (s *SessionThresholds) RespawnBaseBackoffD() time.Duration {
+	v, _ := s.RespawnBaseBackoffWithSource()
+	return v
+}
+
+// RespawnBaseBackoffWithSource returns RespawnBaseBackoffD and where the value came from.
+func (s *SessionThresholds) RespawnBaseBackoffWithSource() (time.Duration, Source) {
+	var v string
+	if s != nil {
+		v = s.RespawnBaseBackoff
+	}
+	return durationWithSource("session.respawn_base_backoff", v)
+}
+
+// RespawnBackoffMaxD returns the configured or default respawn backoff cap.
+func (s *SessionThresholds) RespawnBackoffMaxD() time.Duration {
+	v, _ := s.RespawnBackoffMaxWithSource()
+	return v
+}
+
+// RespawnBackoffMaxWithSource returns RespawnBackoffMaxD and where the value came from.
+func (s *SessionThresholds) RespawnBackoffMaxWithSource() (time.Duration, Source) {
+	var v string
+	if s != nil {
+		v = s.RespawnBackoffMax
 	}
-	return DefaultStartupNudgeMaxRetries
+	return durationWithSource("session.respawn_backoff_max", v)
+}
+
+// RespawnStablePeriodD returns the configured or default period after which
+// a respawned pane's backoff resets.
+func (s *SessionThresholds) RespawnStablePeriodD() time.Duration {
+	v, _ := s.RespawnStablePeriodWithSource()
+	return v
+}
+
+// RespawnStablePeriodWithSource returns RespawnStablePeriodD and where the value came from.
+func (s *SessionThresholds) RespawnStablePeriodWithSource() (time.Duration, Source) {
+	var v string
+	if s != nil {
+		v = s.RespawnStablePeriod
+	}
+	return durationWithSource("session.respawn_stable_period", v)
 }
 
 // --- Nudge accessors ---
@@ -223,58 +428,113 @@ func (c *OperationalConfig) GetNudgeConfig() *NudgeThresholds {
 
 // ReadyTimeoutD returns the configured or default nudge ready timeout.
 func (n *NudgeThresholds) ReadyTimeoutD() time.Duration {
+	v, _ := n.ReadyTimeoutWithSource()
+	return v
+}
+
+// ReadyTimeoutWithSource returns ReadyTimeoutD and where the value came from.
+func (n *NudgeThresholds) ReadyTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if n != nil {
-		return ParseDurationOrDefault(n.ReadyTimeout, DefaultNudgeReadyTimeout)
+		v = n.ReadyTimeout
 	}
-	return DefaultNudgeReadyTimeout
+	return durationWithSource("nudge.ready_timeout", v)
 }
 
 // RetryIntervalD returns the configured or default nudge retry interval.
 func (n *NudgeThresholds) RetryIntervalD() time.Duration {
+	v, _ := n.RetryIntervalWithSource()
+	return v
+}
+
+// RetryIntervalWithSource returns RetryIntervalD and where the value came from.
+func (n *NudgeThresholds) RetryIntervalWithSource() (time.Duration, Source) {
+	var v string
 	if n != nil {
-		return ParseDurationOrDefault(n.RetryInterval, DefaultNudgeRetryInterval)
+		v = n.RetryInterval
 	}
-	return DefaultNudgeRetryInterval
+	return durationWithSource("nudge.retry_interval", v)
 }
 
 // LockTimeoutD returns the configured or default nudge lock timeout.
 func (n *NudgeThresholds) LockTimeoutD() time.Duration {
+	v, _ := n.LockTimeoutWithSource()
+	return v
+}
+
+// LockTimeoutWithSource returns LockTimeoutD and where the value came from.
+func (n *NudgeThresholds) LockTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if n != nil {
-		return ParseDurationOrDefault(n.LockTimeout, DefaultNudgeLockTimeout)
+		v = n.LockTimeout
 	}
-	return DefaultNudgeLockTimeout
+	return durationWithSource("nudge.lock_timeout", v)
 }
 
 // NormalTTLD returns the configured or default normal nudge TTL.
 func (n *NudgeThresholds) NormalTTLD() time.Duration {
+	v, _ := n.NormalTTLWithSource()
+	return v
+}
+
+// NormalTTLWithSource returns NormalTTLD and where the value came from.
+func (n *NudgeThresholds) NormalTTLWithSource() (time.Duration, Source) {
+	var v string
 	if n != nil {
-		return ParseDurationOrDefault(n.NormalTTL, DefaultNudgeNormalTTL)
+		v = n.NormalTTL
 	}
-	return DefaultNudgeNormalTTL
+	return durationWithSource("nudge.normal_ttl", v)
 }
 
 // UrgentTTLD returns the configured or default urgent nudge TTL.
 func (n *NudgeThresholds) UrgentTTLD() time.Duration {
+	v, _ := n.UrgentTTLWithSource()
+	return v
+}
+
+// UrgentTTLWithSource returns UrgentTTLD and where the value came from.
+func (n *NudgeThresholds) UrgentTTLWithSource() (time.Duration, Source) {
+	var v string
 	if n != nil {
-		return ParseDurationOrDefault(n.UrgentTTL, DefaultNudgeUrgentTTL)
+		v = n.UrgentTTL
 	}
-	return DefaultNudgeUrgentTTL
+	return durationWithSource("nudge.urgent_ttl", v)
 }
 
 // MaxQueueDepthV returns the configured or default max queue depth.
+// Values outside [MinNudgeQueueDepth, MaxNudgeQueueDepthLimit] are clamped.
 func (n *NudgeThresholds) MaxQueueDepthV() int {
-	if n != nil && n.MaxQueueDepth != nil {
-		return *n.MaxQueueDepth
+	var v *int
+	if n != nil {
+		v = n.MaxQueueDepth
+	}
+	return intSetting("nudge.max_queue_depth", v)
+}
+
+// UrgentQueueHeadroomV returns the configured or default number of urgent
+// nudges allowed beyond MaxQueueDepthV.
+// Values outside [MinNudgeUrgentHeadroom, MaxNudgeUrgentHeadroomLimit] are clamped.
+func (n *NudgeThresholds) UrgentQueueHeadroomV() int {
+	var v *int
+	if n != nil {
+		v = n.UrgentQueueHeadroom
 	}
-	return DefaultNudgeMaxQueueDepth
+	return intSetting("nudge.urgent_queue_headroom", v)
 }
 
 // StaleClaimThresholdD returns the configured or default stale claim threshold.
 func (n *NudgeThresholds) StaleClaimThresholdD() time.Duration {
+	v, _ := n.StaleClaimThresholdWithSource()
+	return v
+}
+
+// StaleClaimThresholdWithSource returns StaleClaimThresholdD and where the value came from.
+func (n *NudgeThresholds) StaleClaimThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if n != nil {
-		return ParseDurationOrDefault(n.StaleClaimThreshold, DefaultNudgeStaleClaimTimeout)
+		v = n.StaleClaimThreshold
 	}
-	return DefaultNudgeStaleClaimTimeout
+	return durationWithSource("nudge.stale_claim_threshold", v)
 }
 
 // --- Daemon accessors ---
@@ -289,26 +549,58 @@ func (c *OperationalConfig) GetDaemonConfig() *DaemonThresholds {
 
 // MassDeathWindowD returns the configured or default mass death window.
 func (d *DaemonThresholds) MassDeathWindowD() time.Duration {
+	v, _ := d.MassDeathWindowWithSource()
+	return v
+}
+
+// MassDeathWindowWithSource returns MassDeathWindowD and where the value came from.
+func (d *DaemonThresholds) MassDeathWindowWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.MassDeathWindow, DefaultMassDeathWindow)
+		v = d.MassDeathWindow
 	}
-	return DefaultMassDeathWindow
+	return durationWithSource("daemon.mass_death_window", v)
 }
 
 // MassDeathThresholdV returns the configured or default mass death threshold.
+// Values outside [MinMassDeathThreshold, MaxMassDeathThresholdLimit] are clamped.
 func (d *DaemonThresholds) MassDeathThresholdV() int {
-	if d != nil && d.MassDeathThreshold != nil {
-		return *d.MassDeathThreshold
+	var v *int
+	if d != nil {
+		v = d.MassDeathThreshold
+	}
+	return intSetting("daemon.mass_death_threshold", v)
+}
+
+// MassDeathRespawnPauseD returns the configured or default auto-respawn pause
+// after a mass death.
+func (d *DaemonThresholds) MassDeathRespawnPauseD() time.Duration {
+	v, _ := d.MassDeathRespawnPauseWithSource()
+	return v
+}
+
+// MassDeathRespawnPauseWithSource returns MassDeathRespawnPauseD and where the value came from.
+func (d *DaemonThresholds) MassDeathRespawnPauseWithSource() (time.Duration, Source) {
+	var v string
+	if d != nil {
+		v = d.MassDeathRespawnPause
 	}
-	return DefaultMassDeathThreshold
+	return durationWithSource("daemon.mass_death_respawn_pause", v)
 }
 
 // DogIdleSessionTimeoutD returns the configured or default dog idle session timeout.
 func (d *DaemonThresholds) DogIdleSessionTimeoutD() time.Duration {
+	v, _ := d.DogIdleSessionTimeoutWithSource()
+	return v
+}
+
+// DogIdleSessionTimeoutWithSource returns DogIdleSessionTimeoutD and where the value came from.
+func (d *DaemonThresholds) DogIdleSessionTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.DogIdleSessionTimeout, DefaultDogIdleSessionTimeout)
+		v = d.DogIdleSessionTimeout
 	}
-	return DefaultDogIdleSessionTimeout
+	return durationWithSource("daemon.dog_idle_session_timeout", v)
 }
 
 // PolecatIdleSessionTimeoutD returns the configured or default polecat idle session timeout.
@@ -316,115 +608,280 @@ func (d *DaemonThresholds) DogIdleSessionTimeoutD() time.Duration {
 // threshold are auto-killed to prevent API slot burn. Default 15 minutes — long enough
 // for polecats to run gt done after completing work, short enough to prevent hour-long burns.
 func (d *DaemonThresholds) PolecatIdleSessionTimeoutD() time.Duration {
+	v, _ := d.PolecatIdleSessionTimeoutWithSource()
+	return v
+}
+
+// PolecatIdleSessionTimeoutWithSource returns PolecatIdleSessionTimeoutD and where the value came from.
+func (d *DaemonThresholds) PolecatIdleSessionTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.PolecatIdleSessionTimeout, DefaultPolecatIdleSessionTimeout)
+		v = d.PolecatIdleSessionTimeout
 	}
-	return DefaultPolecatIdleSessionTimeout
+	return durationWithSource("daemon.polecat_idle_session_timeout", v)
 }
 
 // DogIdleRemoveTimeoutD returns the configured or default dog idle remove timeout.
 func (d *DaemonThresholds) DogIdleRemoveTimeoutD() time.Duration {
+	v, _ := d.DogIdleRemoveTimeoutWithSource()
+	return v
+}
+
+// DogIdleRemoveTimeoutWithSource returns DogIdleRemoveTimeoutD and where the value came from.
+func (d *DaemonThresholds) DogIdleRemoveTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.DogIdleRemoveTimeout, DefaultDogIdleRemoveTimeout)
+		v = d.DogIdleRemoveTimeout
 	}
-	return DefaultDogIdleRemoveTimeout
+	return durationWithSource("daemon.dog_idle_remove_timeout", v)
 }
 
 // StaleWorkingTimeoutD returns the configured or default stale working timeout.
 func (d *DaemonThresholds) StaleWorkingTimeoutD() time.Duration {
+	v, _ := d.StaleWorkingTimeoutWithSource()
+	return v
+}
+
+// StaleWorkingTimeoutWithSource returns StaleWorkingTimeoutD and where the value came from.
+func (d *DaemonThresholds) StaleWorkingTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.StaleWorkingTimeout, DefaultStaleWorkingTimeout)
+		v = d.StaleWorkingTimeout
 	}
-	return DefaultStaleWorkingTimeout
+	return durationWithSource("daemon.stale_working_timeout", v)
 }
 
 // MaxDogPoolSizeV returns the configured or default max dog pool size.
+// Values outside [MinDogPoolSize, MaxDogPoolSizeLimit] are clamped.
 func (d *DaemonThresholds) MaxDogPoolSizeV() int {
-	if d != nil && d.MaxDogPoolSize != nil {
-		return *d.MaxDogPoolSize
+	var v *int
+	if d != nil {
+		v = d.MaxDogPoolSize
 	}
-	return DefaultMaxDogPoolSize
+	return intSetting("daemon.max_dog_pool_size", v)
 }
 
 // MaxLifecycleMessageAgeD returns the configured or default max lifecycle message age.
 func (d *DaemonThresholds) MaxLifecycleMessageAgeD() time.Duration {
+	v, _ := d.MaxLifecycleMessageAgeWithSource()
+	return v
+}
+
+// MaxLifecycleMessageAgeWithSource returns MaxLifecycleMessageAgeD and where the value came from.
+func (d *DaemonThresholds) MaxLifecycleMessageAgeWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.MaxLifecycleMessageAge, DefaultMaxLifecycleMessageAge)
+		v = d.MaxLifecycleMessageAge
 	}
-	return DefaultMaxLifecycleMessageAge
+	return durationWithSource("daemon.max_lifecycle_message_age", v)
 }
 
 // SyncFailureEscalationThresholdV returns the configured or default threshold.
 func (d *DaemonThresholds) SyncFailureEscalationThresholdV() int {
-	if d != nil && d.SyncFailureEscalationThreshold != nil {
-		return *d.SyncFailureEscalationThreshold
+	var v *int
+	if d != nil {
+		v = d.SyncFailureEscalationThreshold
+	}
+	return intSetting("daemon.sync_failure_escalation_threshold", v)
+}
+
+// SyncFailureStagesV returns the configured sync failure escalation stages,
+// normalized by NormalizeEscalationStages. When none are configured (or none
+// are valid) it returns a single EscalationActionError stage at
+// SyncFailureEscalationThresholdV, matching the legacy WARN→ERROR behavior.
+func (d *DaemonThresholds) SyncFailureStagesV() []EscalationStage {
+	if d != nil {
+		if stages := NormalizeEscalationStages(d.SyncFailureStages); len(stages) > 0 {
+			return stages
+		}
+	}
+	return []EscalationStage{{Count: d.SyncFailureEscalationThresholdV(), Action: EscalationActionError}}
+}
+
+// NormalizeEscalationStages returns stages sorted by ascending Count with
+// invalid entries (Count < 1 or an unknown action) dropped and actions
+// lowercased. When several stages share a Count, the one defined last wins.
+func NormalizeEscalationStages(stages []EscalationStage) []EscalationStage {
+	out := make([]EscalationStage, 0, len(stages))
+	for _, st := range stages {
+		st.Action = strings.ToLower(strings.TrimSpace(st.Action))
+		if st.Count < 1 || !IsEscalationAction(st.Action) {
+			continue
+		}
+		out = append(out, st)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Count < out[j].Count })
+
+	deduped := out[:0]
+	for i, st := range out {
+		if i+1 < len(out) && out[i+1].Count == st.Count {
+			continue
+		}
+		deduped = append(deduped, st)
 	}
-	return DefaultSyncFailureEscalationThreshold
+	return deduped
+}
+
+// IsEscalationAction reports whether action is a known EscalationAction* value.
+func IsEscalationAction(action string) bool {
+	switch action {
+	case EscalationActionWarn, EscalationActionError, EscalationActionPage, EscalationActionHalt:
+		return true
+	}
+	return false
+}
+
+// ActiveEscalationStage returns the highest stage whose Count is at most
+// failures. stages must be normalized. ok is false when no stage applies yet.
+func ActiveEscalationStage(stages []EscalationStage, failures int) (stage EscalationStage, ok bool) {
+	for _, st := range stages {
+		if st.Count > failures {
+			break
+		}
+		stage, ok = st, true
+	}
+	return stage, ok
 }
 
 // DoctorMolCooldownD returns the configured or default doctor mol cooldown.
 func (d *DaemonThresholds) DoctorMolCooldownD() time.Duration {
+	v, _ := d.DoctorMolCooldownWithSource()
+	return v
+}
+
+// DoctorMolCooldownWithSource returns DoctorMolCooldownD and where the value came from.
+func (d *DaemonThresholds) DoctorMolCooldownWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.DoctorMolCooldown, DefaultDoctorMolCooldown)
+		v = d.DoctorMolCooldown
 	}
-	return DefaultDoctorMolCooldown
+	return durationWithSource("daemon.doctor_mol_cooldown", v)
 }
 
 // RecoveryHeartbeatIntervalD returns the configured or default recovery heartbeat interval.
 func (d *DaemonThresholds) RecoveryHeartbeatIntervalD() time.Duration {
+	v, _ := d.RecoveryHeartbeatIntervalWithSource()
+	return v
+}
+
+// RecoveryHeartbeatIntervalWithSource returns RecoveryHeartbeatIntervalD and where the value came from.
+func (d *DaemonThresholds) RecoveryHeartbeatIntervalWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.RecoveryHeartbeatInterval, DefaultRecoveryHeartbeatInterval)
+		v = d.RecoveryHeartbeatInterval
 	}
-	return DefaultRecoveryHeartbeatInterval
+	return durationWithSource("daemon.recovery_heartbeat_interval", v)
 }
 
 // BootSpawnCooldownD returns the configured or default boot spawn cooldown.
 func (d *DaemonThresholds) BootSpawnCooldownD() time.Duration {
+	v, _ := d.BootSpawnCooldownWithSource()
+	return v
+}
+
+// BootSpawnCooldownWithSource returns BootSpawnCooldownD and where the value came from.
+func (d *DaemonThresholds) BootSpawnCooldownWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.BootSpawnCooldown, DefaultBootSpawnCooldown)
+		v = d.BootSpawnCooldown
 	}
-	return DefaultBootSpawnCooldown
+	return durationWithSource("daemon.boot_spawn_cooldown", v)
+}
+
+// BootSpawnCooldownForRole returns the boot spawn cooldown for role: its
+// boot_spawn_cooldown_by_role override if set, otherwise BootSpawnCooldownD.
+// The override at daemon.boot_spawn_cooldown_by_role.<role> resolves like any
+// other duration threshold, so GT_DAEMON_BOOT_SPAWN_COOLDOWN_BY_ROLE_<ROLE>
+// takes precedence over the file value.
+func (d *DaemonThresholds) BootSpawnCooldownForRole(role string) time.Duration {
+	var v string
+	if d != nil {
+		v = d.BootSpawnCooldownByRole[role]
+	}
+	cooldown, _ := durationWithFallback("daemon.boot_spawn_cooldown_by_role."+role, v, d.BootSpawnCooldownD())
+	return cooldown
 }
 
 // BootIdleSuppressionD returns the configured or default boot idle suppression duration.
 // When Boot's last action was "nothing" (deacon healthy), spawns are suppressed for this long.
 func (d *DaemonThresholds) BootIdleSuppressionD() time.Duration {
+	v, _ := d.BootIdleSuppressionWithSource()
+	return v
+}
+
+// BootIdleSuppressionWithSource returns BootIdleSuppressionD and where the value came from.
+func (d *DaemonThresholds) BootIdleSuppressionWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.BootIdleSuppression, DefaultBootIdleSuppression)
+		v = d.BootIdleSuppression
 	}
-	return DefaultBootIdleSuppression
+	return durationWithSource("daemon.boot_idle_suppression", v)
 }
 
 // DeaconGracePeriodD returns the configured or default deacon grace period.
 func (d *DaemonThresholds) DeaconGracePeriodD() time.Duration {
+	v, _ := d.DeaconGracePeriodWithSource()
+	return v
+}
+
+// DeaconGracePeriodWithSource returns DeaconGracePeriodD and where the value came from.
+func (d *DaemonThresholds) DeaconGracePeriodWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.DeaconGracePeriod, DefaultDeaconGracePeriod)
+		v = d.DeaconGracePeriod
 	}
-	return DefaultDeaconGracePeriod
+	return durationWithSource("daemon.deacon_grace_period", v)
+}
+
+// RespawnMaxAttemptsV returns the configured or default max hook respawns per window.
+func (d *DaemonThresholds) RespawnMaxAttemptsV() int {
+	var v *int
+	if d != nil {
+		v = d.RespawnMaxAttempts
+	}
+	return intSetting("daemon.respawn_max_attempts", v)
+}
+
+// RespawnWindowD returns the configured or default respawn counting window.
+func (d *DaemonThresholds) RespawnWindowD() time.Duration {
+	v, _ := d.RespawnWindowWithSource()
+	return v
+}
+
+// RespawnWindowWithSource returns RespawnWindowD and where the value came from.
+func (d *DaemonThresholds) RespawnWindowWithSource() (time.Duration, Source) {
+	var v string
+	if d != nil {
+		v = d.RespawnWindow
+	}
+	return durationWithSource("daemon.respawn_window", v)
 }
 
 // PressureCPUThresholdV returns the configured or default CPU pressure threshold (load per core).
 func (d *DaemonThresholds) PressureCPUThresholdV() float64 {
-	if d != nil && d.PressureCPUThreshold != nil {
-		return *d.PressureCPUThreshold
+	var v *float64
+	if d != nil {
+		v = d.PressureCPUThreshold
 	}
-	return DefaultPressureCPUThreshold
+	return floatSetting("daemon.pressure_cpu_threshold", v)
 }
 
 // PressureMemThresholdGBV returns the configured or default memory pressure threshold in GB.
 func (d *DaemonThresholds) PressureMemThresholdGBV() float64 {
-	if d != nil && d.PressureMemThresholdGB != nil {
-		return *d.PressureMemThresholdGB
+	var v *float64
+	if d != nil {
+		v = d.PressureMemThresholdGB
 	}
-	return DefaultPressureMemThresholdGB
+	return floatSetting("daemon.pressure_mem_threshold_gb", v)
 }
 
 // PressureMaxSessionsV returns the configured or default max concurrent sessions (0 = unlimited).
 func (d *DaemonThresholds) PressureMaxSessionsV() int {
-	if d != nil && d.PressureMaxSessions != nil {
-		return *d.PressureMaxSessions
+	var v *int
+	if d != nil {
+		v = d.PressureMaxSessions
 	}
-	return DefaultPressureMaxSessions
+	return intSetting("daemon.pressure_max_sessions", v)
 }
 
 // --- Deacon accessors ---
@@ -439,74 +896,176 @@ func (c *OperationalConfig) GetDeaconConfig() *DeaconThresholds {
 
 // PingTimeoutD returns the configured or default deacon ping timeout.
 func (d *DeaconThresholds) PingTimeoutD() time.Duration {
+	v, _ := d.PingTimeoutWithSource()
+	return v
+}
+
+// PingTimeoutWithSource returns PingTimeoutD and where the value came from.
+func (d *DeaconThresholds) PingTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.PingTimeout, DefaultDeaconPingTimeout)
+		v = d.PingTimeout
 	}
-	return DefaultDeaconPingTimeout
+	return durationWithSource("deacon.ping_timeout", v)
 }
 
 // ConsecutiveFailuresV returns the configured or default consecutive failures.
 func (d *DeaconThresholds) ConsecutiveFailuresV() int {
-	if d != nil && d.ConsecutiveFailures != nil {
-		return *d.ConsecutiveFailures
+	var v *int
+	if d != nil {
+		v = d.ConsecutiveFailures
 	}
-	return DefaultDeaconConsecutiveFailures
+	return intSetting("deacon.consecutive_failures", v)
 }
 
 // CooldownD returns the configured or default deacon cooldown.
 func (d *DeaconThresholds) CooldownD() time.Duration {
+	v, _ := d.CooldownWithSource()
+	return v
+}
+
+// CooldownWithSource returns CooldownD and where the value came from.
+func (d *DeaconThresholds) CooldownWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.Cooldown, DefaultDeaconCooldown)
+		v = d.Cooldown
 	}
-	return DefaultDeaconCooldown
+	return durationWithSource("deacon.cooldown", v)
 }
 
 // HeartbeatStaleThresholdD returns the configured or default heartbeat stale threshold.
 func (d *DeaconThresholds) HeartbeatStaleThresholdD() time.Duration {
+	v, _ := d.HeartbeatStaleThresholdWithSource()
+	return v
+}
+
+// HeartbeatStaleThresholdWithSource returns HeartbeatStaleThresholdD and where the value came from.
+func (d *DeaconThresholds) HeartbeatStaleThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.HeartbeatStaleThreshold, DefaultDeaconHeartbeatStaleThreshold)
+		v = d.HeartbeatStaleThreshold
 	}
-	return DefaultDeaconHeartbeatStaleThreshold
+	return durationWithSource("deacon.heartbeat_stale_threshold", v)
 }
 
 // HeartbeatVeryStaleThresholdD returns the configured or default heartbeat very stale threshold.
 func (d *DeaconThresholds) HeartbeatVeryStaleThresholdD() time.Duration {
+	v, _ := d.HeartbeatVeryStaleThresholdWithSource()
+	return v
+}
+
+// HeartbeatVeryStaleThresholdWithSource returns HeartbeatVeryStaleThresholdD and where the value came from.
+func (d *DeaconThresholds) HeartbeatVeryStaleThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.HeartbeatVeryStaleThreshold, DefaultDeaconHeartbeatVeryStale)
+		v = d.HeartbeatVeryStaleThreshold
 	}
-	return DefaultDeaconHeartbeatVeryStale
+	return durationWithSource("deacon.heartbeat_very_stale_threshold", v)
 }
 
 // MaxRedispatchesV returns the configured or default max redispatches.
 func (d *DeaconThresholds) MaxRedispatchesV() int {
-	if d != nil && d.MaxRedispatches != nil {
-		return *d.MaxRedispatches
+	var v *int
+	if d != nil {
+		v = d.MaxRedispatches
 	}
-	return DefaultMaxRedispatches
+	return intSetting("deacon.max_redispatches", v)
 }
 
 // RedispatchCooldownD returns the configured or default redispatch cooldown.
 func (d *DeaconThresholds) RedispatchCooldownD() time.Duration {
+	v, _ := d.RedispatchCooldownWithSource()
+	return v
+}
+
+// RedispatchCooldownWithSource returns RedispatchCooldownD and where the value came from.
+func (d *DeaconThresholds) RedispatchCooldownWithSource() (time.Duration, Source) {
+	var v string
+	if d != nil {
+		v = d.RedispatchCooldown
+	}
+	return durationWithSource("deacon.redispatch_cooldown", v)
+}
+
+// RedispatchCooldownJitterD returns the configured or default redispatch cooldown jitter.
+func (d *DeaconThresholds) RedispatchCooldownJitterD() time.Duration {
+	v, _ := d.RedispatchCooldownJitterWithSource()
+	return v
+}
+
+// RedispatchCooldownJitterWithSource returns RedispatchCooldownJitterD and where the value came from.
+func (d *DeaconThresholds) RedispatchCooldownJitterWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.RedispatchCooldown, DefaultRedispatchCooldown)
+		v = d.RedispatchCooldownJitter
 	}
-	return DefaultRedispatchCooldown
+	return durationWithSource("deacon.redispatch_cooldown_jitter", v)
+}
+
+// RedispatchCooldownWithJitter returns RedispatchCooldownD plus a random
+// duration in [0, RedispatchCooldownJitterD). With no jitter configured it
+// returns exactly RedispatchCooldownD.
+func (d *DeaconThresholds) RedispatchCooldownWithJitter() time.Duration {
+	return addJitter(d.RedispatchCooldownD(), d.RedispatchCooldownJitterD(), jitterInt63n)
 }
 
 // MaxFeedsPerCycleV returns the configured or default max feeds per cycle.
 func (d *DeaconThresholds) MaxFeedsPerCycleV() int {
-	if d != nil && d.MaxFeedsPerCycle != nil {
-		return *d.MaxFeedsPerCycle
+	var v *int
+	if d != nil {
+		v = d.MaxFeedsPerCycle
 	}
-	return DefaultMaxFeedsPerCycle
+	return intSetting("deacon.max_feeds_per_cycle", v)
 }
 
 // FeedCooldownD returns the configured or default feed cooldown.
 func (d *DeaconThresholds) FeedCooldownD() time.Duration {
+	v, _ := d.FeedCooldownWithSource()
+	return v
+}
+
+// FeedCooldownWithSource returns FeedCooldownD and where the value came from.
+func (d *DeaconThresholds) FeedCooldownWithSource() (time.Duration, Source) {
+	var v string
+	if d != nil {
+		v = d.FeedCooldown
+	}
+	return durationWithSource("deacon.feed_cooldown", v)
+}
+
+// FeedCooldownJitterD returns the configured or default feed cooldown jitter.
+func (d *DeaconThresholds) FeedCooldownJitterD() time.Duration {
+	v, _ := d.FeedCooldownJitterWithSource()
+	return v
+}
+
+// FeedCooldownJitterWithSource returns FeedCooldownJitterD and where the value came from.
+func (d *DeaconThresholds) FeedCooldownJitterWithSource() (time.Duration, Source) {
+	var v string
 	if d != nil {
-		return ParseDurationOrDefault(d.FeedCooldown, DefaultFeedCooldown)
+		v = d.FeedCooldownJitter
 	}
-	return DefaultFeedCooldown
+	return durationWithSource("deacon.feed_cooldown_jitter", v)
+}
+
+// FeedCooldownWithJitter returns FeedCooldownD plus a random duration in
+// [0, FeedCooldownJitterD). With no jitter configured it returns exactly
+// FeedCooldownD.
+func (d *DeaconThresholds) FeedCooldownWithJitter() time.Duration {
+	return addJitter(d.FeedCooldownD(), d.FeedCooldownJitterD(), jitterInt63n)
+}
+
+// jitterInt63n is the random source for cooldown jitter; tests replace it
+// to get deterministic output.
+var jitterInt63n = rand.Int63n
+
+// addJitter returns base plus a random duration in [0, jitter) drawn from
+// int63n. A non-positive jitter, or a disabled/non-positive base, returns base unchanged.
+func addJitter(base, jitter time.Duration, int63n func(int64) int64) time.Duration {
+	if jitter <= 0 || base <= 0 {
+		return base
+	}
+	return base + time.Duration(int63n(int64(jitter)))
 }
 
 // --- Polecat accessors ---
@@ -521,50 +1080,102 @@ func (c *OperationalConfig) GetPolecatConfig() *PolecatThresholds {
 
 // HeartbeatStaleThresholdD returns the configured or default polecat heartbeat stale threshold.
 func (p *PolecatThresholds) HeartbeatStaleThresholdD() time.Duration {
+	v, _ := p.HeartbeatStaleThresholdWithSource()
+	return v
+}
+
+// HeartbeatStaleThresholdWithSource returns HeartbeatStaleThresholdD and where the value came from.
+func (p *PolecatThresholds) HeartbeatStaleThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if p != nil {
-		return ParseDurationOrDefault(p.HeartbeatStaleThreshold, DefaultPolecatHeartbeatStale)
+		v = p.HeartbeatStaleThreshold
 	}
-	return DefaultPolecatHeartbeatStale
+	return durationWithSource("polecat.heartbeat_stale_threshold", v)
 }
 
 // DoltMaxRetriesV returns the configured or default Dolt max retries.
 func (p *PolecatThresholds) DoltMaxRetriesV() int {
-	if p != nil && p.DoltMaxRetries != nil {
-		return *p.DoltMaxRetries
+	var v *int
+	if p != nil {
+		v = p.DoltMaxRetries
 	}
-	return DefaultPolecatDoltMaxRetries
+	return intSetting("polecat.dolt_max_retries", v)
 }
 
 // DoltBaseBackoffD returns the configured or default Dolt base backoff.
 func (p *PolecatThresholds) DoltBaseBackoffD() time.Duration {
+	v, _ := p.DoltBaseBackoffWithSource()
+	return v
+}
+
+// DoltBaseBackoffWithSource returns DoltBaseBackoffD and where the value came from.
+func (p *PolecatThresholds) DoltBaseBackoffWithSource() (time.Duration, Source) {
+	var v string
 	if p != nil {
-		return ParseDurationOrDefault(p.DoltBaseBackoff, DefaultPolecatDoltBaseBackoff)
+		v = p.DoltBaseBackoff
 	}
-	return DefaultPolecatDoltBaseBackoff
+	return durationWithSource("polecat.dolt_base_backoff", v)
 }
 
 // DoltBackoffMaxD returns the configured or default Dolt backoff max.
 func (p *PolecatThresholds) DoltBackoffMaxD() time.Duration {
+	v, _ := p.DoltBackoffMaxWithSource()
+	return v
+}
+
+// DoltBackoffMaxWithSource returns DoltBackoffMaxD and where the value came from.
+func (p *PolecatThresholds) DoltBackoffMaxWithSource() (time.Duration, Source) {
+	var v string
 	if p != nil {
-		return ParseDurationOrDefault(p.DoltBackoffMax, DefaultPolecatDoltBackoffMax)
+		v = p.DoltBackoffMax
 	}
-	return DefaultPolecatDoltBackoffMax
+	return durationWithSource("polecat.dolt_backoff_max", v)
 }
 
 // PendingMaxAgeD returns the configured or default pending max age.
 func (p *PolecatThresholds) PendingMaxAgeD() time.Duration {
+	v, _ := p.PendingMaxAgeWithSource()
+	return v
+}
+
+// PendingMaxAgeWithSource returns PendingMaxAgeD and where the value came from.
+func (p *PolecatThresholds) PendingMaxAgeWithSource() (time.Duration, Source) {
+	var v string
 	if p != nil {
-		return ParseDurationOrDefault(p.PendingMaxAge, DefaultPolecatPendingMaxAge)
+		v = p.PendingMaxAge
 	}
-	return DefaultPolecatPendingMaxAge
+	return durationWithSource("polecat.pending_max_age", v)
 }
 
 // NamepoolSizeV returns the configured or default namepool size.
+// Values outside [MinPolecatNamepoolSize, MaxPolecatNamepoolSizeLimit] are clamped.
 func (p *PolecatThresholds) NamepoolSizeV() int {
-	if p != nil && p.NamepoolSize != nil {
-		return *p.NamepoolSize
+	var v *int
+	if p != nil {
+		v = p.NamepoolSize
 	}
-	return DefaultPolecatNamepoolSize
+	return intSetting("polecat.namepool_size", v)
+}
+
+// NamepoolGrowIncrementV returns the configured or default number of slots added
+// when the namepool is exhausted. Zero disables growth.
+// Values outside [MinPolecatNamepoolGrowBy, MaxPolecatNamepoolGrowByLimit] are clamped.
+func (p *PolecatThresholds) NamepoolGrowIncrementV() int {
+	var v *int
+	if p != nil {
+		v = p.NamepoolGrowIncrement
+	}
+	return intSetting("polecat.namepool_grow_increment", v)
+}
+
+// NamepoolMaxSizeV returns the configured or default hard cap on namepool growth.
+// Values outside [MinPolecatNamepoolSize, MaxPolecatNamepoolSizeLimit] are clamped.
+func (p *PolecatThresholds) NamepoolMaxSizeV() int {
+	var v *int
+	if p != nil {
+		v = p.NamepoolMaxSize
+	}
+	return intSetting("polecat.namepool_max_size", v)
 }
 
 // --- Dolt accessors ---
@@ -579,34 +1190,67 @@ func (c *OperationalConfig) GetDoltConfig() *DoltThresholds {
 
 // HealthCheckIntervalD returns the configured or default health check interval.
 func (dt *DoltThresholds) HealthCheckIntervalD() time.Duration {
+	v, _ := dt.HealthCheckIntervalWithSource()
+	return v
+}
+
+// HealthCheckIntervalWithSource returns HealthCheckIntervalD and where the value came from.
+func (dt *DoltThresholds) HealthCheckIntervalWithSource() (time.Duration, Source) {
+	var v string
 	if dt != nil {
-		return ParseDurationOrDefault(dt.HealthCheckInterval, DefaultDoltHealthCheckInterval)
+		v = dt.HealthCheckInterval
 	}
-	return DefaultDoltHealthCheckInterval
+	return durationWithSource("dolt.health_check_interval", v)
 }
 
 // CmdTimeoutD returns the configured or default cmd timeout.
 func (dt *DoltThresholds) CmdTimeoutD() time.Duration {
+	v, _ := dt.CmdTimeoutWithSource()
+	return v
+}
+
+// CmdTimeoutWithSource returns CmdTimeoutD and where the value came from.
+func (dt *DoltThresholds) CmdTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if dt != nil {
-		return ParseDurationOrDefault(dt.CmdTimeout, DefaultDoltCmdTimeout)
+		v = dt.CmdTimeout
 	}
-	return DefaultDoltCmdTimeout
+	return durationWithSource("dolt.cmd_timeout", v)
 }
 
 // MaxConnectionsV returns the configured or default max connections.
+// Values outside [MinDoltConnections, MaxDoltConnectionsLimit] are clamped.
 func (dt *DoltThresholds) MaxConnectionsV() int {
-	if dt != nil && dt.MaxConnections != nil {
-		return *dt.MaxConnections
+	var v *int
+	if dt != nil {
+		v = dt.MaxConnections
 	}
-	return DefaultDoltMaxConnections
+	return intSetting("dolt.max_connections", v)
 }
 
 // SlowQueryThresholdD returns the configured or default slow query threshold.
 func (dt *DoltThresholds) SlowQueryThresholdD() time.Duration {
+	v, _ := dt.SlowQueryThresholdWithSource()
+	return v
+}
+
+// SlowQueryThresholdWithSource returns SlowQueryThresholdD and where the value came from.
+func (dt *DoltThresholds) SlowQueryThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if dt != nil {
-		return ParseDurationOrDefault(dt.SlowQueryThreshold, DefaultDoltSlowQueryThreshold)
+		v = dt.SlowQueryThreshold
 	}
-	return DefaultDoltSlowQueryThreshold
+	return durationWithSource("dolt.slow_query_threshold", v)
+}
+
+// PortV returns the configured Dolt port, or 0 when unset.
+// Values above MaxDoltPort are clamped.
+func (dt *DoltThresholds) PortV() int {
+	var v *int
+	if dt != nil {
+		v = dt.Port
+	}
+	return intSetting("dolt.port", v)
 }
 
 // --- Mail accessors ---
@@ -621,43 +1265,73 @@ func (c *OperationalConfig) GetMailConfig() *MailThresholds {
 
 // IdleNotifyTimeoutD returns the configured or default idle notify timeout.
 func (m *MailThresholds) IdleNotifyTimeoutD() time.Duration {
+	v, _ := m.IdleNotifyTimeoutWithSource()
+	return v
+}
+
+// IdleNotifyTimeoutWithSource returns IdleNotifyTimeoutD and where the value came from.
+func (m *MailThresholds) IdleNotifyTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if m != nil {
-		return ParseDurationOrDefault(m.IdleNotifyTimeout, DefaultMailIdleNotifyTimeout)
+		v = m.IdleNotifyTimeout
 	}
-	return DefaultMailIdleNotifyTimeout
+	return durationWithSource("mail.idle_notify_timeout", v)
 }
 
 // BdReadTimeoutD returns the configured or default bd read timeout.
 func (m *MailThresholds) BdReadTimeoutD() time.Duration {
+	v, _ := m.BdReadTimeoutWithSource()
+	return v
+}
+
+// BdReadTimeoutWithSource returns BdReadTimeoutD and where the value came from.
+func (m *MailThresholds) BdReadTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if m != nil {
-		return ParseDurationOrDefault(m.BdReadTimeout, DefaultMailBdReadTimeout)
+		v = m.BdReadTimeout
 	}
-	return DefaultMailBdReadTimeout
+	return durationWithSource("mail.bd_read_timeout", v)
 }
 
 // BdWriteTimeoutD returns the configured or default bd write timeout.
 func (m *MailThresholds) BdWriteTimeoutD() time.Duration {
+	v, _ := m.BdWriteTimeoutWithSource()
+	return v
+}
+
+// BdWriteTimeoutWithSource returns BdWriteTimeoutD and where the value came from.
+func (m *MailThresholds) BdWriteTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if m != nil {
-		return ParseDurationOrDefault(m.BdWriteTimeout, DefaultMailBdWriteTimeout)
+		v = m.BdWriteTimeout
 	}
-	return DefaultMailBdWriteTimeout
+	return durationWithSource("mail.bd_write_timeout", v)
 }
 
 // MaxConcurrentAckOpsV returns the configured or default max concurrent ack ops.
+// Values outside [MinMailConcurrentAcks, MaxMailConcurrentAcksLimit] are clamped.
 func (m *MailThresholds) MaxConcurrentAckOpsV() int {
-	if m != nil && m.MaxConcurrentAckOps != nil {
-		return *m.MaxConcurrentAckOps
+	var v *int
+	if m != nil {
+		v = m.MaxConcurrentAckOps
 	}
-	return DefaultMailMaxConcurrentAcks
+	return intSetting("mail.max_concurrent_ack_ops", v)
 }
 
 // ReplyReminderDelayD returns the configured or default reply reminder delay.
 // A zero duration means reply reminders are disabled.
 func (m *MailThresholds) ReplyReminderDelayD() time.Duration {
+	v, _ := m.ReplyReminderDelayWithSource()
+	return v
+}
+
+// ReplyReminderDelayWithSource returns ReplyReminderDelayD and where the value came from.
+func (m *MailThresholds) ReplyReminderDelayWithSource() (time.Duration, Source) {
+	var v string
 	if m != nil {
-		return ParseDurationOrDefault(m.ReplyReminderDelay, DefaultMailReplyReminderDelay)
+		v = m.ReplyReminderDelay
 	}
-	return DefaultMailReplyReminderDelay
+	return durationWithSource("mail.reply_reminder_delay", v)
 }
 
 // --- Web accessors ---
@@ -671,27 +1345,41 @@ func (c *OperationalConfig) GetWebConfig() *WebThresholds {
 }
 
 // MaxConcurrentCommandsV returns the configured or default max concurrent commands.
+// Values outside [MinWebConcurrentCmds, MaxWebConcurrentCmdsLimit] are clamped.
 func (w *WebThresholds) MaxConcurrentCommandsV() int {
-	if w != nil && w.MaxConcurrentCommands != nil {
-		return *w.MaxConcurrentCommands
+	var v *int
+	if w != nil {
+		v = w.MaxConcurrentCommands
 	}
-	return DefaultWebMaxConcurrentCmds
+	return intSetting("web.max_concurrent_commands", v)
 }
 
 // MaxSubjectLenV returns the configured or default max subject length.
 func (w *WebThresholds) MaxSubjectLenV() int {
-	if w != nil && w.MaxSubjectLen != nil {
-		return *w.MaxSubjectLen
+	var v *int
+	if w != nil {
+		v = w.MaxSubjectLen
 	}
-	return DefaultWebMaxSubjectLen
+	return intSetting("web.max_subject_len", v)
 }
 
 // MaxBodyLenV returns the configured or default max body length.
 func (w *WebThresholds) MaxBodyLenV() int {
-	if w != nil && w.MaxBodyLen != nil {
-		return *w.MaxBodyLen
+	var v *int
+	if w != nil {
+		v = w.MaxBodyLen
 	}
-	return DefaultWebMaxBodyLen
+	return intSetting("web.max_body_len", v)
+}
+
+// MaxCommandsPerMinuteV returns the configured or default per-client command
+// rate limit. Unset uses DefaultWebMaxCommandsPerMinute; 0 means unlimited.
+func (w *WebThresholds) MaxCommandsPerMinuteV() int {
+	var v *int
+	if w != nil {
+		v = w.MaxCommandsPerMinute
+	}
+	return intSetting("web.max_commands_per_minute", v)
 }
 
 // --- Witness accessors ---
@@ -706,50 +1394,86 @@ func (c *OperationalConfig) GetWitnessConfig() *WitnessThresholds {
 
 // StartupStallThresholdD returns the configured or default startup stall threshold.
 func (wt *WitnessThresholds) StartupStallThresholdD() time.Duration {
+	v, _ := wt.StartupStallThresholdWithSource()
+	return v
+}
+
+// StartupStallThresholdWithSource returns StartupStallThresholdD and where the value came from.
+func (wt *WitnessThresholds) StartupStallThresholdWithSource() (time.Duration, Source) {
+	var v string
 	if wt != nil {
-		return ParseDurationOrDefault(wt.StartupStallThreshold, DefaultWitnessStartupStallThreshold)
+		v = wt.StartupStallThreshold
 	}
-	return DefaultWitnessStartupStallThreshold
+	return durationWithSource("witness.startup_stall_threshold", v)
 }
 
 // StartupActivityGraceD returns the configured or default startup activity grace.
 func (wt *WitnessThresholds) StartupActivityGraceD() time.Duration {
+	v, _ := wt.StartupActivityGraceWithSource()
+	return v
+}
+
+// StartupActivityGraceWithSource returns StartupActivityGraceD and where the value came from.
+func (wt *WitnessThresholds) StartupActivityGraceWithSource() (time.Duration, Source) {
+	var v string
 	if wt != nil {
-		return ParseDurationOrDefault(wt.StartupActivityGrace, DefaultWitnessStartupActivityGrace)
+		v = wt.StartupActivityGrace
 	}
-	return DefaultWitnessStartupActivityGrace
+	return durationWithSource("witness.startup_activity_grace", v)
 }
 
 // MaxBeadRespawnsV returns the configured or default max bead respawns.
 func (wt *WitnessThresholds) MaxBeadRespawnsV() int {
-	if wt != nil && wt.MaxBeadRespawns != nil {
-		return *wt.MaxBeadRespawns
+	var v *int
+	if wt != nil {
+		v = wt.MaxBeadRespawns
 	}
-	return DefaultWitnessMaxBeadRespawns
+	return intSetting("witness.max_bead_respawns", v)
 }
 
 // DoneIntentStuckTimeoutD returns the configured or default done-intent stuck timeout.
 func (wt *WitnessThresholds) DoneIntentStuckTimeoutD() time.Duration {
+	v, _ := wt.DoneIntentStuckTimeoutWithSource()
+	return v
+}
+
+// DoneIntentStuckTimeoutWithSource returns DoneIntentStuckTimeoutD and where the value came from.
+func (wt *WitnessThresholds) DoneIntentStuckTimeoutWithSource() (time.Duration, Source) {
+	var v string
 	if wt != nil {
-		return ParseDurationOrDefault(wt.DoneIntentStuckTimeout, DefaultWitnessDoneIntentStuckTimeout)
+		v = wt.DoneIntentStuckTimeout
 	}
-	return DefaultWitnessDoneIntentStuckTimeout
+	return durationWithSource("witness.done_intent_stuck_timeout", v)
 }
 
 // DoneIntentRecentGraceD returns the configured or default done-intent recent grace.
 func (wt *WitnessThresholds) DoneIntentRecentGraceD() time.Duration {
+	v, _ := wt.DoneIntentRecentGraceWithSource()
+	return v
+}
+
+// DoneIntentRecentGraceWithSource returns DoneIntentRecentGraceD and where the value came from.
+func (wt *WitnessThresholds) DoneIntentRecentGraceWithSource() (time.Duration, Source) {
+	var v string
 	if wt != nil {
-		return ParseDurationOrDefault(wt.DoneIntentRecentGrace, DefaultWitnessDoneIntentRecentGrace)
+		v = wt.DoneIntentRecentGrace
 	}
-	return DefaultWitnessDoneIntentRecentGrace
+	return durationWithSource("witness.done_intent_recent_grace", v)
 }
 
 // HeartbeatStartupGraceD returns the configured or default heartbeat startup grace period.
 // A live polecat with assigned work but no heartbeat file older than this is flagged
 // for review as possibly stuck at startup (e.g., auth 401). (gt-uk7)
 func (wt *WitnessThresholds) HeartbeatStartupGraceD() time.Duration {
+	v, _ := wt.HeartbeatStartupGraceWithSource()
+	return v
+}
+
+// HeartbeatStartupGraceWithSource returns HeartbeatStartupGraceD and where the value came from.
+func (wt *WitnessThresholds) HeartbeatStartupGraceWithSource() (time.Duration, Source) {
+	var v string
 	if wt != nil {
-		return ParseDurationOrDefault(wt.HeartbeatStartupGrace, DefaultWitnessHeartbeatStartupGrace)
+		v = wt.HeartbeatStartupGrace
 	}
-	return DefaultWitnessHeartbeatStartupGrace
+	return durationWithSource("witness.heartbeat_startup_grace", v)
 }
@@ -0,0 +1,134 @@
+package config
+
+import "math"
+
+// operationalDefaults is the compiled-in default of every threshold that has
+// an accessor, keyed by JSON path below "operational". Accessors fall back to
+// it and OperationalSchema reports it, so each default is declared once.
+var operationalDefaults = map[string]any{
+	"session.claude_start_timeout":       DefaultClaudeStartTimeout,
+	"session.shell_ready_timeout":        DefaultShellReadyTimeout,
+	"session.graceful_shutdown_timeout":  DefaultGracefulShutdownTimeout,
+	"session.bd_command_timeout":         DefaultBdCommandTimeout,
+	"session.bd_subprocess_timeout":      DefaultBdSubprocessTimeout,
+	"session.gupp_violation_timeout":     DefaultGUPPViolationTimeout,
+	"session.hung_session_threshold":     DefaultHungSessionThreshold,
+	"session.startup_nudge_verify_delay": DefaultStartupNudgeVerifyDelay,
+	"session.startup_nudge_max_retries":  DefaultStartupNudgeMaxRetries,
+	"session.respawn_hook_delay":         DefaultRespawnHookDelay,
+	"session.respawn_base_backoff":       DefaultRespawnBaseBackoff,
+	"session.respawn_backoff_max":        DefaultRespawnBackoffMax,
+	"session.respawn_stable_period":      DefaultRespawnStablePeriod,
+
+	"nudge.ready_timeout":         DefaultNudgeReadyTimeout,
+	"nudge.retry_interval":        DefaultNudgeRetryInterval,
+	"nudge.lock_timeout":          DefaultNudgeLockTimeout,
+	"nudge.normal_ttl":            DefaultNudgeNormalTTL,
+	"nudge.urgent_ttl":            DefaultNudgeUrgentTTL,
+	"nudge.max_queue_depth":       DefaultNudgeMaxQueueDepth,
+	"nudge.urgent_queue_headroom": DefaultNudgeUrgentHeadroom,
+	"nudge.stale_claim_threshold": DefaultNudgeStaleClaimTimeout,
+
+	"daemon.mass_death_window":                 DefaultMassDeathWindow,
+	"daemon.mass_death_threshold":              DefaultMassDeathThreshold,
+	"daemon.mass_death_respawn_pause":          DefaultMassDeathRespawnPause,
+	"daemon.dog_idle_session_timeout":          DefaultDogIdleSessionTimeout,
+	"daemon.polecat_idle_session_timeout":      DefaultPolecatIdleSessionTimeout,
+	"daemon.dog_idle_remove_timeout":           DefaultDogIdleRemoveTimeout,
+	"daemon.stale_working_timeout":             DefaultStaleWorkingTimeout,
+	"daemon.max_dog_pool_size":                 DefaultMaxDogPoolSize,
+	"daemon.max_lifecycle_message_age":         DefaultMaxLifecycleMessageAge,
+	"daemon.sync_failure_escalation_threshold": DefaultSyncFailureEscalationThreshold,
+	"daemon.doctor_mol_cooldown":               DefaultDoctorMolCooldown,
+	"daemon.recovery_heartbeat_interval":       DefaultRecoveryHeartbeatInterval,
+	"daemon.boot_spawn_cooldown":               DefaultBootSpawnCooldown,
+	"daemon.boot_idle_suppression":             DefaultBootIdleSuppression,
+	"daemon.deacon_grace_period":               DefaultDeaconGracePeriod,
+	"daemon.respawn_max_attempts":              DefaultRespawnMaxAttempts,
+	"daemon.respawn_window":                    DefaultRespawnWindow,
+	"daemon.pressure_cpu_threshold":            DefaultPressureCPUThreshold,
+	"daemon.pressure_mem_threshold_gb":         DefaultPressureMemThresholdGB,
+	"daemon.pressure_max_sessions":             DefaultPressureMaxSessions,
+
+	"deacon.ping_timeout":                   DefaultDeaconPingTimeout,
+	"deacon.consecutive_failures":           DefaultDeaconConsecutiveFailures,
+	"deacon.cooldown":                       DefaultDeaconCooldown,
+	"deacon.heartbeat_stale_threshold":      DefaultDeaconHeartbeatStaleThreshold,
+	"deacon.heartbeat_very_stale_threshold": DefaultDeaconHeartbeatVeryStale,
+	"deacon.max_redispatches":               DefaultMaxRedispatches,
+	"deacon.redispatch_cooldown":            DefaultRedispatchCooldown,
+	"deacon.redispatch_cooldown_jitter":     DefaultRedispatchCooldownJitter,
+	"deacon.max_feeds_per_cycle":            DefaultMaxFeedsPerCycle,
+	"deacon.feed_cooldown":                  DefaultFeedCooldown,
+	"deacon.feed_cooldown_jitter":           DefaultFeedCooldownJitter,
+
+	"polecat.heartbeat_stale_threshold": DefaultPolecatHeartbeatStale,
+	"polecat.dolt_max_retries":          DefaultPolecatDoltMaxRetries,
+	"polecat.dolt_base_backoff":         DefaultPolecatDoltBaseBackoff,
+	"polecat.dolt_backoff_max":          DefaultPolecatDoltBackoffMax,
+	"polecat.pending_max_age":           DefaultPolecatPendingMaxAge,
+	"polecat.namepool_size":             DefaultPolecatNamepoolSize,
+	"polecat.namepool_grow_increment":   DefaultPolecatNamepoolGrowBy,
+	"polecat.namepool_max_size":         DefaultPolecatNamepoolMaxSize,
+
+	"dolt.health_check_interval": DefaultDoltHealthCheckInterval,
+	"dolt.cmd_timeout":           DefaultDoltCmdTimeout,
+	"dolt.max_connections":       DefaultDoltMaxConnections,
+	"dolt.slow_query_threshold":  DefaultDoltSlowQueryThreshold,
+	"dolt.port":                  0,
+
+	"mail.idle_notify_timeout":    DefaultMailIdleNotifyTimeout,
+	"mail.bd_read_timeout":        DefaultMailBdReadTimeout,
+	"mail.bd_write_timeout":       DefaultMailBdWriteTimeout,
+	"mail.max_concurrent_ack_ops": DefaultMailMaxConcurrentAcks,
+	"mail.reply_reminder_delay":   DefaultMailReplyReminderDelay,
+
+	"web.max_concurrent_commands": DefaultWebMaxConcurrentCmds,
+	"web.max_subject_len":         DefaultWebMaxSubjectLen,
+	"web.max_body_len":            DefaultWebMaxBodyLen,
+	"web.max_commands_per_minute": DefaultWebMaxCommandsPerMinute,
+
+	"witness.startup_stall_threshold":   DefaultWitnessStartupStallThreshold,
+	"witness.startup_activity_grace":    DefaultWitnessStartupActivityGrace,
+	"witness.max_bead_respawns":         DefaultWitnessMaxBeadRespawns,
+	"witness.done_intent_stuck_timeout": DefaultWitnessDoneIntentStuckTimeout,
+	"witness.done_intent_recent_grace":  DefaultWitnessDoneIntentRecentGrace,
+	"witness.heartbeat_startup_grace":   DefaultWitnessHeartbeatStartupGrace,
+}
+
+// operationalIntBounds is the allowed [min, max] range of count thresholds,
+// keyed like operationalDefaults. Accessors clamp values into it and
+// ValidateOperationalConfig reports values outside it. Counts without an
+// entry must not be negative.
+var operationalIntBounds = map[string][2]int{
+	"nudge.max_queue_depth":           {MinNudgeQueueDepth, MaxNudgeQueueDepthLimit},
+	"nudge.urgent_queue_headroom":     {MinNudgeUrgentHeadroom, MaxNudgeUrgentHeadroomLimit},
+	"daemon.mass_death_threshold":     {MinMassDeathThreshold, MaxMassDeathThresholdLimit},
+	"daemon.max_dog_pool_size":        {MinDogPoolSize, MaxDogPoolSizeLimit},
+	"polecat.namepool_size":           {MinPolecatNamepoolSize, MaxPolecatNamepoolSizeLimit},
+	"polecat.namepool_grow_increment": {MinPolecatNamepoolGrowBy, MaxPolecatNamepoolGrowByLimit},
+	"polecat.namepool_max_size":       {MinPolecatNamepoolSize, MaxPolecatNamepoolSizeLimit},
+	"dolt.max_connections":            {MinDoltConnections, MaxDoltConnectionsLimit},
+	"dolt.port":                       {0, MaxDoltPort},
+	"mail.max_concurrent_ack_ops":     {MinMailConcurrentAcks, MaxMailConcurrentAcksLimit},
+	"web.max_concurrent_commands":     {MinWebConcurrentCmds, MaxWebConcurrentCmdsLimit},
+}
+
+// operationalDisableable lists the duration thresholds that accept "off" or
+// "disabled". Only fields whose callers check for DurationDisabled belong
+// here; on any other field the keywords are invalid and the default applies.
+var operationalDisableable = map[string]bool{
+	"daemon.max_lifecycle_message_age":    true,
+	"daemon.stale_working_timeout":        true,
+	"daemon.dog_idle_session_timeout":     true,
+	"daemon.dog_idle_remove_timeout":      true,
+	"daemon.polecat_idle_session_timeout": true,
+}
+
+// intBounds returns the [min, max] range of the count threshold at path.
+func intBounds(path string) (lo, hi int) {
+	if b, ok := operationalIntBounds[path]; ok {
+		return b[0], b[1]
+	}
+	return 0, math.MaxInt
+}
@@ -0,0 +1,28 @@
+package config
+
+// ConfigFieldDiff is one operational threshold whose effective value differs
+// between two configs.
+type ConfigFieldDiff struct {
+	// Path is the JSON path below "operational", e.g. "daemon.max_dog_pool_size".
+	Path string `json:"path"`
+	// Old is the effective value in the first config.
+	Old string `json:"old"`
+	// New is the effective value in the second config.
+	New string `json:"new"`
+}
+
+// DiffOperationalConfig reports the thresholds whose effective values differ
+// between a and b, in struct declaration order. Values are compared after
+// resolution through their accessors, so a field set explicitly to its
+// default is equal to one left unset, and an out-of-range value is compared
+// as its clamped value. Either config may be nil.
+func DiffOperationalConfig(a, b *OperationalConfig) []ConfigFieldDiff {
+	newValues := EffectiveOperationalConfig(b)
+	var diffs []ConfigFieldDiff
+	for _, v := range EffectiveOperationalValues(a) {
+		if n := newValues[v.Path]; n != v.Value {
+			diffs = append(diffs, ConfigFieldDiff{Path: v.Path, Old: v.Value, New: n})
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffOperationalConfig(t *testing.T) {
+	t.Parallel()
+
+	four, six, eight := 4, 6, 8
+	a := &OperationalConfig{
+		Session: &SessionThresholds{ClaudeStartTimeout: "180s", ShellReadyTimeout: "20s"},
+		Daemon:  &DaemonThresholds{MaxDogPoolSize: &four, DogIdleRemoveTimeout: "off"},
+		Mail:    &MailThresholds{MaxConcurrentAckOps: &eight},
+	}
+	b := &OperationalConfig{
+		// claude_start_timeout unset: the default equals a's explicit 180s.
+		Session: &SessionThresholds{ShellReadyTimeout: "30s"},
+		Daemon:  &DaemonThresholds{MaxDogPoolSize: &six, DogIdleRemoveTimeout: "off"},
+		Nudge:   &NudgeThresholds{NormalTTL: "45m"},
+	}
+
+	got := DiffOperationalConfig(a, b)
+	want := []ConfigFieldDiff{
+		{Path: "session.shell_ready_timeout", Old: "20s", New: "30s"},
+		{Path: "nudge.normal_ttl", Old: DefaultNudgeNormalTTL.String(), New: "45m0s"},
+		{Path: "daemon.max_dog_pool_size", Old: "4", New: "6"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffOperationalConfig() =\n  %+v\nwant\n  %+v", got, want)
+	}
+
+	if diffs := DiffOperationalConfig(b, b); len(diffs) != 0 {
+		t.Errorf("config compared with itself has diffs: %+v", diffs)
+	}
+	if diffs := DiffOperationalConfig(nil, &OperationalConfig{Daemon: &DaemonThresholds{MaxDogPoolSize: &four}}); len(diffs) != 0 {
+		t.Errorf("explicit default compared with nil has diffs: %+v", diffs)
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Source is the layer an operational value was resolved from.
+type Source string
+
+// Value sources reported by EffectiveOperationalValues and the
+// FieldWithSource duration accessors.
+const (
+	SourceEnv     Source = "env"     // GT_<SUBSYSTEM>_<FIELD> environment variable
+	SourceFile    Source = "file"    // settings/config.json
+	SourceDefault Source = "default" // compiled-in Default* constant
+
+	// SourceInvalidFellBack marks a value that was set but failed to parse,
+	// so a lower layer (usually the default) was used instead. Only the
+	// WithSource accessors report it.
+	SourceInvalidFellBack Source = "invalid"
+)
+
+// EffectiveValue is the resolved value of one operational threshold.
+type EffectiveValue struct {
+	// Path is the JSON path below "operational", e.g. "session.claude_start_timeout".
+	Path string `json:"path"`
+	// Value is the resolved value as a human string ("30s", "4", "off").
+	Value string `json:"value"`
+	// Source is where the value came from: SourceEnv, SourceFile, or SourceDefault.
+	Source Source `json:"source"`
+}
+
+// EffectiveOperationalValues resolves every operational threshold through its
+// accessor and reports the value and where it came from, in struct
+// declaration order. A malformed file or env value is reported with the
+// source that actually supplied the value (usually SourceDefault).
+func EffectiveOperationalValues(c *OperationalConfig) []EffectiveValue {
+	var values []EffectiveValue
+	walkOperationalFields(c, func(f operationalField) {
+		accessor := operationalAccessorName(f)
+		if accessor == "" {
+			return
+		}
+		out := f.Recv.MethodByName(accessor).Call(nil)[0]
+		values = append(values, EffectiveValue{
+			Path:   f.Path(),
+			Value:  formatOperationalValue(out),
+			Source: operationalValueSource(f),
+		})
+	})
+	return values
+}
+
+// EffectiveOperationalConfig returns the resolved value of every operational
+// threshold keyed by JSON path, e.g. "daemon.max_dog_pool_size" → "4".
+// Durations are formatted with time.Duration.String; DurationDisabled is "off".
+func EffectiveOperationalConfig(c *OperationalConfig) map[string]string {
+	values := EffectiveOperationalValues(c)
+	m := make(map[string]string, len(values))
+	for _, v := range values {
+		m[v.Path] = v.Value
+	}
+	return m
+}
+
+// formatOperationalValue renders an accessor result as a human string.
+func formatOperationalValue(v reflect.Value) string {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d := time.Duration(v.Int())
+		if d == DurationDisabled {
+			return "off"
+		}
+		return d.String()
+	}
+	switch v.Kind() {
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	return ""
+}
+
+// operationalValueSource reports which layer supplies f's effective value,
+// mirroring the precedence in the accessors: env, then file, then default.
+func operationalValueSource(f operationalField) Source {
+	if env := strings.TrimSpace(os.Getenv(OperationalEnvVarName(f.Path()))); env != "" && parsesAsKind(f.Path(), f.Type, env) {
+		return SourceEnv
+	}
+	if f.Value.IsValid() {
+		switch f.Type.Kind() {
+		case reflect.String:
+			if s := f.Value.String(); s != "" && parsesAsKind(f.Path(), f.Type, s) {
+				return SourceFile
+			}
+		case reflect.Pointer:
+			if !f.Value.IsNil() {
+				return SourceFile
+			}
+		}
+	}
+	return SourceDefault
+}
+
+// parsesAsKind reports whether s is a valid value for the field at path of
+// type t.
+func parsesAsKind(path string, t reflect.Type, s string) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		_, err = parseOperationalDuration(path, s)
+	case reflect.Int:
+		_, err = strconv.Atoi(s)
+	case reflect.Float64:
+		_, err = strconv.ParseFloat(s, 64)
+	}
+	return err == nil
+}
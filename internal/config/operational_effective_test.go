@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestEffectiveOperationalConfig_Defaults(t *testing.T) {
+	t.Parallel()
+
+	got := EffectiveOperationalConfig(nil)
+	if got["session.claude_start_timeout"] != "3m0s" {
+		t.Errorf("session.claude_start_timeout = %q, want 3m0s", got["session.claude_start_timeout"])
+	}
+	if got["daemon.max_dog_pool_size"] != "4" {
+		t.Errorf("daemon.max_dog_pool_size = %q, want 4", got["daemon.max_dog_pool_size"])
+	}
+	if len(got) != len(OperationalSchema()) {
+		t.Errorf("got %d values, want one per schema field (%d)", len(got), len(OperationalSchema()))
+	}
+}
+
+func TestEffectiveOperationalValues_Sources(t *testing.T) {
+	t.Setenv("GT_NUDGE_NORMAL_TTL", "45m")
+	t.Setenv("GT_NUDGE_URGENT_TTL", "garbage")
+
+	pool := 6
+	op := &OperationalConfig{
+		Session: &SessionThresholds{ClaudeStartTimeout: "90s", ShellReadyTimeout: "nope"},
+		Daemon:  &DaemonThresholds{MaxDogPoolSize: &pool, DogIdleRemoveTimeout: "off"},
+	}
+
+	want := map[string]EffectiveValue{
+		"session.claude_start_timeout":   {Value: "1m30s", Source: SourceFile},
+		"session.shell_ready_timeout":    {Value: DefaultShellReadyTimeout.String(), Source: SourceDefault},
+		"daemon.max_dog_pool_size":       {Value: "6", Source: SourceFile},
+		"daemon.dog_idle_remove_timeout": {Value: "off", Source: SourceFile},
+		"nudge.normal_ttl":               {Value: "45m0s", Source: SourceEnv},
+		"nudge.urgent_ttl":               {Value: DefaultNudgeUrgentTTL.String(), Source: SourceDefault},
+		"mail.max_concurrent_ack_ops":    {Value: "8", Source: SourceDefault},
+	}
+	for _, v := range EffectiveOperationalValues(op) {
+		w, ok := want[v.Path]
+		if !ok {
+			continue
+		}
+		if v.Value != w.Value || v.Source != w.Source {
+			t.Errorf("%s: got %s (%s), want %s (%s)", v.Path, v.Value, v.Source, w.Value, w.Source)
+		}
+		delete(want, v.Path)
+	}
+	for path := range want {
+		t.Errorf("%s: missing from effective values", path)
+	}
+}
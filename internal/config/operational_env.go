@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// OperationalEnvVar describes one environment variable that overrides an
+// operational threshold.
+type OperationalEnvVar struct {
+	// Name is the environment variable, e.g. "GT_SESSION_CLAUDE_START_TIMEOUT".
+	Name string `json:"name"`
+	// Path is the JSON path below "operational", e.g. "session.claude_start_timeout".
+	Path string `json:"path"`
+	// Accessor is the method that honors the override, e.g. "SessionThresholds.ClaudeStartTimeoutD".
+	Accessor string `json:"accessor"`
+}
+
+// OperationalEnvVarName returns the environment variable that overrides the
+// operational field at the given JSON path: "GT_" + subsystem + "_" + field,
+// uppercased. For example "daemon.max_dog_pool_size" → "GT_DAEMON_MAX_DOG_POOL_SIZE".
+func OperationalEnvVarName(path string) string {
+	return "GT_" + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// OperationalEnvVars lists every environment override, generated from the
+// struct tags of the OperationalConfig sub-structs so the list cannot drift.
+// Only fields with an accessor method (FieldD for durations, FieldV for
+// numbers) are included.
+func OperationalEnvVars() []OperationalEnvVar {
+	var vars []OperationalEnvVar
+	walkOperationalFields(nil, func(f operationalField) {
+		accessor := operationalAccessorName(f)
+		if accessor == "" {
+			return
+		}
+		vars = append(vars, OperationalEnvVar{
+			Name:     OperationalEnvVarName(f.Path()),
+			Path:     f.Path(),
+			Accessor: f.Owner.Name() + "." + accessor,
+		})
+	})
+	return vars
+}
+
+// operationalAccessorName returns the name of the accessor method for f, or ""
+// if the sub-struct has none. Duration strings use a "D" suffix and numeric
+// pointers a "V" suffix.
+func operationalAccessorName(f operationalField) string {
+	var name string
+	switch {
+	case f.Type.Kind() == reflect.String:
+		name = f.GoName + "D"
+	case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() != reflect.Bool:
+		name = f.GoName + "V"
+	default:
+		return ""
+	}
+	if _, ok := reflect.PointerTo(f.Owner).MethodByName(name); !ok {
+		return ""
+	}
+	return name
+}
+
+// operationalEnv returns the environment override for the threshold at
+// path, or "" when the variable is unset.
+func operationalEnv(path string) string {
+	return strings.TrimSpace(os.Getenv(OperationalEnvVarName(path)))
+}
+
+// intSetting resolves the count threshold at path like durationWithSource;
+// v is the config file value, nil when unset. The result is clamped to
+// intBounds(path).
+func intSetting(path string, v *int) int {
+	n := operationalDefaults[path].(int)
+	if v != nil {
+		n = *v
+	}
+	if s := operationalEnv(path); s != "" {
+		if env, err := strconv.Atoi(s); err == nil {
+			n = env
+		}
+	}
+	lo, hi := intBounds(path)
+	return min(max(n, lo), hi)
+}
+
+// floatSetting resolves the float threshold at path like durationWithSource;
+// v is the config file value, nil when unset.
+func floatSetting(path string, v *float64) float64 {
+	if s := operationalEnv(path); s != "" {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	if v != nil {
+		return *v
+	}
+	return operationalDefaults[path].(float64)
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOperationalEnvVarName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"session.claude_start_timeout": "GT_SESSION_CLAUDE_START_TIMEOUT",
+		"daemon.max_dog_pool_size":     "GT_DAEMON_MAX_DOG_POOL_SIZE",
+	}
+	for path, want := range tests {
+		if got := OperationalEnvVarName(path); got != want {
+			t.Errorf("OperationalEnvVarName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestEnvOverride_TakesPrecedenceOverFile(t *testing.T) {
+	t.Setenv("GT_SESSION_CLAUDE_START_TIMEOUT", "120s")
+	t.Setenv("GT_DAEMON_MAX_DOG_POOL_SIZE", "8")
+	t.Setenv("GT_DAEMON_PRESSURE_CPU_THRESHOLD", "2.5")
+
+	pool := 2
+	op := &OperationalConfig{
+		Session: &SessionThresholds{ClaudeStartTimeout: "90s"},
+		Daemon:  &DaemonThresholds{MaxDogPoolSize: &pool},
+	}
+	if got := op.GetSessionConfig().ClaudeStartTimeoutD(); got != 120*time.Second {
+		t.Errorf("ClaudeStartTimeout: got %v, want 120s", got)
+	}
+	if got := op.GetDaemonConfig().MaxDogPoolSizeV(); got != 8 {
+		t.Errorf("MaxDogPoolSize: got %v, want 8", got)
+	}
+	if got := op.GetDaemonConfig().PressureCPUThresholdV(); got != 2.5 {
+		t.Errorf("PressureCPUThreshold: got %v, want 2.5", got)
+	}
+
+	// Overrides apply even when the sub-config is absent.
+	var empty *OperationalConfig
+	if got := empty.GetSessionConfig().ClaudeStartTimeoutD(); got != 120*time.Second {
+		t.Errorf("nil config ClaudeStartTimeout: got %v, want 120s", got)
+	}
+}
+
+func TestEnvOverride_InvalidFallsBack(t *testing.T) {
+	t.Setenv("GT_SESSION_CLAUDE_START_TIMEOUT", "not-a-duration")
+	t.Setenv("GT_DAEMON_MAX_DOG_POOL_SIZE", "many")
+
+	pool := 2
+	op := &OperationalConfig{
+		Session: &SessionThresholds{ClaudeStartTimeout: "90s"},
+		Daemon:  &DaemonThresholds{MaxDogPoolSize: &pool},
+	}
+	if got := op.GetSessionConfig().ClaudeStartTimeoutD(); got != 90*time.Second {
+		t.Errorf("ClaudeStartTimeout: got %v, want file value 90s", got)
+	}
+	if got := op.GetDaemonConfig().MaxDogPoolSizeV(); got != 2 {
+		t.Errorf("MaxDogPoolSize: got %v, want file value 2", got)
+	}
+
+	if got := (&OperationalConfig{}).GetSessionConfig().ClaudeStartTimeoutD(); got != DefaultClaudeStartTimeout {
+		t.Errorf("ClaudeStartTimeout without file value: got %v, want default %v", got, DefaultClaudeStartTimeout)
+	}
+}
+
+// TestOperationalEnvVars_MatchAccessors sets every generated env var and
+// calls the listed accessor, guarding against drift between struct tags and
+// the paths hardcoded in the accessors.
+func TestOperationalEnvVars_MatchAccessors(t *testing.T) {
+	vars := OperationalEnvVars()
+	if len(vars) == 0 {
+		t.Fatal("no env vars generated")
+	}
+
+	owners := map[string]reflect.Type{}
+	walkOperationalFields(nil, func(f operationalField) {
+		owners[f.Owner.Name()] = f.Owner
+	})
+
+	// Each variable is set in its own subtest, so an accessor that reads a
+	// path other than its field's JSON tag sees no override and fails.
+	for _, v := range vars {
+		t.Run(v.Name, func(t *testing.T) {
+			typeName, method, _ := strings.Cut(v.Accessor, ".")
+			owner, ok := owners[typeName]
+			if !ok {
+				t.Fatalf("unknown owner type %q", typeName)
+			}
+			recv := reflect.Zero(reflect.PointerTo(owner))
+			m := recv.MethodByName(method)
+
+			var want any
+			switch m.Type().Out(0).Kind() {
+			case reflect.Int64: // time.Duration
+				t.Setenv(v.Name, "4242s")
+				want = 4242 * time.Second
+			case reflect.Int:
+				// Small enough to sit inside every count's clamp bounds.
+				t.Setenv(v.Name, "1")
+				want = 1
+			case reflect.Float64:
+				t.Setenv(v.Name, "42.5")
+				want = 42.5
+			default:
+				t.Fatalf("unexpected accessor return type %v", m.Type().Out(0))
+			}
+			if got := m.Call(nil)[0].Interface(); got != want {
+				t.Errorf("%s=%v: %s() = %v, want %v", v.Name, want, v.Accessor, got, want)
+			}
+		})
+	}
+}
+
+// TestOperationalDefaults_MatchTags checks that the defaults table has exactly
+// one entry per accessor, keyed by the field's JSON tag path.
+func TestOperationalDefaults_MatchTags(t *testing.T) {
+	t.Parallel()
+
+	paths := map[string]bool{}
+	for _, v := range OperationalEnvVars() {
+		paths[v.Path] = true
+		if _, ok := operationalDefaults[v.Path]; !ok {
+			t.Errorf("%s: no entry in operationalDefaults", v.Path)
+		}
+	}
+	for path := range operationalDefaults {
+		if !paths[path] {
+			t.Errorf("operationalDefaults[%q] does not match any accessor field", path)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeSettings(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadOperationalConfigLayered_Precedence(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	townRoot := t.TempDir()
+
+	// Global layer sets three fields; town overrides two of them, one with
+	// a value equal to the compiled-in default.
+	writeSettings(t, GlobalSettingsPath(), `{"operational": {
+		"session": {"claude_start_timeout": "120s", "gupp_violation_timeout": "1h"},
+		"daemon": {"max_dog_pool_size": 8, "mass_death_threshold": 5}
+	}}`)
+	writeSettings(t, TownSettingsPath(townRoot), `{"operational": {
+		"session": {"claude_start_timeout": "90s"},
+		"daemon": {"max_dog_pool_size": 4}
+	}}`)
+
+	op := LoadOperationalConfigLayered(townRoot)
+	session := op.GetSessionConfig()
+	daemon := op.GetDaemonConfig()
+
+	// Town beats global.
+	if got := session.ClaudeStartTimeoutD(); got != 90*time.Second {
+		t.Errorf("ClaudeStartTimeout: got %v, want town 90s", got)
+	}
+	// Explicit town int equal to the default still counts as set.
+	if got := daemon.MaxDogPoolSizeV(); got != DefaultMaxDogPoolSize {
+		t.Errorf("MaxDogPoolSize: got %v, want town %v", got, DefaultMaxDogPoolSize)
+	}
+	// Global beats default.
+	if got := session.GUPPViolationTimeoutD(); got != time.Hour {
+		t.Errorf("GUPPViolationTimeout: got %v, want global 1h", got)
+	}
+	if got := daemon.MassDeathThresholdV(); got != 5 {
+		t.Errorf("MassDeathThreshold: got %v, want global 5", got)
+	}
+	// Unset in both falls back to the default.
+	if got := session.HungSessionThresholdD(); got != DefaultHungSessionThreshold {
+		t.Errorf("HungSessionThreshold: got %v, want default %v", got, DefaultHungSessionThreshold)
+	}
+	if got := op.GetNudgeConfig().NormalTTLD(); got != DefaultNudgeNormalTTL {
+		t.Errorf("NormalTTL: got %v, want default %v", got, DefaultNudgeNormalTTL)
+	}
+}
+
+func TestLoadOperationalConfigLayered_MissingFiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	op := LoadOperationalConfigLayered(t.TempDir())
+	if op == nil {
+		t.Fatal("LoadOperationalConfigLayered must never return nil")
+	}
+	if got := op.GetSessionConfig().ClaudeStartTimeoutD(); got != DefaultClaudeStartTimeout {
+		t.Errorf("ClaudeStartTimeout: got %v, want default %v", got, DefaultClaudeStartTimeout)
+	}
+}
+
+func TestLoadOperationalConfigLayered_GlobalOnly(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	writeSettings(t, GlobalSettingsPath(), `{"operational": {"nudge": {"normal_ttl": "45m"}}}`)
+
+	op := LoadOperationalConfigLayered(t.TempDir())
+	if got := op.GetNudgeConfig().NormalTTLD(); got != 45*time.Minute {
+		t.Errorf("NormalTTL: got %v, want global 45m", got)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// mergeThresholds overlays every set field of src onto dst, field by field.
+// Both must be pointers to the same struct type. A field is "set" when it is a
+// non-empty string, a non-nil pointer, or a non-empty map/slice — so an
+// explicit value equal to the compiled-in default still overrides. Nested
+// struct pointers and maps of struct pointers are merged recursively into
+// fresh values, so dst never aliases src's sub-structs.
+func mergeThresholds(dst, src any) {
+	dv, sv := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if dv.IsNil() || sv.IsNil() {
+		return
+	}
+	mergeStruct(dv.Elem(), sv.Elem())
+}
+
+func mergeStruct(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Type().Field(i).IsExported() {
+			continue
+		}
+		df, sf := dst.Field(i), src.Field(i)
+		switch sf.Kind() {
+		case reflect.String:
+			if sf.String() != "" {
+				df.SetString(sf.String())
+			}
+		case reflect.Pointer:
+			if sf.IsNil() {
+				continue
+			}
+			if sf.Elem().Kind() == reflect.Struct {
+				merged := reflect.New(sf.Type().Elem())
+				if !df.IsNil() {
+					mergeStruct(merged.Elem(), df.Elem())
+				}
+				mergeStruct(merged.Elem(), sf.Elem())
+				df.Set(merged)
+			} else {
+				df.Set(sf)
+			}
+		case reflect.Map:
+			if sf.Len() == 0 {
+				continue
+			}
+			merged := reflect.MakeMapWithSize(sf.Type(), df.Len()+sf.Len())
+			for _, k := range df.MapKeys() {
+				merged.SetMapIndex(k, df.MapIndex(k))
+			}
+			for _, k := range sf.MapKeys() {
+				v := sf.MapIndex(k)
+				existing := merged.MapIndex(k)
+				if v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct &&
+					existing.IsValid() && !existing.IsNil() {
+					m := reflect.New(v.Type().Elem())
+					mergeStruct(m.Elem(), existing.Elem())
+					mergeStruct(m.Elem(), v.Elem())
+					v = m
+				}
+				merged.SetMapIndex(k, v)
+			}
+			df.Set(merged)
+		case reflect.Slice:
+			if sf.Len() > 0 {
+				df.Set(sf)
+			}
+		}
+	}
+}
+
+// SessionConfigForRole returns the session thresholds for the given role
+// (e.g. "deacon", "polecat"), with the role's entry in Session.PerRole merged
+// field by field over the base session thresholds. Fields unset at both
+// levels fall back to compiled-in defaults through the usual accessors.
+// Never nil; nil-safe like GetSessionConfig.
+func (c *OperationalConfig) SessionConfigForRole(role string) *SessionThresholds {
+	base := c.GetSessionConfig()
+	override := base.PerRole[strings.TrimSpace(role)]
+	if override == nil {
+		return base
+	}
+
+	merged := &SessionThresholds{}
+	mergeThresholds(merged, base)
+	mergeThresholds(merged, override)
+	merged.PerRole = nil
+	return merged
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionConfigForRole_MergesOverBase(t *testing.T) {
+	t.Parallel()
+
+	baseRetries := 4
+	op := &OperationalConfig{
+		Session: &SessionThresholds{
+			ClaudeStartTimeout:     "60s",
+			GUPPViolationTimeout:   "45m",
+			StartupNudgeMaxRetries: &baseRetries,
+			PerRole: map[string]*SessionThresholds{
+				"deacon": {ClaudeStartTimeout: "180s"},
+			},
+		},
+	}
+
+	deacon := op.SessionConfigForRole("deacon")
+	if got := deacon.ClaudeStartTimeoutD(); got != 180*time.Second {
+		t.Errorf("deacon ClaudeStartTimeout: got %v, want 180s", got)
+	}
+	if got := deacon.GUPPViolationTimeoutD(); got != 45*time.Minute {
+		t.Errorf("deacon GUPPViolationTimeout: got %v, want base 45m", got)
+	}
+	if got := deacon.StartupNudgeMaxRetriesV(); got != 4 {
+		t.Errorf("deacon StartupNudgeMaxRetries: got %v, want base 4", got)
+	}
+	if got := deacon.HungSessionThresholdD(); got != DefaultHungSessionThreshold {
+		t.Errorf("deacon HungSessionThreshold: got %v, want default %v", got, DefaultHungSessionThreshold)
+	}
+
+	polecat := op.SessionConfigForRole("polecat")
+	if got := polecat.ClaudeStartTimeoutD(); got != 60*time.Second {
+		t.Errorf("polecat ClaudeStartTimeout: got %v, want base 60s", got)
+	}
+
+	// The base config must not be modified by the merge.
+	if op.Session.ClaudeStartTimeout != "60s" {
+		t.Errorf("base mutated: ClaudeStartTimeout = %q", op.Session.ClaudeStartTimeout)
+	}
+}
+
+func TestSessionConfigForRole_IntPointerOverride(t *testing.T) {
+	t.Parallel()
+
+	base := 5
+	role := DefaultStartupNudgeMaxRetries // explicit value equal to the default still overrides
+	op := &OperationalConfig{
+		Session: &SessionThresholds{
+			StartupNudgeMaxRetries: &base,
+			PerRole: map[string]*SessionThresholds{
+				"witness": {StartupNudgeMaxRetries: &role},
+			},
+		},
+	}
+	if got := op.SessionConfigForRole("witness").StartupNudgeMaxRetriesV(); got != DefaultStartupNudgeMaxRetries {
+		t.Errorf("StartupNudgeMaxRetries: got %v, want %v", got, DefaultStartupNudgeMaxRetries)
+	}
+}
+
+func TestSessionConfigForRole_NilSafe(t *testing.T) {
+	t.Parallel()
+
+	var op *OperationalConfig
+	s := op.SessionConfigForRole("deacon")
+	if s == nil {
+		t.Fatal("SessionConfigForRole must never return nil")
+	}
+	if got := s.ClaudeStartTimeoutD(); got != DefaultClaudeStartTimeout {
+		t.Errorf("ClaudeStartTimeout: got %v, want %v", got, DefaultClaudeStartTimeout)
+	}
+
+	op = &OperationalConfig{Session: &SessionThresholds{
+		PerRole: map[string]*SessionThresholds{"deacon": nil},
+	}}
+	if got := op.SessionConfigForRole("deacon").ClaudeStartTimeoutD(); got != DefaultClaudeStartTimeout {
+		t.Errorf("nil role entry: got %v, want %v", got, DefaultClaudeStartTimeout)
+	}
+}
+
+func TestValidateOperationalConfig_PerRole(t *testing.T) {
+	t.Parallel()
+
+	op := &OperationalConfig{Session: &SessionThresholds{
+		PerRole: map[string]*SessionThresholds{
+			"deacon": {ClaudeStartTimeout: "three minutes"},
+		},
+	}}
+	errs := ValidateOperationalConfig(op)
+	if len(errs) != 1 || errs[0].Path != "session.per_role.deacon.claude_start_timeout" {
+		t.Errorf("got %v, want one error at session.per_role.deacon.claude_start_timeout", errs)
+	}
+}
@@ -0,0 +1,18 @@
+package config
+
+// OverriddenSubsystems returns the JSON names of the subsystems in c (e.g.
+// "daemon", "nudge") that set at least one field, in struct declaration
+// order. A sub-struct that is present but empty, such as "nudge": {}, is not
+// an override. Nil-safe.
+func OverriddenSubsystems(c *OperationalConfig) []string {
+	var names []string
+	walkOperationalFields(c, func(f operationalField) {
+		if !f.Value.IsValid() || f.Value.IsZero() {
+			return
+		}
+		if n := len(names); n == 0 || names[n-1] != f.Subsystem {
+			names = append(names, f.Subsystem)
+		}
+	})
+	return names
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOverriddenSubsystems(t *testing.T) {
+	t.Parallel()
+
+	four := 4
+	tests := []struct {
+		name string
+		c    *OperationalConfig
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"all defaults", &OperationalConfig{}, nil},
+		{"empty sub-structs", &OperationalConfig{Daemon: &DaemonThresholds{}, Nudge: &NudgeThresholds{}}, nil},
+		{"one subsystem", &OperationalConfig{
+			Daemon: &DaemonThresholds{MaxDogPoolSize: &four},
+			Nudge:  &NudgeThresholds{},
+		}, []string{"daemon"}},
+		{"multiple subsystems", &OperationalConfig{
+			Witness: &WitnessThresholds{},
+			Daemon:  &DaemonThresholds{DogIdleRemoveTimeout: "off", MaxDogPoolSize: &four},
+			Session: &SessionThresholds{ClaudeStartTimeout: "90s"},
+			Nudge:   &NudgeThresholds{NormalTTL: "45m"},
+		}, []string{"session", "nudge", "daemon"}},
+	}
+	for _, tt := range tests {
+		if got := OverriddenSubsystems(tt.c); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: OverriddenSubsystems() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProfileEnvVar names the environment variable that selects the active
+// operational profile when none is given explicitly.
+const ProfileEnvVar = "GT_PROFILE"
+
+// profileWarned records which missing profile names have already been
+// reported, so repeated config loads warn only once per name.
+var profileWarned sync.Map
+
+// LoadOperationalConfigProfile loads the town's operational config with the
+// named profile from TownSettings.Profiles merged over the base Operational
+// block field by field. An empty profile uses GT_PROFILE; if that is also
+// empty the base config is returned unchanged. A profile that does not exist
+// logs a one-time warning and falls back to the base. Anything the profile
+// leaves unset inherits the base value and then the compiled-in default.
+// Never returns nil.
+func LoadOperationalConfigProfile(townRoot, profile string) *OperationalConfig {
+	ts, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	if err != nil || ts == nil {
+		return &OperationalConfig{}
+	}
+	return selectOperationalProfile(ts, profile)
+}
+
+// selectOperationalProfile returns ts.Operational with the named (or
+// GT_PROFILE) profile merged over it.
+func selectOperationalProfile(ts *TownSettings, profile string) *OperationalConfig {
+	base := ts.Operational
+	if base == nil {
+		base = &OperationalConfig{}
+	}
+
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		profile = strings.TrimSpace(os.Getenv(ProfileEnvVar))
+	}
+	if profile == "" {
+		return base
+	}
+
+	overlay, ok := ts.Profiles[profile]
+	if !ok {
+		if _, seen := profileWarned.LoadOrStore(profile, true); !seen {
+			fmt.Fprintf(os.Stderr, "warning: operational profile %q not found (available: %s), using base config\n",
+				profile, strings.Join(profileNames(ts), ", "))
+		}
+		return base
+	}
+
+	merged := &OperationalConfig{}
+	mergeThresholds(merged, base)
+	mergeThresholds(merged, overlay)
+	return merged
+}
+
+// profileNames returns the sorted profile names defined in ts, or "none".
+func profileNames(ts *TownSettings) []string {
+	if len(ts.Profiles) == 0 {
+		return []string{"none"}
+	}
+	names := make([]string, 0, len(ts.Profiles))
+	for name := range ts.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+const profileSettings = `{"operational": {
+	"session": {"claude_start_timeout": "90s", "gupp_violation_timeout": "1h"},
+	"daemon": {"max_dog_pool_size": 6}
+},
+"profiles": {
+	"dev": {"session": {"claude_start_timeout": "5m"}, "daemon": {"max_dog_pool_size": 2}},
+	"prod": {"daemon": {"mass_death_threshold": 5}}
+}}`
+
+func TestLoadOperationalConfigProfile_MergesOverBase(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	townRoot := t.TempDir()
+	writeSettings(t, TownSettingsPath(townRoot), profileSettings)
+
+	op := LoadOperationalConfigProfile(townRoot, "dev")
+	session := op.GetSessionConfig()
+
+	// Profile beats base.
+	if got := session.ClaudeStartTimeoutD(); got != 5*time.Minute {
+		t.Errorf("ClaudeStartTimeout: got %v, want profile 5m", got)
+	}
+	if got := op.GetDaemonConfig().MaxDogPoolSizeV(); got != 2 {
+		t.Errorf("MaxDogPoolSize: got %v, want profile 2", got)
+	}
+	// Base fills what the profile leaves unset.
+	if got := session.GUPPViolationTimeoutD(); got != time.Hour {
+		t.Errorf("GUPPViolationTimeout: got %v, want base 1h", got)
+	}
+	// Unset in both falls back to the default.
+	if got := op.GetDaemonConfig().MassDeathThresholdV(); got != DefaultMassDeathThreshold {
+		t.Errorf("MassDeathThreshold: got %v, want default %v", got, DefaultMassDeathThreshold)
+	}
+}
+
+func TestLoadOperationalConfigProfile_FromEnv(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "prod")
+	townRoot := t.TempDir()
+	writeSettings(t, TownSettingsPath(townRoot), profileSettings)
+
+	op := LoadOperationalConfigProfile(townRoot, "")
+	if got := op.GetDaemonConfig().MassDeathThresholdV(); got != 5 {
+		t.Errorf("MassDeathThreshold: got %v, want prod 5", got)
+	}
+	if got := op.GetDaemonConfig().MaxDogPoolSizeV(); got != 6 {
+		t.Errorf("MaxDogPoolSize: got %v, want base 6", got)
+	}
+
+	// An explicit profile beats GT_PROFILE.
+	op = LoadOperationalConfigProfile(townRoot, "dev")
+	if got := op.GetDaemonConfig().MaxDogPoolSizeV(); got != 2 {
+		t.Errorf("MaxDogPoolSize: got %v, want dev 2", got)
+	}
+}
+
+func TestLoadOperationalConfigProfile_MissingUsesBase(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	townRoot := t.TempDir()
+	writeSettings(t, TownSettingsPath(townRoot), profileSettings)
+
+	op := LoadOperationalConfigProfile(townRoot, "stress")
+	if got := op.GetSessionConfig().ClaudeStartTimeoutD(); got != 90*time.Second {
+		t.Errorf("ClaudeStartTimeout: got %v, want base 90s", got)
+	}
+	if got := op.GetDaemonConfig().MaxDogPoolSizeV(); got != 6 {
+		t.Errorf("MaxDogPoolSize: got %v, want base 6", got)
+	}
+}
+
+func TestLoadOperationalConfigProfile_DoesNotMutateBase(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	townRoot := t.TempDir()
+	writeSettings(t, TownSettingsPath(townRoot), profileSettings)
+
+	ts, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = selectOperationalProfile(ts, "dev")
+	if got := ts.Operational.GetSessionConfig().ClaudeStartTimeoutD(); got != 90*time.Second {
+		t.Errorf("base ClaudeStartTimeout mutated: got %v, want 90s", got)
+	}
+}
+
+func TestLoadOperationalConfigLayered_AppliesProfile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(ProfileEnvVar, "dev")
+	townRoot := t.TempDir()
+	writeSettings(t, TownSettingsPath(townRoot), profileSettings)
+
+	op := LoadOperationalConfigLayered(townRoot)
+	if got := op.GetSessionConfig().ClaudeStartTimeoutD(); got != 5*time.Minute {
+		t.Errorf("ClaudeStartTimeout: got %v, want dev 5m", got)
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// Field kinds reported by OperationalSchema.
+const (
+	FieldKindDuration = "duration" // Go duration string, e.g. "30s"
+	FieldKindCount    = "count"    // integer
+	FieldKindFloat    = "float"    // floating-point number
+)
+
+// FieldSpec describes one tunable operational threshold.
+type FieldSpec struct {
+	// Subsystem is the JSON name of the owning sub-struct, e.g. "session".
+	Subsystem string `json:"subsystem"`
+	// Field is the JSON name of the field, e.g. "claude_start_timeout".
+	Field string `json:"field"`
+	// Path is Subsystem + "." + Field.
+	Path string `json:"path"`
+	// Kind is one of FieldKindDuration, FieldKindCount, FieldKindFloat.
+	Kind string `json:"kind"`
+	// Default is the compiled-in default, formatted as it would appear in config.json.
+	Default string `json:"default"`
+	// EnvVar is the environment variable that overrides this field.
+	EnvVar string `json:"env_var"`
+	// Accessor is the method that resolves this field, e.g. "SessionThresholds.ClaudeStartTimeoutD".
+	Accessor string `json:"accessor"`
+}
+
+// OperationalSchema returns a spec for every tunable operational threshold,
+// in struct declaration order. Defaults come from operationalDefaults, the
+// same table the accessors fall back to. Fields without an accessor are omitted.
+func OperationalSchema() []FieldSpec {
+	var specs []FieldSpec
+	walkOperationalFields(nil, func(f operationalField) {
+		accessor := operationalAccessorName(f)
+		if accessor == "" {
+			return
+		}
+		spec := FieldSpec{
+			Subsystem: f.Subsystem,
+			Field:     f.Name,
+			Path:      f.Path(),
+			EnvVar:    OperationalEnvVarName(f.Path()),
+			Accessor:  f.Owner.Name() + "." + accessor,
+		}
+		switch def := operationalDefaults[f.Path()].(type) {
+		case time.Duration:
+			spec.Kind = FieldKindDuration
+			spec.Default = def.String()
+		case int:
+			spec.Kind = FieldKindCount
+			spec.Default = strconv.Itoa(def)
+		case float64:
+			spec.Kind = FieldKindFloat
+			spec.Default = strconv.FormatFloat(def, 'g', -1, 64)
+		default:
+			return
+		}
+		specs = append(specs, spec)
+	})
+	return specs
+}
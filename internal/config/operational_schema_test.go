@@ -0,0 +1,68 @@
+package config
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOperationalSchema_Defaults(t *testing.T) {
+	specs := OperationalSchema()
+	byPath := make(map[string]FieldSpec, len(specs))
+	for _, s := range specs {
+		if _, dup := byPath[s.Path]; dup {
+			t.Errorf("duplicate schema path %q", s.Path)
+		}
+		byPath[s.Path] = s
+	}
+
+	tests := []struct {
+		path    string
+		kind    string
+		def     string
+		envVar  string
+		subsyst string
+	}{
+		{"session.claude_start_timeout", FieldKindDuration, DefaultClaudeStartTimeout.String(), "GT_SESSION_CLAUDE_START_TIMEOUT", "session"},
+		{"nudge.retry_interval", FieldKindDuration, DefaultNudgeRetryInterval.String(), "GT_NUDGE_RETRY_INTERVAL", "nudge"},
+		{"daemon.max_dog_pool_size", FieldKindCount, strconv.Itoa(DefaultMaxDogPoolSize), "GT_DAEMON_MAX_DOG_POOL_SIZE", "daemon"},
+		{"daemon.pressure_cpu_threshold", FieldKindFloat, "0", "GT_DAEMON_PRESSURE_CPU_THRESHOLD", "daemon"},
+		{"witness.heartbeat_startup_grace", FieldKindDuration, DefaultWitnessHeartbeatStartupGrace.String(), "GT_WITNESS_HEARTBEAT_STARTUP_GRACE", "witness"},
+	}
+	for _, tt := range tests {
+		s, ok := byPath[tt.path]
+		if !ok {
+			t.Errorf("%s: missing from schema", tt.path)
+			continue
+		}
+		if s.Kind != tt.kind || s.Default != tt.def || s.EnvVar != tt.envVar || s.Subsystem != tt.subsyst {
+			t.Errorf("%s: got %+v, want kind=%s default=%s env=%s subsystem=%s",
+				tt.path, s, tt.kind, tt.def, tt.envVar, tt.subsyst)
+		}
+	}
+
+	if _, ok := byPath["daemon.polecat_self_terminate"]; ok {
+		t.Error("fields without an accessor should be omitted")
+	}
+	if len(specs) != len(OperationalEnvVars()) {
+		t.Errorf("schema has %d fields, env list has %d", len(specs), len(OperationalEnvVars()))
+	}
+}
+
+func TestOperationalSchema_IgnoresEnvOverrides(t *testing.T) {
+	t.Setenv("GT_SESSION_CLAUDE_START_TIMEOUT", "999s")
+
+	for _, s := range OperationalSchema() {
+		if s.Path == "session.claude_start_timeout" {
+			if s.Default != DefaultClaudeStartTimeout.String() {
+				t.Errorf("default = %s, want %s", s.Default, DefaultClaudeStartTimeout)
+			}
+			d, err := time.ParseDuration(s.Default)
+			if err != nil || d != DefaultClaudeStartTimeout {
+				t.Errorf("default %q does not round-trip: %v", s.Default, err)
+			}
+			return
+		}
+	}
+	t.Fatal("session.claude_start_timeout missing from schema")
+}
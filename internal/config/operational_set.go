@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// SetOperationalField sets the threshold at path (e.g. "daemon.max_dog_pool_size"
+// or "session.claude_start_timeout") from its string form, creating the
+// subsystem if needed. Duration fields accept the formats ParseDuration does
+// plus "off"/"disabled"; integer and float fields accept plain numbers. The
+// value is checked as ValidateOperationalConfig would, and c is left
+// unchanged when it is rejected. Fields that are not a single value (per-role
+// maps, escalation stages) cannot be set this way.
+func SetOperationalField(c *OperationalConfig, path, value string) error {
+	if c == nil {
+		return fmt.Errorf("setting %s: nil config", path)
+	}
+	subsystem, name, ok := strings.Cut(path, ".")
+	if !ok {
+		return fmt.Errorf("unknown operational setting %q (want subsystem.field)", path)
+	}
+
+	rv := reflect.ValueOf(c).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		sf := rv.Type().Field(i)
+		if jsonFieldName(sf) != subsystem || sf.Type.Kind() != reflect.Pointer || sf.Type.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		st := sf.Type.Elem()
+		for j := 0; j < st.NumField(); j++ {
+			if jsonFieldName(st.Field(j)) != name {
+				continue
+			}
+			v, err := parseOperationalValue(path, st.Field(j).Type, value)
+			if err != nil {
+				return err
+			}
+			if errs := validateOperationalValue(path, v); len(errs) > 0 {
+				return errs[0]
+			}
+			if rv.Field(i).IsNil() {
+				rv.Field(i).Set(reflect.New(st))
+			}
+			rv.Field(i).Elem().Field(j).Set(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown operational setting %q", path)
+}
+
+// parseOperationalValue converts value to a field of type t.
+func parseOperationalValue(path string, t reflect.Type, value string) (reflect.Value, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case t.Kind() == reflect.String:
+		return reflect.ValueOf(value), nil
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return reflect.Value{}, ConfigError{Path: path, Value: value, Message: "not an integer"}
+		}
+		return reflect.ValueOf(&n), nil
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return reflect.Value{}, ConfigError{Path: path, Value: value, Message: "not a number"}
+		}
+		return reflect.ValueOf(&f), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s cannot be set from a single value; edit settings/config.json", path)
+}
+
+// SaveOperationalConfig writes c as the "operational" section of the town's
+// settings/config.json, atomically. Every other key in the file, including
+// ones this version of gt does not know, is kept as is. A nil c removes the
+// section. The file is created (as NewTownSettings) if it does not exist.
+func SaveOperationalConfig(townRoot string, c *OperationalConfig) error {
+	path := TownSettingsPath(townRoot)
+	doc := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	if os.IsNotExist(err) {
+		data, err = json.Marshal(NewTownSettings())
+	}
+	if err != nil {
+		return fmt.Errorf("reading town settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if c == nil {
+		delete(doc, "operational")
+	} else {
+		raw, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("encoding operational config: %w", err)
+		}
+		doc["operational"] = raw
+	}
+
+	if err := atomicfile.EnsureDirAndWriteJSON(path, doc); err != nil {
+		return fmt.Errorf("writing town settings: %w", err)
+	}
+	return nil
+}
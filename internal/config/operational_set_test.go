@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSetOperationalField(t *testing.T) {
+	t.Parallel()
+
+	c := &OperationalConfig{}
+	if err := SetOperationalField(c, "session.claude_start_timeout", "90s"); err != nil {
+		t.Fatalf("set duration: %v", err)
+	}
+	if got := c.GetSessionConfig().ClaudeStartTimeoutD().String(); got != "1m30s" {
+		t.Errorf("claude_start_timeout = %s, want 1m30s", got)
+	}
+	if err := SetOperationalField(c, "daemon.dog_idle_remove_timeout", "off"); err != nil {
+		t.Fatalf("set disabled duration: %v", err)
+	}
+	if c.Daemon.DogIdleRemoveTimeout != "off" {
+		t.Errorf("dog_idle_remove_timeout = %q, want off", c.Daemon.DogIdleRemoveTimeout)
+	}
+	if err := SetOperationalField(c, "daemon.max_dog_pool_size", "6"); err != nil {
+		t.Fatalf("set int: %v", err)
+	}
+	if got := c.GetDaemonConfig().MaxDogPoolSizeV(); got != 6 {
+		t.Errorf("max_dog_pool_size = %d, want 6", got)
+	}
+
+	before, _ := json.Marshal(c)
+	for _, tc := range []struct{ path, value string }{
+		{"daemon.max_dog_pool_size", "5m"},         // duration for an int
+		{"session.claude_start_timeout", "twelve"}, // not a duration
+		{"session.claude_start_timeout", "-5s"},    // negative duration
+		{"daemon.max_dog_pool_size", "-1"},         // negative int
+	} {
+		err := SetOperationalField(c, tc.path, tc.value)
+		var cerr ConfigError
+		if !errors.As(err, &cerr) || cerr.Path != tc.path {
+			t.Errorf("SetOperationalField(%s, %q) = %v, want a ConfigError for the path", tc.path, tc.value, err)
+		}
+	}
+	for _, path := range []string{"daemon.no_such_field", "nosuch.field", "daemon", "session.per_role"} {
+		if err := SetOperationalField(c, path, "1"); err == nil {
+			t.Errorf("SetOperationalField(%s) = nil, want error", path)
+		}
+	}
+	if after, _ := json.Marshal(c); string(after) != string(before) {
+		t.Errorf("rejected sets changed the config:\n%s\n%s", before, after)
+	}
+}
+
+func TestSaveOperationalConfig_PreservesOtherSettings(t *testing.T) {
+	t.Parallel()
+
+	townRoot := t.TempDir()
+	path := TownSettingsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	orig := `{"type":"town-settings","version":1,"cli_theme":"dark","future_key":{"x":1},"operational":{"nudge":{"normal_ttl":"45m"}}}`
+	if err := os.WriteFile(path, []byte(orig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := LoadOperationalConfig(townRoot)
+	if err := SetOperationalField(c, "daemon.max_dog_pool_size", "7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveOperationalConfig(townRoot, c); err != nil {
+		t.Fatalf("SaveOperationalConfig: %v", err)
+	}
+
+	got := LoadOperationalConfig(townRoot)
+	if got.GetDaemonConfig().MaxDogPoolSizeV() != 7 || got.GetNudgeConfig().NormalTTL != "45m" {
+		t.Errorf("reloaded operational = %+v", got)
+	}
+	ts, err := LoadOrCreateTownSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.CLITheme != "dark" {
+		t.Errorf("cli_theme = %q, want dark preserved", ts.CLITheme)
+	}
+	var doc map[string]any
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(doc["future_key"], map[string]any{"x": 1.0}) {
+		t.Errorf("unknown key not preserved: %v", doc["future_key"])
+	}
+}
+
+func TestSaveOperationalConfig_CreatesSettings(t *testing.T) {
+	t.Parallel()
+
+	townRoot := t.TempDir()
+	four := 4
+	if err := SaveOperationalConfig(townRoot, &OperationalConfig{Daemon: &DaemonThresholds{MaxDogPoolSize: &four}}); err != nil {
+		t.Fatalf("SaveOperationalConfig: %v", err)
+	}
+	ts, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.Type != "town-settings" || ts.Operational.GetDaemonConfig().MaxDogPoolSizeV() != 4 {
+		t.Errorf("created settings = %+v", ts)
+	}
+}
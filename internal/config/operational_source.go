@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// durationWithSource resolves the duration threshold at path from its config
+// file value v and reports which layer supplied it: a valid environment
+// override wins, then v, then the compiled-in default from operationalDefaults.
+// When the highest-priority value that was set fails to parse, the next layer
+// is used and the source is SourceInvalidFellBack. Negative durations are
+// invalid; "off" and "disabled" are only valid on paths in
+// operationalDisableable.
+func durationWithSource(path, v string) (time.Duration, Source) {
+	return durationWithFallback(path, v, operationalDefaults[path].(time.Duration))
+}
+
+// durationWithFallback is durationWithSource with an explicit lowest layer,
+// for paths such as per-role overrides that fall back to another threshold
+// rather than to a compiled-in default.
+func durationWithFallback(path, v string, fallback time.Duration) (time.Duration, Source) {
+	d, src := fallback, SourceDefault
+	if strings.TrimSpace(v) != "" {
+		if parsed, err := parseOperationalDuration(path, v); err == nil {
+			d, src = parsed, SourceFile
+		} else {
+			src = SourceInvalidFellBack
+		}
+	}
+	if env := operationalEnv(path); env != "" {
+		if parsed, err := parseOperationalDuration(path, env); err == nil {
+			return parsed, SourceEnv
+		}
+		return d, SourceInvalidFellBack
+	}
+	return d, src
+}
+
+// parseOperationalDuration parses s as the duration threshold at path,
+// rejecting negative durations and the disable keywords unless the field
+// opts in to them.
+func parseOperationalDuration(path, s string) (time.Duration, error) {
+	if isDisabledDuration(s) {
+		if !operationalDisableable[path] {
+			return 0, fmt.Errorf("%s cannot be disabled", path)
+		}
+		return DurationDisabled, nil
+	}
+	d, err := ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative", path)
+	}
+	return d, nil
+}
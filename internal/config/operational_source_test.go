@@ -0,0 +1,108 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClaudeStartTimeoutWithSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		session *SessionThresholds
+		want    time.Duration
+		source  Source
+	}{
+		{"nil sub-struct", nil, DefaultClaudeStartTimeout, SourceDefault},
+		{"unset", &SessionThresholds{}, DefaultClaudeStartTimeout, SourceDefault},
+		{"configured", &SessionThresholds{ClaudeStartTimeout: "90s"}, 90 * time.Second, SourceFile},
+		{"cannot be disabled", &SessionThresholds{ClaudeStartTimeout: "off"}, DefaultClaudeStartTimeout, SourceInvalidFellBack},
+		{"invalid", &SessionThresholds{ClaudeStartTimeout: "not-a-duration"}, DefaultClaudeStartTimeout, SourceInvalidFellBack},
+		{"negative", &SessionThresholds{ClaudeStartTimeout: "-5s"}, DefaultClaudeStartTimeout, SourceInvalidFellBack},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &OperationalConfig{Session: tt.session}
+			got, source := op.GetSessionConfig().ClaudeStartTimeoutWithSource()
+			if got != tt.want || source != tt.source {
+				t.Errorf("got %v (%s), want %v (%s)", got, source, tt.want, tt.source)
+			}
+			if d := op.GetSessionConfig().ClaudeStartTimeoutD(); d != got {
+				t.Errorf("ClaudeStartTimeoutD = %v, WithSource = %v", d, got)
+			}
+		})
+	}
+}
+
+func TestDurationWithSource_Env(t *testing.T) {
+	session := &SessionThresholds{ClaudeStartTimeout: "90s"}
+
+	t.Setenv("GT_SESSION_CLAUDE_START_TIMEOUT", "2m")
+	if got, source := session.ClaudeStartTimeoutWithSource(); got != 2*time.Minute || source != SourceEnv {
+		t.Errorf("valid env: got %v (%s), want 2m0s (env)", got, source)
+	}
+
+	// A malformed override falls back to the config value, which the plain
+	// accessor cannot tell apart from an unset variable.
+	t.Setenv("GT_SESSION_CLAUDE_START_TIMEOUT", "garbage")
+	if got, source := session.ClaudeStartTimeoutWithSource(); got != 90*time.Second || source != SourceInvalidFellBack {
+		t.Errorf("invalid env: got %v (%s), want 1m30s (invalid)", got, source)
+	}
+	if got, source := (&SessionThresholds{}).ClaudeStartTimeoutWithSource(); got != DefaultClaudeStartTimeout || source != SourceInvalidFellBack {
+		t.Errorf("invalid env, no file value: got %v (%s), want default (invalid)", got, source)
+	}
+
+	// The start timeout cannot be disabled, so "off" is a malformed override.
+	t.Setenv("GT_SESSION_CLAUDE_START_TIMEOUT", "off")
+	if got, source := session.ClaudeStartTimeoutWithSource(); got != 90*time.Second || source != SourceInvalidFellBack {
+		t.Errorf("off env: got %v (%s), want 1m30s (invalid)", got, source)
+	}
+}
+
+// TestWithSource_MatchesAccessors checks that every FieldD accessor has a
+// FieldWithSource twin that resolves to the same duration.
+func TestWithSource_MatchesAccessors(t *testing.T) {
+	t.Parallel()
+
+	// Populate every sub-struct, cycling duration fields through configured,
+	// malformed, and unset values.
+	op := &OperationalConfig{}
+	rv := reflect.ValueOf(op).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		if f := rv.Field(i); f.Kind() == reflect.Pointer && f.Type().Elem().Kind() == reflect.Struct {
+			f.Set(reflect.New(f.Type().Elem()))
+		}
+	}
+	values := []string{"7m", "garbage", ""}
+	n := 0
+	walkOperationalFields(op, func(f operationalField) {
+		if f.Type.Kind() == reflect.String && f.Value.CanSet() {
+			f.Value.SetString(values[n%len(values)])
+			n++
+		}
+	})
+
+	checked := 0
+	for _, c := range []*OperationalConfig{nil, op} {
+		walkOperationalFields(c, func(f operationalField) {
+			if f.Type.Kind() != reflect.String || operationalAccessorName(f) == "" {
+				return
+			}
+			ws := f.Recv.MethodByName(f.GoName + "WithSource")
+			if !ws.IsValid() {
+				t.Errorf("%s.%sD has no %sWithSource", f.Owner.Name(), f.GoName, f.GoName)
+				return
+			}
+			want := f.Recv.MethodByName(f.GoName + "D").Call(nil)[0].Interface()
+			if got := ws.Call(nil)[0].Interface(); got != want {
+				t.Errorf("%s: WithSource = %v, D = %v", f.Path(), got, want)
+			}
+			checked++
+		})
+	}
+	if checked == 0 {
+		t.Fatal("no duration accessors found")
+	}
+}
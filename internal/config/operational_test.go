@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -113,6 +114,15 @@ func TestDaemonThresholds_Defaults(t *testing.T) {
 	if got := daemon.DeaconGracePeriodD(); got != DefaultDeaconGracePeriod {
 		t.Errorf("DeaconGracePeriod: got %v, want %v", got, DefaultDeaconGracePeriod)
 	}
+	if got := daemon.RespawnMaxAttemptsV(); got != DefaultRespawnMaxAttempts {
+		t.Errorf("RespawnMaxAttempts: got %v, want %v", got, DefaultRespawnMaxAttempts)
+	}
+	if got := daemon.RespawnWindowD(); got != DefaultRespawnWindow {
+		t.Errorf("RespawnWindow: got %v, want %v", got, DefaultRespawnWindow)
+	}
+	if got := daemon.MassDeathRespawnPauseD(); got != DefaultMassDeathRespawnPause {
+		t.Errorf("MassDeathRespawnPause: got %v, want %v", got, DefaultMassDeathRespawnPause)
+	}
 }
 
 func TestDaemonThresholds_Overrides(t *testing.T) {
@@ -340,6 +350,27 @@ func TestWebThresholds_Overrides(t *testing.T) {
 	}
 }
 
+func TestWebThresholds_MaxCommandsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	zero, thirty := 0, 30
+	tests := []struct {
+		name string
+		w    *WebThresholds
+		want int
+	}{
+		{"nil uses default", nil, DefaultWebMaxCommandsPerMinute},
+		{"unset uses default", &WebThresholds{}, DefaultWebMaxCommandsPerMinute},
+		{"zero disables", &WebThresholds{MaxCommandsPerMinute: &zero}, 0},
+		{"explicit value", &WebThresholds{MaxCommandsPerMinute: &thirty}, 30},
+	}
+	for _, tt := range tests {
+		if got := tt.w.MaxCommandsPerMinuteV(); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestWitnessThresholds_Defaults(t *testing.T) {
 	t.Parallel()
 
@@ -506,3 +537,351 @@ func TestPressureThresholds_JSON(t *testing.T) {
 		t.Errorf("JSON max sessions: got %v, want 8", raw.Daemon.PressureMaxSessionsV())
 	}
 }
+
+func TestDurationAccessors_ZeroVsDisabledVsDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty uses default", "", DefaultDogIdleRemoveTimeout},
+		{"0s means zero", "0s", 0},
+		{"off means disabled", "off", DurationDisabled},
+		{"disabled means disabled", "disabled", DurationDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			op := &OperationalConfig{Daemon: &DaemonThresholds{DogIdleRemoveTimeout: tt.value}}
+			if got := op.GetDaemonConfig().DogIdleRemoveTimeoutD(); got != tt.want {
+				t.Errorf("DogIdleRemoveTimeoutD(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOperationalConfig_AcceptsDisabled(t *testing.T) {
+	t.Parallel()
+
+	op := &OperationalConfig{Daemon: &DaemonThresholds{DogIdleRemoveTimeout: "off"}}
+	if errs := ValidateOperationalConfig(op); len(errs) != 0 {
+		t.Errorf("\"off\" should be valid, got %v", errs)
+	}
+}
+
+func TestDurationAccessors_OffOnlyWhereDisableable(t *testing.T) {
+	t.Parallel()
+
+	op := &OperationalConfig{
+		Session: &SessionThresholds{GUPPViolationTimeout: "off", HungSessionThreshold: "disabled"},
+		Polecat: &PolecatThresholds{PendingMaxAge: "off"},
+	}
+	if got := op.GetSessionConfig().GUPPViolationTimeoutD(); got != DefaultGUPPViolationTimeout {
+		t.Errorf("GUPPViolationTimeoutD(off) = %v, want default %v", got, DefaultGUPPViolationTimeout)
+	}
+	if got := op.GetSessionConfig().HungSessionThresholdD(); got != DefaultHungSessionThreshold {
+		t.Errorf("HungSessionThresholdD(disabled) = %v, want default %v", got, DefaultHungSessionThreshold)
+	}
+	if got := op.GetPolecatConfig().PendingMaxAgeD(); got != DefaultPolecatPendingMaxAge {
+		t.Errorf("PendingMaxAgeD(off) = %v, want default %v", got, DefaultPolecatPendingMaxAge)
+	}
+
+	errs := ValidateOperationalConfig(op)
+	if len(errs) != 3 {
+		t.Fatalf("want 3 errors for \"off\" on non-disableable fields, got %v", errs)
+	}
+	for _, e := range errs {
+		if operationalDisableable[e.Path] {
+			t.Errorf("unexpected error for disableable field %s", e.Path)
+		}
+	}
+}
+
+func TestOperationalDisableable_PathsExist(t *testing.T) {
+	t.Parallel()
+
+	for path := range operationalDisableable {
+		if _, ok := operationalDefaults[path].(time.Duration); !ok {
+			t.Errorf("operationalDisableable lists %q, which is not a duration threshold", path)
+		}
+	}
+}
+
+func TestCountAccessors_Clamp(t *testing.T) {
+	t.Parallel()
+
+	huge := 10000
+	negative := -3
+	zero := 0
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"too-large dog pool clamps down", (&DaemonThresholds{MaxDogPoolSize: &huge}).MaxDogPoolSizeV(), MaxDogPoolSizeLimit},
+		{"negative dog pool clamps to min", (&DaemonThresholds{MaxDogPoolSize: &negative}).MaxDogPoolSizeV(), MinDogPoolSize},
+		{"zero dolt connections clamps to min", (&DoltThresholds{MaxConnections: &zero}).MaxConnectionsV(), MinDoltConnections},
+		{"too-large queue depth clamps down", (&NudgeThresholds{MaxQueueDepth: &huge}).MaxQueueDepthV(), MaxNudgeQueueDepthLimit},
+		{"negative urgent headroom clamps to zero", (&NudgeThresholds{UrgentQueueHeadroom: &negative}).UrgentQueueHeadroomV(), MinNudgeUrgentHeadroom},
+		{"zero urgent headroom is allowed", (&NudgeThresholds{UrgentQueueHeadroom: &zero}).UrgentQueueHeadroomV(), 0},
+		{"too-large namepool growth clamps down", (&PolecatThresholds{NamepoolGrowIncrement: &huge}).NamepoolGrowIncrementV(), MaxPolecatNamepoolGrowByLimit},
+		{"zero namepool max size clamps to min", (&PolecatThresholds{NamepoolMaxSize: &zero}).NamepoolMaxSizeV(), MinPolecatNamepoolSize},
+		{"negative web commands clamps to min", (&WebThresholds{MaxConcurrentCommands: &negative}).MaxConcurrentCommandsV(), MinWebConcurrentCmds},
+		{"in-range value is unchanged", (&DaemonThresholds{MaxDogPoolSize: func() *int { n := 8; return &n }()}).MaxDogPoolSizeV(), 8},
+		{"negative startup nudge retries clamps to zero", (&SessionThresholds{StartupNudgeMaxRetries: &negative}).StartupNudgeMaxRetriesV(), 0},
+		{"negative consecutive failures clamps to zero", (&DeaconThresholds{ConsecutiveFailures: &negative}).ConsecutiveFailuresV(), 0},
+		{"negative max redispatches clamps to zero", (&DeaconThresholds{MaxRedispatches: &negative}).MaxRedispatchesV(), 0},
+		{"negative sync failure threshold clamps to zero", (&DaemonThresholds{SyncFailureEscalationThreshold: &negative}).SyncFailureEscalationThresholdV(), 0},
+		{"negative respawn max attempts clamps to zero", (&DaemonThresholds{RespawnMaxAttempts: &negative}).RespawnMaxAttemptsV(), 0},
+		{"negative subject length clamps to zero", (&WebThresholds{MaxSubjectLen: &negative}).MaxSubjectLenV(), 0},
+		{"negative body length clamps to zero", (&WebThresholds{MaxBodyLen: &negative}).MaxBodyLenV(), 0},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCountDefaults_WithinBounds(t *testing.T) {
+	t.Parallel()
+
+	bounds := []struct {
+		name        string
+		def, lo, hi int
+	}{
+		{"MaxDogPoolSize", DefaultMaxDogPoolSize, MinDogPoolSize, MaxDogPoolSizeLimit},
+		{"DoltMaxConnections", DefaultDoltMaxConnections, MinDoltConnections, MaxDoltConnectionsLimit},
+		{"NudgeMaxQueueDepth", DefaultNudgeMaxQueueDepth, MinNudgeQueueDepth, MaxNudgeQueueDepthLimit},
+		{"NudgeUrgentHeadroom", DefaultNudgeUrgentHeadroom, MinNudgeUrgentHeadroom, MaxNudgeUrgentHeadroomLimit},
+		{"MassDeathThreshold", DefaultMassDeathThreshold, MinMassDeathThreshold, MaxMassDeathThresholdLimit},
+		{"PolecatNamepoolSize", DefaultPolecatNamepoolSize, MinPolecatNamepoolSize, MaxPolecatNamepoolSizeLimit},
+		{"PolecatNamepoolGrowBy", DefaultPolecatNamepoolGrowBy, MinPolecatNamepoolGrowBy, MaxPolecatNamepoolGrowByLimit},
+		{"PolecatNamepoolMaxSize", DefaultPolecatNamepoolMaxSize, MinPolecatNamepoolSize, MaxPolecatNamepoolSizeLimit},
+		{"MailMaxConcurrentAcks", DefaultMailMaxConcurrentAcks, MinMailConcurrentAcks, MaxMailConcurrentAcksLimit},
+		{"WebMaxConcurrentCmds", DefaultWebMaxConcurrentCmds, MinWebConcurrentCmds, MaxWebConcurrentCmdsLimit},
+	}
+	for _, b := range bounds {
+		if b.def < b.lo || b.def > b.hi {
+			t.Errorf("%s default %d outside [%d, %d]", b.name, b.def, b.lo, b.hi)
+		}
+	}
+}
+
+func TestDurationAccessors_DayWeekUnits(t *testing.T) {
+	t.Parallel()
+
+	op := &OperationalConfig{Daemon: &DaemonThresholds{
+		DogIdleRemoveTimeout:   "3d",
+		MaxLifecycleMessageAge: "1w",
+		StaleWorkingTimeout:    "2dd",
+	}}
+	d := op.GetDaemonConfig()
+	if got := d.DogIdleRemoveTimeoutD(); got != 72*time.Hour {
+		t.Errorf("DogIdleRemoveTimeout: got %v, want 72h", got)
+	}
+	if got := d.MaxLifecycleMessageAgeD(); got != 7*24*time.Hour {
+		t.Errorf("MaxLifecycleMessageAge: got %v, want 168h", got)
+	}
+	if got := d.StaleWorkingTimeoutD(); got != DefaultStaleWorkingTimeout {
+		t.Errorf("invalid StaleWorkingTimeout should fall back: got %v, want %v", got, DefaultStaleWorkingTimeout)
+	}
+	if errs := ValidateOperationalConfig(op); len(errs) != 1 || errs[0].Path != "daemon.stale_working_timeout" {
+		t.Errorf("validation: got %v, want one error for daemon.stale_working_timeout", errs)
+	}
+}
+
+func TestAddJitter(t *testing.T) {
+	t.Parallel()
+
+	// Deterministic source: always returns n-1, the largest allowed draw.
+	maxDraw := func(n int64) int64 { return n - 1 }
+	tests := []struct {
+		name         string
+		base, jitter time.Duration
+		want         time.Duration
+	}{
+		{"zero jitter is exact", 5 * time.Minute, 0, 5 * time.Minute},
+		{"negative jitter is exact", 5 * time.Minute, -time.Second, 5 * time.Minute},
+		{"jitter stays below bound", 5 * time.Minute, time.Minute, 6*time.Minute - 1},
+		{"disabled base is unchanged", DurationDisabled, time.Minute, DurationDisabled},
+	}
+	for _, tt := range tests {
+		if got := addJitter(tt.base, tt.jitter, maxDraw); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDeaconThresholds_CooldownWithJitter(t *testing.T) {
+	// Not parallel: replaces the package-level jitter source.
+	orig := jitterInt63n
+	t.Cleanup(func() { jitterInt63n = orig })
+	jitterInt63n = func(n int64) int64 { return n / 2 }
+
+	// Unset jitter reproduces the plain cooldown exactly.
+	var unset *DeaconThresholds
+	if got := unset.RedispatchCooldownWithJitter(); got != DefaultRedispatchCooldown {
+		t.Errorf("RedispatchCooldownWithJitter unset: got %v, want %v", got, DefaultRedispatchCooldown)
+	}
+	if got := unset.FeedCooldownWithJitter(); got != DefaultFeedCooldown {
+		t.Errorf("FeedCooldownWithJitter unset: got %v, want %v", got, DefaultFeedCooldown)
+	}
+
+	d := &DeaconThresholds{
+		RedispatchCooldown:       "4m",
+		RedispatchCooldownJitter: "2m",
+		FeedCooldownJitter:       "10m",
+	}
+	if got := d.RedispatchCooldownWithJitter(); got != 5*time.Minute {
+		t.Errorf("RedispatchCooldownWithJitter: got %v, want 5m", got)
+	}
+	if got := d.FeedCooldownWithJitter(); got != DefaultFeedCooldown+5*time.Minute {
+		t.Errorf("FeedCooldownWithJitter: got %v, want %v", got, DefaultFeedCooldown+5*time.Minute)
+	}
+}
+
+func TestDaemonThresholds_SyncFailureStages(t *testing.T) {
+	t.Parallel()
+
+	// Unset: single error stage at the legacy threshold.
+	var unset *DaemonThresholds
+	want := []EscalationStage{{Count: DefaultSyncFailureEscalationThreshold, Action: EscalationActionError}}
+	if got := unset.SyncFailureStagesV(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unset: got %v, want %v", got, want)
+	}
+	threshold := 5
+	legacy := &DaemonThresholds{SyncFailureEscalationThreshold: &threshold}
+	if got := legacy.SyncFailureStagesV(); len(got) != 1 || got[0].Count != 5 {
+		t.Errorf("legacy threshold: got %v, want single stage at 5", got)
+	}
+
+	// Out of order, overlapping, and invalid entries are normalized.
+	d := &DaemonThresholds{SyncFailureStages: []EscalationStage{
+		{Count: 10, Action: "halt"},
+		{Count: 3, Action: "warn"},
+		{Count: 6, Action: "error"},
+		{Count: 6, Action: " PAGE "},
+		{Count: 0, Action: "warn"},
+		{Count: 8, Action: "reboot"},
+	}}
+	want = []EscalationStage{
+		{Count: 3, Action: EscalationActionWarn},
+		{Count: 6, Action: EscalationActionPage},
+		{Count: 10, Action: EscalationActionHalt},
+	}
+	if got := d.SyncFailureStagesV(); !reflect.DeepEqual(got, want) {
+		t.Errorf("normalized: got %v, want %v", got, want)
+	}
+
+	// All-invalid stages fall back to the legacy stage.
+	bad := &DaemonThresholds{SyncFailureStages: []EscalationStage{{Count: -1, Action: "warn"}}}
+	if got := bad.SyncFailureStagesV(); len(got) != 1 || got[0].Action != EscalationActionError {
+		t.Errorf("all invalid: got %v, want legacy error stage", got)
+	}
+}
+
+func TestActiveEscalationStage(t *testing.T) {
+	t.Parallel()
+
+	stages := []EscalationStage{
+		{Count: 3, Action: EscalationActionWarn},
+		{Count: 6, Action: EscalationActionPage},
+		{Count: 10, Action: EscalationActionHalt},
+	}
+	tests := []struct {
+		failures int
+		want     string
+	}{
+		{1, ""},
+		{3, EscalationActionWarn},
+		{5, EscalationActionWarn},
+		{6, EscalationActionPage},
+		{12, EscalationActionHalt},
+	}
+	for _, tt := range tests {
+		stage, ok := ActiveEscalationStage(stages, tt.failures)
+		if got := stage.Action; got != tt.want || ok != (tt.want != "") {
+			t.Errorf("ActiveEscalationStage(%d) = %q, %v; want %q", tt.failures, got, ok, tt.want)
+		}
+	}
+}
+
+func TestValidateOperationalConfig_SyncFailureStages(t *testing.T) {
+	t.Parallel()
+
+	op := &OperationalConfig{Daemon: &DaemonThresholds{SyncFailureStages: []EscalationStage{
+		{Count: 3, Action: "warn"},
+		{Count: 0, Action: "explode"},
+	}}}
+	errs := ValidateOperationalConfig(op)
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	want := []string{"daemon.sync_failure_stages[1].count", "daemon.sync_failure_stages[1].action"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("validation paths: got %v, want %v", paths, want)
+	}
+}
+
+func TestDaemonThresholds_BootSpawnCooldownForRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		daemon *DaemonThresholds
+		role   string
+		want   time.Duration
+	}{
+		{"overridden role", &DaemonThresholds{BootSpawnCooldown: "90s", BootSpawnCooldownByRole: map[string]string{"deacon": "10m"}}, "deacon", 10 * time.Minute},
+		{"other role uses global", &DaemonThresholds{BootSpawnCooldown: "90s", BootSpawnCooldownByRole: map[string]string{"deacon": "10m"}}, "polecat", 90 * time.Second},
+		{"no global uses default", &DaemonThresholds{BootSpawnCooldownByRole: map[string]string{"deacon": "10m"}}, "witness", DefaultBootSpawnCooldown},
+		{"invalid override uses global", &DaemonThresholds{BootSpawnCooldown: "90s", BootSpawnCooldownByRole: map[string]string{"deacon": "soon"}}, "deacon", 90 * time.Second},
+		{"disabled override uses global", &DaemonThresholds{BootSpawnCooldown: "90s", BootSpawnCooldownByRole: map[string]string{"deacon": "off"}}, "deacon", 90 * time.Second},
+		{"nil thresholds use default", nil, "deacon", DefaultBootSpawnCooldown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.daemon.BootSpawnCooldownForRole(tt.role); got != tt.want {
+				t.Errorf("BootSpawnCooldownForRole(%q) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaemonThresholds_BootSpawnCooldownForRole_EnvOverride(t *testing.T) {
+	d := &DaemonThresholds{BootSpawnCooldown: "90s", BootSpawnCooldownByRole: map[string]string{"deacon": "10m"}}
+
+	t.Setenv("GT_DAEMON_BOOT_SPAWN_COOLDOWN_BY_ROLE_DEACON", "3m")
+	if got := d.BootSpawnCooldownForRole("deacon"); got != 3*time.Minute {
+		t.Errorf("env override: got %v, want 3m", got)
+	}
+
+	t.Setenv("GT_DAEMON_BOOT_SPAWN_COOLDOWN_BY_ROLE_DEACON", "off")
+	if got := d.BootSpawnCooldownForRole("deacon"); got != 10*time.Minute {
+		t.Errorf("disabled env override: got %v, want file value 10m", got)
+	}
+}
+
+func TestValidateOperationalConfig_BootSpawnCooldownByRole(t *testing.T) {
+	t.Parallel()
+
+	c := &OperationalConfig{Daemon: &DaemonThresholds{BootSpawnCooldownByRole: map[string]string{
+		"deacon":  "soon",
+		"polecat": "5m",
+		"witness": "off",
+	}}}
+	var paths []string
+	for _, e := range ValidateOperationalConfig(c) {
+		paths = append(paths, e.Path)
+	}
+	want := []string{"daemon.boot_spawn_cooldown_by_role.deacon", "daemon.boot_spawn_cooldown_by_role.witness"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("validation paths: got %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownOperationalKeys reads the town settings file at path and returns one
+// ConfigError per key under "operational" that the config structs do not
+// recognize, such as a misspelled threshold. LoadOrCreateTownSettings
+// ignores such keys, so the threshold silently keeps its default. A missing
+// file has no unknown keys.
+func UnknownOperationalKeys(path string) ([]ConfigError, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var settings struct {
+		Operational json.RawMessage `json:"operational"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	if len(settings.Operational) == 0 {
+		return nil, nil
+	}
+	return unknownKeys("", settings.Operational, reflect.TypeOf(OperationalConfig{})), nil
+}
+
+// unknownKeys checks the JSON value raw against type t. Each key of an
+// object is decoded into t on its own with DisallowUnknownFields, so every
+// unknown key is reported rather than only the first; the values of known
+// keys are checked recursively. Values of the wrong shape are skipped: the lenient
+// load reports those.
+func unknownKeys(path string, raw json.RawMessage, t reflect.Type) []ConfigError {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var errs []ConfigError
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return nil
+		}
+		for _, key := range sortedRawKeys(obj) {
+			keyPath := joinConfigPath(path, key)
+			// Decode the key alone with a null value, so only the key itself
+			// is checked here and nested keys are left to the recursion.
+			single, _ := json.Marshal(map[string]json.RawMessage{key: json.RawMessage("null")})
+			dec := json.NewDecoder(bytes.NewReader(single))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(reflect.New(t).Interface()); err != nil && strings.Contains(err.Error(), "unknown field") {
+				errs = append(errs, ConfigError{Path: keyPath, Value: rawValueString(obj[key]), Message: "unknown key (ignored)"})
+				continue
+			}
+			if ft, ok := jsonFieldType(t, key); ok {
+				errs = append(errs, unknownKeys(keyPath, obj[key], ft)...)
+			}
+		}
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return nil
+		}
+		for _, key := range sortedRawKeys(obj) {
+			errs = append(errs, unknownKeys(joinConfigPath(path, key), obj[key], t.Elem())...)
+		}
+	case reflect.Slice:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return nil
+		}
+		for i, item := range items {
+			errs = append(errs, unknownKeys(fmt.Sprintf("%s[%d]", path, i), item, t.Elem())...)
+		}
+	}
+	return errs
+}
+
+// jsonFieldType returns the type of t's field that encoding/json decodes key
+// into: an exact tag match, else a case-insensitive one.
+func jsonFieldType(t reflect.Type, key string) (reflect.Type, bool) {
+	var fold reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonFieldName(t.Field(i))
+		if name == key {
+			return t.Field(i).Type, true
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			fold = t.Field(i).Type
+		}
+	}
+	return fold, fold != nil
+}
+
+// rawValueString renders a JSON value for a ConfigError: strings unquoted,
+// anything else as its JSON text.
+func rawValueString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedRawKeys(obj map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestUnknownOperationalKeys(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeSettings(t, path, `{
+  "type": "town-settings",
+  "operational": {
+    "daemon": {"max_dog_pool_size": 6, "max_dog_pools": 7},
+    "session": {
+      "claude_start_timeout": "90s",
+      "per_role": {"polecat": {"gupp_violation_timeout": "1h", "gupp_timeout": "2h"}}
+    },
+    "nudgee": {"normal_ttl": "1h"}
+  }
+}`)
+
+	errs, err := UnknownOperationalKeys(path)
+	if err != nil {
+		t.Fatalf("UnknownOperationalKeys: %v", err)
+	}
+	want := []ConfigError{
+		{Path: "daemon.max_dog_pools", Value: "7", Message: "unknown key (ignored)"},
+		{Path: "nudgee", Value: `{"normal_ttl": "1h"}`, Message: "unknown key (ignored)"},
+		{Path: "session.per_role.polecat.gupp_timeout", Value: "2h", Message: "unknown key (ignored)"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %v, want %v", errs, want)
+	}
+	for i := range want {
+		if errs[i] != want[i] {
+			t.Errorf("errs[%d] = %+v, want %+v", i, errs[i], want[i])
+		}
+	}
+
+	// The lenient load still succeeds and keeps the valid field.
+	ts, err := LoadOrCreateTownSettings(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateTownSettings: %v", err)
+	}
+	if got := ts.Operational.GetDaemonConfig().MaxDogPoolSizeV(); got != 6 {
+		t.Errorf("MaxDogPoolSize = %d, want 6", got)
+	}
+}
+
+func TestUnknownOperationalKeys_Clean(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeSettings(t, path, `{"operational": {"daemon": {"max_dog_pool_size": 6}, "nudge": {"normal_ttl": "45m"}}}`)
+	if errs, err := UnknownOperationalKeys(path); err != nil || len(errs) != 0 {
+		t.Errorf("UnknownOperationalKeys = %v, %v; want none", errs, err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	if errs, err := UnknownOperationalKeys(missing); err != nil || len(errs) != 0 {
+		t.Errorf("missing file: got %v, %v; want none", errs, err)
+	}
+}
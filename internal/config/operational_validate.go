@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigError describes a single invalid operational config value.
+// Path is the dotted JSON path below "operational" (e.g. "session.gupp_violation_timeout").
+type ConfigError struct {
+	Path    string
+	Value   string
+	Message string
+}
+
+func (e ConfigError) Error() string {
+	return fmt.Sprintf("%s: invalid value %q: %s", e.Path, e.Value, e.Message)
+}
+
+// operationalField is one leaf field of an OperationalConfig sub-struct,
+// addressed by its JSON path. Used by validation and other reflective walkers
+// so the set of tunables is derived from struct tags rather than hand-listed.
+type operationalField struct {
+	Subsystem string        // JSON name of the sub-struct, e.g. "session"
+	Name      string        // JSON name of the field, e.g. "claude_start_timeout"
+	GoName    string        // Go name of the field, e.g. "ClaudeStartTimeout"
+	Value     reflect.Value // the field value; invalid when the sub-struct is nil
+	Type      reflect.Type  // the field type
+	Owner     reflect.Type  // the sub-struct type, e.g. SessionThresholds
+	Recv      reflect.Value // pointer to the sub-struct (typed nil when absent), for calling accessors
+}
+
+// Path returns the dotted JSON path of the field.
+func (f operationalField) Path() string {
+	return f.Subsystem + "." + f.Name
+}
+
+// walkOperationalFields calls fn for every leaf field of every subsystem in c.
+// Nil-safe: fields of nil sub-structs (or a nil c) are visited with an
+// invalid Value so callers can still enumerate the full set of tunables.
+func walkOperationalFields(c *OperationalConfig, fn func(f operationalField)) {
+	rt := reflect.TypeOf(OperationalConfig{})
+	var rv reflect.Value
+	if c != nil {
+		rv = reflect.ValueOf(c).Elem()
+	}
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		subsystem := jsonFieldName(sf)
+		if subsystem == "" || sf.Type.Kind() != reflect.Pointer || sf.Type.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		var sub reflect.Value
+		recv := reflect.Zero(sf.Type)
+		if rv.IsValid() && !rv.Field(i).IsNil() {
+			recv = rv.Field(i)
+			sub = recv.Elem()
+		}
+		st := sf.Type.Elem()
+		for j := 0; j < st.NumField(); j++ {
+			ff := st.Field(j)
+			name := jsonFieldName(ff)
+			if name == "" {
+				continue
+			}
+			f := operationalField{Subsystem: subsystem, Name: name, GoName: ff.Name, Type: ff.Type, Owner: st, Recv: recv}
+			if sub.IsValid() {
+				f.Value = sub.Field(j)
+			}
+			fn(f)
+		}
+	}
+}
+
+// jsonFieldName returns the JSON name from a struct field's tag, or "" if the
+// field is not serialized.
+func jsonFieldName(sf reflect.StructField) string {
+	tag := sf.Tag.Get("json")
+	if tag == "-" || !sf.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return sf.Name
+	}
+	return name
+}
+
+// ValidateOperationalConfig checks every duration string and numeric field in c
+// and returns one ConfigError per malformed value. A nil or empty config is valid.
+//
+// LoadOperationalConfig stays tolerant (bad values silently use defaults);
+// callers that want to surface typos should run this on the loaded config.
+func ValidateOperationalConfig(c *OperationalConfig) []ConfigError {
+	var errs []ConfigError
+	walkOperationalFields(c, func(f operationalField) {
+		if f.Value.IsValid() {
+			errs = append(errs, validateOperationalValue(f.Path(), f.Value)...)
+		}
+	})
+	return errs
+}
+
+// validateOperationalValue validates a single field value at path. Maps of
+// nested thresholds (e.g. session.per_role) are validated entry by entry.
+func validateOperationalValue(path string, v reflect.Value) []ConfigError {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if s == "" {
+			return nil
+		}
+		if isDisabledDuration(s) {
+			if operationalDisableable[path] {
+				return nil
+			}
+			return []ConfigError{{Path: path, Value: s, Message: "cannot be disabled; using the default"}}
+		}
+		d, err := ParseDuration(s)
+		if err != nil {
+			return []ConfigError{{Path: path, Value: s, Message: "not a valid duration (e.g. \"30s\", \"5m\", \"1h\", \"3d\"); using the default"}}
+		}
+		if d < 0 {
+			return []ConfigError{{Path: path, Value: s, Message: "duration must not be negative; using the default"}}
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		e := v.Elem()
+		switch e.Kind() {
+		case reflect.Int:
+			n := int(e.Int())
+			lo, hi := intBounds(path)
+			switch {
+			case n < 0 && lo == 0:
+				return []ConfigError{{Path: path, Value: fmt.Sprint(n), Message: "must not be negative; clamped to 0"}}
+			case n < lo || n > hi:
+				return []ConfigError{{Path: path, Value: fmt.Sprint(n), Message: fmt.Sprintf("must be between %d and %d; clamped to %d", lo, hi, min(max(n, lo), hi))}}
+			}
+		case reflect.Float64:
+			if e.Float() < 0 {
+				return []ConfigError{{Path: path, Value: fmt.Sprint(e.Float()), Message: "must not be negative"}}
+			}
+		case reflect.Struct:
+			var errs []ConfigError
+			for i := 0; i < e.NumField(); i++ {
+				if name := jsonFieldName(e.Type().Field(i)); name != "" {
+					errs = append(errs, validateOperationalValue(path+"."+name, e.Field(i))...)
+				}
+			}
+			return errs
+		}
+	case reflect.Slice:
+		if stages, ok := v.Interface().([]EscalationStage); ok {
+			return validateEscalationStages(path, stages)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		var errs []ConfigError
+		for _, k := range keys {
+			errs = append(errs, validateOperationalValue(path+"."+k.String(), v.MapIndex(k))...)
+		}
+		return errs
+	}
+	return nil
+}
+
+// validateEscalationStages reports stages that NormalizeEscalationStages
+// would drop, addressed as path[i].
+func validateEscalationStages(path string, stages []EscalationStage) []ConfigError {
+	var errs []ConfigError
+	for i, st := range stages {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if st.Count < 1 {
+			errs = append(errs, ConfigError{Path: p + ".count", Value: fmt.Sprint(st.Count), Message: "must be at least 1"})
+		}
+		if !IsEscalationAction(strings.ToLower(strings.TrimSpace(st.Action))) {
+			errs = append(errs, ConfigError{Path: p + ".action", Value: st.Action, Message: "must be one of warn, error, page, halt"})
+		}
+	}
+	return errs
+}
+
+// LoadOperationalConfigStrict loads operational config like LoadOperationalConfig
+// but reports a settings file that cannot be read or parsed, and any invalid
+// values found by ValidateOperationalConfig. The returned config is always
+// usable (never nil) so callers may choose to warn and continue.
+func LoadOperationalConfigStrict(townRoot string) (*OperationalConfig, []ConfigError, error) {
+	ts, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	if err != nil {
+		return &OperationalConfig{}, nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	if ts == nil || ts.Operational == nil {
+		return &OperationalConfig{}, nil, nil
+	}
+	return ts.Operational, ValidateOperationalConfig(ts.Operational), nil
+}
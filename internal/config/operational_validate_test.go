@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateOperationalConfig_Empty(t *testing.T) {
+	t.Parallel()
+
+	if errs := ValidateOperationalConfig(nil); len(errs) != 0 {
+		t.Errorf("nil config: got %v, want no errors", errs)
+	}
+	if errs := ValidateOperationalConfig(&OperationalConfig{}); len(errs) != 0 {
+		t.Errorf("empty config: got %v, want no errors", errs)
+	}
+}
+
+func TestValidateOperationalConfig_Valid(t *testing.T) {
+	t.Parallel()
+
+	pool := 8
+	op := &OperationalConfig{
+		Session: &SessionThresholds{GUPPViolationTimeout: "45m"},
+		Daemon:  &DaemonThresholds{MaxDogPoolSize: &pool},
+	}
+	if errs := ValidateOperationalConfig(op); len(errs) != 0 {
+		t.Errorf("valid config: got %v, want no errors", errs)
+	}
+}
+
+func TestValidateOperationalConfig_Invalid(t *testing.T) {
+	t.Parallel()
+
+	negative := -1
+	negativeF := -0.5
+	op := &OperationalConfig{
+		Session: &SessionThresholds{GUPPViolationTimeout: "not-a-duration"},
+		Nudge:   &NudgeThresholds{NormalTTL: "-5m"},
+		Daemon: &DaemonThresholds{
+			MaxDogPoolSize:       &negative,
+			PressureCPUThreshold: &negativeF,
+		},
+	}
+
+	errs := ValidateOperationalConfig(op)
+	got := make(map[string]string)
+	for _, e := range errs {
+		got[e.Path] = e.Value
+	}
+	want := map[string]string{
+		"session.gupp_violation_timeout": "not-a-duration",
+		"nudge.normal_ttl":               "-5m",
+		"daemon.max_dog_pool_size":       "-1",
+		"daemon.pressure_cpu_threshold":  "-0.5",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d errors %v, want %d", len(errs), errs, len(want))
+	}
+	for path, val := range want {
+		if got[path] != val {
+			t.Errorf("%s: got value %q, want %q", path, got[path], val)
+		}
+	}
+}
+
+func TestValidateOperationalConfig_OutOfBounds(t *testing.T) {
+	t.Parallel()
+
+	huge := 10000
+	op := &OperationalConfig{Daemon: &DaemonThresholds{MaxDogPoolSize: &huge}}
+	errs := ValidateOperationalConfig(op)
+	if len(errs) != 1 || errs[0].Path != "daemon.max_dog_pool_size" {
+		t.Fatalf("got %v, want one error for daemon.max_dog_pool_size", errs)
+	}
+	want := fmt.Sprintf("must be between %d and %d; clamped to %d", MinDogPoolSize, MaxDogPoolSizeLimit, MaxDogPoolSizeLimit)
+	if errs[0].Message != want {
+		t.Errorf("Message = %q, want %q", errs[0].Message, want)
+	}
+
+	negative := -2
+	op = &OperationalConfig{Session: &SessionThresholds{StartupNudgeMaxRetries: &negative}}
+	errs = ValidateOperationalConfig(op)
+	if len(errs) != 1 || errs[0].Message != "must not be negative; clamped to 0" {
+		t.Errorf("unbounded negative count: got %v, want one error clamped to 0", errs)
+	}
+}
+
+func TestLoadOperationalConfigStrict(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"operational": {"session": {"claude_start_timeout": "sixty"}}}`
+	if err := os.WriteFile(filepath.Join(dir, "settings", "config.json"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	op, errs, err := LoadOperationalConfigStrict(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 1 || errs[0].Path != "session.claude_start_timeout" {
+		t.Fatalf("got %v, want one error for session.claude_start_timeout", errs)
+	}
+	// Tolerant accessors still fall back to the default.
+	if got := op.GetSessionConfig().ClaudeStartTimeoutD(); got != DefaultClaudeStartTimeout {
+		t.Errorf("ClaudeStartTimeout: got %v, want %v", got, DefaultClaudeStartTimeout)
+	}
+}
+
+func TestLoadOperationalConfigStrict_MalformedJSON(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "settings", "config.json"), []byte(`{`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	op, _, err := LoadOperationalConfigStrict(dir)
+	if err == nil {
+		t.Error("expected error for malformed settings JSON")
+	}
+	if op == nil {
+		t.Error("config must never be nil")
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -115,11 +117,16 @@ type TownSettings struct {
 	// All values are optional — omitted values use compiled-in defaults.
 	Operational *OperationalConfig `json:"operational,omitempty"`
 
+	// Profiles defines named operational overlays (e.g. "dev", "prod") that
+	// are merged over Operational when selected via GT_PROFILE.
+	// Example: {"dev": {"session": {"claude_start_timeout": "5m"}}}
+	Profiles map[string]*OperationalConfig `json:"profiles,omitempty"`
+
 	// DisabledPatrols lists patrol names to disable at the town level.
 	// This provides a simple way to turn off individual daemon patrol dogs
 	// without editing mayor/daemon.json. Patrol names match the keys used
 	// in daemon.json patrols section (e.g., "deacon", "witness", "refinery",
-	// "doctor_dog", "compactor_dog", "checkpoint_dog", "wisp_reaper",
+	// "doctor_dog", "compactor_dog", "checkpoint_dog", "wisp_reaper", "molecule_reaper",
 	// "dolt_remotes", "dolt_backup", "jsonl_git_backup", "scheduled_maintenance",
 	// "main_branch_test", "handler").
 	// Example: ["doctor_dog", "compactor_dog"]
@@ -247,7 +254,7 @@ type OperationalConfig struct {
 
 // SessionThresholds configures session management timeouts.
 type SessionThresholds struct {
-	// ClaudeStartTimeout is how long to wait for Claude to start (default "60s").
+	// ClaudeStartTimeout is how long to wait for Claude to start (default "180s").
 	ClaudeStartTimeout string `json:"claude_start_timeout,omitempty"`
 
 	// ShellReadyTimeout is how long to wait for shell prompt after command (default "5s").
@@ -275,6 +282,27 @@ type SessionThresholds struct {
 
 	// StartupNudgeMaxRetries is max retries for startup nudge (default 3).
 	StartupNudgeMaxRetries *int `json:"startup_nudge_max_retries,omitempty"`
+
+	// RespawnHookDelay is how long the tmux auto-respawn hook waits after a pane
+	// dies before respawning it, giving the daemon a chance to restart the
+	// session first (default "3s"). Raise it on slow machines.
+	RespawnHookDelay string `json:"respawn_hook_delay,omitempty"`
+
+	// RespawnBaseBackoff is the delay before the second respawn of a crashing
+	// session; later respawns double it (default "2s"). The first is immediate.
+	RespawnBaseBackoff string `json:"respawn_base_backoff,omitempty"`
+
+	// RespawnBackoffMax is the cap for respawn backoff (default "1m").
+	RespawnBackoffMax string `json:"respawn_backoff_max,omitempty"`
+
+	// RespawnStablePeriod is how long a respawned pane must stay alive before
+	// its backoff resets (default "5m").
+	RespawnStablePeriod string `json:"respawn_stable_period,omitempty"`
+
+	// PerRole overrides any of the above for a specific role, keyed by role
+	// name (e.g. "deacon", "polecat"). Unset fields fall through to the base
+	// session thresholds. Resolve with OperationalConfig.SessionConfigForRole.
+	PerRole map[string]*SessionThresholds `json:"per_role,omitempty"`
 }
 
 // NudgeThresholds configures nudge queue and delivery timeouts.
@@ -297,6 +325,10 @@ type NudgeThresholds struct {
 	// MaxQueueDepth is max pending nudges per session (default 50).
 	MaxQueueDepth *int `json:"max_queue_depth,omitempty"`
 
+	// UrgentQueueHeadroom is how many urgent nudges may be queued beyond
+	// MaxQueueDepth so critical signals aren't dropped (default 5).
+	UrgentQueueHeadroom *int `json:"urgent_queue_headroom,omitempty"`
+
 	// StaleClaimThreshold is how long a .claimed file must be untouched
 	// before treated as orphan (default "5m").
 	StaleClaimThreshold string `json:"stale_claim_threshold,omitempty"`
@@ -310,15 +342,21 @@ type DaemonThresholds struct {
 	// MassDeathThreshold is session deaths within window to trigger alert (default 3).
 	MassDeathThreshold *int `json:"mass_death_threshold,omitempty"`
 
+	// MassDeathRespawnPause is how long auto-respawn hooks stay disabled on
+	// sessions caught in a mass death, to avoid a restart storm (default "5m").
+	MassDeathRespawnPause string `json:"mass_death_respawn_pause,omitempty"`
+
 	// DogIdleSessionTimeout is how long a dog can be idle with tmux before kill (default "1h").
+	// Set to "off" to never kill idle dog sessions.
 	DogIdleSessionTimeout string `json:"dog_idle_session_timeout,omitempty"`
 
 	// DogIdleRemoveTimeout is how long a dog can be idle before removal (default "4h").
+	// Set to "off" to never remove idle dogs.
 	DogIdleRemoveTimeout string `json:"dog_idle_remove_timeout,omitempty"`
 
 	// PolecatIdleSessionTimeout is how long a polecat can be idle before its session
 	// is killed to prevent API slot burn (default "15m"). Polecats are ephemeral workers;
-	// unlike dogs, they should not persist when idle.
+	// unlike dogs, they should not persist when idle. Set to "off" to disable reaping.
 	PolecatIdleSessionTimeout string `json:"polecat_idle_session_timeout,omitempty"`
 
 	// PolecatSelfTerminate controls whether polecats kill their own session after
@@ -330,19 +368,26 @@ type DaemonThresholds struct {
 	PolecatSelfTerminate *bool `json:"polecat_self_terminate,omitempty"`
 
 	// StaleWorkingTimeout is how long a dog in state=working with no activity
-	// before considered stuck (default "2h").
+	// before considered stuck (default "2h"). Set to "off" to disable the check.
 	StaleWorkingTimeout string `json:"stale_working_timeout,omitempty"`
 
 	// MaxDogPoolSize is target dog pool size (default 4).
 	MaxDogPoolSize *int `json:"max_dog_pool_size,omitempty"`
 
 	// MaxLifecycleMessageAge is max age of lifecycle mail before discard (default "6h").
+	// Set to "off" to process lifecycle mail regardless of age.
 	MaxLifecycleMessageAge string `json:"max_lifecycle_message_age,omitempty"`
 
 	// SyncFailureEscalationThreshold is consecutive git pull failures before
 	// logging escalates from WARN to ERROR (default 3).
 	SyncFailureEscalationThreshold *int `json:"sync_failure_escalation_threshold,omitempty"`
 
+	// SyncFailureStages grades the response to consecutive git pull failures,
+	// e.g. [{"count": 3, "action": "warn"}, {"count": 6, "action": "page"},
+	// {"count": 10, "action": "halt"}]. When unset, a single "error" stage at
+	// SyncFailureEscalationThreshold is used.
+	SyncFailureStages []EscalationStage `json:"sync_failure_stages,omitempty"`
+
 	// DoctorMolCooldown is min interval between mol-dog-doctor molecules (default "5m").
 	DoctorMolCooldown string `json:"doctor_mol_cooldown,omitempty"`
 
@@ -352,6 +397,10 @@ type DaemonThresholds struct {
 	// BootSpawnCooldown prevents Boot from spawning on every daemon heartbeat (default "2m").
 	BootSpawnCooldown string `json:"boot_spawn_cooldown,omitempty"`
 
+	// BootSpawnCooldownByRole overrides boot_spawn_cooldown for individual roles,
+	// e.g. {"deacon": "10m"}. Unset roles use boot_spawn_cooldown.
+	BootSpawnCooldownByRole map[string]string `json:"boot_spawn_cooldown_by_role,omitempty"`
+
 	// BootIdleSuppression is how long to suppress Boot spawns after Boot reported "nothing"
 	// (deacon was healthy). Prevents burning API calls when deacon is running fine (default "15m").
 	BootIdleSuppression string `json:"boot_idle_suppression,omitempty"`
@@ -359,6 +408,14 @@ type DaemonThresholds struct {
 	// DeaconGracePeriod is time to wait after starting Deacon before checking heartbeat (default "5m").
 	DeaconGracePeriod string `json:"deacon_grace_period,omitempty"`
 
+	// RespawnMaxAttempts is how many times the tmux auto-respawn hook may restart
+	// a session within RespawnWindow before leaving the pane dead (default 5).
+	// Breaks crash loops where the agent exits immediately on startup. 0 = unlimited.
+	RespawnMaxAttempts *int `json:"respawn_max_attempts,omitempty"`
+
+	// RespawnWindow is the period over which RespawnMaxAttempts is counted (default "10m").
+	RespawnWindow string `json:"respawn_window,omitempty"`
+
 	// PressureCPUThreshold is the per-core load average above which new
 	// non-infrastructure spawns are deferred. Disabled by default (0).
 	// Recommended starting value: 3.0 (only trips under severe load).
@@ -374,6 +431,21 @@ type DaemonThresholds struct {
 	PressureMaxSessions *int `json:"pressure_max_sessions,omitempty"`
 }
 
+// Escalation stage actions, from least to most severe.
+const (
+	EscalationActionWarn  = "warn"  // log a warning
+	EscalationActionError = "error" // log an error
+	EscalationActionPage  = "page"  // log an error and escalate to the mayor
+	EscalationActionHalt  = "halt"  // log an error and stop retrying
+)
+
+// EscalationStage is one graded response to repeated failures: once the
+// consecutive failure count reaches Count, Action applies.
+type EscalationStage struct {
+	Count  int    `json:"count"`
+	Action string `json:"action"`
+}
+
 // DeaconThresholds configures deacon health-check and dispatch thresholds.
 type DeaconThresholds struct {
 	// PingTimeout is how long to wait for HEALTH_CHECK nudge response (default "30s").
@@ -397,11 +469,19 @@ type DeaconThresholds struct {
 	// RedispatchCooldown is min time between re-dispatches of same bead (default "5m").
 	RedispatchCooldown string `json:"redispatch_cooldown,omitempty"`
 
+	// RedispatchCooldownJitter adds a random delay up to this duration to each
+	// redispatch cooldown so mass recoveries don't redispatch in lockstep (default "0s").
+	RedispatchCooldownJitter string `json:"redispatch_cooldown_jitter,omitempty"`
+
 	// MaxFeedsPerCycle is max stranded convoys to feed per invocation (default 3).
 	MaxFeedsPerCycle *int `json:"max_feeds_per_cycle,omitempty"`
 
 	// FeedCooldown is min time between feeding same convoy (default "10m").
 	FeedCooldown string `json:"feed_cooldown,omitempty"`
+
+	// FeedCooldownJitter adds a random delay up to this duration to each
+	// feed cooldown (default "0s").
+	FeedCooldownJitter string `json:"feed_cooldown_jitter,omitempty"`
 }
 
 // PolecatThresholds configures polecat session and retry thresholds.
@@ -421,8 +501,16 @@ type PolecatThresholds struct {
 	// PendingMaxAge is max age for .pending reservation marker (default "5m").
 	PendingMaxAge string `json:"pending_max_age,omitempty"`
 
-	// NamepoolSize is number of name slots in pool (default 50).
+	// NamepoolSize caps how many polecat names can be in use at once. Unset
+	// means unlimited; the default of 50 applies only to the accessor.
 	NamepoolSize *int `json:"namepool_size,omitempty"`
+
+	// NamepoolGrowIncrement is how many slots to add when the pool is
+	// exhausted (default 0: don't grow; allocation fails instead).
+	NamepoolGrowIncrement *int `json:"namepool_grow_increment,omitempty"`
+
+	// NamepoolMaxSize is the hard cap on auto-growth (default 200).
+	NamepoolMaxSize *int `json:"namepool_max_size,omitempty"`
 }
 
 // DoltThresholds configures Dolt server operation thresholds.
@@ -438,6 +526,11 @@ type DoltThresholds struct {
 
 	// SlowQueryThreshold is duration above which a query is flagged slow (default "1s").
 	SlowQueryThreshold string `json:"slow_query_threshold,omitempty"`
+
+	// Port is the Dolt SQL server port used by daemon patrols when the daemon
+	// does not manage the server itself (default unset: use the town's Dolt
+	// config, then 3307).
+	Port *int `json:"port,omitempty"`
 }
 
 // MailThresholds configures mail system thresholds.
@@ -470,6 +563,10 @@ type WebThresholds struct {
 
 	// MaxBodyLen is max body length for mail API (default 100000).
 	MaxBodyLen *int `json:"max_body_len,omitempty"`
+
+	// MaxCommandsPerMinute is max /api/run commands per client per minute (default 60).
+	// Unset uses the default; set to 0 to disable rate limiting.
+	MaxCommandsPerMinute *int `json:"max_commands_per_minute,omitempty"`
 }
 
 // WitnessThresholds configures witness patrol detection thresholds.
@@ -523,12 +620,57 @@ type PolecatConfig struct {
 	TargetCleanPolicy string `json:"target_clean_policy,omitempty"`
 }
 
-// ParseDurationOrDefault parses a Go duration string, returning fallback on error or empty input.
-func ParseDurationOrDefault(s string, fallback time.Duration) time.Duration {
-	if s == "" {
-		return fallback
+// DurationDisabled is returned by ParseDurationOrDefault (and every duration
+// accessor built on it) when the config value is "off" or "disabled".
+// Callers that support disabling a timeout must check for it explicitly;
+// it is distinct from an explicit "0s", which means zero.
+const DurationDisabled time.Duration = -1
+
+// isDisabledDuration reports whether s is one of the explicit "disable" keywords.
+func isDisabledDuration(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off", "disabled":
+		return true
+	}
+	return false
+}
+
+// dayWeekUnit matches a number followed by a "d" (day) or "w" (week) unit.
+var dayWeekUnit = regexp.MustCompile(`(\d+\.?\d*|\.\d+)([dw])`)
+
+// ParseDuration parses a duration exactly as operational config does: a Go
+// duration string extended with "d" (24h) and "w" (7d) units, which compose
+// with the standard ones (e.g. "1d12h", "2w"). The keywords "off" and
+// "disabled" yield DurationDisabled. Empty or malformed input returns an error.
+func ParseDuration(s string) (time.Duration, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if isDisabledDuration(s) {
+		return DurationDisabled, nil
+	}
+	expanded := dayWeekUnit.ReplaceAllStringFunc(s, func(m string) string {
+		parts := dayWeekUnit.FindStringSubmatch(m)
+		n, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return m // leave as-is so time.ParseDuration reports it
+		}
+		hours := n * 24
+		if parts[2] == "w" {
+			hours *= 7
+		}
+		return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+	})
+	d, err := time.ParseDuration(expanded)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (e.g. \"30s\", \"5m\", \"1h\", \"3d\", \"off\")", s)
 	}
-	d, err := time.ParseDuration(s)
+	return d, nil
+}
+
+// ParseDurationOrDefault is ParseDuration returning fallback on error or empty input.
+func ParseDurationOrDefault(s string, fallback time.Duration) time.Duration {
+	d, err := ParseDuration(s)
 	if err != nil {
 		return fallback
 	}
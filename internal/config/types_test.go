@@ -31,6 +31,17 @@ func TestParseDurationOrDefault(t *testing.T) {
 		{"bare number returns fallback", "15", 3 * time.Second, 3 * time.Second},
 		{"whitespace returns fallback", "  ", 1 * time.Second, 1 * time.Second},
 		{"zero fallback with empty", "", 0, 0},
+		{"off disables", "off", 10 * time.Second, DurationDisabled},
+		{"disabled disables", "disabled", 10 * time.Second, DurationDisabled},
+		{"disable keyword is case-insensitive", " OFF ", 10 * time.Second, DurationDisabled},
+		{"days", "3d", 0, 72 * time.Hour},
+		{"weeks", "2w", 0, 14 * 24 * time.Hour},
+		{"fractional days", "1.5d", 0, 36 * time.Hour},
+		{"days compose with hours", "1d12h", 0, 36 * time.Hour},
+		{"weeks compose with days and minutes", "1w1d30m", 0, 8*24*time.Hour + 30*time.Minute},
+		{"bare day unit returns fallback", "d", 5 * time.Second, 5 * time.Second},
+		{"unknown unit after days returns fallback", "1d2x", 5 * time.Second, 5 * time.Second},
+		{"spelled-out days returns fallback", "3days", 5 * time.Second, 5 * time.Second},
 	}
 
 	for _, tt := range tests {
@@ -45,6 +56,43 @@ func TestParseDurationOrDefault(t *testing.T) {
 	}
 }
 
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"1d12h", 36 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"off", DurationDisabled, false},
+		{"", 0, true},
+		{"  ", 0, true},
+		{"15", 0, true},
+		{"3days", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuration_ErrorNamesInput(t *testing.T) {
+	t.Parallel()
+	_, err := ParseDuration("5 minutes")
+	if err == nil || !strings.Contains(err.Error(), `"5 minutes"`) {
+		t.Errorf("ParseDuration error = %v, want it to quote the input", err)
+	}
+}
+
 // --- Default*Config functions ---
 
 func TestDefaultWebTimeoutsConfig(t *testing.T) {
@@ -370,18 +370,9 @@ func (m *Manager) syncRemotesFromRig(crewPath string) error {
 			continue
 		}
 
-		// Check if remote exists in crew clone
-		existingURL, existErr := crewGit.RemoteURL(remote)
-		if existErr != nil {
-			// Remote doesn't exist — add it
-			if _, addErr := crewGit.AddRemote(remote, url); addErr != nil {
-				style.PrintWarning("could not add remote %s: %v", remote, addErr)
-			}
-		} else if existingURL != url {
-			// Remote exists but URL differs — update it
-			if _, setErr := crewGit.SetRemoteURL(remote, url); setErr != nil {
-				style.PrintWarning("could not update remote %s: %v", remote, setErr)
-			}
+		// Add the remote to the crew clone, or update it if the URL differs
+		if addErr := crewGit.AddRemote(remote, url); addErr != nil {
+			style.PrintWarning("could not sync remote %s: %v", remote, addErr)
 		}
 
 		// Sync push URL for read-only upstream forks.
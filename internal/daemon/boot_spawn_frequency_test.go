@@ -139,7 +139,7 @@ func TestEnsureBootRunning_SuppressesWhenDeaconHealthy(t *testing.T) {
 		tmux:   tmux.NewTmux(),
 	}
 
-	// Even though cooldown has expired (bootLastSpawned is zero),
+	// Even though no Boot spawn is in cooldown,
 	// idle suppression should prevent spawning.
 	d.ensureBootRunning()
 
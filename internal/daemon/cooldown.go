@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+)
+
+// Cooldown registry keys for daemon actions.
+const (
+	cooldownDoctorMol = "doctor-mol"
+	cooldownBootSpawn = "boot-spawn:" // + role
+)
+
+// CooldownRegistry records when rate-limited daemon actions last fired, keyed
+// by action, so each cooldown is tracked in one place instead of in its own
+// last-fired field. The zero value is ready to use and reads the wall clock.
+// Safe for concurrent use.
+type CooldownRegistry struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	last map[string]time.Time
+}
+
+// NewCooldownRegistry returns a registry that reads time from now (nil: time.Now).
+func NewCooldownRegistry(now func() time.Time) *CooldownRegistry {
+	return &CooldownRegistry{now: now}
+}
+
+func (r *CooldownRegistry) clock() time.Time {
+	if r.now == nil {
+		return time.Now()
+	}
+	return r.now()
+}
+
+// Allow reports whether key is out of cooldown and, if so, records that it
+// fires now. A key that has never fired, or a cooldown <= 0, is always
+// allowed; otherwise at least cooldown must have elapsed since the last fire.
+func (r *CooldownRegistry) Allow(key string, cooldown time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock()
+	if r.remainingLocked(key, cooldown, now) > 0 {
+		return false
+	}
+	r.recordLocked(key, now)
+	return true
+}
+
+// Remaining returns how long key stays in cooldown, or 0 if it may fire.
+// Unlike Allow it records nothing, for actions that only count as fired
+// once they succeed (see Record).
+func (r *CooldownRegistry) Remaining(key string, cooldown time.Duration) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.remainingLocked(key, cooldown, r.clock())
+}
+
+// Record marks key as having fired now.
+func (r *CooldownRegistry) Record(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recordLocked(key, r.clock())
+}
+
+func (r *CooldownRegistry) remainingLocked(key string, cooldown time.Duration, now time.Time) time.Duration {
+	last, ok := r.last[key]
+	if !ok || cooldown <= 0 {
+		return 0
+	}
+	if remaining := cooldown - now.Sub(last); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (r *CooldownRegistry) recordLocked(key string, now time.Time) {
+	if r.last == nil {
+		r.last = make(map[string]time.Time)
+	}
+	r.last[key] = now
+}
@@ -0,0 +1,126 @@
+package daemon
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable clock for CooldownRegistry tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestCooldownRegistry_AllowBoundaries(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	r := NewCooldownRegistry(clock.now)
+	const cooldown = 5 * time.Minute
+
+	if !r.Allow("doctor-mol", cooldown) {
+		t.Fatal("first Allow = false, want true for a key that never fired")
+	}
+	if r.Allow("doctor-mol", cooldown) {
+		t.Error("immediate second Allow = true, want false")
+	}
+
+	clock.advance(cooldown - time.Nanosecond)
+	if r.Allow("doctor-mol", cooldown) {
+		t.Error("Allow 1ns before the cooldown ends = true, want false")
+	}
+	// A denied Allow does not restart the cooldown.
+	clock.advance(time.Nanosecond)
+	if !r.Allow("doctor-mol", cooldown) {
+		t.Error("Allow exactly at the cooldown = false, want true")
+	}
+	// An allowed one does.
+	clock.advance(cooldown / 2)
+	if r.Allow("doctor-mol", cooldown) {
+		t.Error("Allow half a cooldown after the last fire = true, want false")
+	}
+}
+
+func TestCooldownRegistry_KeysAreIndependent(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	r := NewCooldownRegistry(clock.now)
+
+	if !r.Allow("a", time.Hour) || !r.Allow("b", time.Hour) {
+		t.Fatal("first Allow for distinct keys should both succeed")
+	}
+	if r.Allow("a", time.Hour) {
+		t.Error("key a allowed again within its cooldown")
+	}
+	// The cooldown is supplied per call, so a shorter one applies at once.
+	clock.advance(time.Minute)
+	if !r.Allow("a", time.Minute) {
+		t.Error("Allow with a cooldown that has elapsed = false, want true")
+	}
+}
+
+func TestCooldownRegistry_ZeroCooldownAlwaysAllows(t *testing.T) {
+	r := NewCooldownRegistry(func() time.Time { return time.Unix(0, 0) })
+	for i := 0; i < 3; i++ {
+		if !r.Allow("k", 0) {
+			t.Fatalf("Allow #%d with zero cooldown = false", i)
+		}
+	}
+	if !r.Allow("k", -time.Second) {
+		t.Error("Allow with negative cooldown = false")
+	}
+}
+
+func TestCooldownRegistry_RemainingAndRecord(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	r := NewCooldownRegistry(clock.now)
+	const cooldown = 10 * time.Minute
+
+	if got := r.Remaining("boot", cooldown); got != 0 {
+		t.Errorf("Remaining before any fire = %v, want 0", got)
+	}
+	// Remaining only reads; it never starts a cooldown.
+	if got := r.Remaining("boot", cooldown); got != 0 {
+		t.Errorf("Remaining after a read = %v, want 0", got)
+	}
+
+	r.Record("boot")
+	clock.advance(3 * time.Minute)
+	if got := r.Remaining("boot", cooldown); got != 7*time.Minute {
+		t.Errorf("Remaining = %v, want 7m", got)
+	}
+	clock.advance(7 * time.Minute)
+	if got := r.Remaining("boot", cooldown); got != 0 {
+		t.Errorf("Remaining at the boundary = %v, want 0", got)
+	}
+}
+
+func TestCooldownRegistry_ZeroValue(t *testing.T) {
+	var r CooldownRegistry
+	if !r.Allow("k", time.Hour) {
+		t.Fatal("zero-value registry: first Allow = false")
+	}
+	if r.Allow("k", time.Hour) {
+		t.Error("zero-value registry: second Allow within cooldown = true")
+	}
+}
+
+func TestCooldownRegistry_ConcurrentAllowFiresOnce(t *testing.T) {
+	r := NewCooldownRegistry(func() time.Time { return time.Unix(1000, 0) })
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r.Allow("k", time.Minute) {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != 1 {
+		t.Errorf("concurrent Allow fired %d times, want 1", allowed)
+	}
+}
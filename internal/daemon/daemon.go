@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -71,24 +72,51 @@ type Daemon struct {
 	deathsMu     sync.Mutex
 	recentDeaths []sessionDeath
 
+	// respawnPause holds the sessions whose auto-respawn hooks were cleared
+	// after a mass death (see pauseAutoRespawn).
+	respawnPause respawnPause
+
+	// respawnHooks pauses and restores auto-respawn hooks. Nil uses d.tmux.
+	// Replaced in tests.
+	respawnHooks autoRespawnHooks
+
 	// Deacon startup tracking: prevents race condition where newly started
 	// sessions are immediately killed by the heartbeat check.
 	// See: https://github.com/steveyegge/gastown/issues/567
 	// Note: Only accessed from heartbeat loop goroutine - no sync needed.
 	deaconLastStarted time.Time
 
+	// heartbeatStaleness grades monitored heartbeats and reports threshold
+	// crossings. Only accessed from heartbeat loop goroutine - no sync needed.
+	heartbeatStaleness *deacon.StalenessTracker
+
+	// hungSessions tracks pane output across heartbeats to detect hung
+	// sessions. Only accessed from heartbeat loop goroutine - no sync needed.
+	hungSessions *session.HungSessionDetector
+
+	// guppReported maps each polecat agent whose GUPP violation has been
+	// recorded to its hook bead, so gupp_violation is emitted once per
+	// violation instead of every heartbeat.
+	// Only accessed from heartbeat loop goroutine - no sync needed.
+	guppReported map[string]string
+
 	// syncFailures tracks consecutive git pull failures per workdir.
 	// Used to escalate logging from WARN to ERROR after repeated failures.
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	syncFailures map[string]int
 
+	// syncHalted marks workdirs whose sync reached a "halt" escalation stage.
+	// Halted workdirs are skipped until the daemon restarts.
+	// Only accessed from heartbeat loop goroutine - no sync needed.
+	syncHalted map[string]bool
+
 	// PATCH-006: Resolved binary paths to avoid PATH issues in subprocesses.
 	gtPath string
 	bdPath string
 
-	// Boot spawn cooldown: prevents Boot from spawning on every heartbeat tick.
-	// Only accessed from heartbeat loop goroutine - no sync needed.
-	bootLastSpawned time.Time
+	// cooldowns rate-limits daemon actions such as Boot spawns and
+	// mol-dog-doctor pours (see the cooldown* keys).
+	cooldowns CooldownRegistry
 
 	// Restart tracking with exponential backoff to prevent crash loops
 	restartTracker *RestartTracker
@@ -102,11 +130,6 @@ type Daemon struct {
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	jsonlPushFailures int
 
-	// lastDoctorMolTime tracks when the last mol-dog-doctor molecule was poured.
-	// Option B throttling: only pour when anomaly detected AND cooldown elapsed.
-	// Only accessed from heartbeat loop goroutine - no sync needed.
-	lastDoctorMolTime time.Time
-
 	// lastMaintenanceRun tracks when scheduled maintenance last ran.
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	lastMaintenanceRun time.Time
@@ -133,6 +156,29 @@ type Daemon struct {
 	// legacySocketCleanupOnce ensures upgrade cleanup only runs once per daemon
 	// lifetime, before any patrol agent can be started on the current socket.
 	legacySocketCleanupOnce sync.Once
+
+	// lastWispReaper is the result of the most recent inline wisp_reaper cycle.
+	// Guarded by wispReaperMu; read via LastWispReaperResult.
+	wispReaperMu   sync.Mutex
+	lastWispReaper *WispReaperResult
+
+	// patrolRuns records the last run of each ticker-driven patrol, keyed by
+	// patrol name. Guarded by patrolRunsMu; read via DaemonStatus.
+	patrolRunsMu sync.Mutex
+	patrolRuns   map[string]patrolRun
+
+	// metricsServer serves /metrics when metrics.enabled is set in daemon.json.
+	// Nil when the endpoint is disabled.
+	metricsServer *http.Server
+
+	// webhook forwards critical feed events to webhook.url in daemon.json.
+	// Nil when no webhook is configured.
+	webhook *webhookSink
+
+	// doltPool holds the Dolt connections shared by the reaper patrols.
+	// Created on first use by doltPoolFor; closed on shutdown.
+	doltPoolMu sync.Mutex
+	doltPool   *DoltPool
 }
 
 // sessionDeath records a detected session death for mass death analysis.
@@ -141,18 +187,6 @@ type sessionDeath struct {
 	timestamp   time.Time
 }
 
-// Mass death detection parameters — these are fallback defaults.
-// Prefer config.OperationalConfig.GetDaemonConfig() accessors when
-// a TownSettings is available (loaded via d.loadOperationalConfig()).
-const (
-	massDeathWindow    = 30 * time.Second // Time window to detect mass death
-	massDeathThreshold = 3                // Number of deaths to trigger alert
-
-	// doctorMolCooldown is the minimum interval between mol-dog-doctor molecules.
-	// Configurable via operational.daemon.doctor_mol_cooldown.
-	doctorMolCooldown = 5 * time.Minute
-)
-
 const beadsModulePath = "github.com/steveyegge/beads"
 
 var semverPattern = regexp.MustCompile(`v?(\d+\.\d+\.\d+)`)
@@ -249,10 +283,16 @@ func New(config *Config) (*Daemon, error) {
 	// sessionPrefixPattern) can read it without relying on GT_ROOT.
 	os.Setenv("GT_TOWN_ROOT", config.TownRoot)
 
+	// Start the town's tmux server with its town-wide options (exit-empty,
+	// window-size) so even the first session inherits them.
+	t := tmux.NewTmux()
+	if err := t.EnsureServer(); err != nil && !errors.Is(err, tmux.ErrNoTownSocket) {
+		logger.Printf("Warning: failed to start tmux server: %v", err)
+	}
+
 	// Also set GT_TOWN_ROOT in tmux global environment so run-shell subprocesses
 	// (e.g., gt cycle next/prev) can find the workspace even when CWD is $HOME.
-	// Non-fatal: tmux server may not be running yet — daemon creates sessions shortly.
-	t := tmux.NewTmux()
+	// Non-fatal: without a town socket there may be no server yet.
 	if err := t.SetGlobalEnvironment("GT_TOWN_ROOT", config.TownRoot); err != nil {
 		logger.Printf("Warning: failed to set GT_TOWN_ROOT in tmux global env: %v", err)
 	}
@@ -400,6 +440,7 @@ func New(config *Config) (*Daemon, error) {
 		otelProvider:    otelProvider,
 		metrics:         dm,
 		rigPool:         newRigWorkerPool(0, 0, logger), // defaults: 10 workers, 30s timeout
+		webhook:         newWebhookSink(webhookConfig(patrolConfig), logger),
 	}
 	return d, nil
 }
@@ -454,6 +495,11 @@ func (d *Daemon) Run() (err error) {
 		return err
 	}
 
+	// Validate configured wisp_reaper databases once, not every cycle.
+	if err := d.checkWispReaperDatabases(); err != nil {
+		return err
+	}
+
 	// Repair metadata.json for all rigs on startup.
 	// This ensures all rigs have proper Dolt server configuration.
 	if _, errs := doltserver.EnsureAllMetadata(d.config.TownRoot); len(errs) > 0 {
@@ -470,9 +516,10 @@ func (d *Daemon) Run() (err error) {
 
 	// Update state
 	state := &State{
-		Running:   true,
-		PID:       os.Getpid(),
-		StartedAt: time.Now(),
+		Running:     true,
+		PID:         os.Getpid(),
+		StartedAt:   time.Now(),
+		Operational: d.loadOperationalConfig(),
 	}
 	if err := SaveState(d.config.TownRoot, state); err != nil {
 		d.logger.Printf("Warning: failed to save state: %v", err)
@@ -542,6 +589,9 @@ func (d *Daemon) Run() (err error) {
 		})
 	}
 
+	// Start the Prometheus-style metrics endpoint (opt-in via daemon.json).
+	d.startMetricsServer()
+
 	// Start KRC pruner for automatic ephemeral data cleanup
 	krcPruner, err := NewKRCPruner(d.config.TownRoot, d.logger.Printf)
 	if err != nil {
@@ -568,126 +618,131 @@ func (d *Daemon) Run() (err error) {
 		d.logger.Printf("Dolt health check ticker started (interval %v)", interval)
 	}
 
+	// Seed patrol run times from the previous daemon so status stays
+	// accurate and the patrol tickers below keep their cadence.
+	d.loadPatrolHistory()
+
 	// Start dedicated Dolt remotes push ticker if configured.
 	// This runs at a lower frequency (default 15 min) than the heartbeat (3 min)
 	// to periodically push databases to their git remotes.
-	var doltRemotesTicker *time.Ticker
 	var doltRemotesChan <-chan time.Time
 	if d.isPatrolActive("dolt_remotes") {
 		interval := doltRemotesInterval(d.patrolConfig)
-		doltRemotesTicker = time.NewTicker(interval)
-		doltRemotesChan = doltRemotesTicker.C
-		defer doltRemotesTicker.Stop()
+		var stop func()
+		doltRemotesChan, stop = d.startPatrolTicker("dolt_remotes", interval)
+		defer stop()
 		d.logger.Printf("Dolt remotes push ticker started (interval %v)", interval)
 	}
 
 	// Start dedicated Dolt backup ticker if configured.
 	// Runs filesystem backup sync (dolt backup sync) for production databases.
-	var doltBackupTicker *time.Ticker
 	var doltBackupChan <-chan time.Time
 	if d.isPatrolActive("dolt_backup") {
 		interval := doltBackupInterval(d.patrolConfig)
-		doltBackupTicker = time.NewTicker(interval)
-		doltBackupChan = doltBackupTicker.C
-		defer doltBackupTicker.Stop()
+		var stop func()
+		doltBackupChan, stop = d.startPatrolTicker("dolt_backup", interval)
+		defer stop()
 		d.logger.Printf("Dolt backup ticker started (interval %v)", interval)
 	}
 
 	// Start JSONL git backup ticker if configured.
 	// Exports issues to JSONL, scrubs ephemeral data, pushes to git repo.
-	var jsonlGitBackupTicker *time.Ticker
 	var jsonlGitBackupChan <-chan time.Time
 	if d.isPatrolActive("jsonl_git_backup") {
 		interval := jsonlGitBackupInterval(d.patrolConfig)
-		jsonlGitBackupTicker = time.NewTicker(interval)
-		jsonlGitBackupChan = jsonlGitBackupTicker.C
-		defer jsonlGitBackupTicker.Stop()
+		var stop func()
+		jsonlGitBackupChan, stop = d.startPatrolTicker("jsonl_git_backup", interval)
+		defer stop()
 		d.logger.Printf("JSONL git backup ticker started (interval %v)", interval)
 	}
 
 	// Start wisp reaper ticker if configured.
 	// Closes stale wisps (abandoned molecule steps, old patrol data) across all databases.
-	var wispReaperTicker *time.Ticker
 	var wispReaperChan <-chan time.Time
 	if d.isPatrolActive("wisp_reaper") {
 		interval := wispReaperInterval(d.patrolConfig)
-		wispReaperTicker = time.NewTicker(interval)
-		wispReaperChan = wispReaperTicker.C
-		defer wispReaperTicker.Stop()
+		var stop func()
+		wispReaperChan, stop = d.startPatrolTicker("wisp_reaper", interval)
+		defer stop()
 		d.logger.Printf("Wisp reaper ticker started (interval %v)", interval)
 	}
 
+	// Start molecule reaper ticker if configured.
+	// Closes abandoned molecules (open, idle past max_age, no live steps).
+	var moleculeReaperChan <-chan time.Time
+	if d.isPatrolActive("molecule_reaper") {
+		interval := moleculeReaperInterval(d.patrolConfig)
+		var stop func()
+		moleculeReaperChan, stop = d.startPatrolTicker("molecule_reaper", interval)
+		defer stop()
+		d.logger.Printf("Molecule reaper ticker started (interval %v)", interval)
+	}
+
 	// Start doctor dog ticker if configured.
 	// Health monitor: TCP check, latency, DB count, gc, zombie detection, backup/disk checks.
-	var doctorDogTicker *time.Ticker
 	var doctorDogChan <-chan time.Time
 	if d.isPatrolActive("doctor_dog") {
 		interval := doctorDogInterval(d.patrolConfig)
-		doctorDogTicker = time.NewTicker(interval)
-		doctorDogChan = doctorDogTicker.C
-		defer doctorDogTicker.Stop()
+		var stop func()
+		doctorDogChan, stop = d.startPatrolTicker("doctor_dog", interval)
+		defer stop()
 		d.logger.Printf("Doctor dog ticker started (interval %v)", interval)
 	}
 
 	// Start compactor dog ticker if configured.
 	// Flattens Dolt commit history to reclaim graph storage (daily).
-	var compactorDogTicker *time.Ticker
 	var compactorDogChan <-chan time.Time
 	if d.isPatrolActive("compactor_dog") {
 		interval := compactorDogInterval(d.patrolConfig)
-		compactorDogTicker = time.NewTicker(interval)
-		compactorDogChan = compactorDogTicker.C
-		defer compactorDogTicker.Stop()
+		var stop func()
+		compactorDogChan, stop = d.startPatrolTicker("compactor_dog", interval)
+		defer stop()
 		d.logger.Printf("Compactor dog ticker started (interval %v)", interval)
 	}
 
 	// Start checkpoint dog ticker if configured.
 	// Auto-commits WIP changes in active polecat worktrees to prevent data loss.
-	var checkpointDogTicker *time.Ticker
 	var checkpointDogChan <-chan time.Time
 	if d.isPatrolActive("checkpoint_dog") {
 		interval := checkpointDogInterval(d.patrolConfig)
-		checkpointDogTicker = time.NewTicker(interval)
-		checkpointDogChan = checkpointDogTicker.C
-		defer checkpointDogTicker.Stop()
+		var stop func()
+		checkpointDogChan, stop = d.startPatrolTicker("checkpoint_dog", interval)
+		defer stop()
 		d.logger.Printf("Checkpoint dog ticker started (interval %v)", interval)
 	}
 
 	// Start scheduled maintenance ticker if configured.
 	// Checks periodically whether we're in the maintenance window and
 	// runs `gt maintain --force` when commit counts exceed threshold.
-	var scheduledMaintenanceTicker *time.Ticker
 	var scheduledMaintenanceChan <-chan time.Time
 	if d.isPatrolActive("scheduled_maintenance") {
 		interval := maintenanceCheckInterval(d.patrolConfig)
-		scheduledMaintenanceTicker = time.NewTicker(interval)
-		scheduledMaintenanceChan = scheduledMaintenanceTicker.C
-		defer scheduledMaintenanceTicker.Stop()
+		var stop func()
+		scheduledMaintenanceChan, stop = d.startPatrolTicker("scheduled_maintenance", interval)
+		defer stop()
 		window := maintenanceWindow(d.patrolConfig)
 		d.logger.Printf("Scheduled maintenance ticker started (check interval %v, window %s)", interval, window)
 	}
 
 	// Start main-branch test runner ticker if configured.
 	// Periodically runs quality gates on each rig's main branch to catch regressions.
-	var mainBranchTestTicker *time.Ticker
 	var mainBranchTestChan <-chan time.Time
 	if d.isPatrolActive("main_branch_test") {
 		interval := mainBranchTestInterval(d.patrolConfig)
-		mainBranchTestTicker = time.NewTicker(interval)
-		mainBranchTestChan = mainBranchTestTicker.C
-		defer mainBranchTestTicker.Stop()
+		var stop func()
+		mainBranchTestChan, stop = d.startPatrolTicker("main_branch_test", interval)
+		defer stop()
 		d.logger.Printf("Main branch test ticker started (interval %v)", interval)
 	}
 
 	// Start quota dog ticker if configured.
 	// Scans for rate-limited sessions and automatically rotates credentials.
-	var quotaDogTicker *time.Ticker
 	var quotaDogChan <-chan time.Time
 	if d.isPatrolActive("quota_dog") {
 		interval := quotaDogInterval(d.patrolConfig)
-		quotaDogTicker = time.NewTicker(interval)
-		quotaDogChan = quotaDogTicker.C
-		defer quotaDogTicker.Stop()
+		var stop func()
+		quotaDogChan, stop = d.startPatrolTicker("quota_dog", interval)
+		defer stop()
 		d.logger.Printf("Quota dog ticker started (interval %v)", interval)
 	}
 
@@ -734,70 +789,77 @@ func (d *Daemon) Run() (err error) {
 			// Periodic Dolt remote push — pushes databases to their configured
 			// git remotes on a 15-minute cadence (independent of heartbeat).
 			if !d.isShutdownInProgress() {
-				d.pushDoltRemotes()
+				d.runPatrol("dolt_remotes", d.pushDoltRemotes)
 			}
 
 		case <-doltBackupChan:
 			// Periodic Dolt filesystem backup — syncs production databases to
 			// local backup directory on a 15-minute cadence.
 			if !d.isShutdownInProgress() {
-				d.syncDoltBackups()
+				d.runPatrol("dolt_backup", d.syncDoltBackups)
 			}
 
 		case <-jsonlGitBackupChan:
 			// Periodic JSONL git backup — exports issues, scrubs ephemeral data,
 			// commits and pushes to git repo.
 			if !d.isShutdownInProgress() {
-				d.syncJsonlGitBackup()
+				d.runPatrol("jsonl_git_backup", d.syncJsonlGitBackup)
 			}
 
 		case <-wispReaperChan:
 			// Periodic wisp reaper — closes stale wisps (abandoned molecule steps,
 			// old patrol data) to prevent unbounded table growth (Clown Show audit).
 			if !d.isShutdownInProgress() {
-				d.reapWisps()
+				d.runPatrol("wisp_reaper", d.reapWisps)
+			}
+
+		case <-moleculeReaperChan:
+			// Periodic molecule reaper — closes abandoned molecules that the
+			// wisp reaper leaves open (it only closes their steps).
+			if !d.isShutdownInProgress() {
+				d.runPatrol("molecule_reaper", d.reapMolecules)
 			}
 
 		case <-doctorDogChan:
 			// Doctor dog — comprehensive Dolt health monitor: connectivity, latency,
 			// gc, zombie detection, backup staleness, and disk usage checks.
 			if !d.isShutdownInProgress() {
-				d.runDoctorDog()
+				d.runPatrol("doctor_dog", d.runDoctorDog)
 			}
 
 		case <-compactorDogChan:
 			// Compactor dog — flattens Dolt commit history on production databases.
 			// Reclaims commit graph storage, then runs gc to reclaim chunks.
 			if !d.isShutdownInProgress() {
-				d.runCompactorDog()
+				d.runPatrol("compactor_dog", d.runCompactorDog)
 			}
 
 		case <-checkpointDogChan:
 			// Checkpoint dog — auto-commits WIP changes in active polecat
 			// worktrees to prevent data loss from session crashes.
 			if !d.isShutdownInProgress() {
-				d.runCheckpointDog()
+				d.runPatrol("checkpoint_dog", d.runCheckpointDog)
 			}
 
 		case <-scheduledMaintenanceChan:
 			// Scheduled maintenance — checks if we're in the maintenance window
 			// and runs `gt maintain --force` when commit counts exceed threshold.
 			if !d.isShutdownInProgress() {
-				d.runScheduledMaintenance()
+				d.runPatrol("scheduled_maintenance", d.runScheduledMaintenance)
 			}
 
 		case <-mainBranchTestChan:
 			// Main branch test runner — periodically runs quality gates on each
 			// rig's main branch to catch regressions from merges or direct pushes.
 			if !d.isShutdownInProgress() {
-				d.runMainBranchTests()
+				d.runPatrol("main_branch_test", d.runMainBranchTests)
 			}
 
 		case <-quotaDogChan:
 			// Quota dog — scans for rate-limited sessions and automatically
 			// rotates credentials to available accounts via keychain swap.
 			if !d.isShutdownInProgress() {
-				d.runQuotaDog()
+				d.runPatrol("quota_dog", d.runQuotaDog)
 			}
 
 		case <-timer.C:
@@ -813,6 +875,7 @@ func (d *Daemon) Run() (err error) {
 // Normal wake is handled by feed subscription (bd activity --follow).
 // The daemon is a safety net for dead sessions, GUPP violations, and orphaned work.
 // Default: 3 minutes — fast enough to detect stuck agents promptly.
+// The heartbeat cannot be disabled; config resolves "off" to the default.
 func (d *Daemon) recoveryHeartbeatInterval() time.Duration {
 	return d.loadOperationalConfig().GetDaemonConfig().RecoveryHeartbeatIntervalD()
 }
@@ -851,6 +914,10 @@ func (d *Daemon) heartbeat(state *State) {
 	// between ticks.
 	d.invalidateKnownRigsCache()
 
+	// Restore auto-respawn hooks paused after a mass death once the pause
+	// has elapsed.
+	d.resumeAutoRespawn(time.Now(), tmux.RespawnPolicyFromConfig(d.loadOperationalConfig()))
+
 	// 0a. Reload prefix registry so new/changed rigs get correct session names.
 	// Without this, rigs added after daemon startup get the "gt" default prefix,
 	// causing ghost sessions like gt-witness instead of ti-witness. (hq-ouz, hq-eqf, hq-3i4)
@@ -941,6 +1008,9 @@ func (d *Daemon) heartbeat(state *State) {
 	// 10. Check for GUPP violations (agents with work-on-hook not progressing)
 	d.checkGUPPViolations()
 
+	// 10a. Report hung sessions (idle with frozen pane output)
+	d.detectHungSessions()
+
 	// 11. Check for orphaned work (assigned to dead agents)
 	d.checkOrphanedWork()
 
@@ -973,13 +1043,14 @@ func (d *Daemon) heartbeat(state *State) {
 		d.dispatchQueuedWork()
 	}
 
-	// 15. Rotate oversized Dolt logs (copytruncate for child process fds).
-	// daemon.log uses lumberjack for automatic rotation; this handles Dolt server logs.
+	// 15. Rotate oversized Dolt logs (copytruncate for child process fds) and
+	// the town events file. daemon.log uses lumberjack for automatic rotation.
 	d.rotateOversizedLogs()
 
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
+	state.Patrols = d.DaemonStatus()
 	if err := SaveState(d.config.TownRoot, state); err != nil {
 		d.logger.Printf("Warning: failed to save state: %v", err)
 	}
@@ -989,7 +1060,8 @@ func (d *Daemon) heartbeat(state *State) {
 
 // rotateOversizedLogs checks Dolt server log files and rotates any that exceed
 // the size threshold. Uses copytruncate which is safe for logs held open by
-// child processes. Runs every heartbeat but is cheap (just stat calls).
+// child processes. Also rotates .events.jsonl, whose writers reopen it per
+// event. Runs every heartbeat but is cheap (just stat calls).
 func (d *Daemon) rotateOversizedLogs() {
 	result := RotateLogs(d.config.TownRoot)
 	for _, path := range result.Rotated {
@@ -998,12 +1070,16 @@ func (d *Daemon) rotateOversizedLogs() {
 	for _, err := range result.Errors {
 		d.logger.Printf("log_rotation: error: %v", err)
 	}
+	if err := events.RotateEvents(d.config.TownRoot, eventsRotationMaxSize, eventsRotationKeep); err != nil {
+		d.logger.Printf("log_rotation: events: %v", err)
+	}
 }
 
 // ensureDoltServerRunning ensures the Dolt SQL server is running if configured.
 // This provides the backend for beads database access in server mode.
 // Option B throttling: pours a mol-dog-doctor molecule only when health check
-// warnings are detected, with a 5-minute cooldown to avoid wisp spam.
+// warnings are detected, at most once per daemon.doctor_mol_cooldown (default
+// 5m) to avoid wisp spam.
 func (d *Daemon) ensureDoltServerRunning() {
 	if d.doltServer == nil || !d.doltServer.IsEnabled() {
 		return
@@ -1015,8 +1091,7 @@ func (d *Daemon) ensureDoltServerRunning() {
 
 	// Option B throttling: pour mol-dog-doctor only on anomaly with cooldown.
 	if warnings := d.doltServer.LastWarnings(); len(warnings) > 0 {
-		if time.Since(d.lastDoctorMolTime) >= doctorMolCooldown {
-			d.lastDoctorMolTime = time.Now()
+		if d.cooldowns.Allow(cooldownDoctorMol, d.loadOperationalConfig().GetDaemonConfig().DoctorMolCooldownD()) {
 			go d.pourDoctorMolecule(warnings)
 		}
 	}
@@ -1281,21 +1356,23 @@ func (d *Daemon) getDeaconSessionName() string {
 	return session.DeaconSessionName()
 }
 
+// bootSpawnCooldown returns the config-driven boot spawn cooldown for the
+// role being booted. Boot triage runs are expensive (AI reasoning); if one
+// just ran, skip.
+func (d *Daemon) bootSpawnCooldown(role string) time.Duration {
+	return d.loadOperationalConfig().GetDaemonConfig().BootSpawnCooldownForRole(role)
+}
+
 // ensureBootRunning spawns Boot to triage the Deacon.
 // Boot is a fresh-each-tick watchdog that decides whether to start/wake/nudge
 // the Deacon, centralizing the "when to wake" decision in an agent.
 // In degraded mode (no tmux), falls back to mechanical checks.
-// bootSpawnCooldown returns the config-driven boot spawn cooldown.
-// Boot triage runs are expensive (AI reasoning); if one just ran, skip.
-func (d *Daemon) bootSpawnCooldown() time.Duration {
-	return d.loadOperationalConfig().GetDaemonConfig().BootSpawnCooldownD()
-}
-
 func (d *Daemon) ensureBootRunning() {
 	// Cooldown gate: skip if Boot was spawned recently (fixes #2084)
-	if !d.bootLastSpawned.IsZero() && time.Since(d.bootLastSpawned) < d.bootSpawnCooldown() {
+	cooldown := d.bootSpawnCooldown(DeaconRole)
+	if remaining := d.cooldowns.Remaining(cooldownBootSpawn+DeaconRole, cooldown); remaining > 0 {
 		d.logger.Printf("Boot spawned %s ago, within cooldown (%s), skipping",
-			time.Since(d.bootLastSpawned).Round(time.Second), d.bootSpawnCooldown())
+			(cooldown - remaining).Round(time.Second), cooldown)
 		return
 	}
 
@@ -1305,7 +1382,7 @@ func (d *Daemon) ensureBootRunning() {
 	// in_progress/hooked work. If Deacon has written a fresh heartbeat and no
 	// beads are in_progress or hooked, there is nothing to triage.
 	//
-	// We deliberately do NOT update bootLastSpawned on an idle skip: the cooldown
+	// We deliberately do NOT record the boot-spawn cooldown on an idle skip: the cooldown
 	// is about rate-limiting real spawns; the idle check should re-run every
 	// heartbeat so Boot fires promptly when work actually appears.
 	hb := deacon.ReadHeartbeat(d.config.TownRoot)
@@ -1365,7 +1442,7 @@ func (d *Daemon) ensureBootRunning() {
 		return
 	}
 
-	d.bootLastSpawned = time.Now()
+	d.cooldowns.Record(cooldownBootSpawn + DeaconRole)
 	d.logger.Println("Boot spawned successfully")
 }
 
@@ -1506,7 +1583,22 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	}
 
 	// Always read heartbeat first (PATCH-005)
-	hb := deacon.ReadHeartbeat(d.config.TownRoot)
+	hb, err := deacon.LoadHeartbeat(d.config.TownRoot)
+	if errors.Is(err, deacon.ErrHeartbeatCorrupt) {
+		// A corrupt heartbeat still has an mtime. Judge staleness by it, so
+		// a file that stays corrupt ages into the normal stale handling
+		// instead of suppressing the check on every tick.
+		modTime, statErr := deacon.HeartbeatModTime(d.config.TownRoot)
+		if statErr != nil {
+			d.logger.Printf("Deacon heartbeat unreadable, skipping check this tick: %v", statErr)
+			return
+		}
+		d.logger.Printf("Deacon heartbeat unreadable, using file mtime %s: %v", modTime.Format(time.RFC3339), err)
+		hb = &deacon.Heartbeat{Timestamp: modTime}
+	} else if err != nil {
+		d.logger.Printf("Deacon heartbeat unreadable, skipping check this tick: %v", err)
+		return
+	}
 
 	sessionName := d.getDeaconSessionName()
 
@@ -1556,6 +1648,7 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	}
 
 	age := hb.Age()
+	d.gradeHeartbeat(DeaconRole, age)
 
 	// If heartbeat is fresh (< 5 min), nothing to do
 	if hb.IsFresh() {
@@ -1609,6 +1702,53 @@ func (d *Daemon) checkDeaconHeartbeat() {
 	}
 }
 
+// gradeHeartbeat emits a heartbeat_staleness event when agent's heartbeat
+// crosses the stale or very-stale threshold. Recoveries are debounced by the
+// stale threshold so a heartbeat flapping near a boundary is reported once.
+func (d *Daemon) gradeHeartbeat(agent string, age time.Duration) {
+	cfg := d.loadOperationalConfig().GetDeaconConfig()
+	thresholds := deacon.StalenessThresholds{
+		Stale:     cfg.HeartbeatStaleThresholdD(),
+		VeryStale: cfg.HeartbeatVeryStaleThresholdD(),
+	}
+	if d.heartbeatStaleness == nil {
+		d.heartbeatStaleness = deacon.NewStalenessTracker(thresholds.Stale)
+	}
+	d.heartbeatStaleness.Debounce = thresholds.Stale
+
+	tr := d.heartbeatStaleness.Observe(agent, age, time.Now(), thresholds)
+	if tr == nil {
+		return
+	}
+	d.logger.Printf("Heartbeat %s: %s -> %s (age %s, threshold %s)",
+		agent, tr.From, tr.To, age.Round(time.Second), tr.Threshold)
+	d.logFeed(events.TypeHeartbeatStaleness, "daemon",
+		events.HeartbeatStalenessPayload(agent, tr.From.String(), tr.To.String(), age, tr.Threshold))
+}
+
+// detectHungSessions logs sessions that have been idle, with unchanged pane
+// output, for operational.session.hung_session_threshold. Report-only: hung
+// session detection that killed sessions was removed (serial killer bug).
+func (d *Daemon) detectHungSessions() {
+	if d.tmux == nil {
+		return
+	}
+	threshold := d.loadOperationalConfig().GetSessionConfig().HungSessionThresholdD()
+	if d.hungSessions == nil {
+		d.hungSessions = session.NewHungSessionDetector(d.tmux, threshold)
+	}
+	d.hungSessions.Threshold = threshold
+
+	hung, err := d.hungSessions.DetectHungSessions(time.Now())
+	if err != nil {
+		return
+	}
+	for _, h := range hung {
+		d.logger.Printf("Session %s appears hung: no output for %s, idle %s (threshold %s)",
+			h.Session, h.Frozen.Round(time.Second), h.Inactive.Round(time.Second), h.Threshold)
+	}
+}
+
 // restartStuckDeacon kills a stuck Deacon session and respawns it.
 // Uses RestartTracker for exponential backoff and crash-loop prevention.
 // Notifies via gt-notify (zero token cost) if the notify script exists.
@@ -2233,6 +2373,10 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 	}
 	d.beadsStores = nil
 
+	d.stopMetricsServer()
+	d.webhook.wait()
+	d.closeDoltPool()
+
 	// Stop KRC pruner
 	if d.krcPruner != nil {
 		d.krcPruner.Stop()
@@ -2680,6 +2824,13 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 		return // Session came back - no restart needed
 	}
 
+	// Mass-death pause: this polecat's group already died together, so hold
+	// off the restart until the pause ends. A later heartbeat picks it up.
+	if d.respawnPaused(sessionName, time.Now()) {
+		d.logger.Printf("Deferring restart of %s/%s: respawns paused after mass death", rigName, polecatName)
+		return
+	}
+
 	// Polecat has work but session is dead - this is a crash!
 	d.logger.Printf("CRASH DETECTED: polecat %s/%s has hook_bead=%s but session %s is dead",
 		rigName, polecatName, info.HookBead, sessionName)
@@ -2688,20 +2839,38 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	d.recordSessionDeath(sessionName)
 
 	// Emit session_death event for audit trail / feed visibility
-	_ = events.LogFeed(events.TypeSessionDeath, sessionName,
+	d.logFeed(events.TypeSessionDeath, sessionName,
 		events.SessionDeathPayload(sessionName, rigName+"/polecats/"+polecatName, "crash detected by daemon health check", "daemon"))
 
+	// This death may have completed a mass death and started a pause.
+	if d.respawnPaused(sessionName, time.Now()) {
+		d.logger.Printf("Deferring restart of %s/%s: respawns paused after mass death", rigName, polecatName)
+		return
+	}
+
 	// Notify witness — stuck-agent-dog plugin handles context-aware restart
 	d.notifyWitnessOfCrashedPolecat(rigName, polecatName, info.HookBead)
 }
 
 // recordSessionDeath records a session death and checks for mass death pattern.
-func (d *Daemon) recordSessionDeath(sessionName string) {
+// Returns the mass death event if this death completed one, nil otherwise.
+func (d *Daemon) recordSessionDeath(sessionName string) *MassDeathEvent {
+	opCfg := d.loadOperationalConfig().GetDaemonConfig()
+	ev := d.detectMassDeath(sessionName, time.Now(), opCfg.MassDeathWindowD(), opCfg.MassDeathThresholdV())
+	if ev != nil {
+		d.emitMassDeathEvent(ev)
+		d.pauseAutoRespawn(ev.SessionNames(), time.Now(), opCfg.MassDeathRespawnPauseD())
+	}
+	return ev
+}
+
+// detectMassDeath adds a death at now and, if threshold deaths fall within
+// window, returns them as a MassDeathEvent and clears the tracked deaths to
+// avoid repeated alerts.
+func (d *Daemon) detectMassDeath(sessionName string, now time.Time, window time.Duration, threshold int) *MassDeathEvent {
 	d.deathsMu.Lock()
 	defer d.deathsMu.Unlock()
 
-	now := time.Now()
-
 	// Add this death
 	d.recentDeaths = append(d.recentDeaths, sessionDeath{
 		sessionName: sessionName,
@@ -2709,7 +2878,7 @@ func (d *Daemon) recordSessionDeath(sessionName string) {
 	})
 
 	// Prune deaths outside the window
-	cutoff := now.Add(-massDeathWindow)
+	cutoff := now.Add(-window)
 	var recent []sessionDeath
 	for _, death := range d.recentDeaths {
 		if death.timestamp.After(cutoff) {
@@ -2718,31 +2887,20 @@ func (d *Daemon) recordSessionDeath(sessionName string) {
 	}
 	d.recentDeaths = recent
 
-	// Check for mass death
-	if len(d.recentDeaths) >= massDeathThreshold {
-		d.emitMassDeathEvent()
+	if len(d.recentDeaths) < threshold {
+		return nil
 	}
+	ev := newMassDeathEvent(d.recentDeaths, window)
+	d.recentDeaths = nil
+	return ev
 }
 
 // emitMassDeathEvent logs a mass death event when multiple sessions die in a short window.
-func (d *Daemon) emitMassDeathEvent() {
-	// Collect session names
-	var sessions []string
-	for _, death := range d.recentDeaths {
-		sessions = append(sessions, death.sessionName)
-	}
-
-	count := len(sessions)
-	window := massDeathWindow.String()
-
-	d.logger.Printf("MASS DEATH DETECTED: %d sessions died in %s: %v", count, window, sessions)
+func (d *Daemon) emitMassDeathEvent(ev *MassDeathEvent) {
+	d.logger.Printf("MASS DEATH DETECTED: %d sessions died in %s: %v", ev.Count, ev.Window, ev.SessionNames())
 
 	// Emit feed event
-	_ = events.LogFeed(events.TypeMassDeath, "daemon",
-		events.MassDeathPayload(count, window, sessions, ""))
-
-	// Clear the deaths to avoid repeated alerts
-	d.recentDeaths = nil
+	d.logFeed(events.TypeMassDeath, "daemon", ev.payload())
 }
 
 // isBeadClosed checks if a bead's status is "closed" by querying bd show --json.
@@ -2828,6 +2986,9 @@ Restart deferred to stuck-agent-dog plugin for context-aware recovery.`,
 func (d *Daemon) reapIdlePolecats() {
 	opCfg := d.loadOperationalConfig().GetDaemonConfig()
 	idleTimeout := opCfg.PolecatIdleSessionTimeoutD()
+	if idleTimeout == agentconfig.DurationDisabled {
+		return
+	}
 
 	d.rigPool.runPerRig(d.ctx, d.getKnownRigs(), func(ctx context.Context, rigName string) error {
 		d.reapRigIdlePolecats(rigName, idleTimeout)
@@ -2956,7 +3117,7 @@ func (d *Daemon) killIdlePolecat(rigName, polecatName, sessionName string, idleD
 	d.logger.Printf("Reaped idle polecat %s/%s — session killed, API slot freed", rigName, polecatName)
 
 	// Emit feed event so the activity feed shows the reap
-	_ = events.LogFeed(events.TypeSessionDeath, fmt.Sprintf("%s/%s", rigName, polecatName),
+	d.logFeed(events.TypeSessionDeath, fmt.Sprintf("%s/%s", rigName, polecatName),
 		events.SessionDeathPayload(sessionName, fmt.Sprintf("%s/polecats/%s", rigName, polecatName),
 			fmt.Sprintf("idle-reap: %s, idle %v (threshold %v)", reason, idleDuration.Truncate(time.Second), timeout),
 			"daemon"))
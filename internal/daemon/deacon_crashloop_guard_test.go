@@ -107,3 +107,96 @@ func TestCheckDeaconHeartbeat_RespectsCrashLoopGuard(t *testing.T) {
 		t.Fatalf("kill-session count = %d, want 0 while crash-loop guard is active", kills)
 	}
 }
+
+// A heartbeat file truncated by a kill mid-write must not read as "no
+// heartbeat" and trigger a stuck-Deacon restart.
+func TestCheckDeaconHeartbeat_CorruptHeartbeatNoRestart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping on Windows — fake tmux requires bash")
+	}
+	townRoot := t.TempDir()
+	fakeBinDir := t.TempDir()
+	tmuxLog := filepath.Join(t.TempDir(), "tmux.log")
+	if err := os.WriteFile(tmuxLog, []byte{}, 0o644); err != nil {
+		t.Fatalf("create tmux log: %v", err)
+	}
+
+	writeFakeTmuxCrashLoop(t, fakeBinDir)
+	t.Setenv("PATH", fakeBinDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TMUX_LOG", tmuxLog)
+
+	hbFile := deacon.HeartbeatFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(hbFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(hbFile, []byte(`{"timestamp": "20`), 0o600); err != nil {
+		t.Fatalf("write truncated heartbeat: %v", err)
+	}
+
+	var logBuf strings.Builder
+	d := &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(&logBuf, "", 0),
+		tmux:   tmux.NewTmux(),
+		// Started long enough ago that a missing heartbeat would be "stuck".
+		deaconLastStarted: time.Now().Add(-time.Hour),
+	}
+
+	d.checkDeaconHeartbeat()
+
+	data, err := os.ReadFile(tmuxLog)
+	if err != nil {
+		t.Fatalf("read tmux log: %v", err)
+	}
+	if strings.Contains(string(data), "kill-session") {
+		t.Fatalf("corrupt heartbeat triggered a restart:\n%s", data)
+	}
+	if strings.Contains(logBuf.String(), "STUCK DEACON") {
+		t.Errorf("corrupt heartbeat reported as stuck:\n%s", logBuf.String())
+	}
+	if !strings.Contains(logBuf.String(), "heartbeat unreadable") {
+		t.Errorf("expected unreadable heartbeat to be logged:\n%s", logBuf.String())
+	}
+}
+
+// A heartbeat that stays corrupt must not suppress the check forever: once
+// the file's mtime is very stale, the Deacon is treated as stuck.
+func TestCheckDeaconHeartbeat_CorruptHeartbeatAgesByMtime(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping on Windows — fake tmux requires bash")
+	}
+	townRoot := t.TempDir()
+	fakeBinDir := t.TempDir()
+	writeFakeTmuxCrashLoop(t, fakeBinDir)
+	t.Setenv("PATH", fakeBinDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	hbFile := deacon.HeartbeatFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(hbFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(hbFile, []byte(`{"timestamp": "20`), 0o600); err != nil {
+		t.Fatalf("write truncated heartbeat: %v", err)
+	}
+	old := time.Now().Add(-30 * time.Minute)
+	if err := os.Chtimes(hbFile, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restart backoff stops restartStuckDeacon before it touches the session.
+	rt := NewRestartTracker(townRoot, RestartTrackerConfig{})
+	rt.state.Agents["deacon"] = &AgentRestartInfo{BackoffUntil: time.Now().Add(time.Hour)}
+
+	var logBuf strings.Builder
+	d := &Daemon{
+		config:         &Config{TownRoot: townRoot},
+		logger:         log.New(&logBuf, "", 0),
+		tmux:           tmux.NewTmux(),
+		restartTracker: rt,
+	}
+
+	d.checkDeaconHeartbeat()
+
+	if !strings.Contains(logBuf.String(), "STUCK DEACON") {
+		t.Errorf("corrupt heartbeat with a 30m-old mtime should read as very stale:\n%s", logBuf.String())
+	}
+}
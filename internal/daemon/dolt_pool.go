@@ -0,0 +1,552 @@
+package daemon
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+// DoltPool shares *sql.DB handles to the Dolt server across daemon patrols,
+// one per database, instead of opening and closing a connection per database
+// per cycle. It applies the operational.dolt thresholds:
+//   - max_connections caps each handle's open connections
+//   - health_check_interval paces a background ping of every handle; a failed
+//     ping starts a reconnect (see reconnect)
+//   - cmd_timeout bounds each statement, whether standalone (ExecLogged,
+//     QueryLogged) or on a pinned connection (Conn), and is the driver's
+//     read/write timeout
+//   - slow_query_threshold is the duration above which a statement is
+//     logged, with its text redacted (see redactQuery)
+type DoltPool struct {
+	host string
+	port int
+
+	maxConns       int
+	cmdTimeout     time.Duration
+	slowQuery      time.Duration
+	healthInterval time.Duration
+	reconnectRetry time.Duration
+
+	logf func(format string, args ...interface{})
+	// open opens a handle for a database. Replaced in tests.
+	open func(dbName string) (*sql.DB, error)
+
+	mu  sync.Mutex
+	dbs map[string]*sql.DB
+	// reconnecting is closed when an in-progress reconnect finishes; nil when
+	// the pool is not reconnecting.
+	reconnecting chan struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewDoltPool returns a pool for the Dolt server at host:port and starts its
+// health check. logf receives health-check failures and slow queries. Close
+// the pool to stop the health check and release connections.
+func NewDoltPool(host string, port int, cfg *config.DoltThresholds, logf func(format string, args ...interface{})) *DoltPool {
+	p := newDoltPool(host, port, cfg, logf)
+	go p.healthLoop()
+	return p
+}
+
+// newDoltPool builds a pool without starting the health check.
+func newDoltPool(host string, port int, cfg *config.DoltThresholds, logf func(format string, args ...interface{})) *DoltPool {
+	p := &DoltPool{
+		host:           host,
+		port:           port,
+		maxConns:       cfg.MaxConnectionsV(),
+		cmdTimeout:     cfg.CmdTimeoutD(),
+		slowQuery:      cfg.SlowQueryThresholdD(),
+		healthInterval: cfg.HealthCheckIntervalD(),
+		reconnectRetry: doltReconnectRetry,
+		logf:           logf,
+		dbs:            make(map[string]*sql.DB),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	p.open = func(dbName string) (*sql.DB, error) {
+		return reaper.OpenDB(p.host, p.port, dbName, p.cmdTimeout, p.cmdTimeout)
+	}
+	return p
+}
+
+// errDoltPoolClosed is returned by DB after Close.
+var errDoltPoolClosed = errors.New("dolt pool closed")
+
+// Port returns the Dolt server port the pool connects to.
+func (p *DoltPool) Port() int {
+	return p.port
+}
+
+// DB returns the shared handle for dbName, opening it on first use.
+// Callers must not close it.
+func (p *DoltPool) DB(dbName string) (*sql.DB, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	select {
+	case <-p.stop:
+		return nil, errDoltPoolClosed
+	default:
+	}
+	if db, ok := p.dbs[dbName]; ok {
+		return db, nil
+	}
+	db, err := p.open(dbName)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(p.maxConns)
+	p.dbs[dbName] = db
+	return db, nil
+}
+
+// doltReconnectRetry is how often a reconnecting pool probes the server.
+const doltReconnectRetry = 250 * time.Millisecond
+
+// reconnect drops every handle and re-establishes the connection in the
+// background after cause, a connection error on dbName. Until the server
+// answers a ping again, queries wait (see awaitReconnect) instead of failing
+// against stale connections. No-op if a reconnect is already in progress.
+func (p *DoltPool) reconnect(dbName string, cause error) {
+	p.mu.Lock()
+	if p.reconnecting != nil {
+		p.mu.Unlock()
+		return
+	}
+	gate := make(chan struct{})
+	p.reconnecting = gate
+	stale := p.dbs
+	p.dbs = make(map[string]*sql.DB)
+	p.mu.Unlock()
+
+	if p.logf != nil {
+		p.logf("dolt: lost connection to port %d (%v), reconnecting", p.port, cause)
+	}
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			p.reconnecting = nil
+			p.mu.Unlock()
+			close(gate)
+		}()
+		for _, db := range stale {
+			_ = db.Close()
+		}
+		start := time.Now()
+		for {
+			if err := p.ping(dbName); err == nil {
+				if p.logf != nil {
+					p.logf("dolt: reconnected to port %d after %v", p.port, time.Since(start).Round(time.Millisecond))
+				}
+				return
+			}
+			select {
+			case <-p.stop:
+				return
+			case <-time.After(p.reconnectRetry):
+			}
+		}
+	}()
+}
+
+// awaitReconnect blocks while a reconnect is in progress, for at most
+// cmd_timeout or until ctx is done.
+func (p *DoltPool) awaitReconnect(ctx context.Context) error {
+	p.mu.Lock()
+	gate := p.reconnecting
+	p.mu.Unlock()
+	if gate == nil {
+		return nil
+	}
+	var timeout <-chan time.Time
+	if p.cmdTimeout > 0 {
+		timer := time.NewTimer(p.cmdTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-gate:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return fmt.Errorf("dolt: still reconnecting to port %d after %v", p.port, p.cmdTimeout)
+	}
+}
+
+// run executes op against dbName's handle, timing it for slow-query logging.
+// If op fails with a connection error, run starts a reconnect, waits for it,
+// and runs op once more, so a server restart costs callers a delay rather
+// than an error. op must therefore be safe to repeat on its own: a statement
+// inside a transaction is not, since the transaction died with the old
+// connection. Transactional work uses Conn, which never retries, and is
+// restarted as a whole by its caller.
+func (p *DoltPool) run(ctx context.Context, dbName, query string, op func(db *sql.DB) error) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if err = p.awaitReconnect(ctx); err != nil {
+			return err
+		}
+		var db *sql.DB
+		if db, err = p.DB(dbName); err == nil {
+			start := time.Now()
+			err = op(db)
+			p.observe(dbName, query, time.Since(start))
+		}
+		if attempt > 0 || ctx.Err() != nil || !isRetryableDoltError(err) {
+			return err
+		}
+		p.reconnect(dbName, err)
+	}
+	return err
+}
+
+// ping checks dbName's handle, opening it if needed.
+func (p *DoltPool) ping(dbName string) error {
+	db, err := p.DB(dbName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := p.withCmdTimeout(context.Background())
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+// withCmdTimeout returns ctx bounded by cmd_timeout. A deadline ctx already
+// has still applies if it is sooner, so a caller's overall deadline (e.g. a
+// whole reap) and the per-statement cmd_timeout both hold.
+func (p *DoltPool) withCmdTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.cmdTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.cmdTimeout)
+}
+
+// withDetachedCmdTimeout is withCmdTimeout for work that outlives the call,
+// such as rows the caller reads later. The context is released when its
+// deadline passes rather than by the caller.
+func (p *DoltPool) withDetachedCmdTimeout(ctx context.Context) context.Context {
+	if p.cmdTimeout <= 0 {
+		return ctx
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.cmdTimeout)
+	// Release once the context ends; a separate timer could fire first and
+	// turn a timeout into context.Canceled.
+	context.AfterFunc(ctx, cancel)
+	return ctx
+}
+
+// observe logs a query on dbName that took longer than slow_query_threshold,
+// with its text redacted by redactQuery.
+func (p *DoltPool) observe(dbName, query string, elapsed time.Duration) {
+	if p.slowQuery > 0 && elapsed > p.slowQuery && p.logf != nil {
+		p.logf("dolt: slow query on %s took %v (threshold %v): %s", dbName, elapsed.Round(time.Millisecond), p.slowQuery, redactQuery(query))
+	}
+}
+
+// maxLoggedQueryLen bounds the query text in slow-query logs.
+const maxLoggedQueryLen = 200
+
+// redactQuery returns query with every string and numeric literal replaced by
+// "?" and whitespace collapsed, so slow-query logs never carry values: bound
+// arguments are never logged, and many queries here inline values with
+// fmt.Sprintf. Identifiers, including `quoted` ones, are kept. Long queries
+// are truncated to maxLoggedQueryLen.
+func redactQuery(query string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			// String literal; a doubled or backslash-escaped quote doesn't end it.
+			j := i + 1
+			for j < len(query) {
+				if query[j] == '\\' {
+					j += 2
+					continue
+				}
+				if query[j] == c {
+					if j+1 < len(query) && query[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			b.WriteByte('?')
+			i = min(j+1, len(query))
+		case c == '`':
+			j := strings.IndexByte(query[i+1:], '`')
+			if j < 0 {
+				b.WriteString(query[i:])
+				i = len(query)
+			} else {
+				b.WriteString(query[i : i+j+2])
+				i += j + 2
+			}
+		case isDigit(c) && (i == 0 || !isIdentByte(query[i-1])):
+			j := i
+			for j < len(query) && (isIdentByte(query[j]) || query[j] == '.') {
+				j++
+			}
+			b.WriteByte('?')
+			i = j
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if !space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = true
+			i++
+			continue
+		default:
+			b.WriteByte(c)
+			i++
+		}
+		space = false
+	}
+	out := strings.TrimSpace(b.String())
+	if len(out) > maxLoggedQueryLen {
+		out = out[:maxLoggedQueryLen] + "..."
+	}
+	return out
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// isIdentByte reports whether c can appear in an unquoted SQL identifier.
+func isIdentByte(c byte) bool {
+	return isDigit(c) || c == '_' || c == '$' || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
+
+// ExecLogged runs a standalone statement on dbName with the default
+// cmd_timeout and logs it if it was slow. A statement that fails on a lost
+// connection is retried once after the pool reconnects, so it must be safe
+// to repeat and must not depend on session state; use Conn for that.
+func (p *DoltPool) ExecLogged(ctx context.Context, dbName, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := p.withCmdTimeout(ctx)
+	defer cancel()
+
+	var res sql.Result
+	err := p.run(ctx, dbName, query, func(db *sql.DB) error {
+		var err error
+		res, err = db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+// QueryLogged runs a query on dbName with the default cmd_timeout and logs it
+// if it was slow. The timeout also bounds reading the returned rows; the
+// duration logged is the time to the first result, not to drain the rows.
+// Like ExecLogged, it is retried once across a reconnect.
+func (p *DoltPool) QueryLogged(ctx context.Context, dbName, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx = p.withDetachedCmdTimeout(ctx)
+
+	var rows *sql.Rows
+	err := p.run(ctx, dbName, query, func(db *sql.DB) error {
+		var err error
+		rows, err = db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// DoltConn is one connection pinned from a DoltPool's handle for a database,
+// as a reaper.DB. The reapers depend on session state: SET @@autocommit = 0,
+// the batched UPDATEs, COMMIT and DOLT_COMMIT must all run on the same
+// connection, which the shared *sql.DB does not guarantee. Statements get the
+// pool's cmd_timeout and slow-query logging.
+type DoltConn struct {
+	pool   *DoltPool
+	dbName string
+	conn   *sql.Conn
+	// failed is set once a statement errors; the connection may then hold
+	// autocommit=0 or an open transaction, so Close discards it.
+	failed bool
+}
+
+// Conn pins a connection to dbName, waiting out a reconnect in progress.
+// The caller must Close it.
+func (p *DoltPool) Conn(ctx context.Context, dbName string) (*DoltConn, error) {
+	if err := p.awaitReconnect(ctx); err != nil {
+		return nil, err
+	}
+	db, err := p.DB(dbName)
+	if err != nil {
+		return nil, err
+	}
+	connCtx, cancel := p.withCmdTimeout(ctx)
+	defer cancel()
+	conn, err := db.Conn(connCtx)
+	if err != nil {
+		if connCtx.Err() == nil && isRetryableDoltError(err) {
+			p.reconnect(dbName, err)
+		}
+		return nil, err
+	}
+	return &DoltConn{pool: p, dbName: dbName, conn: conn}, nil
+}
+
+// WithConn runs fn on a connection pinned to dbName (see Conn) and releases
+// it afterwards.
+func (p *DoltPool) WithConn(ctx context.Context, dbName string, fn func(db reaper.DB) error) error {
+	conn, err := p.Conn(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return fn(conn)
+}
+
+// ExecContext runs a statement on the pinned connection with cmd_timeout.
+// It is not retried: after a connection error the session, and any open
+// transaction, are gone.
+func (c *DoltConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := c.pool.withCmdTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	res, err := c.conn.ExecContext(ctx, query, args...)
+	c.pool.observe(c.dbName, query, time.Since(start))
+	c.noteErr(ctx, err)
+	return res, err
+}
+
+// QueryContext runs a query on the pinned connection. As with QueryLogged,
+// cmd_timeout also bounds reading the returned rows.
+func (c *DoltConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx = c.pool.withDetachedCmdTimeout(ctx)
+
+	start := time.Now()
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	c.pool.observe(c.dbName, query, time.Since(start))
+	c.noteErr(ctx, err)
+	return rows, err
+}
+
+// noteErr records a failed statement and starts a pool reconnect on a
+// connection error.
+func (c *DoltConn) noteErr(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	c.failed = true
+	if ctx.Err() == nil && isRetryableDoltError(err) {
+		c.pool.reconnect(c.dbName, err)
+	}
+}
+
+// Close returns the connection to the pool, or discards it if a statement
+// failed so no later user inherits its session state.
+func (c *DoltConn) Close() error {
+	if c.failed {
+		_ = c.conn.Raw(func(any) error { return driver.ErrBadConn })
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// healthLoop pings every open handle each health_check_interval until Close.
+func (p *DoltPool) healthLoop() {
+	defer close(p.done)
+	if p.healthInterval <= 0 {
+		<-p.stop
+		return
+	}
+	ticker := time.NewTicker(p.healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.checkHealth()
+		}
+	}
+}
+
+// checkHealth pings each open handle, logging failures. A failed ping
+// starts a reconnect, which replaces every handle.
+func (p *DoltPool) checkHealth() {
+	p.mu.Lock()
+	names := make([]string, 0, len(p.dbs))
+	for name := range p.dbs {
+		names = append(names, name)
+	}
+	reconnecting := p.reconnecting != nil
+	p.mu.Unlock()
+	if reconnecting {
+		return
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := p.ping(name); err != nil {
+			if p.logf != nil {
+				p.logf("dolt: health check failed for %s on port %d: %v", name, p.port, err)
+			}
+			p.reconnect(name, err)
+			return
+		}
+	}
+}
+
+// Close stops the health check and closes every handle.
+func (p *DoltPool) Close() error {
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var firstErr error
+	for name, db := range p.dbs {
+		if err := db.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("closing %s: %w", name, err)
+		}
+	}
+	p.dbs = make(map[string]*sql.DB)
+	return firstErr
+}
+
+// doltPoolFor returns the daemon's shared Dolt pool for port, replacing the
+// pool if the resolved port changed since the last cycle.
+func (d *Daemon) doltPoolFor(port int) *DoltPool {
+	d.doltPoolMu.Lock()
+	defer d.doltPoolMu.Unlock()
+
+	if d.doltPool != nil && d.doltPool.Port() == port {
+		return d.doltPool
+	}
+	if d.doltPool != nil {
+		_ = d.doltPool.Close()
+	}
+	d.doltPool = NewDoltPool("127.0.0.1", port, d.loadOperationalConfig().GetDoltConfig(), d.logger.Printf)
+	return d.doltPool
+}
+
+// closeDoltPool releases the daemon's shared Dolt pool, if any.
+func (d *Daemon) closeDoltPool() {
+	d.doltPoolMu.Lock()
+	defer d.doltPoolMu.Unlock()
+
+	if d.doltPool != nil {
+		_ = d.doltPool.Close()
+		d.doltPool = nil
+	}
+}
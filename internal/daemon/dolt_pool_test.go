@@ -0,0 +1,476 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// stubDoltDriver is a database/sql driver whose statements take a
+// configurable time, for DoltPool tests.
+type stubDoltDriver struct {
+	mu    sync.Mutex
+	delay time.Duration
+	down  bool // server unreachable: new connections are refused, old ones are bad
+	opens int
+	row   driver.Value // if non-nil, queries return one row holding it
+	// respond, if set, returns the rows (one value each) for a query and
+	// overrides row.
+	respond func(query string) []driver.Value
+	stmts   []stubStmt // every Exec and Query, in order
+	// failQuery, if set, makes the next statement containing it fail with
+	// failErr; it is then cleared.
+	failQuery string
+	failErr   error
+}
+
+// failOnce makes the next statement containing query fail with err.
+func (d *stubDoltDriver) failOnce(query string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failQuery, d.failErr = query, err
+}
+
+// stubStmt is a statement the stub driver ran, and the connection it ran on.
+type stubStmt struct {
+	conn  int
+	query string
+}
+
+// statements returns the statements run so far.
+func (d *stubDoltDriver) statements() []stubStmt {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]stubStmt(nil), d.stmts...)
+}
+
+// record logs a statement and returns the failOnce error if it matches.
+func (d *stubDoltDriver) record(conn int, query string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stmts = append(d.stmts, stubStmt{conn: conn, query: query})
+	if d.failQuery != "" && strings.Contains(query, d.failQuery) {
+		d.failQuery = ""
+		return d.failErr
+	}
+	return nil
+}
+
+func (d *stubDoltDriver) setDown(down bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.down = down
+}
+
+func (d *stubDoltDriver) setRow(v driver.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.row = v
+}
+
+func (d *stubDoltDriver) setDelay(delay time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.delay = delay
+}
+
+func (d *stubDoltDriver) wait(ctx context.Context) error {
+	d.mu.Lock()
+	delay, down := d.delay, d.down
+	d.mu.Unlock()
+	if down {
+		return driver.ErrBadConn
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *stubDoltDriver) Open(string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.down {
+		return nil, errors.New("dial tcp 127.0.0.1:3307: connect: connection refused")
+	}
+	d.opens++
+	return &stubDoltConn{d: d, id: d.opens}, nil
+}
+
+type stubDoltConn struct {
+	d  *stubDoltDriver
+	id int
+}
+
+func (c *stubDoltConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *stubDoltConn) Close() error                        { return nil }
+func (c *stubDoltConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+func (c *stubDoltConn) Ping(ctx context.Context) error      { return c.d.wait(ctx) }
+
+func (c *stubDoltConn) ExecContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if err := c.d.record(c.id, query); err != nil {
+		return nil, err
+	}
+	if err := c.d.wait(ctx); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *stubDoltConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if err := c.d.record(c.id, query); err != nil {
+		return nil, err
+	}
+	if err := c.d.wait(ctx); err != nil {
+		return nil, err
+	}
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	if c.d.respond != nil {
+		return &stubDoltRows{rows: c.d.respond(query)}, nil
+	}
+	if c.d.row == nil {
+		return &stubDoltRows{}, nil
+	}
+	return &stubDoltRows{rows: []driver.Value{c.d.row}}, nil
+}
+
+type stubDoltRows struct{ rows []driver.Value }
+
+func (r *stubDoltRows) Columns() []string { return []string{"n"} }
+func (r *stubDoltRows) Close() error      { return nil }
+
+func (r *stubDoltRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+var (
+	stubDoltDriverSeq int
+	stubDoltDriverMu  sync.Mutex
+)
+
+// newStubDoltPool returns a pool (without health check) whose handles use
+// a fresh stub driver, and a buffer capturing its log.
+func newStubDoltPool(t *testing.T, cfg *config.DoltThresholds) (*DoltPool, *stubDoltDriver, *bytes.Buffer) {
+	t.Helper()
+	stubDoltDriverMu.Lock()
+	stubDoltDriverSeq++
+	name := fmt.Sprintf("stubdolt%d", stubDoltDriverSeq)
+	stubDoltDriverMu.Unlock()
+
+	drv := &stubDoltDriver{}
+	sql.Register(name, drv)
+
+	var logBuf bytes.Buffer
+	var logMu sync.Mutex
+	logf := func(format string, args ...interface{}) {
+		logMu.Lock()
+		defer logMu.Unlock()
+		fmt.Fprintf(&logBuf, format+"\n", args...)
+	}
+	p := newDoltPool("127.0.0.1", 3307, cfg, logf)
+	p.open = func(dbName string) (*sql.DB, error) { return sql.Open(name, dbName) }
+	p.reconnectRetry = 10 * time.Millisecond
+	t.Cleanup(func() { _ = p.Close() })
+	return p, drv, &logBuf
+}
+
+func TestDoltPool_MaxConnections(t *testing.T) {
+	maxConns := 7
+	p, _, _ := newStubDoltPool(t, &config.DoltThresholds{MaxConnections: &maxConns})
+
+	db, err := p.DB("hq")
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	if got := db.Stats().MaxOpenConnections; got != 7 {
+		t.Errorf("MaxOpenConnections = %d, want 7", got)
+	}
+
+	again, err := p.DB("hq")
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	if again != db {
+		t.Error("DB returned a new handle for the same database; want the shared one")
+	}
+	other, _ := p.DB("gastown")
+	if other == db {
+		t.Error("DB returned the same handle for different databases")
+	}
+}
+
+func TestDoltPool_MaxConnectionsDefault(t *testing.T) {
+	p, _, _ := newStubDoltPool(t, &config.DoltThresholds{})
+	db, err := p.DB("hq")
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	if got := db.Stats().MaxOpenConnections; got != config.DefaultDoltMaxConnections {
+		t.Errorf("MaxOpenConnections = %d, want %d", got, config.DefaultDoltMaxConnections)
+	}
+}
+
+func TestDoltPool_SlowQueryLogging(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{SlowQueryThreshold: "20ms"})
+	ctx := context.Background()
+
+	// Fast statement: nothing logged.
+	if _, err := p.ExecLogged(ctx, "hq", "UPDATE wisps SET status = 'closed'"); err != nil {
+		t.Fatalf("ExecLogged: %v", err)
+	}
+	if logBuf.Len() != 0 {
+		t.Errorf("fast query was logged: %q", logBuf.String())
+	}
+
+	// Slow statement: logged with database and threshold.
+	drv.setDelay(60 * time.Millisecond)
+	if _, err := p.ExecLogged(ctx, "hq", "UPDATE wisps SET status = 'closed'"); err != nil {
+		t.Fatalf("ExecLogged: %v", err)
+	}
+	out := logBuf.String()
+	if !strings.Contains(out, "slow query on hq") || !strings.Contains(out, "threshold 20ms") {
+		t.Errorf("slow exec not logged as expected: %q", out)
+	}
+
+	// Slow query through QueryLogged is logged too.
+	logBuf.Reset()
+	rows, err := p.QueryLogged(ctx, "gastown", "SELECT 1")
+	if err != nil {
+		t.Fatalf("QueryLogged: %v", err)
+	}
+	rows.Close()
+	if !strings.Contains(logBuf.String(), "slow query on gastown") {
+		t.Errorf("slow query not logged: %q", logBuf.String())
+	}
+}
+
+func TestDoltPool_CmdTimeout(t *testing.T) {
+	p, drv, _ := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "20ms"})
+	drv.setDelay(time.Second)
+
+	start := time.Now()
+	_, err := p.ExecLogged(context.Background(), "hq", "SELECT SLEEP(1)")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ExecLogged err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("ExecLogged took %v; cmd_timeout not applied", elapsed)
+	}
+}
+
+func TestDoltPool_HealthCheckLogsFailure(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "10ms"})
+	if _, err := p.DB("hq"); err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+
+	p.checkHealth()
+	if logBuf.Len() != 0 {
+		t.Errorf("healthy ping was logged: %q", logBuf.String())
+	}
+
+	drv.setDelay(time.Second)
+	p.checkHealth()
+	if !strings.Contains(logBuf.String(), "health check failed for hq") {
+		t.Errorf("failed ping not logged: %q", logBuf.String())
+	}
+}
+
+func TestDoltPool_CloseReleasesHandles(t *testing.T) {
+	p, _, _ := newStubDoltPool(t, &config.DoltThresholds{})
+	db, _ := p.DB("hq")
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := db.Ping(); err == nil {
+		t.Error("handle still usable after Close")
+	}
+	// Close is idempotent.
+	if err := p.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT id FROM wisps WHERE id = ?", "SELECT id FROM wisps WHERE id = ?"},
+		{"UPDATE wisps SET status='closed' WHERE id = 'gt-abc'", "UPDATE wisps SET status=? WHERE id = ?"},
+		{`SELECT * FROM issues WHERE title = "it's \"quoted\""`, "SELECT * FROM issues WHERE title = ?"},
+		{"SELECT 'it''s' AS s", "SELECT ? AS s"},
+		{"DELETE FROM wisps WHERE created_at < NOW() - INTERVAL 7 DAY LIMIT 500", "DELETE FROM wisps WHERE created_at < NOW() - INTERVAL ? DAY LIMIT ?"},
+		{"SELECT x FROM t2 WHERE v = 3.14", "SELECT x FROM t2 WHERE v = ?"},
+		{"SELECT `col 1`, col2 FROM `db-1`.t", "SELECT `col 1`, col2 FROM `db-1`.t"},
+		{"SELECT\n\t  id\n FROM  wisps", "SELECT id FROM wisps"},
+		{"SELECT 'unterminated", "SELECT ?"},
+	}
+	for _, tt := range tests {
+		if got := redactQuery(tt.query); got != tt.want {
+			t.Errorf("redactQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	long := "SELECT " + strings.Repeat("a, ", 200) + "b FROM t"
+	if got := redactQuery(long); len(got) != maxLoggedQueryLen+3 || !strings.HasSuffix(got, "...") {
+		t.Errorf("long query not truncated: %d bytes", len(got))
+	}
+}
+
+func TestDoltPool_SlowQueryLogRedactsText(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{SlowQueryThreshold: "30ms"})
+	ctx := context.Background()
+	query := "UPDATE wisps SET status = 'closed' WHERE id = 'gt-secret' AND n > 42"
+
+	// Below the threshold: not logged.
+	drv.setDelay(5 * time.Millisecond)
+	if _, err := p.ExecLogged(ctx, "hq", query); err != nil {
+		t.Fatalf("ExecLogged: %v", err)
+	}
+	if logBuf.Len() != 0 {
+		t.Fatalf("query under threshold was logged: %q", logBuf.String())
+	}
+
+	// Above the threshold: logged with database, duration, and redacted text.
+	drv.setDelay(80 * time.Millisecond)
+	if _, err := p.ExecLogged(ctx, "hq", query, "bound-arg"); err != nil {
+		t.Fatalf("ExecLogged: %v", err)
+	}
+	out := logBuf.String()
+	for _, want := range []string{"slow query on hq took ", "UPDATE wisps SET status = ? WHERE id = ? AND n > ?"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log %q missing %q", out, want)
+		}
+	}
+	for _, leaked := range []string{"gt-secret", "> 42", "bound-arg", "closed"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log leaked %q: %q", leaked, out)
+		}
+	}
+}
+
+func TestDoltPool_ReconnectsAfterServerRestart(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "2s"})
+	ctx := context.Background()
+	if _, err := p.ExecLogged(ctx, "hq", "UPDATE wisps SET status = 'closed'"); err != nil {
+		t.Fatalf("ExecLogged before restart: %v", err)
+	}
+
+	// The server goes away, and comes back shortly after.
+	drv.setDown(true)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		drv.setDown(false)
+	}()
+
+	// The caller sees a delay, not an error.
+	start := time.Now()
+	if _, err := p.ExecLogged(ctx, "hq", "UPDATE wisps SET status = 'closed'"); err != nil {
+		t.Fatalf("ExecLogged across restart: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("ExecLogged returned after %v; expected it to wait for the reconnect", elapsed)
+	}
+	rows, err := p.QueryLogged(ctx, "gastown", "SELECT 1")
+	if err != nil {
+		t.Fatalf("QueryLogged after reconnect: %v", err)
+	}
+	rows.Close()
+
+	out := logBuf.String()
+	if !strings.Contains(out, "lost connection to port 3307") || !strings.Contains(out, "reconnected to port 3307") {
+		t.Errorf("reconnect not logged: %q", out)
+	}
+}
+
+func TestDoltPool_HealthCheckStartsReconnect(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "2s"})
+	if _, err := p.DB("hq"); err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+
+	drv.setDown(true)
+	p.checkHealth()
+
+	// Queries issued during the reconnect window wait for it.
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.ExecLogged(context.Background(), "hq", "SELECT 1")
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	drv.setDown(false)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("ExecLogged during reconnect: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ExecLogged did not return after the server came back")
+	}
+	if !strings.Contains(logBuf.String(), "health check failed for hq") {
+		t.Errorf("failed health check not logged: %q", logBuf.String())
+	}
+}
+
+func TestDoltPool_ReconnectWaitBoundedByCmdTimeout(t *testing.T) {
+	p, drv, _ := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "100ms"})
+	if _, err := p.DB("hq"); err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+
+	// The server stays down: the caller gets an error once cmd_timeout passes.
+	drv.setDown(true)
+	start := time.Now()
+	if _, err := p.ExecLogged(context.Background(), "hq", "SELECT 1"); err == nil {
+		t.Fatal("ExecLogged succeeded with the server down")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ExecLogged took %v; wait not bounded by cmd_timeout", elapsed)
+	}
+}
+
+func TestStartupDrift_OnlyStartupThresholds(t *testing.T) {
+	started := &config.OperationalConfig{}
+	maxConns := 8
+	current := &config.OperationalConfig{
+		Dolt:   &config.DoltThresholds{MaxConnections: &maxConns},
+		Daemon: &config.DaemonThresholds{MassDeathWindow: "90s"},
+	}
+
+	drift := StartupDrift(started, current)
+	if len(drift) != 1 || drift[0].Path != "dolt.max_connections" || drift[0].New != "8" {
+		t.Fatalf("StartupDrift = %+v, want only dolt.max_connections -> 8", drift)
+	}
+}
+
+func TestStartupOperationalPaths_Exist(t *testing.T) {
+	known := make(map[string]bool)
+	for _, v := range config.EffectiveOperationalValues(nil) {
+		known[v.Path] = true
+	}
+	for path := range startupOperationalPaths {
+		if !known[path] {
+			t.Errorf("startupOperationalPaths has unknown threshold %q", path)
+		}
+	}
+}
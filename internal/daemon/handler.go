@@ -123,6 +123,9 @@ func (d *Daemon) detectStaleWorkingDogs(mgr *dog.Manager, sm *dog.SessionManager
 	}
 
 	threshold := daemonCfg.StaleWorkingTimeoutD()
+	if threshold == config.DurationDisabled {
+		return
+	}
 	now := time.Now()
 	for _, dg := range dogs {
 		if dg.State != dog.StateWorking {
@@ -180,7 +183,7 @@ func (d *Daemon) reapIdleDogs(mgr *dog.Manager, sm *dog.SessionManager, daemonCf
 		idleDuration := now.Sub(dg.LastActive)
 
 		// Phase 1: kill stale tmux sessions for idle dogs.
-		if idleDuration >= idleSessionTimeout {
+		if idleSessionTimeout != config.DurationDisabled && idleDuration >= idleSessionTimeout {
 			running, err := sm.IsRunning(dg.Name)
 			if err != nil {
 				d.logger.Printf("Handler: error checking session for idle dog %s: %v", dg.Name, err)
@@ -195,7 +198,7 @@ func (d *Daemon) reapIdleDogs(mgr *dog.Manager, sm *dog.SessionManager, daemonCf
 		}
 
 		// Phase 2: remove long-idle dogs when pool is oversized.
-		if poolSize > poolMax && idleDuration >= idleRemoveTimeout {
+		if poolSize > poolMax && idleRemoveTimeout != config.DurationDisabled && idleDuration >= idleRemoveTimeout {
 			d.logger.Printf("Handler: removing long-idle dog %s from kennel (idle %v, pool %d/%d)",
 				dg.Name, idleDuration.Truncate(time.Minute), poolSize, poolMax)
 
@@ -370,8 +373,9 @@ func (d *Daemon) loadRigsConfig() (*config.RigsConfig, error) {
 	return config.LoadRigsConfig(rigsPath)
 }
 
-// loadOperationalConfig loads operational thresholds from town settings.
+// loadOperationalConfig loads operational thresholds from the machine-wide
+// settings with town settings layered on top.
 // Returns a valid (never nil) config — accessors return defaults for nil fields.
 func (d *Daemon) loadOperationalConfig() *config.OperationalConfig {
-	return config.LoadOperationalConfig(d.config.TownRoot)
+	return config.LoadOperationalConfigLayered(d.config.TownRoot)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
 	gtgit "github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -74,7 +75,7 @@ func (d *Daemon) ProcessLifecycleRequests() {
 
 		// Check message age - ignore stale lifecycle requests
 		maxAge := d.loadOperationalConfig().GetDaemonConfig().MaxLifecycleMessageAgeD()
-		if msgTime, err := time.Parse(time.RFC3339, msg.Timestamp); err == nil {
+		if msgTime, err := time.Parse(time.RFC3339, msg.Timestamp); err == nil && maxAge != config.DurationDisabled {
 			age := time.Since(msgTime)
 			if age > maxAge {
 				d.logger.Printf("Ignoring stale lifecycle request from %s (age: %v, max: %v) - deleting",
@@ -629,6 +630,11 @@ func (d *Daemon) syncWorkspace(workDir string) {
 		d.logger.Printf("Error: refusing daemon git sync in unsafe workdir %s: %v", workDir, err)
 		return
 	}
+	if d.syncHalted[workDir] {
+		d.logger.Printf("Warning: git sync halted for %s after %d consecutive failures (restart the daemon to resume)",
+			workDir, d.getSyncFailures(workDir))
+		return
+	}
 
 	// Determine default branch from rig config
 	// workDir is like <townRoot>/<rigName>/<role>/rig or <townRoot>/<rigName>/crew/<name>
@@ -701,13 +707,7 @@ func (d *Daemon) syncWorkspace(workDir string) {
 			errMsg = err.Error()
 		}
 		d.recordSyncFailure(workDir)
-		failures := d.getSyncFailures(workDir)
-		escalationThreshold := d.loadOperationalConfig().GetDaemonConfig().SyncFailureEscalationThresholdV()
-		if failures >= escalationThreshold {
-			d.logger.Printf("Error: git pull repeatedly failing in %s (%d consecutive failures): %s", workDir, failures, errMsg)
-		} else {
-			d.logger.Printf("Warning: git pull failed in %s (%d consecutive failure(s)): %s", workDir, failures, errMsg)
-		}
+		d.handleSyncFailure(workDir, errMsg)
 	} else {
 		// Pull succeeded - reset failure counter
 		d.resetSyncFailures(workDir)
@@ -748,6 +748,34 @@ func (d *Daemon) isWorkingTreeDirty(workDir string) bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
+// handleSyncFailure applies the escalation stage for the workdir's current
+// consecutive failure count (see DaemonThresholds.SyncFailureStages).
+// Below the first stage, failures are logged as warnings.
+func (d *Daemon) handleSyncFailure(workDir, errMsg string) {
+	failures := d.getSyncFailures(workDir)
+	stages := d.loadOperationalConfig().GetDaemonConfig().SyncFailureStagesV()
+	stage, ok := config.ActiveEscalationStage(stages, failures)
+	if !ok || stage.Action == config.EscalationActionWarn {
+		d.logger.Printf("Warning: git pull failed in %s (%d consecutive failure(s)): %s", workDir, failures, errMsg)
+		return
+	}
+
+	d.logger.Printf("Error: git pull repeatedly failing in %s (%d consecutive failures): %s", workDir, failures, errMsg)
+	switch stage.Action {
+	case config.EscalationActionPage:
+		// Page once on entering the stage, not on every failure within it.
+		if failures == stage.Count {
+			d.escalate("sync", fmt.Sprintf("git pull failing in %s (%d consecutive failures): %s", workDir, failures, errMsg))
+		}
+	case config.EscalationActionHalt:
+		if d.syncHalted == nil {
+			d.syncHalted = make(map[string]bool)
+		}
+		d.syncHalted[workDir] = true
+		d.logger.Printf("Error: halting git sync for %s until the daemon restarts", workDir)
+	}
+}
+
 // recordSyncFailure increments the consecutive failure counter for a workdir.
 func (d *Daemon) recordSyncFailure(workDir string) {
 	if d.syncFailures == nil {
@@ -1101,6 +1129,8 @@ func (d *Daemon) checkRigGUPPViolations(rigName string) {
 	rigPrefix := config.GetRigPrefix(d.config.TownRoot, rigName)
 	// Pattern: <prefix>-<rig>-polecat-<name>
 	prefix := rigPrefix + "-" + rigName + "-polecat-"
+	timeout := d.loadOperationalConfig().GetSessionConfig().GUPPViolationTimeoutD()
+	violating := make(map[string]bool)
 	for _, agent := range agents {
 		// Only check polecats for this rig
 		if !strings.HasPrefix(agent.ID, prefix) {
@@ -1135,15 +1165,81 @@ func (d *Daemon) checkRigGUPPViolations(rigName string) {
 			}
 
 			age := time.Since(updatedAt)
-			if age > GUPPViolationTimeout {
+			if age > timeout {
+				violating[agent.ID] = true
 				d.logger.Printf("GUPP violation: agent %s has hook_bead=%s but hasn't updated in %v (timeout: %v)",
-					agent.ID, agent.HookBead, age.Round(time.Minute), GUPPViolationTimeout)
+					agent.ID, agent.HookBead, age.Round(time.Minute), timeout)
+				if d.markGUPPReported(agent.ID, agent.HookBead) {
+					d.recordGUPPViolation(sessionName, agent.ID, agent.HookBead, age, timeout)
+				}
 
 				// Notify the witness for this rig
 				d.notifyWitnessOfGUPP(rigName, agent.ID, agent.HookBead, age)
 			}
 		}
 	}
+	d.clearGUPPReported(prefix, violating)
+}
+
+// markGUPPReported records that agentID's GUPP violation on hookBead has been
+// reported. It returns false if that violation was already reported, so the
+// gupp_violation event is not repeated every heartbeat.
+func (d *Daemon) markGUPPReported(agentID, hookBead string) bool {
+	if reported, ok := d.guppReported[agentID]; ok && reported == hookBead {
+		return false
+	}
+	if d.guppReported == nil {
+		d.guppReported = make(map[string]string)
+	}
+	d.guppReported[agentID] = hookBead
+	return true
+}
+
+// clearGUPPReported forgets the reported violations of agents under prefix
+// that are no longer violating, so a later violation is reported again.
+func (d *Daemon) clearGUPPReported(prefix string, violating map[string]bool) {
+	for agentID := range d.guppReported {
+		if strings.HasPrefix(agentID, prefix) && !violating[agentID] {
+			delete(d.guppReported, agentID)
+		}
+	}
+}
+
+// guppPaneLines is how many pane lines a gupp_violation event captures.
+const guppPaneLines = 30
+
+// guppCaptureTimeout bounds the pane capture for a gupp_violation event.
+// Replaced in tests.
+var guppCaptureTimeout = 5 * time.Second
+
+// captureGUPPPane captures the offending session's pane. Replaced in tests.
+var captureGUPPPane = func(t *tmux.Tmux, session string, lines int) (string, error) {
+	return t.CapturePane(session, lines)
+}
+
+// recordGUPPViolation writes a gupp_violation event with the session's last
+// pane output for debugging. The capture is best-effort: if it fails or takes
+// longer than guppCaptureTimeout, the event is written without it.
+func (d *Daemon) recordGUPPViolation(sessionName, agentID, hookBead string, elapsed, timeout time.Duration) {
+	capture := captureGUPPPane
+	done := make(chan string, 1)
+	go func() {
+		pane, err := capture(d.tmux, sessionName, guppPaneLines)
+		if err != nil {
+			d.logger.Printf("GUPP violation: capturing pane of %s: %v", sessionName, err)
+		}
+		done <- pane
+	}()
+
+	var pane string
+	select {
+	case pane = <-done:
+	case <-time.After(guppCaptureTimeout):
+		d.logger.Printf("GUPP violation: capturing pane of %s timed out after %v", sessionName, guppCaptureTimeout)
+	}
+
+	d.logFeed(events.TypeGUPPViolation, "daemon",
+		events.GUPPViolationPayload(sessionName, agentID, hookBead, elapsed, timeout, pane))
 }
 
 // notifyWitnessOfGUPP sends a mail to the rig's witness about a GUPP violation.
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"os"
@@ -9,10 +10,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 )
 
 // testDaemon creates a minimal Daemon for testing.
@@ -339,6 +343,48 @@ func TestSyncFailureEscalationThreshold(t *testing.T) {
 	}
 }
 
+func TestHandleSyncFailure_Stages(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	settings := `{"operational": {"daemon": {"sync_failure_stages": [
+		{"count": 4, "action": "halt"},
+		{"count": 2, "action": "error"}
+	]}}}`
+	if err := os.MkdirAll(filepath.Join(townRoot, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "settings", "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var logs strings.Builder
+	d := &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(&logs, "", 0),
+	}
+	workDir := t.TempDir()
+
+	wantPrefixes := []string{"Warning:", "Error:", "Error:", "Error:"}
+	for i, want := range wantPrefixes {
+		logs.Reset()
+		d.recordSyncFailure(workDir)
+		d.handleSyncFailure(workDir, "boom")
+		if !strings.HasPrefix(logs.String(), want) {
+			t.Errorf("failure %d: log = %q, want prefix %q", i+1, logs.String(), want)
+		}
+	}
+	if !d.syncHalted[workDir] {
+		t.Fatal("expected sync to be halted after reaching the halt stage")
+	}
+
+	// A halted workdir is skipped without running git.
+	logs.Reset()
+	d.syncWorkspace(workDir)
+	if !strings.Contains(logs.String(), "git sync halted") {
+		t.Errorf("syncWorkspace on halted workdir logged %q, want halt notice", logs.String())
+	}
+}
+
 func TestIsWorkingTreeDirty(t *testing.T) {
 	d := testDaemon()
 
@@ -574,3 +620,97 @@ func TestGetStartCommand_ClaudeAgentFallsThrough(t *testing.T) {
 		t.Errorf("getStartCommand returned literal TOML start_command verbatim — beacon injection was skipped: %q", startCmd)
 	}
 }
+
+func TestRecordGUPPViolation_EventShape(t *testing.T) {
+	d, cleanup := testDaemonWithTown(t, "test")
+	defer cleanup()
+	t.Chdir(d.config.TownRoot)
+
+	origCapture, origTimeout := captureGUPPPane, guppCaptureTimeout
+	t.Cleanup(func() { captureGUPPPane, guppCaptureTimeout = origCapture, origTimeout })
+	guppCaptureTimeout = 50 * time.Millisecond
+
+	release := make(chan struct{})
+	defer close(release)
+	tests := []struct {
+		name    string
+		capture func(*tmux.Tmux, string, int) (string, error)
+		pane    string
+	}{
+		{"captured", func(_ *tmux.Tmux, session string, lines int) (string, error) {
+			if session != "gt-Toast" || lines != guppPaneLines {
+				t.Errorf("capture(%q, %d)", session, lines)
+			}
+			return "Thinking...\n> ", nil
+		}, "Thinking...\n> "},
+		{"capture fails", func(*tmux.Tmux, string, int) (string, error) {
+			return "", errors.New("no server running")
+		}, ""},
+		{"capture hangs", func(*tmux.Tmux, string, int) (string, error) {
+			<-release
+			return "too late", nil
+		}, ""},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captureGUPPPane = tt.capture
+			d.recordGUPPViolation("gt-Toast", "gt-gastown-polecat-Toast", "gt-abc", 42*time.Minute, 30*time.Minute)
+
+			raw, err := os.ReadFile(filepath.Join(d.config.TownRoot, events.EventsFile))
+			if err != nil {
+				t.Fatalf("read events log: %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+			if len(lines) != i+1 {
+				t.Fatalf("event count = %d, want %d", len(lines), i+1)
+			}
+			var event events.Event
+			if err := json.Unmarshal([]byte(lines[i]), &event); err != nil {
+				t.Fatalf("unmarshal event: %v", err)
+			}
+			if event.Type != events.TypeGUPPViolation || event.Visibility != events.VisibilityFeed {
+				t.Errorf("event = %s/%s, want gupp_violation/feed", event.Type, event.Visibility)
+			}
+			want := map[string]interface{}{
+				"session":   "gt-Toast",
+				"agent":     "gt-gastown-polecat-Toast",
+				"hook_bead": "gt-abc",
+				"elapsed":   "42m0s",
+				"timeout":   "30m0s",
+				"pane":      tt.pane,
+			}
+			for k, v := range want {
+				if event.Payload[k] != v {
+					t.Errorf("payload[%s] = %v, want %v", k, event.Payload[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestGUPPReported_OncePerViolation(t *testing.T) {
+	d := &Daemon{}
+	const prefix = "gt-gastown-polecat-"
+
+	if !d.markGUPPReported("gt-gastown-polecat-Toast", "gt-abc") {
+		t.Fatal("first violation should be reported")
+	}
+	if d.markGUPPReported("gt-gastown-polecat-Toast", "gt-abc") {
+		t.Error("ongoing violation should not be reported again")
+	}
+	if !d.markGUPPReported("gt-gastown-polecat-Toast", "gt-def") {
+		t.Error("violation on a new hook bead should be reported")
+	}
+	if !d.markGUPPReported("gt-beads-polecat-Nux", "bd-xyz") {
+		t.Fatal("violation in another rig should be reported")
+	}
+
+	// Toast recovered; the other rig's entry is left alone.
+	d.clearGUPPReported(prefix, map[string]bool{})
+	if !d.markGUPPReported("gt-gastown-polecat-Toast", "gt-def") {
+		t.Error("violation after recovery should be reported again")
+	}
+	if d.markGUPPReported("gt-beads-polecat-Nux", "bd-xyz") {
+		t.Error("clearing one rig should not clear another")
+	}
+}
@@ -27,6 +27,13 @@ const (
 	// daemonDiskBudget is the maximum total size of the daemon/ directory in bytes.
 	// If exceeded, oldest .gz files are deleted until under budget.
 	daemonDiskBudget int64 = 500 * 1024 * 1024 // 500MB
+
+	// eventsRotationMaxSize is the size at which the town's .events.jsonl is
+	// rotated (see events.RotateEvents).
+	eventsRotationMaxSize int64 = 50 * 1024 * 1024
+
+	// eventsRotationKeep is the number of rotated events files to keep.
+	eventsRotationKeep = 3
 )
 
 // staleArchivePattern matches timestamped archive files like dolt-2026-02-28T23-19-42.log.gz
@@ -1,10 +1,14 @@
 package daemon
 
 import (
+	"io"
+	"log"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
 )
 
 func TestCopyTruncateRotate(t *testing.T) {
@@ -87,6 +91,28 @@ func TestRotateLogs_SkipsSmallFiles(t *testing.T) {
 	}
 }
 
+func TestRotateOversizedLogs_RotatesEventsFile(t *testing.T) {
+	townRoot := t.TempDir()
+	eventsPath := filepath.Join(townRoot, events.EventsFile)
+	if err := os.WriteFile(eventsPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Sparse file just over the threshold.
+	if err := os.Truncate(eventsPath, eventsRotationMaxSize+1); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Daemon{config: &Config{TownRoot: townRoot}, logger: log.New(io.Discard, "", 0)}
+	d.rotateOversizedLogs()
+
+	if info, err := os.Stat(eventsPath + ".1"); err != nil || info.Size() != eventsRotationMaxSize+1 {
+		t.Fatalf("rotated events file: %v, %v", info, err)
+	}
+	if info, err := os.Stat(eventsPath); err != nil || info.Size() != 0 {
+		t.Fatalf("fresh events file: %v, %v", info, err)
+	}
+}
+
 func TestForceRotateLogs_RotatesSmallFiles(t *testing.T) {
 	townRoot := t.TempDir()
 	daemonDir := filepath.Join(townRoot, "daemon")
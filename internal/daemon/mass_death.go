@@ -0,0 +1,236 @@
+package daemon
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// MassDeathEvent describes sessions that died together within the mass death
+// window, so postmortems can see whether the deaths clustered by role.
+type MassDeathEvent struct {
+	// Window is the detection window the deaths fell within.
+	Window time.Duration
+
+	// Count is the number of deaths in the window.
+	Count int
+
+	// Deaths lists the dead sessions in the order they were detected.
+	Deaths []MassDeathSession
+}
+
+// MassDeathSession is one session in a MassDeathEvent.
+type MassDeathSession struct {
+	Session string    `json:"session"`
+	Role    string    `json:"role"` // e.g. "polecat", "witness"; "unknown" if unparseable
+	DiedAt  time.Time `json:"died_at"`
+}
+
+// newMassDeathEvent builds the event for deaths detected within window.
+func newMassDeathEvent(deaths []sessionDeath, window time.Duration) *MassDeathEvent {
+	ev := &MassDeathEvent{Window: window, Count: len(deaths)}
+	for _, death := range deaths {
+		ev.Deaths = append(ev.Deaths, MassDeathSession{
+			Session: death.sessionName,
+			Role:    sessionRole(death.sessionName),
+			DiedAt:  death.timestamp,
+		})
+	}
+	return ev
+}
+
+// sessionRole returns the agent role of a tmux session name, or "unknown".
+func sessionRole(sessionName string) string {
+	id, err := session.ParseSessionName(sessionName)
+	if err != nil || id.Role == "" {
+		return "unknown"
+	}
+	return string(id.Role)
+}
+
+// SessionNames returns the names of the dead sessions.
+func (e *MassDeathEvent) SessionNames() []string {
+	names := make([]string, 0, len(e.Deaths))
+	for _, d := range e.Deaths {
+		names = append(names, d.Session)
+	}
+	return names
+}
+
+// RoleCounts returns the number of deaths per role.
+func (e *MassDeathEvent) RoleCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, d := range e.Deaths {
+		counts[d.Role]++
+	}
+	return counts
+}
+
+// payload is the mass_death feed payload: the standard count/window/sessions
+// fields plus the per-session roles and a per-role tally.
+func (e *MassDeathEvent) payload() map[string]interface{} {
+	p := events.MassDeathPayload(e.Count, e.Window.String(), e.SessionNames(), "")
+	p["deaths"] = e.Deaths
+	p["roles"] = e.RoleCounts()
+	return p
+}
+
+// autoRespawnHooks is the subset of *tmux.Tmux used to pause and restore
+// auto-respawn hooks around a mass death.
+type autoRespawnHooks interface {
+	HasSession(name string) (bool, error)
+	ListSessions() ([]string, error)
+	HasAutoRespawnHook(session string) (bool, error)
+	ClearPaneDiedHook(session string) error
+	SetAutoRespawnHook(session string, policy tmux.RespawnPolicy) error
+}
+
+// respawnPause tracks the rig/role groups that suffered a mass death, the
+// live sessions in them whose auto-respawn hooks were cleared, and when the
+// pause ends.
+type respawnPause struct {
+	mu       sync.Mutex
+	groups   map[string]bool
+	sessions map[string]bool
+	until    time.Time
+}
+
+// sessionGroup returns the rig/role group of a tmux session name, e.g.
+// "gastown/polecat" or "/deacon" for town-level agents, or "" if the name
+// cannot be parsed.
+func sessionGroup(sessionName string) string {
+	id, err := session.ParseSessionName(sessionName)
+	if err != nil || id.Role == "" {
+		return ""
+	}
+	return id.Rig + "/" + string(id.Role)
+}
+
+// respawnHookController returns the hooks used for respawn pausing, or nil
+// when the daemon has no tmux.
+func (d *Daemon) respawnHookController() autoRespawnHooks {
+	if d.respawnHooks != nil {
+		return d.respawnHooks
+	}
+	if d.tmux == nil {
+		return nil
+	}
+	return d.tmux
+}
+
+// pauseAutoRespawn pauses respawns in the rig/role groups of the dead
+// sessions, so tmux and the daemon don't restart them all at once and
+// re-trigger whatever killed them. The dead sessions are usually gone by the
+// time a mass death is detected, so the auto-respawn hook is cleared on every
+// live session that shares a group with them; the daemon's own restarts in
+// those groups are held off through respawnPaused. Hooks are restored by
+// resumeAutoRespawn once pause has elapsed; a further mass death during the
+// pause extends it.
+func (d *Daemon) pauseAutoRespawn(dead []string, now time.Time, pause time.Duration) {
+	hooks := d.respawnHookController()
+	if hooks == nil || pause <= 0 {
+		return
+	}
+
+	d.respawnPause.mu.Lock()
+	defer d.respawnPause.mu.Unlock()
+
+	for _, name := range dead {
+		if g := sessionGroup(name); g != "" {
+			if d.respawnPause.groups == nil {
+				d.respawnPause.groups = make(map[string]bool)
+			}
+			d.respawnPause.groups[g] = true
+		}
+	}
+	if len(d.respawnPause.groups) == 0 {
+		return
+	}
+	d.respawnPause.until = now.Add(pause)
+
+	live, err := hooks.ListSessions()
+	if err != nil {
+		d.logger.Printf("Warning: failed to list sessions to pause auto-respawn: %v", err)
+	}
+	sort.Strings(live)
+
+	var paused []string
+	for _, name := range live {
+		if d.respawnPause.sessions[name] || !d.respawnPause.groups[sessionGroup(name)] {
+			continue
+		}
+		if has, err := hooks.HasAutoRespawnHook(name); err != nil || !has {
+			continue
+		}
+		if err := hooks.ClearPaneDiedHook(name); err != nil {
+			d.logger.Printf("Warning: failed to pause auto-respawn for %s: %v", name, err)
+			continue
+		}
+		if d.respawnPause.sessions == nil {
+			d.respawnPause.sessions = make(map[string]bool)
+		}
+		d.respawnPause.sessions[name] = true
+		paused = append(paused, name)
+	}
+
+	d.logger.Printf("Respawns paused for %s after mass death in %v; cleared auto-respawn hooks: %v",
+		pause, sortedKeys(d.respawnPause.groups), paused)
+	d.logFeed(events.TypeRespawnPaused, "daemon", events.RespawnPausePayload(paused, pause.String()))
+}
+
+// respawnPaused reports whether restarts of sessionName are on hold at now
+// because its rig/role group is in a mass-death respawn pause.
+func (d *Daemon) respawnPaused(sessionName string, now time.Time) bool {
+	d.respawnPause.mu.Lock()
+	defer d.respawnPause.mu.Unlock()
+	return now.Before(d.respawnPause.until) && d.respawnPause.groups[sessionGroup(sessionName)]
+}
+
+// resumeAutoRespawn ends the pause started by pauseAutoRespawn once it has
+// elapsed at now, restoring the cleared auto-respawn hooks. Sessions that no
+// longer exist are skipped; whatever recreates them installs a fresh hook.
+func (d *Daemon) resumeAutoRespawn(now time.Time, policy tmux.RespawnPolicy) {
+	hooks := d.respawnHookController()
+	if hooks == nil {
+		return
+	}
+
+	d.respawnPause.mu.Lock()
+	if len(d.respawnPause.groups) == 0 || now.Before(d.respawnPause.until) {
+		d.respawnPause.mu.Unlock()
+		return
+	}
+	pending := sortedKeys(d.respawnPause.sessions)
+	d.respawnPause.groups = nil
+	d.respawnPause.sessions = nil
+	d.respawnPause.mu.Unlock()
+
+	var restored []string
+	for _, name := range pending {
+		if alive, err := hooks.HasSession(name); err != nil || !alive {
+			continue
+		}
+		if err := hooks.SetAutoRespawnHook(name, policy); err != nil {
+			d.logger.Printf("Warning: failed to restore auto-respawn for %s: %v", name, err)
+			continue
+		}
+		restored = append(restored, name)
+	}
+
+	d.logger.Printf("Respawn pause ended, restored hooks: %v", restored)
+	d.logFeed(events.TypeRespawnResumed, "daemon", events.RespawnPausePayload(restored, ""))
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
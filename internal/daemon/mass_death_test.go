@@ -0,0 +1,250 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// registerGastownPrefix makes gt-* session names resolve to the gastown rig.
+func registerGastownPrefix(t *testing.T) {
+	t.Helper()
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	session.SetDefaultRegistry(reg)
+	t.Cleanup(func() { session.SetDefaultRegistry(session.NewPrefixRegistry()) })
+}
+
+func TestDetectMassDeath(t *testing.T) {
+	registerGastownPrefix(t)
+	const window = 30 * time.Second
+	base := time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)
+
+	t.Run("deaths inside window", func(t *testing.T) {
+		d := &Daemon{}
+		if ev := d.detectMassDeath("gt-furiosa", base, window, 3); ev != nil {
+			t.Fatalf("first death should not trigger: %+v", ev)
+		}
+		if ev := d.detectMassDeath("gt-nux", base.Add(10*time.Second), window, 3); ev != nil {
+			t.Fatalf("second death should not trigger: %+v", ev)
+		}
+		ev := d.detectMassDeath("gt-witness", base.Add(20*time.Second), window, 3)
+		if ev == nil {
+			t.Fatal("third death within window should trigger")
+		}
+
+		if ev.Count != 3 || ev.Window != window {
+			t.Errorf("event = count %d window %s, want 3 in %s", ev.Count, ev.Window, window)
+		}
+		want := []MassDeathSession{
+			{Session: "gt-furiosa", Role: "polecat", DiedAt: base},
+			{Session: "gt-nux", Role: "polecat", DiedAt: base.Add(10 * time.Second)},
+			{Session: "gt-witness", Role: "witness", DiedAt: base.Add(20 * time.Second)},
+		}
+		for i, w := range want {
+			if i >= len(ev.Deaths) || ev.Deaths[i] != w {
+				t.Errorf("Deaths = %+v, want %+v", ev.Deaths, want)
+				break
+			}
+		}
+		if roles := ev.RoleCounts(); roles["polecat"] != 2 || roles["witness"] != 1 {
+			t.Errorf("RoleCounts = %v", roles)
+		}
+
+		p := ev.payload()
+		if p["count"] != 3 || p["window"] != "30s" || len(p["sessions"].([]string)) != 3 {
+			t.Errorf("payload = %v", p)
+		}
+
+		// Tracked deaths are cleared so the next death starts a new window.
+		if ev := d.detectMassDeath("gt-slit", base.Add(25*time.Second), window, 3); ev != nil {
+			t.Errorf("death after alert should not re-trigger: %+v", ev)
+		}
+	})
+
+	t.Run("deaths outside window", func(t *testing.T) {
+		d := &Daemon{}
+		for i, name := range []string{"gt-furiosa", "gt-nux", "hq-deacon"} {
+			if ev := d.detectMassDeath(name, base.Add(time.Duration(i)*20*time.Second), window, 3); ev != nil {
+				t.Fatalf("deaths 20s apart should not trigger in a %s window: %+v", window, ev)
+			}
+		}
+		if len(d.recentDeaths) != 2 {
+			t.Errorf("expected the oldest death pruned, have %d tracked", len(d.recentDeaths))
+		}
+	})
+}
+
+func TestSessionRole(t *testing.T) {
+	registerGastownPrefix(t)
+	for name, want := range map[string]string{
+		"hq-deacon":  "deacon",
+		"gt-witness": "witness",
+		"gt-furiosa": "polecat",
+		"":           "unknown",
+	} {
+		if got := sessionRole(name); got != want {
+			t.Errorf("sessionRole(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// fakeRespawnHooks records each live session's pane-died hook: "respawn" for
+// the auto-respawn hook, any other non-empty value for some other hook. Like
+// tmux, hook operations fail for sessions that do not exist.
+type fakeRespawnHooks struct {
+	hooks    map[string]string
+	policies map[string]tmux.RespawnPolicy
+}
+
+func (f *fakeRespawnHooks) HasSession(name string) (bool, error) {
+	_, ok := f.hooks[name]
+	return ok, nil
+}
+
+func (f *fakeRespawnHooks) ListSessions() ([]string, error) {
+	names := make([]string, 0, len(f.hooks))
+	for name := range f.hooks {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (f *fakeRespawnHooks) exists(session string) error {
+	if _, ok := f.hooks[session]; !ok {
+		return fmt.Errorf("can't find session: %s", session)
+	}
+	return nil
+}
+
+func (f *fakeRespawnHooks) HasAutoRespawnHook(session string) (bool, error) {
+	if err := f.exists(session); err != nil {
+		return false, err
+	}
+	return f.hooks[session] == "respawn", nil
+}
+
+func (f *fakeRespawnHooks) ClearPaneDiedHook(session string) error {
+	if err := f.exists(session); err != nil {
+		return err
+	}
+	f.hooks[session] = ""
+	return nil
+}
+
+func (f *fakeRespawnHooks) SetAutoRespawnHook(session string, policy tmux.RespawnPolicy) error {
+	if err := f.exists(session); err != nil {
+		return err
+	}
+	f.hooks[session] = "respawn"
+	f.policies[session] = policy
+	return nil
+}
+
+func TestMassDeathPausesAutoRespawn(t *testing.T) {
+	registerGastownPrefix(t)
+	const (
+		window = 30 * time.Second
+		pause  = 5 * time.Minute
+	)
+	base := time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)
+	policy := tmux.RespawnPolicy{MaxRespawns: 5, Window: 10 * time.Minute, Delay: 3 * time.Second}
+
+	// The dead polecats are already gone; their live siblings are not.
+	fake := &fakeRespawnHooks{
+		hooks: map[string]string{
+			"gt-capable": "respawn",
+			"gt-rictus":  "respawn",
+			"gt-toast":   "crash-log",
+			"gt-witness": "respawn",
+		},
+		policies: map[string]tmux.RespawnPolicy{},
+	}
+	d := &Daemon{logger: log.New(io.Discard, "", 0), respawnHooks: fake}
+
+	var ev *MassDeathEvent
+	for i, name := range []string{"gt-furiosa", "gt-nux", "gt-slit"} {
+		ev = d.detectMassDeath(name, base.Add(time.Duration(i)*time.Second), window, 3)
+	}
+	if ev == nil {
+		t.Fatal("expected a mass death")
+	}
+	d.pauseAutoRespawn(ev.SessionNames(), base, pause)
+
+	if fake.hooks["gt-capable"] != "" || fake.hooks["gt-rictus"] != "" {
+		t.Errorf("sibling auto-respawn hooks not cleared: %v", fake.hooks)
+	}
+	if fake.hooks["gt-toast"] != "crash-log" {
+		t.Errorf("non-respawn hook was touched: %q", fake.hooks["gt-toast"])
+	}
+	if fake.hooks["gt-witness"] != "respawn" {
+		t.Errorf("hook in another rig/role group was cleared")
+	}
+	if !d.respawnPaused("gt-furiosa", base.Add(time.Minute)) {
+		t.Error("daemon restarts of a dead polecat should be paused")
+	}
+	if d.respawnPaused("gt-witness", base.Add(time.Minute)) {
+		t.Error("daemon restarts in another group should not be paused")
+	}
+
+	// Still in cooldown: nothing is restored.
+	d.resumeAutoRespawn(base.Add(pause-time.Second), policy)
+	if fake.hooks["gt-capable"] != "" || len(fake.policies) != 0 {
+		t.Errorf("hooks restored before the pause ended: %v", fake.hooks)
+	}
+
+	// gt-rictus is gone by the end of the pause; it is not revived.
+	delete(fake.hooks, "gt-rictus")
+	d.resumeAutoRespawn(base.Add(pause), policy)
+	if fake.hooks["gt-capable"] != "respawn" || fake.policies["gt-capable"] != policy {
+		t.Errorf("gt-capable hook = %q policy %+v, want respawn hook with %+v",
+			fake.hooks["gt-capable"], fake.policies["gt-capable"], policy)
+	}
+	if _, ok := fake.hooks["gt-rictus"]; ok {
+		t.Error("hook restored on a session that no longer exists")
+	}
+	if fake.hooks["gt-toast"] != "crash-log" {
+		t.Errorf("non-respawn hook was touched on resume: %q", fake.hooks["gt-toast"])
+	}
+	if d.respawnPaused("gt-furiosa", base.Add(pause)) {
+		t.Error("daemon restarts still paused after the pause ended")
+	}
+
+	// The pause is over: a later resume is a no-op.
+	delete(fake.policies, "gt-capable")
+	d.resumeAutoRespawn(base.Add(2*pause), policy)
+	if len(fake.policies) != 0 {
+		t.Errorf("hooks restored twice: %v", fake.policies)
+	}
+}
+
+func TestMassDeathPauseExtendsOnRepeat(t *testing.T) {
+	registerGastownPrefix(t)
+	const pause = 5 * time.Minute
+	base := time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)
+	fake := &fakeRespawnHooks{
+		hooks:    map[string]string{"gt-capable": "respawn"},
+		policies: map[string]tmux.RespawnPolicy{},
+	}
+	d := &Daemon{logger: log.New(io.Discard, "", 0), respawnHooks: fake}
+
+	d.pauseAutoRespawn([]string{"gt-furiosa"}, base, pause)
+	d.pauseAutoRespawn([]string{"gt-nux"}, base.Add(3*time.Minute), pause)
+
+	d.resumeAutoRespawn(base.Add(pause), tmux.RespawnPolicy{})
+	if len(fake.policies) != 0 {
+		t.Errorf("second mass death should extend the pause, restored %v", fake.policies)
+	}
+	if !d.respawnPaused("gt-furiosa", base.Add(pause)) {
+		t.Error("extended pause should still hold daemon restarts")
+	}
+	d.resumeAutoRespawn(base.Add(3*time.Minute+pause), tmux.RespawnPolicy{})
+	if len(fake.policies) != 1 {
+		t.Errorf("restored %v, want gt-capable", fake.policies)
+	}
+}
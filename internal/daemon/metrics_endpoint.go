@@ -0,0 +1,227 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/dog"
+)
+
+// DefaultMetricsListen is the bind address of the metrics endpoint when
+// metrics.listen is not set. Loopback-only, like the proxy admin server.
+const DefaultMetricsListen = "127.0.0.1:9464"
+
+// MetricsEndpointConfig holds configuration for the Prometheus-style metrics
+// endpoint. Opt-in: the daemon serves nothing unless enabled.
+type MetricsEndpointConfig struct {
+	Enabled bool   `json:"enabled"`
+	Listen  string `json:"listen,omitempty"` // host:port, default DefaultMetricsListen
+}
+
+// metricsListenAddr returns the configured bind address, or "" if the
+// endpoint is disabled.
+func metricsListenAddr(config *DaemonPatrolConfig) string {
+	if config == nil || config.Metrics == nil || !config.Metrics.Enabled {
+		return ""
+	}
+	if config.Metrics.Listen != "" {
+		return config.Metrics.Listen
+	}
+	return DefaultMetricsListen
+}
+
+// metricSample is one value of a metric family, with at most one label.
+type metricSample struct {
+	label      string
+	labelValue string
+	value      float64
+}
+
+// metricFamily is a gauge whose samples are collected at scrape time.
+type metricFamily struct {
+	name    string
+	help    string
+	collect func() []metricSample
+}
+
+// metricsRegistry serves registered gauges in the Prometheus text exposition
+// format. Collectors run on the HTTP goroutine, so they must only read state
+// that is safe for concurrent access (mutex-guarded accessors or files).
+type metricsRegistry struct {
+	mu       sync.Mutex
+	families []metricFamily
+}
+
+// gauge registers a metric family. Families are served in name order.
+func (r *metricsRegistry) gauge(name, help string, collect func() []metricSample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, metricFamily{name: name, help: help, collect: collect})
+	sort.Slice(r.families, func(i, j int) bool { return r.families[i].name < r.families[j].name })
+}
+
+// value is a collector for an unlabelled gauge.
+func value(f func() float64) func() []metricSample {
+	return func() []metricSample { return []metricSample{{value: f()}} }
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (r *metricsRegistry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r.mu.Lock()
+	families := append([]metricFamily(nil), r.families...)
+	r.mu.Unlock()
+
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", f.name, f.help, f.name)
+		for _, s := range f.collect() {
+			if s.label != "" {
+				fmt.Fprintf(&b, "%s{%s=\"%s\"} %g\n", f.name, s.label, labelEscaper.Replace(s.labelValue), s.value)
+			} else {
+				fmt.Fprintf(&b, "%s %g\n", f.name, s.value)
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// newTownMetricsRegistry registers the town health gauges. Every value is
+// derived from existing state at scrape time: the last wisp_reaper result,
+// the kennel, tmux, and the deacon's health-check and redispatch state files.
+func (d *Daemon) newTownMetricsRegistry() *metricsRegistry {
+	r := &metricsRegistry{}
+
+	wispCount := func(pick func(WispReaperCounts) int) func() []metricSample {
+		return func() []metricSample {
+			res := d.LastWispReaperResult()
+			if res == nil {
+				return nil
+			}
+			return []metricSample{{value: float64(pick(res.Totals))}}
+		}
+	}
+	r.gauge("gastown_wisps_open", "Open wisps remaining after the last wisp_reaper cycle.",
+		wispCount(func(c WispReaperCounts) int { return c.Open }))
+	r.gauge("gastown_wisps_reaped", "Wisps reaped by the last wisp_reaper cycle.",
+		wispCount(func(c WispReaperCounts) int { return c.Reaped }))
+
+	r.gauge("gastown_dog_pool_size", "Dogs in the kennel.", func() []metricSample {
+		rigsConfig, err := d.loadRigsConfig()
+		if err != nil {
+			return nil
+		}
+		dogs, err := dog.NewManager(d.config.TownRoot, rigsConfig).List()
+		if err != nil {
+			return nil
+		}
+		return []metricSample{{value: float64(len(dogs))}}
+	})
+	r.gauge("gastown_dog_pool_max", "Target dog pool size (max_dog_pool_size).", value(func() float64 {
+		return float64(d.loadOperationalConfig().GetDaemonConfig().MaxDogPoolSizeV())
+	}))
+
+	r.gauge("gastown_agent_sessions", "Active Gas Town agent tmux sessions.", value(func() float64 {
+		return float64(d.countAgentSessions())
+	}))
+
+	r.gauge("gastown_deacon_consecutive_failures", "Consecutive failed deacon health checks, by agent.", func() []metricSample {
+		state, err := deacon.LoadHealthCheckState(d.config.TownRoot)
+		if err != nil {
+			return nil
+		}
+		var samples []metricSample
+		for id, a := range state.Agents {
+			samples = append(samples, metricSample{label: "agent", labelValue: id, value: float64(a.ConsecutiveFailures)})
+		}
+		sort.Slice(samples, func(i, j int) bool { return samples[i].labelValue < samples[j].labelValue })
+		return samples
+	})
+
+	redispatch := func(pick func(*deacon.BeadRedispatchState) int) func() []metricSample {
+		return func() []metricSample {
+			state, err := deacon.LoadRedispatchState(d.config.TownRoot)
+			if err != nil {
+				return nil
+			}
+			total := 0
+			for _, b := range state.Beads {
+				total += pick(b)
+			}
+			return []metricSample{{value: float64(total)}}
+		}
+	}
+	r.gauge("gastown_redispatch_attempts", "Re-dispatch attempts for tracked recovered beads.",
+		redispatch(func(b *deacon.BeadRedispatchState) int { return b.AttemptCount }))
+	r.gauge("gastown_redispatch_escalated", "Recovered beads escalated to the Mayor.",
+		redispatch(func(b *deacon.BeadRedispatchState) int {
+			if b.Escalated {
+				return 1
+			}
+			return 0
+		}))
+
+	r.gauge("gastown_patrol_last_run_timestamp_seconds", "Unix time of each patrol's last run; patrols that have not run are omitted.", func() []metricSample {
+		var samples []metricSample
+		for _, p := range d.DaemonStatus() {
+			if p.NeverRun() {
+				continue
+			}
+			samples = append(samples, metricSample{label: "patrol", labelValue: p.Name, value: float64(p.LastRun.Unix())})
+		}
+		return samples
+	})
+
+	return r
+}
+
+// startMetricsServer starts the metrics endpoint if enabled in daemon.json.
+// Failure to bind is logged, not fatal: metrics are an operator convenience.
+func (d *Daemon) startMetricsServer() {
+	addr := metricsListenAddr(d.patrolConfig)
+	if addr == "" {
+		return
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		d.logger.Printf("Warning: metrics endpoint disabled: listen %s: %v", addr, err)
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", d.newTownMetricsRegistry())
+	d.metricsServer = &http.Server{
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	srv := d.metricsServer
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.logger.Printf("Metrics endpoint error: %v", err)
+		}
+	}()
+	d.logger.Printf("Metrics endpoint listening on http://%s/metrics", ln.Addr())
+}
+
+// stopMetricsServer shuts down the metrics endpoint, if running.
+func (d *Daemon) stopMetricsServer() {
+	if d.metricsServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.metricsServer.Shutdown(ctx); err != nil {
+		d.logger.Printf("Warning: metrics endpoint shutdown: %v", err)
+	}
+	d.metricsServer = nil
+}
@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMetricsRegistry_ServesTextFormat(t *testing.T) {
+	r := &metricsRegistry{}
+	r.gauge("b_metric", "Second.", value(func() float64 { return 2.5 }))
+	r.gauge("a_metric", "First.", func() []metricSample {
+		return []metricSample{{label: "agent", labelValue: `say "hi"`, value: 3}}
+	})
+	r.gauge("c_empty", "No samples.", func() []metricSample { return nil })
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	want := `# HELP a_metric First.
+# TYPE a_metric gauge
+a_metric{agent="say \"hi\""} 3
+# HELP b_metric Second.
+# TYPE b_metric gauge
+b_metric 2.5
+# HELP c_empty No samples.
+# TYPE c_empty gauge
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTownMetricsRegistry(t *testing.T) {
+	townRoot := t.TempDir()
+	deaconDir := filepath.Join(townRoot, "deacon")
+	if err := os.MkdirAll(deaconDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(deaconDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("health-check-state.json", `{"agents": {"gastown/witness": {"agent_id": "gastown/witness", "consecutive_failures": 2}}}`)
+	writeFile("redispatch-state.json", `{"beads": {"gt-1": {"bead_id": "gt-1", "attempt_count": 3, "escalated": true}, "gt-2": {"bead_id": "gt-2", "attempt_count": 1}}}`)
+
+	d := &Daemon{config: &Config{TownRoot: townRoot}, logger: log.New(&bytes.Buffer{}, "", 0)}
+	res := newWispReaperResult([]string{"hq"}, false)
+	res.db("hq").Reaped = 7
+	res.db("hq").Open = 40
+	res.finish()
+	d.recordWispReaperResult(res, false)
+	d.runPatrol("wisp_reaper", func() {})
+
+	srv := httptest.NewServer(d.newTownMetricsRegistry())
+	defer srv.Close()
+
+	// Scrape concurrently with patrol activity; run with -race to check the wiring.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			d.runPatrol("molecule_reaper", func() {})
+			d.recordWispReaperResult(res, false)
+		}
+	}()
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	wg.Wait()
+
+	for _, line := range []string{
+		"gastown_wisps_open 40",
+		"gastown_wisps_reaped 7",
+		"gastown_dog_pool_max 4",
+		`gastown_deacon_consecutive_failures{agent="gastown/witness"} 2`,
+		"gastown_redispatch_attempts 4",
+		"gastown_redispatch_escalated 1",
+		`gastown_patrol_last_run_timestamp_seconds{patrol="wisp_reaper"}`,
+	} {
+		if !strings.Contains(string(body), line) {
+			t.Errorf("metrics missing %q:\n%s", line, body)
+		}
+	}
+}
+
+func TestMetricsListenAddr(t *testing.T) {
+	if got := metricsListenAddr(nil); got != "" {
+		t.Errorf("nil config: %q, want disabled", got)
+	}
+	if got := metricsListenAddr(&DaemonPatrolConfig{Metrics: &MetricsEndpointConfig{Listen: ":9000"}}); got != "" {
+		t.Errorf("not enabled: %q, want disabled", got)
+	}
+	if got := metricsListenAddr(&DaemonPatrolConfig{Metrics: &MetricsEndpointConfig{Enabled: true}}); got != DefaultMetricsListen {
+		t.Errorf("default: %q", got)
+	}
+	if got := metricsListenAddr(&DaemonPatrolConfig{Metrics: &MetricsEndpointConfig{Enabled: true, Listen: ":9000"}}); got != ":9000" {
+		t.Errorf("configured: %q", got)
+	}
+}
@@ -0,0 +1,112 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+const (
+	// defaultMoleculeReaperInterval is the patrol interval. Abandoned molecules
+	// accumulate slowly, so this runs less often than the wisp reaper.
+	defaultMoleculeReaperInterval = 6 * time.Hour
+	// Open molecules not updated for this long (and with no live steps) are closed.
+	defaultMoleculeMaxAge = 7 * 24 * time.Hour
+)
+
+// MoleculeReaperConfig holds configuration for the molecule_reaper patrol,
+// which closes abandoned molecules: open molecule issues with no live child
+// steps and no updates for max_age. Shares its SQL core with wisp_reaper.
+type MoleculeReaperConfig struct {
+	Enabled     bool     `json:"enabled"`
+	DryRun      bool     `json:"dry_run,omitempty"`
+	IntervalStr string   `json:"interval,omitempty"`
+	MaxAgeStr   string   `json:"max_age,omitempty"`
+	Databases   []string `json:"databases,omitempty"`
+}
+
+// moleculeReaperInterval returns the configured interval, or the default (6h).
+func moleculeReaperInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.MoleculeReaper != nil {
+		if config.Patrols.MoleculeReaper.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.MoleculeReaper.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultMoleculeReaperInterval
+}
+
+// moleculeReaperMaxAge returns the configured max age, or the default (7d).
+func moleculeReaperMaxAge(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.MoleculeReaper != nil {
+		if config.Patrols.MoleculeReaper.MaxAgeStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.MoleculeReaper.MaxAgeStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultMoleculeMaxAge
+}
+
+// reapMolecules closes abandoned molecules across all databases. Runs inline:
+// there is no Dog formula for it, and the work is a handful of batched UPDATEs.
+func (d *Daemon) reapMolecules() {
+	if !d.isPatrolActive("molecule_reaper") {
+		return
+	}
+	config := d.patrolConfig.Patrols.MoleculeReaper
+
+	port, source := d.resolveDoltPort()
+	retry := newReaperRetryPolicy(d.loadOperationalConfig().GetPolecatConfig())
+	if err := retry.do(func() error { return dialDolt(port) }); err != nil {
+		d.logger.Printf("molecule_reaper: Dolt server unreachable at 127.0.0.1:%d (port from %s), skipping cycle: %v", port, source, err)
+		return
+	}
+
+	databases := config.Databases
+	if len(databases) == 0 {
+		databases = reaper.DiscoverDatabases("127.0.0.1", port)
+	}
+	maxAge := moleculeReaperMaxAge(d.patrolConfig)
+	d.reapMoleculesIn(databases, func(ctx context.Context, dbName string) (*reaper.ReapResult, bool, error) {
+		return d.reapInDB(ctx, retry, port, "molecule_reaper", dbName, func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+			return reaper.ReapMoleculesContext(ctx, db, dbName, maxAge, config.DryRun)
+		})
+	})
+}
+
+// reapMoleculesIn runs reapOne for each valid database in turn, each under
+// its own wispReaperQueryTimeout context, and logs the outcome. Returns the
+// total number of molecules reaped.
+func (d *Daemon) reapMoleculesIn(databases []string, reapOne reapDBFunc) int {
+	total, open, failed := 0, 0, 0
+	for _, dbName := range databases {
+		if reaper.ValidateDBName(dbName) != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), wispReaperQueryTimeout)
+		result, skipped, err := reapOne(ctx, dbName)
+		cancel()
+		switch {
+		case err != nil:
+			d.logger.Printf("molecule_reaper: %s: reap error: %v", dbName, err)
+			failed++
+		case skipped:
+			d.logger.Printf("molecule_reaper: %s: skipped (no reaper schema)", dbName)
+		case result != nil:
+			total += result.Reaped
+			open += result.OpenRemain
+			if result.Reaped > 0 {
+				verb := "closed"
+				if result.DryRun {
+					verb = "would close"
+				}
+				d.logger.Printf("molecule_reaper: %s: %s %d abandoned molecules, %d open remain", dbName, verb, result.Reaped, result.OpenRemain)
+			}
+		}
+	}
+	d.logger.Printf("molecule_reaper: cycle complete — reaped=%d open=%d databases=%d errors=%d", total, open, len(databases), failed)
+	return total
+}
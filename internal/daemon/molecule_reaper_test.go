@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+func TestMoleculeReaperConfigDefaults(t *testing.T) {
+	if got := moleculeReaperInterval(nil); got != defaultMoleculeReaperInterval {
+		t.Errorf("interval = %v, want %v", got, defaultMoleculeReaperInterval)
+	}
+	if got := moleculeReaperMaxAge(nil); got != defaultMoleculeMaxAge {
+		t.Errorf("max age = %v, want %v", got, defaultMoleculeMaxAge)
+	}
+	if IsPatrolEnabled(nil, "molecule_reaper") {
+		t.Error("molecule_reaper should be opt-in")
+	}
+
+	cfg := &DaemonPatrolConfig{Patrols: &PatrolsConfig{MoleculeReaper: &MoleculeReaperConfig{
+		Enabled:     true,
+		IntervalStr: "2h",
+		MaxAgeStr:   "72h",
+	}}}
+	if got := moleculeReaperInterval(cfg); got != 2*time.Hour {
+		t.Errorf("interval = %v, want 2h", got)
+	}
+	if got := moleculeReaperMaxAge(cfg); got != 72*time.Hour {
+		t.Errorf("max age = %v, want 72h", got)
+	}
+	if !IsPatrolEnabled(cfg, "molecule_reaper") {
+		t.Error("molecule_reaper should be enabled")
+	}
+}
+
+func TestReapMoleculesIn(t *testing.T) {
+	var logBuf bytes.Buffer
+	d := &Daemon{logger: log.New(&logBuf, "", 0)}
+
+	var seen []string
+	total := d.reapMoleculesIn([]string{"hq", "bad;name", "gastown", "noschema", "broken"},
+		func(ctx context.Context, dbName string) (*reaper.ReapResult, bool, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Errorf("%s: context has no deadline", dbName)
+			}
+			seen = append(seen, dbName)
+			switch dbName {
+			case "noschema":
+				return nil, true, nil
+			case "broken":
+				return nil, false, errors.New("connect: connection refused")
+			}
+			return &reaper.ReapResult{Database: dbName, Reaped: len(dbName), OpenRemain: 1}, false, nil
+		})
+
+	if want := len("hq") + len("gastown"); total != want {
+		t.Errorf("total = %d, want %d", total, want)
+	}
+	if strings.Join(seen, ",") != "hq,gastown,noschema,broken" {
+		t.Errorf("reaped databases = %v, want invalid name skipped", seen)
+	}
+	out := logBuf.String()
+	for _, want := range []string{
+		"molecule_reaper: gastown: closed 7 abandoned molecules",
+		"molecule_reaper: noschema: skipped",
+		"molecule_reaper: broken: reap error",
+		"reaped=9 open=2 databases=5 errors=1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package daemon
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
+)
+
+// minPatrolStartDelay is the earliest a patrol that is overdue according to
+// the history runs after startup, so a restart doesn't fire every overdue
+// patrol at once before the first heartbeat has settled.
+const minPatrolStartDelay = time.Minute
+
+// PatrolHistoryEntry is the last recorded cycle of one patrol.
+type PatrolHistoryEntry struct {
+	LastRun      time.Time     `json:"last_run"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastResult   string        `json:"last_result,omitempty"`
+	LastError    string        `json:"last_error,omitempty"`
+}
+
+// PatrolHistory is the on-disk record of each patrol's last cycle, keyed by
+// patrol name, so run times survive daemon restarts.
+type PatrolHistory struct {
+	Patrols map[string]PatrolHistoryEntry `json:"patrols"`
+}
+
+// PatrolHistoryFile returns the path to the patrol history file.
+func PatrolHistoryFile(townRoot string) string {
+	return filepath.Join(townRoot, "mayor", "patrol-history.json")
+}
+
+// LoadPatrolHistory reads the patrol history. A missing file yields an empty
+// history; a corrupt one yields an empty history and the parse error, so
+// callers can log it and start fresh.
+func LoadPatrolHistory(townRoot string) (*PatrolHistory, error) {
+	h := &PatrolHistory{Patrols: make(map[string]PatrolHistoryEntry)}
+	data, err := os.ReadFile(PatrolHistoryFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return h, err
+	}
+	var loaded PatrolHistory
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return h, err
+	}
+	for name, e := range loaded.Patrols {
+		h.Patrols[name] = e
+	}
+	return h, nil
+}
+
+// SavePatrolHistory writes the patrol history atomically.
+func SavePatrolHistory(townRoot string, h *PatrolHistory) error {
+	return atomicfile.EnsureDirAndWriteJSON(PatrolHistoryFile(townRoot), h)
+}
+
+// patrolStartDelay returns how long after startup a patrol with the given
+// interval should first run, given when it last ran: at lastRun+interval,
+// but no sooner than minPatrolStartDelay (or the interval, if shorter) and
+// no later than one interval. Without history it is one interval, as for a
+// plain ticker.
+func patrolStartDelay(lastRun time.Time, interval time.Duration, now time.Time) time.Duration {
+	if lastRun.IsZero() || interval <= 0 {
+		return interval
+	}
+	delay := lastRun.Add(interval).Sub(now)
+	if delay < minPatrolStartDelay {
+		delay = minPatrolStartDelay
+	}
+	if delay > interval {
+		delay = interval
+	}
+	return delay
+}
+
+// loadPatrolHistory seeds the patrol run records from the history file.
+func (d *Daemon) loadPatrolHistory() {
+	h, err := LoadPatrolHistory(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: ignoring unreadable patrol history %s: %v", PatrolHistoryFile(d.config.TownRoot), err)
+	}
+	d.patrolRunsMu.Lock()
+	defer d.patrolRunsMu.Unlock()
+	if d.patrolRuns == nil {
+		d.patrolRuns = make(map[string]patrolRun)
+	}
+	for name, e := range h.Patrols {
+		d.patrolRuns[name] = patrolRun{
+			lastRun:      e.LastRun,
+			lastDuration: e.LastDuration,
+			lastResult:   e.LastResult,
+			lastError:    e.LastError,
+		}
+	}
+}
+
+// savePatrolHistory writes the current patrol run records to the history file.
+func (d *Daemon) savePatrolHistory() {
+	h := &PatrolHistory{Patrols: make(map[string]PatrolHistoryEntry)}
+	d.patrolRunsMu.Lock()
+	for name, run := range d.patrolRuns {
+		h.Patrols[name] = PatrolHistoryEntry{
+			LastRun:      run.lastRun,
+			LastDuration: run.lastDuration,
+			LastResult:   run.lastResult,
+			LastError:    run.lastError,
+		}
+	}
+	d.patrolRunsMu.Unlock()
+	if err := SavePatrolHistory(d.config.TownRoot, h); err != nil {
+		d.logger.Printf("Warning: failed to save patrol history: %v", err)
+	}
+}
+
+// startPatrolTicker is time.NewTicker for the named patrol, except that the
+// first tick comes after patrolStartDelay rather than a full interval, so
+// the patrol keeps its cadence across daemon restarts. Call stop when done.
+func (d *Daemon) startPatrolTicker(name string, interval time.Duration) (ticks <-chan time.Time, stop func()) {
+	d.patrolRunsMu.Lock()
+	lastRun := d.patrolRuns[name].lastRun
+	d.patrolRunsMu.Unlock()
+	first := patrolStartDelay(lastRun, interval, time.Now())
+	if first != interval {
+		d.logger.Printf("%s last ran %s ago, next run in %v", name, time.Since(lastRun).Round(time.Second), first)
+	}
+
+	c := make(chan time.Time, 1)
+	done := make(chan struct{})
+	go func() {
+		timer := time.NewTimer(first)
+		defer timer.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case t := <-timer.C:
+				// Like a Ticker, drop ticks while the last one is unread.
+				select {
+				case c <- t:
+				default:
+				}
+				timer.Reset(interval)
+			}
+		}
+	}()
+	return c, func() { close(done) }
+}
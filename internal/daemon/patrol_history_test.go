@@ -0,0 +1,132 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatrolHistory_RoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	ran := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	want := &PatrolHistory{Patrols: map[string]PatrolHistoryEntry{
+		"wisp_reaper": {LastRun: ran, LastDuration: 1500 * time.Millisecond, LastResult: "reaped 3"},
+		"doctor_dog":  {LastRun: ran.Add(-time.Hour), LastError: "dolt unreachable"},
+	}}
+	if err := SavePatrolHistory(townRoot, want); err != nil {
+		t.Fatalf("SavePatrolHistory: %v", err)
+	}
+	got, err := LoadPatrolHistory(townRoot)
+	if err != nil {
+		t.Fatalf("LoadPatrolHistory: %v", err)
+	}
+	if len(got.Patrols) != 2 {
+		t.Fatalf("loaded %d patrols, want 2", len(got.Patrols))
+	}
+	for name, w := range want.Patrols {
+		g := got.Patrols[name]
+		if !g.LastRun.Equal(w.LastRun) || g.LastDuration != w.LastDuration || g.LastResult != w.LastResult || g.LastError != w.LastError {
+			t.Errorf("%s = %+v, want %+v", name, g, w)
+		}
+	}
+}
+
+func TestLoadPatrolHistory_MissingOrCorrupt(t *testing.T) {
+	townRoot := t.TempDir()
+	h, err := LoadPatrolHistory(townRoot)
+	if err != nil || h == nil || len(h.Patrols) != 0 {
+		t.Fatalf("missing file: got %+v, %v; want empty history", h, err)
+	}
+
+	path := PatrolHistoryFile(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"patrols": {"wisp_reaper": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	h, err = LoadPatrolHistory(townRoot)
+	if err == nil {
+		t.Error("corrupt file: want parse error")
+	}
+	if h == nil || h.Patrols == nil || len(h.Patrols) != 0 {
+		t.Errorf("corrupt file: got %+v, want a usable empty history", h)
+	}
+}
+
+func TestPatrolStartDelay(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	const interval = 30 * time.Minute
+	tests := []struct {
+		name    string
+		lastRun time.Time
+		want    time.Duration
+	}{
+		{"no history", time.Time{}, interval},
+		{"ran just before the restart", now.Add(-2 * time.Minute), 28 * time.Minute},
+		{"ran exactly one interval ago", now.Add(-interval), minPatrolStartDelay},
+		{"overdue", now.Add(-5 * time.Hour), minPatrolStartDelay},
+		{"clock moved back", now.Add(time.Hour), interval},
+	}
+	for _, tt := range tests {
+		if got := patrolStartDelay(tt.lastRun, interval, now); got != tt.want {
+			t.Errorf("%s: patrolStartDelay = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// An interval shorter than the minimum delay is never stretched.
+	if got := patrolStartDelay(now.Add(-time.Hour), 20*time.Second, now); got != 20*time.Second {
+		t.Errorf("short interval: patrolStartDelay = %v, want 20s", got)
+	}
+}
+
+func TestRunPatrol_PersistsHistoryAcrossRestart(t *testing.T) {
+	townRoot := t.TempDir()
+	newDaemon := func(logs *bytes.Buffer) *Daemon {
+		return &Daemon{
+			config: &Config{TownRoot: townRoot},
+			logger: log.New(logs, "", 0),
+			patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{
+				WispReaper: &WispReaperConfig{Enabled: true, IntervalStr: "10m"},
+			}},
+		}
+	}
+
+	var logs bytes.Buffer
+	first := newDaemon(&logs)
+	first.runPatrol("wisp_reaper", func() {
+		time.Sleep(10 * time.Millisecond)
+		first.recordPatrolResult("wisp_reaper", "reaped 2", errors.New("gt: timeout"))
+	})
+	before := first.DaemonStatus()[3]
+	if before.Name != "wisp_reaper" || before.LastDuration < 10*time.Millisecond {
+		t.Fatalf("wisp_reaper status = %+v, want a recorded duration", before)
+	}
+
+	// A new daemon process sees the same last run.
+	second := newDaemon(&logs)
+	second.loadPatrolHistory()
+	after := second.DaemonStatus()[3]
+	if after.NeverRun() || !after.LastRun.Equal(before.LastRun) || after.LastDuration != before.LastDuration ||
+		after.LastResult != "reaped 2" || after.LastError != "gt: timeout" {
+		t.Errorf("after restart = %+v, want %+v", after, before)
+	}
+
+	// A corrupt history is logged and ignored.
+	if err := os.WriteFile(PatrolHistoryFile(townRoot), []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logs.Reset()
+	third := newDaemon(&logs)
+	third.loadPatrolHistory()
+	if !third.DaemonStatus()[3].NeverRun() {
+		t.Error("corrupt history: want patrols to start fresh")
+	}
+	if !strings.Contains(logs.String(), "patrol history") {
+		t.Errorf("corrupt history not logged: %q", logs.String())
+	}
+}
@@ -0,0 +1,119 @@
+package daemon
+
+import (
+	"time"
+)
+
+// PatrolStatus is a snapshot of one ticker-driven patrol, reported by
+// DaemonStatus and persisted in state.json for `gt daemon status`.
+type PatrolStatus struct {
+	Name     string        `json:"name"`
+	Enabled  bool          `json:"enabled"`
+	Interval time.Duration `json:"interval"`
+
+	// LastRun is when the patrol last started a cycle, including cycles
+	// run by a previous daemon (see PatrolHistory); zero if it never ran.
+	LastRun time.Time `json:"last_run"`
+
+	// LastDuration is how long the last cycle took.
+	LastDuration time.Duration `json:"last_duration,omitempty"`
+
+	// LastResult and LastError are the most recent outcome reported by the
+	// patrol. Patrols that don't report results leave them empty.
+	LastResult string `json:"last_result,omitempty"`
+	LastError  string `json:"last_error,omitempty"`
+}
+
+// NeverRun reports whether the patrol has no recorded run.
+func (s PatrolStatus) NeverRun() bool {
+	return s.LastRun.IsZero()
+}
+
+// patrolRun is the recorded state behind a PatrolStatus.
+type patrolRun struct {
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastResult   string
+	lastError    string
+}
+
+// statusPatrols lists the ticker-driven patrols reported by DaemonStatus,
+// in the order their tickers are set up in Run.
+var statusPatrols = []struct {
+	name     string
+	interval func(*DaemonPatrolConfig) time.Duration
+}{
+	{"dolt_remotes", doltRemotesInterval},
+	{"dolt_backup", doltBackupInterval},
+	{"jsonl_git_backup", jsonlGitBackupInterval},
+	{"wisp_reaper", wispReaperInterval},
+	{"molecule_reaper", moleculeReaperInterval},
+	{"doctor_dog", doctorDogInterval},
+	{"compactor_dog", compactorDogInterval},
+	{"checkpoint_dog", checkpointDogInterval},
+	{"scheduled_maintenance", maintenanceCheckInterval},
+	{"main_branch_test", mainBranchTestInterval},
+	{"quota_dog", quotaDogInterval},
+}
+
+// DaemonStatus returns a snapshot of each ticker-driven patrol: whether it is
+// enabled, its interval, and its last run and outcome.
+func (d *Daemon) DaemonStatus() []PatrolStatus {
+	d.patrolRunsMu.Lock()
+	defer d.patrolRunsMu.Unlock()
+
+	statuses := make([]PatrolStatus, 0, len(statusPatrols))
+	for _, p := range statusPatrols {
+		run := d.patrolRuns[p.name]
+		statuses = append(statuses, PatrolStatus{
+			Name:         p.name,
+			Enabled:      d.isPatrolActive(p.name),
+			Interval:     p.interval(d.patrolConfig),
+			LastRun:      run.lastRun,
+			LastDuration: run.lastDuration,
+			LastResult:   run.lastResult,
+			LastError:    run.lastError,
+		})
+	}
+	return statuses
+}
+
+// runPatrol records the start of a patrol cycle, runs it, and then records
+// its duration and saves the patrol history.
+func (d *Daemon) runPatrol(name string, fn func()) {
+	start := time.Now()
+	d.patrolRunsMu.Lock()
+	if d.patrolRuns == nil {
+		d.patrolRuns = make(map[string]patrolRun)
+	}
+	run := d.patrolRuns[name]
+	run.lastRun = start
+	d.patrolRuns[name] = run
+	d.patrolRunsMu.Unlock()
+
+	fn()
+
+	d.patrolRunsMu.Lock()
+	run = d.patrolRuns[name]
+	run.lastDuration = time.Since(start)
+	d.patrolRuns[name] = run
+	d.patrolRunsMu.Unlock()
+	d.savePatrolHistory()
+}
+
+// recordPatrolResult stores the outcome of a patrol's latest cycle. A nil
+// err clears any previous error.
+func (d *Daemon) recordPatrolResult(name, result string, err error) {
+	d.patrolRunsMu.Lock()
+	defer d.patrolRunsMu.Unlock()
+	if d.patrolRuns == nil {
+		d.patrolRuns = make(map[string]patrolRun)
+	}
+	run := d.patrolRuns[name]
+	run.lastResult = result
+	run.lastError = ""
+	if err != nil {
+		run.lastError = err.Error()
+	}
+	d.patrolRuns[name] = run
+}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestDaemonStatus(t *testing.T) {
+	d := &Daemon{
+		config: &Config{TownRoot: t.TempDir()},
+		logger: log.New(&bytes.Buffer{}, "", 0),
+		patrolConfig: &DaemonPatrolConfig{Patrols: &PatrolsConfig{
+			WispReaper:     &WispReaperConfig{Enabled: true, IntervalStr: "10m"},
+			MoleculeReaper: &MoleculeReaperConfig{Enabled: true, IntervalStr: "2h"},
+		}},
+	}
+
+	before := time.Now()
+	d.runPatrol("wisp_reaper", func() {
+		r := newWispReaperResult([]string{"hq", "gt"}, false)
+		r.db("hq").Reaped = 2
+		r.addError("gt", "reap", errors.New("connection reset"))
+		r.finish()
+		d.recordWispReaperResult(r, false)
+	})
+
+	byName := make(map[string]PatrolStatus)
+	for _, s := range d.DaemonStatus() {
+		byName[s.Name] = s
+	}
+	if len(byName) != len(statusPatrols) {
+		t.Fatalf("DaemonStatus returned %d patrols, want %d", len(byName), len(statusPatrols))
+	}
+
+	wisp := byName["wisp_reaper"]
+	if !wisp.Enabled || wisp.Interval != 10*time.Minute {
+		t.Errorf("wisp_reaper = %+v, want enabled every 10m", wisp)
+	}
+	if wisp.NeverRun() || wisp.LastRun.Before(before) {
+		t.Errorf("wisp_reaper LastRun = %v, want after %v", wisp.LastRun, before)
+	}
+	if wisp.LastResult == "" || wisp.LastError != "gt: reap: connection reset" {
+		t.Errorf("wisp_reaper result = %q, error = %q", wisp.LastResult, wisp.LastError)
+	}
+
+	mol := byName["molecule_reaper"]
+	if !mol.Enabled || mol.Interval != 2*time.Hour {
+		t.Errorf("molecule_reaper = %+v, want enabled every 2h", mol)
+	}
+	if !mol.NeverRun() || mol.LastResult != "" || mol.LastError != "" {
+		t.Errorf("molecule_reaper should never have run: %+v", mol)
+	}
+
+	// A clean cycle clears the previous error.
+	d.recordPatrolResult("wisp_reaper", "cycle complete", nil)
+	if s := d.DaemonStatus(); s[3].Name != "wisp_reaper" || s[3].LastError != "" {
+		t.Errorf("error not cleared: %+v", s[3])
+	}
+}
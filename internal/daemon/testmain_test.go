@@ -37,8 +37,25 @@ func TestMain(m *testing.M) {
 		tmux.SetDefaultSocket(tmuxSocket)
 	}
 
+	// Feed events (events.LogFeed) go to the town found from cwd, and from
+	// this package dir that search stops at internal/ because the
+	// internal/mayor package dir looks like a town marker. Run from an empty
+	// temp dir so the suite doesn't leave internal/.events.jsonl behind;
+	// tests that need a town chdir into their own.
+	cwd, err := os.MkdirTemp("", "gt-daemon-test-cwd-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "daemon TestMain: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		fmt.Fprintf(os.Stderr, "daemon TestMain: %v\n", err)
+		os.Exit(1)
+	}
+
 	code := m.Run()
 
+	_ = os.RemoveAll(cwd)
+
 	if tmuxSocket != "" {
 		_ = exec.Command("tmux", "-L", tmuxSocket, "kill-server").Run()
 		socketPath := filepath.Join(tmux.SocketDir(), tmuxSocket)
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/atomicfile"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 )
 
@@ -61,6 +62,36 @@ type State struct {
 
 	// HeartbeatCount is how many heartbeats have completed.
 	HeartbeatCount int64 `json:"heartbeat_count"`
+
+	// Patrols is the per-patrol status snapshot as of the last heartbeat.
+	Patrols []PatrolStatus `json:"patrols,omitempty"`
+
+	// Operational is the operational config the daemon loaded at startup,
+	// for drift detection against the files on disk (gt config drift).
+	Operational *config.OperationalConfig `json:"operational,omitempty"`
+}
+
+// startupOperationalPaths are the operational thresholds the daemon reads
+// once and keeps until restart: the Dolt pool's settings, applied when the
+// pool is built. The daemon re-reads every other threshold when it uses it.
+var startupOperationalPaths = map[string]bool{
+	"dolt.health_check_interval": true,
+	"dolt.cmd_timeout":           true,
+	"dolt.max_connections":       true,
+	"dolt.slow_query_threshold":  true,
+}
+
+// StartupDrift reports the thresholds the daemon holds from its startup
+// config (started) that differ from current. Changes to other thresholds
+// are not drift: the daemon picks them up on its next read.
+func StartupDrift(started, current *config.OperationalConfig) []config.ConfigFieldDiff {
+	var drift []config.ConfigFieldDiff
+	for _, d := range config.DiffOperationalConfig(started, current) {
+		if startupOperationalPaths[d.Path] {
+			drift = append(drift, d)
+		}
+	}
+	return drift
 }
 
 // StateFile returns the path to the state file.
@@ -124,6 +155,7 @@ type PatrolsConfig struct {
 	DoltBackup     *DoltBackupConfig      `json:"dolt_backup,omitempty"`
 	JsonlGitBackup *JsonlGitBackupConfig  `json:"jsonl_git_backup,omitempty"`
 	WispReaper     *WispReaperConfig      `json:"wisp_reaper,omitempty"`
+	MoleculeReaper *MoleculeReaperConfig  `json:"molecule_reaper,omitempty"`
 	DoctorDog      *DoctorDogConfig       `json:"doctor_dog,omitempty"`
 	CompactorDog           *CompactorDogConfig            `json:"compactor_dog,omitempty"`
 	CheckpointDog          *CheckpointDogConfig           `json:"checkpoint_dog,omitempty"`
@@ -204,6 +236,10 @@ type DaemonPatrolConfig struct {
 	// Propagated to all sessions spawned by the daemon and read by gt up/mayor attach.
 	// Example: {"GT_DOLT_PORT": "43211"}
 	Env       map[string]string `json:"env,omitempty"`
+	// Metrics enables the Prometheus-style /metrics endpoint.
+	Metrics *MetricsEndpointConfig `json:"metrics,omitempty"`
+	// Webhook POSTs critical events (mass death, wisp alerts) to a URL.
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
 }
 
 // PatrolConfigFile returns the path to the patrol config file.
@@ -272,6 +308,12 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		}
 		return config.Patrols.WispReaper.Enabled
 	}
+	if patrol == "molecule_reaper" {
+		if config == nil || config.Patrols == nil || config.Patrols.MoleculeReaper == nil {
+			return false
+		}
+		return config.Patrols.MoleculeReaper.Enabled
+	}
 	if patrol == "doctor_dog" {
 		if config == nil || config.Patrols == nil || config.Patrols.DoctorDog == nil {
 			return false
@@ -0,0 +1,161 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DefaultWebhookEvents are the event types posted to the webhook when
+// webhook.events is not set: the alerts an unattended town should not
+// leave sitting in the log.
+var DefaultWebhookEvents = []string{events.TypeMassDeath, events.TypeWispAlert}
+
+const (
+	// webhookTimeout bounds each POST to the webhook.
+	webhookTimeout = 5 * time.Second
+
+	// webhookMaxInFlight is how many deliveries may run at once. Events
+	// arriving while all slots are busy are dropped, so a slow or dead
+	// endpoint can never back up into the daemon loop.
+	webhookMaxInFlight = 2
+)
+
+// webhookRetryDelay is the pause before the single retry of a failed POST.
+// Replaced in tests.
+var webhookRetryDelay = 2 * time.Second
+
+// WebhookConfig configures the webhook that critical daemon events are
+// POSTed to. Opt-in: nothing is sent unless url is set.
+type WebhookConfig struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"` // event types to send; default DefaultWebhookEvents
+}
+
+// webhookConfig returns the webhook config, or nil if none is set.
+func webhookConfig(config *DaemonPatrolConfig) *WebhookConfig {
+	if config == nil || config.Webhook == nil || config.Webhook.URL == "" {
+		return nil
+	}
+	return config.Webhook
+}
+
+// webhookSink POSTs selected feed events, as the JSON line written to
+// .events.jsonl, to a webhook. A nil sink sends nothing.
+type webhookSink struct {
+	url    string
+	types  map[string]bool
+	client *http.Client
+	logger *log.Logger
+	slots  chan struct{} // one token per in-flight delivery
+	wg     sync.WaitGroup
+}
+
+// newWebhookSink returns a sink for cfg, or nil if cfg is nil.
+func newWebhookSink(cfg *WebhookConfig, logger *log.Logger) *webhookSink {
+	if cfg == nil {
+		return nil
+	}
+	types := cfg.Events
+	if len(types) == 0 {
+		types = DefaultWebhookEvents
+	}
+	s := &webhookSink{
+		url:    cfg.URL,
+		types:  make(map[string]bool, len(types)),
+		client: &http.Client{Timeout: webhookTimeout},
+		logger: logger,
+		slots:  make(chan struct{}, webhookMaxInFlight),
+	}
+	for _, t := range types {
+		s.types[t] = true
+	}
+	return s
+}
+
+// notify sends event in the background if its type is configured and
+// reports whether a delivery was started. It never blocks: when
+// webhookMaxInFlight deliveries are already running the event is dropped.
+func (s *webhookSink) notify(event events.Event) bool {
+	if s == nil || !s.types[event.Type] {
+		return false
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return false
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.logger.Printf("webhook: busy, dropped %s event", event.Type)
+		return false
+	}
+	s.wg.Add(1)
+	go func() {
+		defer func() {
+			<-s.slots
+			s.wg.Done()
+		}()
+		if err := s.deliver(body); err != nil {
+			s.logger.Printf("webhook: %s event not delivered: %v", event.Type, err)
+		}
+	}()
+	return true
+}
+
+// deliver POSTs body, retrying once after webhookRetryDelay on failure.
+func (s *webhookSink) deliver(body []byte) error {
+	if err := s.post(body); err == nil {
+		return nil
+	}
+	time.Sleep(webhookRetryDelay)
+	return s.post(body)
+}
+
+// post sends one POST. Errors omit the URL, which may embed a token.
+func (s *webhookSink) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// wait blocks until in-flight deliveries finish. Used on shutdown and in
+// tests.
+func (s *webhookSink) wait() {
+	if s != nil {
+		s.wg.Wait()
+	}
+}
+
+// logFeed records a feed event and forwards it to the webhook when its
+// type is one the webhook is configured for.
+func (d *Daemon) logFeed(eventType, actor string, payload map[string]interface{}) {
+	_ = events.LogFeed(eventType, actor, payload)
+	d.webhook.notify(events.Event{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Source:     "gt",
+		Type:       eventType,
+		Actor:      actor,
+		Payload:    payload,
+		Visibility: events.VisibilityFeed,
+	})
+}
@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestWebhookConfig(t *testing.T) {
+	if webhookConfig(nil) != nil || webhookConfig(&DaemonPatrolConfig{}) != nil {
+		t.Error("no webhook section should disable the webhook")
+	}
+	if webhookConfig(&DaemonPatrolConfig{Webhook: &WebhookConfig{}}) != nil {
+		t.Error("an empty url should disable the webhook")
+	}
+
+	var cfg DaemonPatrolConfig
+	if err := json.Unmarshal([]byte(`{"webhook": {"url": "http://example.test/hook", "events": ["mass_death"]}}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	got := webhookConfig(&cfg)
+	if got == nil || got.URL != "http://example.test/hook" || len(got.Events) != 1 {
+		t.Errorf("webhookConfig = %+v", got)
+	}
+}
+
+func TestWebhookSink_PostsEvent(t *testing.T) {
+	received := make(chan []byte, 1)
+	var contentType atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType.Store(r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer srv.Close()
+
+	d := &Daemon{
+		logger:  log.New(io.Discard, "", 0),
+		webhook: newWebhookSink(&WebhookConfig{URL: srv.URL}, log.New(io.Discard, "", 0)),
+	}
+	d.logFeed(events.TypeWispAlert, "daemon", events.WispAlertPayload(600, 500, 3))
+	d.webhook.wait()
+
+	select {
+	case body := <-received:
+		var got events.Event
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("payload is not an event: %v\n%s", err, body)
+		}
+		if got.Type != events.TypeWispAlert || got.Actor != "daemon" || got.Source != "gt" || got.Timestamp == "" {
+			t.Errorf("event = %+v", got)
+		}
+		if got.Payload["open"] != float64(600) || got.Payload["threshold"] != float64(500) || got.Payload["databases"] != float64(3) {
+			t.Errorf("payload = %v", got.Payload)
+		}
+	default:
+		t.Fatal("webhook received nothing")
+	}
+	if ct, _ := contentType.Load().(string); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	// Types outside the configured set are not sent.
+	if d.webhook.notify(events.Event{Type: events.TypeSessionDeath}) {
+		t.Error("session_death is not a default webhook event")
+	}
+	var nilSink *webhookSink
+	if nilSink.notify(events.Event{Type: events.TypeMassDeath}) {
+		t.Error("a nil sink should send nothing")
+	}
+}
+
+func TestWebhookSink_RetriesOnce(t *testing.T) {
+	orig := webhookRetryDelay
+	webhookRetryDelay = 0
+	t.Cleanup(func() { webhookRetryDelay = orig })
+
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	s := newWebhookSink(&WebhookConfig{URL: srv.URL, Events: []string{"custom"}}, log.New(io.Discard, "", 0))
+	if !s.notify(events.Event{Type: "custom"}) {
+		t.Fatal("notify should start a delivery")
+	}
+	s.wait()
+	if got := hits.Load(); got != 2 {
+		t.Errorf("hits = %d, want 2 (one failure, one retry)", got)
+	}
+}
+
+func TestWebhookSink_DropsWhenBusy(t *testing.T) {
+	release := make(chan struct{})
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+	}))
+	defer srv.Close()
+
+	s := newWebhookSink(&WebhookConfig{URL: srv.URL}, log.New(io.Discard, "", 0))
+	ev := events.Event{Type: events.TypeMassDeath}
+
+	start := time.Now()
+	for i := 0; i < webhookMaxInFlight; i++ {
+		if !s.notify(ev) {
+			t.Fatalf("notify %d dropped with free slots", i)
+		}
+	}
+	// Every slot is stuck on the endpoint: the next event is dropped, and
+	// none of the calls waited on the server.
+	if s.notify(ev) {
+		t.Error("notify should drop the event when all slots are busy")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("notify blocked for %s", elapsed)
+	}
+
+	close(release)
+	s.wait()
+	if got := hits.Load(); got != webhookMaxInFlight {
+		t.Errorf("hits = %d, want %d", got, webhookMaxInFlight)
+	}
+
+	// Slots are freed once deliveries finish.
+	if !s.notify(ev) {
+		t.Error("notify should accept events again after deliveries finish")
+	}
+	s.wait()
+}
@@ -1,12 +1,17 @@
 package daemon
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/reaper"
 	"github.com/steveyegge/gastown/internal/util"
 )
@@ -19,8 +24,9 @@ const (
 	defaultWispMaxAge = 24 * time.Hour
 	// Closed wisps older than this are permanently deleted. Formula var: purge_age.
 	defaultWispDeleteAge = 7 * 24 * time.Hour
-	// Alert threshold: if open wisp count exceeds this, the Dog should escalate.
-	// Shared with `gt reaper run` warning. See reaper.DefaultAlertThreshold.
+	// Default alert threshold: if open wisp count exceeds this, the Dog should
+	// escalate. Shared with `gt reaper run` warning. See reaper.DefaultAlertThreshold.
+	// Configurable via WispReaperConfig.AlertThreshold.
 	wispAlertThreshold = reaper.DefaultAlertThreshold
 	// Closed mail older than this is permanently deleted. Formula var: mail_delete_age.
 	defaultMailDeleteAge = 7 * 24 * time.Hour
@@ -36,6 +42,24 @@ type WispReaperConfig struct {
 	MaxAgeStr    string   `json:"max_age,omitempty"`
 	DeleteAgeStr string   `json:"delete_age,omitempty"`
 	Databases    []string `json:"databases,omitempty"`
+	// MaxAgeByDatabase overrides max_age for individual databases,
+	// e.g. {"beads": "6h", "gastown": "72h"}.
+	MaxAgeByDatabase map[string]string `json:"max_age_by_database,omitempty"`
+	// AlertThreshold is the open wisp count above which a wisp_alert event
+	// is emitted. Defaults to reaper.DefaultAlertThreshold.
+	AlertThreshold *int `json:"alert_threshold,omitempty"`
+	// ReapStatus is the status stale wisps are set to: "closed" (default) or
+	// "reaped" to archive them for later audit. See reaper.ReapStatusReaped.
+	ReapStatus string `json:"reap_status,omitempty"`
+	// WriteStats writes each inline cycle's WispReaperResult as JSON to
+	// daemon/wisp_reaper_stats.json for dashboards.
+	WriteStats bool `json:"write_stats,omitempty"`
+	// Concurrency is how many databases the inline reaper reaps at once
+	// (default 2).
+	Concurrency *int `json:"concurrency,omitempty"`
+	// StrictDatabases makes the daemon refuse to start when a name in
+	// Databases is invalid, instead of logging a warning.
+	StrictDatabases bool `json:"strict_databases,omitempty"`
 }
 
 // wispReaperInterval returns the configured interval, or the default (1h).
@@ -62,6 +86,103 @@ func wispReaperMaxAge(config *DaemonPatrolConfig) time.Duration {
 	return defaultWispMaxAge
 }
 
+// wispReaperMaxAgeFor returns the max age for dbName: its max_age_by_database
+// override if that is a valid positive duration, else wispReaperMaxAge.
+func wispReaperMaxAgeFor(config *DaemonPatrolConfig, dbName string) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if s := config.Patrols.WispReaper.MaxAgeByDatabase[dbName]; s != "" {
+			if d, err := time.ParseDuration(s); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return wispReaperMaxAge(config)
+}
+
+// wispReaperMaxAges resolves the max age of each database in one pass.
+func wispReaperMaxAges(config *DaemonPatrolConfig, databases []string) map[string]time.Duration {
+	ages := make(map[string]time.Duration, len(databases))
+	for _, dbName := range databases {
+		ages[dbName] = wispReaperMaxAgeFor(config, dbName)
+	}
+	return ages
+}
+
+// wispReaperAlertThreshold returns the configured alert threshold, or the
+// default (reaper.DefaultAlertThreshold). Negative values are ignored.
+func wispReaperAlertThreshold(config *DaemonPatrolConfig) int {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if t := config.Patrols.WispReaper.AlertThreshold; t != nil && *t >= 0 {
+			return *t
+		}
+	}
+	return wispAlertThreshold
+}
+
+// wispReaperReapStatus returns the configured reap status, or "closed" when
+// unset or invalid.
+func wispReaperReapStatus(config *DaemonPatrolConfig) string {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if s := config.Patrols.WispReaper.ReapStatus; s != "" && reaper.ValidateReapStatus(s) == nil {
+			return s
+		}
+	}
+	return reaper.ReapStatusClosed
+}
+
+// wispReaperInlineReason returns why the configured behavior can only be run
+// by the inline path (the formula takes a single max_age and always closes),
+// or "" if the Dog can run it.
+func wispReaperInlineReason(config *DaemonPatrolConfig) string {
+	if config == nil || config.Patrols == nil || config.Patrols.WispReaper == nil {
+		return ""
+	}
+	if len(config.Patrols.WispReaper.MaxAgeByDatabase) > 0 {
+		return "per-database max_age overrides configured"
+	}
+	if status := wispReaperReapStatus(config); status != reaper.ReapStatusClosed {
+		return "reap_status " + status + " configured"
+	}
+	return ""
+}
+
+// invalidWispReaperDatabases returns the configured wisp_reaper database
+// names that fail validDBName, in config order.
+func invalidWispReaperDatabases(config *DaemonPatrolConfig) []string {
+	if config == nil || config.Patrols == nil || config.Patrols.WispReaper == nil {
+		return nil
+	}
+	var invalid []string
+	for _, name := range config.Patrols.WispReaper.Databases {
+		if !validDBName.MatchString(name) {
+			invalid = append(invalid, name)
+		}
+	}
+	return invalid
+}
+
+// checkWispReaperDatabases validates the configured wisp_reaper databases
+// once at startup. Reaper cycles skip invalid names, so a typo would
+// otherwise exclude a database from reaping indefinitely. With
+// strict_databases the daemon refuses to start; otherwise one warning
+// lists every invalid name.
+func (d *Daemon) checkWispReaperDatabases() error {
+	invalid := invalidWispReaperDatabases(d.patrolConfig)
+	if len(invalid) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(invalid))
+	for i, name := range invalid {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	list := strings.Join(quoted, ", ")
+	if d.patrolConfig.Patrols.WispReaper.StrictDatabases {
+		return fmt.Errorf("wisp_reaper: invalid database names in daemon.json: %s", list)
+	}
+	d.logger.Printf("Warning: wisp_reaper: ignoring invalid database names in daemon.json: %s (names may only contain letters, digits, '_' and '-')", list)
+	return nil
+}
+
 // wispDeleteAge returns the configured delete age, or the default (7 days).
 func wispDeleteAge(config *DaemonPatrolConfig) time.Duration {
 	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
@@ -92,7 +213,7 @@ func (d *Daemon) reapWisps() {
 		"purge_age":       deleteAge.String(),
 		"stale_issue_age": defaultStaleIssueAge.String(),
 		"mail_delete_age": defaultMailDeleteAge.String(),
-		"alert_threshold": fmt.Sprintf("%d", wispAlertThreshold),
+		"alert_threshold": fmt.Sprintf("%d", wispReaperAlertThreshold(d.patrolConfig)),
 		"dolt_port":       fmt.Sprintf("%d", d.doltServerPort()),
 	}
 
@@ -111,10 +232,16 @@ func (d *Daemon) reapWisps() {
 		d.logger.Printf("wisp_reaper: DRY RUN — reporting only, no changes will be made")
 	}
 
+	if reason := wispReaperInlineReason(d.patrolConfig); reason != "" {
+		d.logger.Printf("wisp_reaper: %s, running inline", reason)
+		d.reapWispsInline(config, deleteAge, mol)
+		return
+	}
+
 	// Try dispatching to a Dog for formula-driven execution.
 	if err := d.dispatchReaperDog(vars); err != nil {
 		d.logger.Printf("wisp_reaper: Dog dispatch failed (%v), running inline fallback", err)
-		d.reapWispsInline(config, maxAge, deleteAge, mol)
+		d.reapWispsInline(config, deleteAge, mol)
 		return
 	}
 
@@ -144,83 +271,89 @@ func (d *Daemon) dispatchReaperDog(vars map[string]string) error {
 
 // reapWispsInline is the fallback that runs the reaper cycle inline when
 // Dog dispatch is unavailable. Delegates to the reaper package for SQL execution.
-func (d *Daemon) reapWispsInline(config *WispReaperConfig, maxAge, deleteAge time.Duration, mol *dogMol) {
+// Each database is reaped with its own max age (see wispReaperMaxAgeFor).
+// Returns the cycle's result, or an error if the cycle was skipped.
+func (d *Daemon) reapWispsInline(config *WispReaperConfig, deleteAge time.Duration, mol *dogMol) (*WispReaperResult, error) {
+	// Check the server once up front: when it is down, every database would
+	// otherwise log its own connect error (and discovery would silently fall
+	// back to the static list).
+	port, source := d.resolveDoltPort()
+	retry := newReaperRetryPolicy(d.loadOperationalConfig().GetPolecatConfig())
+	if err := retry.do(func() error { return dialDolt(port) }); err != nil {
+		d.logger.Printf("wisp_reaper: Dolt server unreachable at 127.0.0.1:%d (port from %s), skipping cycle: %v", port, source, err)
+		mol.failStep("scan", "dolt server unreachable")
+		return nil, fmt.Errorf("dolt server unreachable at 127.0.0.1:%d: %w", port, err)
+	}
+	d.logger.Printf("wisp_reaper: using Dolt port %d (from %s)", port, source)
+
 	databases := config.Databases
 	if len(databases) == 0 {
-		databases = reaper.DiscoverDatabases("127.0.0.1", d.doltServerPort())
+		databases = reaper.DiscoverDatabases("127.0.0.1", port)
 	}
 	if len(databases) == 0 {
 		d.logger.Printf("wisp_reaper: no databases to reap")
 		mol.failStep("scan", "no databases found")
-		return
+		return nil, errors.New("no databases to reap")
 	}
 	d.logger.Printf("wisp_reaper: scanning %d databases (inline fallback)", len(databases))
 	mol.closeStep("scan")
 
-	port := d.doltServerPort()
 	dryRun := config.DryRun
-	var totalReaped, totalOpen, totalPurged, totalMailPurged, totalAutoClosed int
+	maxAges := wispReaperMaxAges(d.patrolConfig, databases)
+	reapStatus := wispReaperReapStatus(d.patrolConfig)
+	if config.ReapStatus != "" && config.ReapStatus != reapStatus {
+		d.logger.Printf("wisp_reaper: invalid reap_status %q, using %q", config.ReapStatus, reapStatus)
+	}
+	res := newWispReaperResult(databases, dryRun)
 
 	// Step 2: Reap
-	reapErrors := 0
-	for _, dbName := range databases {
-		if err := reaper.ValidateDBName(dbName); err != nil {
-			continue
-		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
-		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: connect error: %v", dbName, err)
-			reapErrors++
-			continue
-		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
-			d.logger.Printf("wisp_reaper: %s: skipped (no reaper schema)", dbName)
-			db.Close()
-			continue
-		}
-		result, err := reaper.Reap(db, dbName, maxAge, dryRun)
-		db.Close()
-		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: reap error: %v", dbName, err)
-			reapErrors++
-			continue
-		}
-		totalReaped += result.Reaped
-		totalOpen += result.OpenRemain
-		if result.Reaped > 0 {
-			d.logger.Printf("wisp_reaper: %s: reaped %d stale wisps, %d open remain", dbName, result.Reaped, result.OpenRemain)
-		}
+	// Databases are reaped concurrently (see reapDatabasesConcurrently) so one
+	// slow database does not hold up the others.
+	reapOne := func(ctx context.Context, dbName string) (*reaper.ReapResult, bool, error) {
+		return d.reapInDB(ctx, retry, port, "wisp_reaper", dbName, func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+			return reaper.ReapAsContext(ctx, db, dbName, maxAges[dbName], reapStatus, dryRun)
+		})
 	}
+	reapErrors := d.reapDatabasesConcurrently(databases, wispReaperConcurrency(d.patrolConfig), reapOne, res)
 	if reapErrors > 0 {
 		mol.failStep("reap", fmt.Sprintf("%d databases had reap errors", reapErrors))
 	} else {
 		mol.closeStep("reap")
 	}
 
+	// Steps 3-4 share the daemon's Dolt pool rather than opening a connection
+	// per database per step. Each step pins one connection per database: the
+	// purges and closers disable autocommit and COMMIT on that session.
+	pool := d.doltPoolFor(port)
+
 	// Step 3: Purge
 	purgeErrors := 0
 	for _, dbName := range databases {
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 30*time.Second, 30*time.Second)
+		var result *reaper.PurgeResult
+		err := pool.WithConn(context.Background(), dbName, func(db reaper.DB) error {
+			ok, err := reaper.HasReaperSchema(db)
+			if err != nil || !ok {
+				return err
+			}
+			result, err = reaper.Purge(db, dbName, deleteAge, defaultMailDeleteAge, dryRun)
+			if err != nil {
+				d.logger.Printf("wisp_reaper: %s: purge error: %v", dbName, err)
+			}
+			return err
+		})
 		if err != nil {
+			res.addError(dbName, "purge", err)
 			purgeErrors++
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
-			db.Close()
+		if result == nil {
 			continue
 		}
-		result, err := reaper.Purge(db, dbName, deleteAge, defaultMailDeleteAge, dryRun)
-		db.Close()
-		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: purge error: %v", dbName, err)
-			purgeErrors++
-			continue
-		}
-		totalPurged += result.WispsPurged
-		totalMailPurged += result.MailPurged
+		res.db(dbName).Purged = result.WispsPurged
+		res.db(dbName).MailPurged = result.MailPurged
 		for _, a := range result.Anomalies {
 			d.logger.Printf("wisp_reaper: %s: ANOMALY: %s", dbName, a.Message)
 		}
@@ -233,26 +366,28 @@ func (d *Daemon) reapWispsInline(config *WispReaperConfig, maxAge, deleteAge tim
 
 	// Step 3b: Close plugin receipts (fast-track — 1h instead of 7d stale age)
 	pluginReceiptAge := 1 * time.Hour
-	var totalPluginClosed int
 	for _, dbName := range databases {
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		var result *reaper.ClosePluginReceiptResult
+		err := pool.WithConn(context.Background(), dbName, func(db reaper.DB) error {
+			if ok, _ := reaper.HasReaperSchema(db); !ok {
+				return nil
+			}
+			var err error
+			result, err = reaper.ClosePluginReceipts(db, dbName, pluginReceiptAge, dryRun)
+			return err
+		})
 		if err != nil {
+			d.logger.Printf("wisp_reaper: %s: plugin receipt close error: %v", dbName, err)
+			res.addError(dbName, "plugin-receipts", err)
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
-			db.Close()
-			continue
-		}
-		result, err := reaper.ClosePluginReceipts(db, dbName, pluginReceiptAge, dryRun)
-		db.Close()
-		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: plugin receipt close error: %v", dbName, err)
+		if result == nil {
 			continue
 		}
-		totalPluginClosed += result.Closed
+		res.db(dbName).PluginClosed = result.Closed
 		if result.Closed > 0 {
 			d.logger.Printf("wisp_reaper: %s: closed %d plugin receipts", dbName, result.Closed)
 		}
@@ -260,26 +395,28 @@ func (d *Daemon) reapWispsInline(config *WispReaperConfig, maxAge, deleteAge tim
 
 	// Step 3c: Close plugin dispatch mails (daemon→dog instruction beads that are never closed)
 	pluginDispatchAge := 1 * time.Hour
-	var totalDispatchClosed int
 	for _, dbName := range databases {
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		var result *reaper.ClosePluginReceiptResult
+		err := pool.WithConn(context.Background(), dbName, func(db reaper.DB) error {
+			if ok, _ := reaper.HasReaperSchema(db); !ok {
+				return nil
+			}
+			var err error
+			result, err = reaper.ClosePluginDispatches(db, dbName, pluginDispatchAge, dryRun)
+			return err
+		})
 		if err != nil {
+			d.logger.Printf("wisp_reaper: %s: plugin dispatch close error: %v", dbName, err)
+			res.addError(dbName, "plugin-dispatches", err)
 			continue
 		}
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
-			db.Close()
+		if result == nil {
 			continue
 		}
-		result, err := reaper.ClosePluginDispatches(db, dbName, pluginDispatchAge, dryRun)
-		db.Close()
-		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: plugin dispatch close error: %v", dbName, err)
-			continue
-		}
-		totalDispatchClosed += result.Closed
+		res.db(dbName).DispatchClosed = result.Closed
 		if result.Closed > 0 {
 			d.logger.Printf("wisp_reaper: %s: closed %d plugin dispatches", dbName, result.Closed)
 		}
@@ -291,25 +428,29 @@ func (d *Daemon) reapWispsInline(config *WispReaperConfig, maxAge, deleteAge tim
 		if err := reaper.ValidateDBName(dbName); err != nil {
 			continue
 		}
-		db, err := reaper.OpenDB("127.0.0.1", port, dbName, 10*time.Second, 10*time.Second)
+		var result *reaper.AutoCloseResult
+		err := pool.WithConn(context.Background(), dbName, func(db reaper.DB) error {
+			// Auto-close operates on the issues table, not wisps, but if the database
+			// has no beads schema at all we should skip it too.
+			ok, err := reaper.HasReaperSchema(db)
+			if err != nil || !ok {
+				return err
+			}
+			result, err = reaper.AutoClose(db, dbName, defaultStaleIssueAge, dryRun)
+			if err != nil {
+				d.logger.Printf("wisp_reaper: %s: auto-close error: %v", dbName, err)
+			}
+			return err
+		})
 		if err != nil {
+			res.addError(dbName, "auto-close", err)
 			autoCloseErrors++
 			continue
 		}
-		// Auto-close operates on the issues table, not wisps, but if the database
-		// has no beads schema at all we should skip it too.
-		if ok, _ := reaper.HasReaperSchema(db); !ok {
-			db.Close()
-			continue
-		}
-		result, err := reaper.AutoClose(db, dbName, defaultStaleIssueAge, dryRun)
-		db.Close()
-		if err != nil {
-			d.logger.Printf("wisp_reaper: %s: auto-close error: %v", dbName, err)
-			autoCloseErrors++
+		if result == nil {
 			continue
 		}
-		totalAutoClosed += result.Closed
+		res.db(dbName).AutoClosed = result.Closed
 	}
 	if autoCloseErrors > 0 {
 		mol.failStep("auto-close", fmt.Sprintf("%d databases had auto-close errors", autoCloseErrors))
@@ -318,19 +459,74 @@ func (d *Daemon) reapWispsInline(config *WispReaperConfig, maxAge, deleteAge tim
 	}
 
 	// Step 5: Report
-	if totalOpen > wispAlertThreshold {
-		d.logger.Printf("wisp_reaper: WARNING: %d open wisps exceed threshold %d — investigate wisp lifecycle",
-			totalOpen, wispAlertThreshold)
-	}
-	d.logger.Printf("wisp_reaper: cycle complete — reaped=%d purged=%d mail_purged=%d plugin_closed=%d dispatch_closed=%d auto_closed=%d open=%d databases=%d dryRun=%v",
-		totalReaped, totalPurged, totalMailPurged, totalPluginClosed, totalDispatchClosed, totalAutoClosed, totalOpen, len(databases), dryRun)
+	res.finish()
+	d.checkWispAlert(res.Totals.Open, len(databases))
+	d.logger.Printf("wisp_reaper: %s", res.summary())
+	d.recordWispReaperResult(res, config.WriteStats)
 	mol.closeStep("report")
+	return res, nil
+}
+
+// checkWispAlert logs a warning and emits a wisp_alert feed event when the
+// open wisp count exceeds the configured alert threshold. Returns whether
+// the threshold was exceeded.
+func (d *Daemon) checkWispAlert(totalOpen, databases int) bool {
+	threshold := wispReaperAlertThreshold(d.patrolConfig)
+	if totalOpen <= threshold {
+		return false
+	}
+	d.logger.Printf("wisp_reaper: WARNING: %d open wisps exceed threshold %d — investigate wisp lifecycle",
+		totalOpen, threshold)
+	d.logFeed(events.TypeWispAlert, "daemon",
+		events.WispAlertPayload(totalOpen, threshold, databases))
+	return true
+}
+
+// formatReapResult renders one database's reap outcome for the daemon log.
+// In dry-run mode nothing was closed, so it reports "would reap N".
+func formatReapResult(r *reaper.ReapResult) string {
+	verb := "reaped"
+	if r.DryRun {
+		verb = "would reap"
+	}
+	if r.Status == reaper.ReapStatusReaped {
+		verb += " (archived)"
+	}
+	return fmt.Sprintf("%s: %s %d stale wisps, %d open remain", r.Database, verb, r.Reaped, r.OpenRemain)
 }
 
 // doltServerPort returns the configured Dolt server port.
 func (d *Daemon) doltServerPort() int {
+	port, _ := d.resolveDoltPort()
+	return port
+}
+
+// resolveDoltPort returns the Dolt server port and where it came from, in
+// priority order: the daemon-managed server, operational.dolt.port (or
+// GT_DOLT_PORT), the town's Dolt config (.dolt-data/config.yaml,
+// mayor/daemon.json), and finally the default 3307.
+func (d *Daemon) resolveDoltPort() (port int, source string) {
 	if d.doltServer != nil {
-		return d.doltServer.config.Port
+		return d.doltServer.config.Port, "managed dolt server"
+	}
+	if d.config == nil || d.config.TownRoot == "" {
+		return doltserver.DefaultPort, "default"
+	}
+	if p := d.loadOperationalConfig().GetDoltConfig().PortV(); p > 0 {
+		return p, "operational.dolt.port"
+	}
+	if p := doltserver.DefaultConfig(d.config.TownRoot).Port; p > 0 && p != doltserver.DefaultPort {
+		return p, "town dolt config"
+	}
+	return doltserver.DefaultPort, "default"
+}
+
+// dialDolt checks that something is listening on the local Dolt port.
+// Replaced in tests.
+var dialDolt = func(port int) error {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), 2*time.Second)
+	if err != nil {
+		return err
 	}
-	return 3307
+	return conn.Close()
 }
@@ -0,0 +1,129 @@
+package daemon
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+// defaultWispReaperConcurrency is how many databases are reaped at once.
+// Kept small: every worker holds a Dolt connection doing batched UPDATEs.
+const defaultWispReaperConcurrency = 2
+
+// wispReaperConcurrency returns the configured concurrency, or the default (2).
+func wispReaperConcurrency(config *DaemonPatrolConfig) int {
+	if config != nil && config.Patrols != nil && config.Patrols.WispReaper != nil {
+		if c := config.Patrols.WispReaper.Concurrency; c != nil && *c > 0 {
+			return *c
+		}
+	}
+	return defaultWispReaperConcurrency
+}
+
+// reapDBFunc reaps one database. skipped reports a database without the
+// reaper schema.
+type reapDBFunc func(ctx context.Context, dbName string) (result *reaper.ReapResult, skipped bool, err error)
+
+// reapDatabasesConcurrently runs reapOne for each valid database on a worker
+// pool of the given size, each with its own wispReaperQueryTimeout context.
+// Outcomes are recorded into res and logged in database-name order once all
+// workers finish, so the log is deterministic. Returns the number of
+// databases that failed.
+func (d *Daemon) reapDatabasesConcurrently(databases []string, concurrency int, reapOne reapDBFunc, res *WispReaperResult) int {
+	type outcome struct {
+		result  *reaper.ReapResult
+		skipped bool
+		err     error
+	}
+	var mu sync.Mutex
+	outcomes := make(map[string]outcome, len(databases))
+
+	var valid []string
+	for _, dbName := range databases {
+		if reaper.ValidateDBName(dbName) == nil {
+			valid = append(valid, dbName)
+		}
+	}
+
+	pool := newRigWorkerPool(concurrency, wispReaperQueryTimeout, nil)
+	pool.runPerRig(context.Background(), valid, func(ctx context.Context, dbName string) error {
+		result, skipped, err := reapOne(ctx, dbName)
+		mu.Lock()
+		outcomes[dbName] = outcome{result: result, skipped: skipped, err: err}
+		mu.Unlock()
+		return nil // errors are reported below, in order
+	})
+
+	sort.Strings(valid)
+	reapErrors := 0
+	for _, dbName := range valid {
+		o := outcomes[dbName]
+		switch {
+		case o.err != nil:
+			d.logger.Printf("wisp_reaper: %s: reap error: %v", dbName, o.err)
+			res.addError(dbName, "reap", o.err)
+			reapErrors++
+		case o.skipped:
+			d.logger.Printf("wisp_reaper: %s: skipped (no reaper schema)", dbName)
+		case o.result != nil:
+			for _, a := range o.result.Anomalies {
+				d.logger.Printf("wisp_reaper: %s: %s", dbName, a.Message)
+			}
+			res.db(dbName).Reaped = o.result.Reaped
+			res.db(dbName).Open = o.result.OpenRemain
+			if o.result.Reaped > 0 {
+				d.logger.Printf("wisp_reaper: %s", formatReapResult(o.result))
+			}
+		}
+	}
+	return reapErrors
+}
+
+// reapInDB runs reap against one database for the named patrol, on a
+// connection pinned from the daemon's shared Dolt pool for port (see
+// DoltPool.Conn), so the reap's session state stays on one connection.
+// Schema check and reap are retried together on transient Dolt errors so a
+// momentary server restart doesn't skip the database; a connection error
+// also starts a pool reconnect, so the next attempt waits for the server
+// instead of reusing dead connections. ctx bounds the queries as well as the
+// retries. skipped reports a database without the reaper schema.
+func (d *Daemon) reapInDB(ctx context.Context, retry reaperRetryPolicy, port int, patrol, dbName string, reap func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error)) (*reaper.ReapResult, bool, error) {
+	retry.onRetry = func(attempt int, delay time.Duration, err error) {
+		d.logger.Printf("%s: %s: transient error (attempt %d), retrying in %v: %v", patrol, dbName, attempt, delay, err)
+	}
+	pool := d.doltPoolFor(port)
+	var result *reaper.ReapResult
+	skipped := false
+	attempt := func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Don't race a pool reconnect with fresh connection attempts.
+		if err := pool.awaitReconnect(ctx); err != nil {
+			return err
+		}
+		return pool.WithConn(ctx, dbName, func(db reaper.DB) error {
+			ok, err := reaper.HasReaperSchemaContext(ctx, db)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				skipped = true
+				return nil
+			}
+			result, err = reap(ctx, db)
+			return err
+		})
+	}
+	err := retry.do(func() error {
+		err := attempt()
+		if ctx.Err() == nil && isRetryableDoltError(err) {
+			pool.reconnect(dbName, err)
+		}
+		return err
+	})
+	return result, skipped, err
+}
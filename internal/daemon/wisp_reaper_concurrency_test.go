@@ -0,0 +1,372 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/reaper"
+)
+
+func TestWispReaperConcurrency(t *testing.T) {
+	if got := wispReaperConcurrency(nil); got != defaultWispReaperConcurrency {
+		t.Errorf("nil config: got %d, want %d", got, defaultWispReaperConcurrency)
+	}
+	for _, tc := range []struct {
+		v    int
+		want int
+	}{{4, 4}, {1, 1}, {0, defaultWispReaperConcurrency}, {-3, defaultWispReaperConcurrency}} {
+		v := tc.v
+		cfg := &DaemonPatrolConfig{Patrols: &PatrolsConfig{WispReaper: &WispReaperConfig{Concurrency: &v}}}
+		if got := wispReaperConcurrency(cfg); got != tc.want {
+			t.Errorf("concurrency %d: got %d, want %d", tc.v, got, tc.want)
+		}
+	}
+}
+
+func TestReapDatabasesConcurrently_AggregatesResults(t *testing.T) {
+	var logBuf bytes.Buffer
+	d := &Daemon{logger: log.New(&logBuf, "", 0)}
+
+	databases := []string{"hq", "gastown", "beads", "wyvern", "bad-name!", "noschema"}
+	reaped := map[string]int{"hq": 3, "gastown": 5, "beads": 7, "wyvern": 11}
+
+	var inFlight, maxInFlight atomic.Int32
+	reapOne := func(ctx context.Context, dbName string) (*reaper.ReapResult, bool, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Errorf("%s: context has no deadline", dbName)
+		}
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+
+		switch dbName {
+		case "noschema":
+			return nil, true, nil
+		case "wyvern":
+			return nil, false, errors.New("connect: connection refused")
+		}
+		return &reaper.ReapResult{Database: dbName, Reaped: reaped[dbName], OpenRemain: 1}, false, nil
+	}
+
+	res := newWispReaperResult(databases, false)
+	reapErrors := d.reapDatabasesConcurrently(databases, 3, reapOne, res)
+	res.finish()
+
+	if reapErrors != 1 {
+		t.Errorf("reapErrors = %d, want 1", reapErrors)
+	}
+	if got := maxInFlight.Load(); got < 2 || got > 3 {
+		t.Errorf("max in-flight = %d, want 2..3", got)
+	}
+	sum := 0
+	for _, db := range res.Databases {
+		sum += db.Reaped
+	}
+	if want := 3 + 5 + 7; res.Totals.Reaped != want || sum != want {
+		t.Errorf("Totals.Reaped = %d, per-db sum = %d, want %d", res.Totals.Reaped, sum, want)
+	}
+	if got := res.db("wyvern").Errors; len(got) != 1 {
+		t.Errorf("wyvern errors = %v, want one", got)
+	}
+
+	// Per-database log lines come out in name order regardless of which
+	// worker finished first.
+	out := logBuf.String()
+	var order []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		for _, db := range databases {
+			if strings.HasPrefix(line, "wisp_reaper: "+db+":") {
+				order = append(order, db)
+			}
+		}
+	}
+	want := []string{"beads", "gastown", "hq", "noschema", "wyvern"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("log order = %v, want %v\n%s", order, want, out)
+	}
+}
+
+func TestReapInDB_ContextBoundsQueries(t *testing.T) {
+	p, drv, _ := newStubDoltPool(t, &config.DoltThresholds{})
+	drv.setDelay(2 * time.Second)
+	d := &Daemon{logger: log.New(io.Discard, "", 0), doltPool: p}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err := d.reapInDB(ctx, reaperRetryPolicy{}, p.Port(), "wisp_reaper", "hq",
+		func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+			return reaper.ReapAsContext(ctx, db, "hq", time.Hour, reaper.ReapStatusClosed, true)
+		})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("reapInDB err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("reapInDB took %v; its context did not bound the queries", elapsed)
+	}
+}
+
+func TestReapInDB_UsesPoolThresholds(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{SlowQueryThreshold: "20ms", CmdTimeout: "200ms"})
+	d := &Daemon{logger: log.New(io.Discard, "", 0), doltPool: p}
+	reapOne := func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+		return reaper.ReapAsContext(ctx, db, "hq", time.Hour, reaper.ReapStatusClosed, true)
+	}
+
+	// Reaper statements are slow-query logged.
+	drv.setDelay(50 * time.Millisecond)
+	_, _, _ = d.reapInDB(context.Background(), reaperRetryPolicy{}, p.Port(), "wisp_reaper", "hq", reapOne)
+	if !strings.Contains(logBuf.String(), "slow query on hq") {
+		t.Errorf("reaper query not slow-query logged:\n%s", logBuf.String())
+	}
+
+	// cmd_timeout bounds each statement even under a longer reap deadline.
+	drv.setDelay(5 * time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	start := time.Now()
+	_, _, err := d.reapInDB(ctx, reaperRetryPolicy{}, p.Port(), "wisp_reaper", "hq", reapOne)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("reapInDB err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("reapInDB took %v; cmd_timeout not applied", elapsed)
+	}
+}
+
+func TestReapInDB_ReconnectsPoolOnConnectionError(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "2s"})
+	drv.setRow(int64(2)) // schema check: wisps and issues present
+	d := &Daemon{logger: log.New(io.Discard, "", 0), doltPool: p}
+	retry := reaperRetryPolicy{maxRetries: 1, sleep: func(time.Duration) {}}
+
+	calls := 0
+	reapOne := func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("read tcp 127.0.0.1:3307: connection reset by peer")
+		}
+		return &reaper.ReapResult{Database: "hq"}, nil
+	}
+	result, _, err := d.reapInDB(context.Background(), retry, p.Port(), "wisp_reaper", "hq", reapOne)
+	if err != nil {
+		t.Fatalf("reapInDB: %v", err)
+	}
+	if result == nil || calls != 2 {
+		t.Fatalf("result = %v after %d calls, want a result from the retry", result, calls)
+	}
+	if !strings.Contains(logBuf.String(), "lost connection to port 3307") {
+		t.Errorf("connection error did not start a pool reconnect:\n%s", logBuf.String())
+	}
+}
+
+// The close steps run on a pinned pool connection, like the reap.
+func TestDoltPoolConn_CloseSteps(t *testing.T) {
+	p, drv, logBuf := newStubDoltPool(t, &config.DoltThresholds{SlowQueryThreshold: "20ms", CmdTimeout: "200ms"})
+	drv.setDelay(50 * time.Millisecond)
+
+	err := p.WithConn(context.Background(), "hq", func(db reaper.DB) error {
+		if _, err := reaper.AutoClose(db, "hq", time.Hour, true); err != nil {
+			return fmt.Errorf("AutoClose: %w", err)
+		}
+		if _, err := reaper.ClosePluginReceipts(db, "hq", time.Hour, true); err != nil {
+			return fmt.Errorf("ClosePluginReceipts: %w", err)
+		}
+		if _, err := reaper.ClosePluginDispatches(db, "hq", time.Hour, true); err != nil {
+			return fmt.Errorf("ClosePluginDispatches: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(logBuf.String(), "slow query on hq"); n < 3 {
+		t.Errorf("got %d slow-query log lines, want >= 3:\n%s", n, logBuf.String())
+	}
+}
+
+// A reap's autocommit, UPDATE, COMMIT and DOLT_COMMIT statements all run on
+// one connection even while other statements use the pool concurrently.
+func TestReapInDB_RunsOnOneConnection(t *testing.T) {
+	p, drv, _ := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "2s"})
+	drv.setDelay(2 * time.Millisecond)
+	batches := 3
+	drv.respond = func(query string) []driver.Value {
+		switch {
+		case strings.Contains(query, "information_schema"):
+			return []driver.Value{int64(2)}
+		case strings.Contains(query, ".id FROM wisps"):
+			if batches == 0 {
+				return nil
+			}
+			batches--
+			return []driver.Value{fmt.Sprintf("w-%d", batches)}
+		default:
+			return []driver.Value{int64(0)}
+		}
+	}
+	d := &Daemon{logger: log.New(io.Discard, "", 0), doltPool: p}
+
+	// Background traffic keeps several pool connections busy, so statements
+	// not pinned to one connection would land on different ones.
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_, _ = p.ExecLogged(context.Background(), "hq", "SELECT 1")
+					time.Sleep(time.Millisecond)
+				}
+			}
+		}()
+	}
+	reapOne := func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+		return reaper.ReapAsContext(ctx, db, "hq", time.Hour, reaper.ReapStatusClosed, false)
+	}
+	result, _, err := d.reapInDB(context.Background(), reaperRetryPolicy{}, p.Port(), "wisp_reaper", "hq", reapOne)
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatalf("reapInDB: %v", err)
+	}
+	if result.Reaped != 3 {
+		t.Errorf("Reaped = %d, want 3", result.Reaped)
+	}
+
+	conns := map[int]bool{}
+	var reapStmts []string
+	for _, st := range drv.statements() {
+		if st.query == "SELECT 1" {
+			continue
+		}
+		conns[st.conn] = true
+		reapStmts = append(reapStmts, st.query)
+	}
+	if len(conns) != 1 {
+		t.Errorf("reap statements ran on %d connections, want 1:\n%s", len(conns), strings.Join(reapStmts, "\n"))
+	}
+	joined := strings.Join(reapStmts, "\n")
+	for _, want := range []string{"SET @@autocommit = 0", "UPDATE wisps", "COMMIT", "CALL DOLT_COMMIT", "SET @@autocommit = 1"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("reap did not run %q:\n%s", want, joined)
+		}
+	}
+}
+
+// A connection lost mid-reap rolls back the earlier batches. The failed
+// UPDATE must not be retried on its own on a fresh connection; the whole
+// reap restarts, so Reaped counts only rows that were committed.
+func TestReapInDB_RestartsReapAfterLostConnection(t *testing.T) {
+	p, drv, _ := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "2s"})
+	attempts, batches := 0, 0
+	drv.respond = func(query string) []driver.Value {
+		switch {
+		case strings.Contains(query, "information_schema"):
+			// A new attempt: rows from a rolled-back attempt are stale again.
+			attempts++
+			batches = 2
+			return []driver.Value{int64(2)}
+		case strings.Contains(query, ".id FROM wisps"):
+			if batches == 0 {
+				return nil
+			}
+			batches--
+			if attempts == 1 && batches == 0 {
+				// Lose the connection on the first attempt's second UPDATE.
+				drv.failQuery = "UPDATE wisps"
+				drv.failErr = errors.New("read tcp 127.0.0.1:3307: connection reset by peer")
+			}
+			return []driver.Value{fmt.Sprintf("w-%d", batches)}
+		default:
+			return []driver.Value{int64(0)}
+		}
+	}
+	d := &Daemon{logger: log.New(io.Discard, "", 0), doltPool: p}
+	retry := reaperRetryPolicy{maxRetries: 1, sleep: func(time.Duration) {}}
+	reapOne := func(ctx context.Context, db reaper.DB) (*reaper.ReapResult, error) {
+		return reaper.ReapAsContext(ctx, db, "hq", time.Hour, reaper.ReapStatusClosed, false)
+	}
+
+	result, _, err := d.reapInDB(context.Background(), retry, p.Port(), "wisp_reaper", "hq", reapOne)
+	if err != nil {
+		t.Fatalf("reapInDB: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("reap ran %d times, want 2 (restart after the lost connection)", attempts)
+	}
+	if result.Reaped != 2 {
+		t.Errorf("Reaped = %d, want 2: rolled-back rows must not be counted", result.Reaped)
+	}
+
+	// After the failed UPDATE, the next statement (other than the deferred
+	// autocommit reset) starts a new attempt rather than retrying it.
+	stmts := drv.statements()
+	updates := 0
+	for i, st := range stmts {
+		if !strings.Contains(st.query, "UPDATE wisps") {
+			continue
+		}
+		if updates++; updates != 2 {
+			continue
+		}
+		for _, next := range stmts[i+1:] {
+			if strings.HasPrefix(next.query, "SET @@autocommit") {
+				continue
+			}
+			if !strings.Contains(next.query, "information_schema") {
+				t.Errorf("statement after the failed UPDATE = %q, want a new attempt's schema check", next.query)
+			}
+			break
+		}
+		break
+	}
+}
+
+// A connection whose statement failed is not returned to the pool, so its
+// session state (e.g. autocommit=0) can't leak to the next user.
+func TestDoltConn_DiscardsFailedConnection(t *testing.T) {
+	p, drv, _ := newStubDoltPool(t, &config.DoltThresholds{CmdTimeout: "50ms"})
+	ctx := context.Background()
+
+	drv.setDelay(200 * time.Millisecond)
+	_ = p.WithConn(ctx, "hq", func(db reaper.DB) error {
+		_, _ = db.ExecContext(ctx, "SET @@autocommit = 0")
+		_, err := db.ExecContext(ctx, "UPDATE wisps SET status = 'closed'")
+		return err
+	})
+
+	drv.setDelay(0)
+	if err := p.WithConn(ctx, "hq", func(db reaper.DB) error {
+		_, err := db.ExecContext(ctx, "SELECT 1")
+		return err
+	}); err != nil {
+		t.Fatalf("WithConn: %v", err)
+	}
+	stmts := drv.statements()
+	if first, last := stmts[0].conn, stmts[len(stmts)-1].conn; first == last {
+		t.Errorf("failed connection %d was reused", first)
+	}
+}
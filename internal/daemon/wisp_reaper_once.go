@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"io"
+	"log"
+)
+
+// WispReaperOnceOptions overrides daemon.json's wisp_reaper settings for a
+// single on-demand cycle.
+type WispReaperOnceOptions struct {
+	// DryRun reports what would be reaped without changing anything. It can
+	// only turn dry-run on: a dry_run configured in daemon.json still applies.
+	DryRun bool
+	// Databases, when set, replaces both the configured databases and
+	// discovery.
+	Databases []string
+	// Logger receives the cycle's log lines (default: discarded).
+	Logger *log.Logger
+}
+
+// WispReaperOnceResult is the outcome of RunWispReaperOnce.
+type WispReaperOnceResult struct {
+	*WispReaperResult
+	// AlertThreshold is the configured open-wisp alert threshold.
+	AlertThreshold int
+	// Alert is set when the open count exceeds AlertThreshold.
+	Alert bool
+}
+
+// RunWispReaperOnce runs one wisp_reaper cycle for townRoot synchronously,
+// inline in the calling process, using the town's daemon.json settings. It
+// does not require the daemon to be running and does not dispatch a Dog.
+func RunWispReaperOnce(townRoot string, opts WispReaperOnceOptions) (*WispReaperOnceResult, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	d := &Daemon{
+		config:       &Config{TownRoot: townRoot},
+		patrolConfig: LoadPatrolConfig(townRoot),
+		logger:       logger,
+	}
+	defer d.closeDoltPool()
+
+	config := WispReaperConfig{}
+	if d.patrolConfig != nil && d.patrolConfig.Patrols != nil && d.patrolConfig.Patrols.WispReaper != nil {
+		config = *d.patrolConfig.Patrols.WispReaper
+	}
+	config.DryRun = config.DryRun || opts.DryRun
+	if len(opts.Databases) > 0 {
+		config.Databases = opts.Databases
+	}
+	// Stats are the daemon's record of its own cycles.
+	config.WriteStats = false
+
+	res, err := d.reapWispsInline(&config, wispDeleteAge(d.patrolConfig), &dogMol{})
+	if err != nil {
+		return nil, err
+	}
+	threshold := wispReaperAlertThreshold(d.patrolConfig)
+	return &WispReaperOnceResult{
+		WispReaperResult: res,
+		AlertThreshold:   threshold,
+		Alert:            res.Totals.Open > threshold,
+	}, nil
+}
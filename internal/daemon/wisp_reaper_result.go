@@ -0,0 +1,139 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// WispReaperCounts are the row counts of one wisp_reaper cycle, either for a
+// single database or totalled across all of them.
+type WispReaperCounts struct {
+	Reaped         int `json:"reaped"`
+	Open           int `json:"open"`
+	Purged         int `json:"purged"`
+	MailPurged     int `json:"mail_purged"`
+	PluginClosed   int `json:"plugin_closed"`
+	DispatchClosed int `json:"dispatch_closed"`
+	AutoClosed     int `json:"auto_closed"`
+}
+
+func (c *WispReaperCounts) add(o WispReaperCounts) {
+	c.Reaped += o.Reaped
+	c.Open += o.Open
+	c.Purged += o.Purged
+	c.MailPurged += o.MailPurged
+	c.PluginClosed += o.PluginClosed
+	c.DispatchClosed += o.DispatchClosed
+	c.AutoClosed += o.AutoClosed
+}
+
+// WispReaperDBResult is one database's outcome in a wisp_reaper cycle.
+type WispReaperDBResult struct {
+	Database string `json:"database"`
+	WispReaperCounts
+	Errors []string `json:"errors,omitempty"`
+}
+
+// WispReaperResult is the machine-readable outcome of a wisp_reaper cycle.
+type WispReaperResult struct {
+	Time      time.Time            `json:"time"`
+	DryRun    bool                 `json:"dry_run,omitempty"`
+	Databases []WispReaperDBResult `json:"databases"` // sorted by name
+	Totals    WispReaperCounts     `json:"totals"`
+}
+
+// newWispReaperResult starts a result with an entry per database.
+func newWispReaperResult(databases []string, dryRun bool) *WispReaperResult {
+	r := &WispReaperResult{Time: time.Now().UTC(), DryRun: dryRun}
+	for _, db := range databases {
+		r.Databases = append(r.Databases, WispReaperDBResult{Database: db})
+	}
+	return r
+}
+
+// db returns the entry for dbName, adding one if needed.
+func (r *WispReaperResult) db(dbName string) *WispReaperDBResult {
+	for i := range r.Databases {
+		if r.Databases[i].Database == dbName {
+			return &r.Databases[i]
+		}
+	}
+	r.Databases = append(r.Databases, WispReaperDBResult{Database: dbName})
+	return &r.Databases[len(r.Databases)-1]
+}
+
+// addError records a failed step for dbName.
+func (r *WispReaperResult) addError(dbName, step string, err error) {
+	e := r.db(dbName)
+	e.Errors = append(e.Errors, fmt.Sprintf("%s: %v", step, err))
+}
+
+// finish sorts the databases by name and computes the totals.
+func (r *WispReaperResult) finish() {
+	sort.Slice(r.Databases, func(i, j int) bool { return r.Databases[i].Database < r.Databases[j].Database })
+	r.Totals = WispReaperCounts{}
+	for _, db := range r.Databases {
+		r.Totals.add(db.WispReaperCounts)
+	}
+}
+
+// summary is the one-line cycle log, derived from the totals.
+func (r *WispReaperResult) summary() string {
+	t := r.Totals
+	return fmt.Sprintf("cycle complete — reaped=%d purged=%d mail_purged=%d plugin_closed=%d dispatch_closed=%d auto_closed=%d open=%d databases=%d dryRun=%v",
+		t.Reaped, t.Purged, t.MailPurged, t.PluginClosed, t.DispatchClosed, t.AutoClosed, t.Open, len(r.Databases), r.DryRun)
+}
+
+// firstError returns the first per-database error of the cycle, or nil.
+func (r *WispReaperResult) firstError() error {
+	for _, db := range r.Databases {
+		if len(db.Errors) > 0 {
+			return fmt.Errorf("%s: %s", db.Database, db.Errors[0])
+		}
+	}
+	return nil
+}
+
+// wispReaperStatsFile is where write_stats puts the last cycle's result.
+func wispReaperStatsFile(townRoot string) string {
+	return filepath.Join(townRoot, "daemon", "wisp_reaper_stats.json")
+}
+
+// LastWispReaperResult returns the result of the most recent inline
+// wisp_reaper cycle, or nil if none has run. Cycles dispatched to a Dog
+// report through the mol-dog-reaper molecule instead.
+func (d *Daemon) LastWispReaperResult() *WispReaperResult {
+	d.wispReaperMu.Lock()
+	defer d.wispReaperMu.Unlock()
+	return d.lastWispReaper
+}
+
+// recordWispReaperResult stores r as the last result and, if write_stats is
+// enabled, writes it as JSON to daemon/wisp_reaper_stats.json.
+func (d *Daemon) recordWispReaperResult(r *WispReaperResult, writeStats bool) {
+	d.wispReaperMu.Lock()
+	d.lastWispReaper = r
+	d.wispReaperMu.Unlock()
+	d.recordPatrolResult("wisp_reaper", r.summary(), r.firstError())
+
+	if !writeStats {
+		return
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		d.logger.Printf("wisp_reaper: marshal stats: %v", err)
+		return
+	}
+	path := wispReaperStatsFile(d.config.TownRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		d.logger.Printf("wisp_reaper: write stats: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		d.logger.Printf("wisp_reaper: write stats: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWispReaperResult_Aggregates(t *testing.T) {
+	// Two databases reporting different counts, listed out of order.
+	r := newWispReaperResult([]string{"hq", "gastown"}, false)
+	hq := r.db("hq")
+	hq.Reaped, hq.Open, hq.Purged, hq.AutoClosed = 5, 120, 3, 1
+	gt := r.db("gastown")
+	gt.Reaped, gt.Open, gt.MailPurged, gt.PluginClosed, gt.DispatchClosed = 7, 30, 2, 4, 6
+	r.addError("gastown", "purge", errors.New("connection refused"))
+	r.finish()
+
+	if len(r.Databases) != 2 || r.Databases[0].Database != "gastown" || r.Databases[1].Database != "hq" {
+		t.Fatalf("databases should be sorted by name, got %+v", r.Databases)
+	}
+	want := WispReaperCounts{Reaped: 12, Open: 150, Purged: 3, MailPurged: 2, PluginClosed: 4, DispatchClosed: 6, AutoClosed: 1}
+	if r.Totals != want {
+		t.Errorf("totals = %+v, want %+v", r.Totals, want)
+	}
+	if got := r.Databases[0].Errors; len(got) != 1 || got[0] != "purge: connection refused" {
+		t.Errorf("gastown errors = %v", got)
+	}
+
+	summary := r.summary()
+	for _, s := range []string{"reaped=12", "open=150", "auto_closed=1", "databases=2", "dryRun=false"} {
+		if !strings.Contains(summary, s) {
+			t.Errorf("summary %q missing %q", summary, s)
+		}
+	}
+}
+
+func TestWispReaperResult_JSON(t *testing.T) {
+	r := newWispReaperResult([]string{"hq"}, true)
+	r.db("hq").Reaped = 2
+	r.finish()
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	dbs := decoded["databases"].([]interface{})
+	hq := dbs[0].(map[string]interface{})
+	// Counts are flattened into each database entry.
+	if hq["database"] != "hq" || hq["reaped"] != float64(2) {
+		t.Errorf("database entry = %v", hq)
+	}
+	if decoded["dry_run"] != true {
+		t.Errorf("dry_run = %v, want true", decoded["dry_run"])
+	}
+}
+
+func TestRecordWispReaperResult(t *testing.T) {
+	townRoot := t.TempDir()
+	d := &Daemon{config: &Config{TownRoot: townRoot}, logger: log.New(&bytes.Buffer{}, "", 0)}
+
+	if d.LastWispReaperResult() != nil {
+		t.Fatal("LastWispReaperResult should be nil before any cycle")
+	}
+
+	r := newWispReaperResult([]string{"hq"}, false)
+	r.db("hq").Reaped = 3
+	r.finish()
+
+	d.recordWispReaperResult(r, false)
+	if d.LastWispReaperResult() != r {
+		t.Error("LastWispReaperResult should return the recorded result")
+	}
+	if _, err := os.Stat(wispReaperStatsFile(townRoot)); !os.IsNotExist(err) {
+		t.Errorf("stats file should not be written without write_stats: %v", err)
+	}
+
+	d.recordWispReaperResult(r, true)
+	data, err := os.ReadFile(wispReaperStatsFile(townRoot))
+	if err != nil {
+		t.Fatalf("read stats file: %v", err)
+	}
+	var got WispReaperResult
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal stats: %v", err)
+	}
+	if got.Totals.Reaped != 3 || len(got.Databases) != 1 {
+		t.Errorf("stats file = %+v", got)
+	}
+}
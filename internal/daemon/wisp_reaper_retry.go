@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// wispReaperQueryTimeout bounds the total time spent retrying one database's
+// reap, including its queries, which run under the same context. A Dolt
+// restart usually completes well within it.
+const wispReaperQueryTimeout = 2 * time.Minute
+
+// reaperRetrySleep waits between retries. Replaced in tests.
+var reaperRetrySleep = time.Sleep
+
+// reaperRetryPolicy retries transient Dolt failures with exponential backoff.
+// Backoff settings are shared with polecat Dolt retries (operational.polecat
+// dolt_max_retries, dolt_base_backoff, dolt_backoff_max).
+type reaperRetryPolicy struct {
+	maxRetries int
+	base       time.Duration
+	max        time.Duration
+	budget     time.Duration
+
+	sleep   func(time.Duration)                               // replaced in tests
+	onRetry func(attempt int, delay time.Duration, err error) // optional, for logging
+}
+
+// newReaperRetryPolicy builds the retry policy from the polecat Dolt thresholds.
+func newReaperRetryPolicy(p *config.PolecatThresholds) reaperRetryPolicy {
+	return reaperRetryPolicy{
+		maxRetries: p.DoltMaxRetriesV(),
+		base:       p.DoltBaseBackoffD(),
+		max:        p.DoltBackoffMaxD(),
+		budget:     wispReaperQueryTimeout,
+		sleep:      reaperRetrySleep,
+	}
+}
+
+// backoff returns the delay before retry attempt (1-indexed): base * 2^(attempt-1),
+// capped at max.
+func (p reaperRetryPolicy) backoff(attempt int) time.Duration {
+	d := p.base
+	for i := 1; i < attempt && d < p.max; i++ {
+		d *= 2
+	}
+	return min(d, p.max)
+}
+
+// do runs op, retrying while it fails with a retryable error, retries remain,
+// and the next backoff still fits in the budget. Returns op's last error.
+func (p reaperRetryPolicy) do(op func() error) error {
+	var spent time.Duration
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || !isRetryableDoltError(err) || attempt > p.maxRetries {
+			return err
+		}
+		delay := p.backoff(attempt)
+		if spent+delay > p.budget {
+			return err
+		}
+		if p.onRetry != nil {
+			p.onRetry(attempt, delay, err)
+		}
+		p.sleep(delay)
+		spent += delay
+	}
+}
+
+// isRetryableDoltError reports whether err looks like a transient connection
+// problem (server restarting, network timeout) rather than a SQL or schema
+// error that would fail the same way on every attempt.
+func isRetryableDoltError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "connection refused") ||
+		strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "broken pipe") ||
+		strings.Contains(msg, "bad connection") ||
+		strings.Contains(msg, "invalid connection") ||
+		strings.Contains(msg, "timeout") || // i/o timeout, lock wait timeout
+		strings.Contains(msg, "server has gone away")
+}
@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// testRetryPolicy returns a policy that records sleeps instead of sleeping.
+func testRetryPolicy(maxRetries int, budget time.Duration) (reaperRetryPolicy, *[]time.Duration) {
+	var slept []time.Duration
+	p := reaperRetryPolicy{
+		maxRetries: maxRetries,
+		base:       500 * time.Millisecond,
+		max:        4 * time.Second,
+		budget:     budget,
+		sleep:      func(d time.Duration) { slept = append(slept, d) },
+	}
+	return p, &slept
+}
+
+// failingExecutor fails with err for the first n calls, then succeeds.
+func failingExecutor(n int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= n {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestReaperRetry_TransientThenSuccess(t *testing.T) {
+	p, slept := testRetryPolicy(10, time.Minute)
+	op, calls := failingExecutor(3, errors.New("dial tcp 127.0.0.1:3307: connect: connection refused"))
+
+	if err := p.do(op); err != nil {
+		t.Fatalf("do() = %v, want success after retries", err)
+	}
+	if *calls != 4 {
+		t.Errorf("calls = %d, want 4", *calls)
+	}
+	want := []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second}
+	if fmt.Sprint(*slept) != fmt.Sprint(want) {
+		t.Errorf("backoffs = %v, want %v", *slept, want)
+	}
+}
+
+func TestReaperRetry_NonRetryableFailsFast(t *testing.T) {
+	p, slept := testRetryPolicy(10, time.Minute)
+	sqlErr := errors.New("Error 1064: You have an error in your SQL syntax")
+	op, calls := failingExecutor(5, sqlErr)
+
+	if err := p.do(op); !errors.Is(err, sqlErr) {
+		t.Fatalf("do() = %v, want %v", err, sqlErr)
+	}
+	if *calls != 1 || len(*slept) != 0 {
+		t.Errorf("calls = %d, sleeps = %v; want 1 call, no sleeps", *calls, *slept)
+	}
+}
+
+func TestReaperRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	p, slept := testRetryPolicy(2, time.Minute)
+	op, calls := failingExecutor(100, io.ErrUnexpectedEOF)
+
+	if err := p.do(op); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("do() = %v, want last error", err)
+	}
+	if *calls != 3 || len(*slept) != 2 {
+		t.Errorf("calls = %d, sleeps = %v; want 3 calls, 2 sleeps", *calls, *slept)
+	}
+}
+
+func TestReaperRetry_StaysWithinBudget(t *testing.T) {
+	// 500ms + 1s + 2s = 3.5s fits; the next 4s backoff would exceed 5s.
+	p, slept := testRetryPolicy(10, 5*time.Second)
+	op, calls := failingExecutor(100, errors.New("read tcp: i/o timeout"))
+
+	if err := p.do(op); err == nil {
+		t.Fatal("do() should fail once the budget is exhausted")
+	}
+	if *calls != 4 {
+		t.Errorf("calls = %d, want 4", *calls)
+	}
+	var total time.Duration
+	for _, d := range *slept {
+		total += d
+	}
+	if total > 5*time.Second {
+		t.Errorf("slept %v, exceeds 5s budget", total)
+	}
+}
+
+func TestReaperRetry_BackoffCapped(t *testing.T) {
+	p, _ := testRetryPolicy(10, time.Minute)
+	if got := p.backoff(10); got != 4*time.Second {
+		t.Errorf("backoff(10) = %v, want cap 4s", got)
+	}
+}
+
+func TestIsRetryableDoltError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("dial tcp 127.0.0.1:3307: connect: connection refused"), true},
+		{errors.New("read tcp 127.0.0.1:51234->127.0.0.1:3307: i/o timeout"), true},
+		{errors.New("driver: bad connection"), true},
+		{errors.New("invalid connection"), true},
+		{fmt.Errorf("check reaper schema: %w", io.EOF), true},
+		{errors.New("Error 1064: You have an error in your SQL syntax"), false},
+		{errors.New("table not found: wisps"), false},
+		{errors.New("Unknown column 'reaped_at' in 'field list'"), false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableDoltError(tt.err); got != tt.want {
+			t.Errorf("isRetryableDoltError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNewReaperRetryPolicy_UsesPolecatThresholds(t *testing.T) {
+	retries := 3
+	p := newReaperRetryPolicy(&config.PolecatThresholds{
+		DoltMaxRetries:  &retries,
+		DoltBaseBackoff: "250ms",
+		DoltBackoffMax:  "2s",
+	})
+	if p.maxRetries != 3 || p.base != 250*time.Millisecond || p.max != 2*time.Second {
+		t.Errorf("policy = {retries %d, base %v, max %v}, want {3, 250ms, 2s}", p.maxRetries, p.base, p.max)
+	}
+	if p.budget != wispReaperQueryTimeout {
+		t.Errorf("budget = %v, want %v", p.budget, wispReaperQueryTimeout)
+	}
+
+	p = newReaperRetryPolicy(nil)
+	if p.maxRetries != config.DefaultPolecatDoltMaxRetries || p.base != config.DefaultPolecatDoltBaseBackoff {
+		t.Errorf("nil thresholds should use defaults, got {retries %d, base %v}", p.maxRetries, p.base)
+	}
+}
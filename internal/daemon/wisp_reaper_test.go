@@ -1,8 +1,19 @@
 package daemon
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/reaper"
 )
 
 func TestWispReaperInterval(t *testing.T) {
@@ -49,6 +60,49 @@ func TestWispReaperMaxAge(t *testing.T) {
 	}
 }
 
+func TestWispReaperMaxAges_PerDatabase(t *testing.T) {
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{
+				Enabled:   true,
+				MaxAgeStr: "48h",
+				MaxAgeByDatabase: map[string]string{
+					"beads":   "6h",
+					"gastown": "72h",
+					"broken":  "nope",
+					"zero":    "0s",
+				},
+			},
+		},
+	}
+
+	got := wispReaperMaxAges(config, []string{"beads", "gastown", "hq", "broken", "zero"})
+	want := map[string]time.Duration{
+		"beads":   6 * time.Hour,
+		"gastown": 72 * time.Hour,
+		"hq":      48 * time.Hour, // no override: global max_age
+		"broken":  48 * time.Hour, // invalid override: global max_age
+		"zero":    48 * time.Hour, // non-positive override: global max_age
+	}
+	for db, w := range want {
+		if got[db] != w {
+			t.Errorf("max age for %s = %v, want %v", db, got[db], w)
+		}
+	}
+
+	// Without a global max_age, non-overridden databases use the default.
+	config.Patrols.WispReaper.MaxAgeStr = ""
+	if got := wispReaperMaxAgeFor(config, "hq"); got != defaultWispMaxAge {
+		t.Errorf("max age for hq = %v, want default %v", got, defaultWispMaxAge)
+	}
+	if got := wispReaperMaxAgeFor(config, "beads"); got != 6*time.Hour {
+		t.Errorf("max age for beads = %v, want 6h", got)
+	}
+	if got := wispReaperMaxAgeFor(nil, "beads"); got != defaultWispMaxAge {
+		t.Errorf("max age with nil config = %v, want default %v", got, defaultWispMaxAge)
+	}
+}
+
 func TestWispDeleteAge(t *testing.T) {
 	if got := wispDeleteAge(nil); got != defaultWispDeleteAge {
 		t.Errorf("expected default %v, got %v", defaultWispDeleteAge, got)
@@ -73,3 +127,293 @@ func TestDefaultReaperIntervalIsOneHour(t *testing.T) {
 		t.Errorf("expected default interval 1h, got %v", defaultWispReaperInterval)
 	}
 }
+
+func TestFormatReapResult(t *testing.T) {
+	r := &reaper.ReapResult{Database: "hq", Reaped: 12, OpenRemain: 340}
+	if got, want := formatReapResult(r), "hq: reaped 12 stale wisps, 340 open remain"; got != want {
+		t.Errorf("formatReapResult() = %q, want %q", got, want)
+	}
+
+	r.DryRun = true
+	if got, want := formatReapResult(r), "hq: would reap 12 stale wisps, 340 open remain"; got != want {
+		t.Errorf("formatReapResult(dry run) = %q, want %q", got, want)
+	}
+
+	r.DryRun = false
+	r.Status = reaper.ReapStatusReaped
+	if got, want := formatReapResult(r), "hq: reaped (archived) 12 stale wisps, 340 open remain"; got != want {
+		t.Errorf("formatReapResult(archived) = %q, want %q", got, want)
+	}
+}
+
+func TestWispReaperReapStatus(t *testing.T) {
+	if got := wispReaperReapStatus(nil); got != reaper.ReapStatusClosed {
+		t.Errorf("default reap status = %q, want %q", got, reaper.ReapStatusClosed)
+	}
+	if got := wispReaperInlineReason(nil); got != "" {
+		t.Errorf("default config should dispatch to a Dog, got inline reason %q", got)
+	}
+
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Enabled: true, ReapStatus: "reaped"},
+		},
+	}
+	if got := wispReaperReapStatus(config); got != reaper.ReapStatusReaped {
+		t.Errorf("reap status = %q, want %q", got, reaper.ReapStatusReaped)
+	}
+	if got := wispReaperInlineReason(config); got == "" {
+		t.Error("reap_status reaped should force the inline path")
+	}
+
+	config.Patrols.WispReaper.ReapStatus = "deleted"
+	if got := wispReaperReapStatus(config); got != reaper.ReapStatusClosed {
+		t.Errorf("invalid reap status = %q, want %q", got, reaper.ReapStatusClosed)
+	}
+	if got := wispReaperInlineReason(config); got != "" {
+		t.Errorf("invalid reap status should fall back to closed, got inline reason %q", got)
+	}
+}
+
+func TestWispReaperAlertThreshold(t *testing.T) {
+	if got := wispReaperAlertThreshold(nil); got != wispAlertThreshold {
+		t.Errorf("default threshold = %d, want %d", got, wispAlertThreshold)
+	}
+
+	threshold := 50
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Enabled: true, AlertThreshold: &threshold},
+		},
+	}
+	if got := wispReaperAlertThreshold(config); got != 50 {
+		t.Errorf("threshold = %d, want 50", got)
+	}
+
+	threshold = -1
+	if got := wispReaperAlertThreshold(config); got != wispAlertThreshold {
+		t.Errorf("negative threshold = %d, want default %d", got, wispAlertThreshold)
+	}
+}
+
+func TestCheckWispAlert_EmitsEvent(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	threshold := 10
+	var logBuf bytes.Buffer
+	d := &Daemon{
+		logger: log.New(&logBuf, "", 0),
+		patrolConfig: &DaemonPatrolConfig{
+			Patrols: &PatrolsConfig{
+				WispReaper: &WispReaperConfig{Enabled: true, AlertThreshold: &threshold},
+			},
+		},
+	}
+
+	// At the threshold: no alert.
+	if d.checkWispAlert(10, 2) {
+		t.Error("checkWispAlert(10) with threshold 10 should not alert")
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, events.EventsFile)); !os.IsNotExist(err) {
+		t.Fatalf("no event should be written at the threshold: %v", err)
+	}
+
+	// Over the threshold: logged and emitted.
+	if !d.checkWispAlert(11, 2) {
+		t.Fatal("checkWispAlert(11) with threshold 10 should alert")
+	}
+	if !strings.Contains(logBuf.String(), "11 open wisps exceed threshold 10") {
+		t.Errorf("log missing warning: %q", logBuf.String())
+	}
+
+	raw, err := os.ReadFile(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		t.Fatalf("read events log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("event count = %d, want 1: %s", len(lines), raw)
+	}
+	var event events.Event
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatalf("unmarshal event: %v", err)
+	}
+	if event.Type != events.TypeWispAlert {
+		t.Errorf("event type = %q, want %q", event.Type, events.TypeWispAlert)
+	}
+	if event.Visibility != events.VisibilityFeed {
+		t.Errorf("visibility = %q, want %q", event.Visibility, events.VisibilityFeed)
+	}
+	if open, _ := event.Payload["open"].(float64); open != 11 {
+		t.Errorf("payload open = %v, want 11", event.Payload["open"])
+	}
+	if th, _ := event.Payload["threshold"].(float64); th != 10 {
+		t.Errorf("payload threshold = %v, want 10", event.Payload["threshold"])
+	}
+}
+
+// isolatedDoltPortTown returns a town root with no Dolt port configured
+// anywhere: no settings, no daemon.json, no GT_DOLT_PORT.
+func isolatedDoltPortTown(t *testing.T) string {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GT_DOLT_PORT", "")
+	t.Setenv("GT_DOLT_IGNORE_CONFIG", "")
+	return t.TempDir()
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveDoltPort(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		d := &Daemon{config: &Config{TownRoot: isolatedDoltPortTown(t)}}
+		if port, source := d.resolveDoltPort(); port != doltserver.DefaultPort || source != "default" {
+			t.Errorf("resolveDoltPort() = %d, %q; want %d, default", port, source, doltserver.DefaultPort)
+		}
+	})
+
+	t.Run("town dolt config", func(t *testing.T) {
+		townRoot := isolatedDoltPortTown(t)
+		writeTestFile(t, filepath.Join(townRoot, "mayor", "daemon.json"), `{"env":{"GT_DOLT_PORT":"3399"}}`)
+		d := &Daemon{config: &Config{TownRoot: townRoot}}
+		if port, source := d.resolveDoltPort(); port != 3399 || source != "town dolt config" {
+			t.Errorf("resolveDoltPort() = %d, %q; want 3399, town dolt config", port, source)
+		}
+	})
+
+	t.Run("operational config beats town dolt config", func(t *testing.T) {
+		townRoot := isolatedDoltPortTown(t)
+		writeTestFile(t, filepath.Join(townRoot, "mayor", "daemon.json"), `{"env":{"GT_DOLT_PORT":"3399"}}`)
+		writeTestFile(t, filepath.Join(townRoot, "settings", "config.json"), `{"operational":{"dolt":{"port":3410}}}`)
+		d := &Daemon{config: &Config{TownRoot: townRoot}}
+		if port, source := d.resolveDoltPort(); port != 3410 || source != "operational.dolt.port" {
+			t.Errorf("resolveDoltPort() = %d, %q; want 3410, operational.dolt.port", port, source)
+		}
+	})
+
+	t.Run("managed server wins", func(t *testing.T) {
+		townRoot := isolatedDoltPortTown(t)
+		writeTestFile(t, filepath.Join(townRoot, "settings", "config.json"), `{"operational":{"dolt":{"port":3410}}}`)
+		d := &Daemon{
+			config:     &Config{TownRoot: townRoot},
+			doltServer: &DoltServerManager{config: &DoltServerConfig{Port: 3500}},
+		}
+		if port, source := d.resolveDoltPort(); port != 3500 || source != "managed dolt server" {
+			t.Errorf("resolveDoltPort() = %d, %q; want 3500, managed dolt server", port, source)
+		}
+	})
+}
+
+func TestReapWispsInline_UnreachableServerShortCircuits(t *testing.T) {
+	townRoot := isolatedDoltPortTown(t)
+
+	origDial, origSleep := dialDolt, reaperRetrySleep
+	t.Cleanup(func() { dialDolt, reaperRetrySleep = origDial, origSleep })
+	dials := 0
+	dialDolt = func(port int) error {
+		dials++
+		return errors.New("dial tcp 127.0.0.1:3307: connect: connection refused")
+	}
+	reaperRetrySleep = func(time.Duration) {}
+
+	var logBuf bytes.Buffer
+	d := &Daemon{
+		config: &Config{TownRoot: townRoot},
+		logger: log.New(&logBuf, "", 0),
+	}
+	config := &WispReaperConfig{Enabled: true, Databases: []string{"hq", "gastown", "beads"}}
+	d.reapWispsInline(config, defaultWispDeleteAge, &dogMol{})
+
+	if dials < 2 {
+		t.Errorf("dialed %d times, want the probe retried on connection refused", dials)
+	}
+	out := logBuf.String()
+	if n := strings.Count(out, "unreachable"); n != 1 {
+		t.Errorf("want exactly one unreachable line, got %d:\n%s", n, out)
+	}
+	if !strings.Contains(out, "127.0.0.1:3307 (port from default)") {
+		t.Errorf("log should name the port and its source:\n%s", out)
+	}
+	if strings.Contains(out, "reap error") || strings.Contains(out, "hq") {
+		t.Errorf("no per-database work should run when the server is unreachable:\n%s", out)
+	}
+	if d.LastWispReaperResult() != nil {
+		t.Error("a skipped cycle should not record a result")
+	}
+}
+
+func TestRunWispReaperOnce_UnreachableServerReturnsError(t *testing.T) {
+	townRoot := isolatedDoltPortTown(t)
+
+	origDial, origSleep := dialDolt, reaperRetrySleep
+	t.Cleanup(func() { dialDolt, reaperRetrySleep = origDial, origSleep })
+	dialDolt = func(port int) error { return errors.New("connection refused") }
+	reaperRetrySleep = func(time.Duration) {}
+
+	res, err := RunWispReaperOnce(townRoot, WispReaperOnceOptions{Databases: []string{"hq"}})
+	if err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Fatalf("RunWispReaperOnce() = %v, %v; want unreachable error", res, err)
+	}
+}
+
+func TestCheckWispReaperDatabases(t *testing.T) {
+	patrol := func(strict bool, dbs ...string) *DaemonPatrolConfig {
+		return &DaemonPatrolConfig{Patrols: &PatrolsConfig{
+			WispReaper: &WispReaperConfig{Enabled: true, Databases: dbs, StrictDatabases: strict},
+		}}
+	}
+	mixed := []string{"hq", "gas town", "beads_2", "gastown;drop", "my-rig"}
+
+	if got := invalidWispReaperDatabases(patrol(false, mixed...)); strings.Join(got, "|") != "gas town|gastown;drop" {
+		t.Errorf("invalidWispReaperDatabases = %q, want the two invalid names in order", got)
+	}
+
+	t.Run("warns once listing every invalid name", func(t *testing.T) {
+		var logBuf bytes.Buffer
+		d := &Daemon{logger: log.New(&logBuf, "", 0), patrolConfig: patrol(false, mixed...)}
+		if err := d.checkWispReaperDatabases(); err != nil {
+			t.Fatalf("non-strict check returned %v", err)
+		}
+		out := logBuf.String()
+		if strings.Count(out, "\n") != 1 || !strings.Contains(out, `"gas town", "gastown;drop"`) {
+			t.Errorf("want one warning naming both invalid names, got:\n%s", out)
+		}
+		if strings.Contains(out, `"hq"`) || strings.Contains(out, "my-rig") {
+			t.Errorf("valid names should not be reported:\n%s", out)
+		}
+	})
+
+	t.Run("strict mode refuses to start", func(t *testing.T) {
+		var logBuf bytes.Buffer
+		d := &Daemon{logger: log.New(&logBuf, "", 0), patrolConfig: patrol(true, mixed...)}
+		err := d.checkWispReaperDatabases()
+		if err == nil || !strings.Contains(err.Error(), `"gas town", "gastown;drop"`) {
+			t.Fatalf("strict check = %v, want error naming the invalid databases", err)
+		}
+	})
+
+	t.Run("all valid or unconfigured is silent", func(t *testing.T) {
+		for _, cfg := range []*DaemonPatrolConfig{nil, {}, patrol(true, "hq", "beads")} {
+			var logBuf bytes.Buffer
+			d := &Daemon{logger: log.New(&logBuf, "", 0), patrolConfig: cfg}
+			if err := d.checkWispReaperDatabases(); err != nil || logBuf.Len() != 0 {
+				t.Errorf("check(%+v) = %v, log %q; want nil and no log", cfg, err, logBuf.String())
+			}
+		}
+	})
+}
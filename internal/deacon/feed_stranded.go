@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/util"
 )
@@ -204,13 +205,17 @@ func FindStrandedConvoys(townRoot string) ([]StrandedConvoy, error) {
 // Empty convoys (0 tracked) are auto-closed. Feedable convoys get a dog dispatched.
 // Convoys with tracked-but-not-ready issues are surfaced as "needs_attention" with
 // raw data (tracked_count, ready_count) for the deacon agent to inspect and decide.
-// Rate limits by maxPerCycle and per-convoy cooldown.
+// Rate limits by maxPerCycle and per-convoy cooldown. A zero cooldown uses the
+// configured feed cooldown plus jitter.
 func FeedStranded(townRoot string, maxPerCycle int, cooldown time.Duration) *FeedResult {
 	result := &FeedResult{}
 
 	if maxPerCycle <= 0 {
 		maxPerCycle = DefaultMaxFeedsPerCycle
 	}
+	if cooldown <= 0 {
+		cooldown = config.LoadOperationalConfigLayered(townRoot).GetDeaconConfig().FeedCooldownWithJitter()
+	}
 	if cooldown <= 0 {
 		cooldown = DefaultFeedCooldown
 	}
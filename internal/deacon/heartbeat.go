@@ -5,9 +5,12 @@ package deacon
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/atomicfile"
 )
 
 // Heartbeat age thresholds — these are compiled-in defaults.
@@ -69,7 +72,8 @@ func WriteHeartbeat(townRoot string, hb *Heartbeat) error {
 		return err
 	}
 
-	if err := os.WriteFile(hbFile, data, 0600); err != nil {
+	// Write atomically so a kill mid-write can't leave a truncated file.
+	if err := atomicfile.WriteFile(hbFile, data, 0600); err != nil {
 		return err
 	}
 
@@ -82,22 +86,47 @@ func WriteHeartbeat(townRoot string, hb *Heartbeat) error {
 	return nil
 }
 
-// ReadHeartbeat reads the Deacon heartbeat from disk.
-// Returns nil if the file doesn't exist or can't be read.
-func ReadHeartbeat(townRoot string) *Heartbeat {
-	hbFile := HeartbeatFile(townRoot)
+// ErrHeartbeatCorrupt is returned by LoadHeartbeat when the heartbeat file
+// exists but is empty or unparseable. Its contents say nothing about the
+// Deacon's liveness; see HeartbeatModTime.
+var ErrHeartbeatCorrupt = errors.New("heartbeat file is empty or corrupt")
 
-	data, err := os.ReadFile(hbFile) //nolint:gosec // G304: path is constructed from trusted townRoot
+// LoadHeartbeat reads the Deacon heartbeat from disk. Returns (nil, nil) if
+// the file doesn't exist, and ErrHeartbeatCorrupt if it is empty,
+// unparseable, or has no timestamp.
+func LoadHeartbeat(townRoot string) (*Heartbeat, error) {
+	data, err := os.ReadFile(HeartbeatFile(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
 	if err != nil {
-		return nil
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
 	var hb Heartbeat
-	if err := json.Unmarshal(data, &hb); err != nil {
-		return nil
+	if err := json.Unmarshal(data, &hb); err != nil || hb.Timestamp.IsZero() {
+		return nil, ErrHeartbeatCorrupt
 	}
+	return &hb, nil
+}
 
-	return &hb
+// HeartbeatModTime returns when the heartbeat file was last written. It is a
+// fallback for a corrupt heartbeat: the file's age still bounds how long ago
+// the Deacon last wrote it.
+func HeartbeatModTime(townRoot string) (time.Time, error) {
+	info, err := os.Stat(HeartbeatFile(townRoot))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// ReadHeartbeat reads the Deacon heartbeat from disk.
+// Returns nil if the file doesn't exist or can't be read.
+// Use LoadHeartbeat to tell a missing file from a corrupt one.
+func ReadHeartbeat(townRoot string) *Heartbeat {
+	hb, _ := LoadHeartbeat(townRoot)
+	return hb
 }
 
 // Age returns how old the heartbeat is.
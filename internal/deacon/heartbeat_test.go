@@ -1,6 +1,7 @@
 package deacon
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -374,3 +375,56 @@ func TestWriteHeartbeat_SetsTimestamp(t *testing.T) {
 		t.Error("Timestamp should be recent")
 	}
 }
+
+func TestWriteHeartbeat_LeavesNoTempFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for i := 0; i < 3; i++ {
+		if err := Touch(tmpDir); err != nil {
+			t.Fatalf("Touch error: %v", err)
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(tmpDir, "deacon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != "heartbeat.json" && e.Name() != ".deacon-heartbeat" {
+			t.Errorf("unexpected file left behind: %s", e.Name())
+		}
+	}
+}
+
+func TestLoadHeartbeat_Corrupt(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty", ""},
+		{"truncated", `{"timestamp": "2026-03-28T22:00:00Z", "cy`},
+		{"no timestamp", `{"cycle": 3}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			hbFile := HeartbeatFile(tmpDir)
+			if err := os.MkdirAll(filepath.Dir(hbFile), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(hbFile, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			hb, err := LoadHeartbeat(tmpDir)
+			if !errors.Is(err, ErrHeartbeatCorrupt) || hb != nil {
+				t.Errorf("LoadHeartbeat = (%v, %v), want ErrHeartbeatCorrupt", hb, err)
+			}
+		})
+	}
+}
+
+func TestLoadHeartbeat_Missing(t *testing.T) {
+	hb, err := LoadHeartbeat(t.TempDir())
+	if hb != nil || err != nil {
+		t.Errorf("LoadHeartbeat = (%v, %v), want (nil, nil)", hb, err)
+	}
+}
@@ -32,7 +32,7 @@ type tmuxOps interface {
 	GetPaneID(session string) (string, error)
 	ConfigureGasTownSession(session string, theme *tmux.Theme, rig, worker, role string) error
 	WaitForCommand(session string, excludeCommands []string, timeout time.Duration) error
-	SetAutoRespawnHook(session string) error
+	SetAutoRespawnHook(session string, policy tmux.RespawnPolicy) error
 	AcceptStartupDialogs(session string) error
 	AcceptWorkspaceTrustDialog(session string) error
 	AcceptBypassPermissionsWarning(session string) error
@@ -154,8 +154,11 @@ func (m *Manager) Start(agentOverride string) error {
 	theme := tmux.ResolveSessionTheme(m.townRoot, "", "deacon", "")
 	_ = t.ConfigureGasTownSession(sessionID, theme, "", "Deacon", "health-check")
 
+	opCfg := config.LoadOperationalConfigLayered(m.townRoot)
+	sessionCfg := opCfg.SessionConfigForRole("deacon")
+
 	// Wait for Claude to start - fatal if Claude fails to launch
-	if err := t.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
+	if err := t.WaitForCommand(sessionID, constants.SupportedShells, sessionCfg.ClaudeStartTimeoutD()); err != nil {
 		// Kill the zombie session before returning error
 		_ = t.KillSessionWithProcesses(sessionID)
 		return fmt.Errorf("waiting for deacon to start: %w", err)
@@ -170,7 +173,8 @@ func (m *Manager) Start(agentOverride string) error {
 	// When Claude exits (for any reason), tmux will automatically respawn it.
 	// This prevents the crash loop where daemon repeatedly restarts Deacon.
 	// Note: SetAutoRespawnHook calls SetRemainOnExit again (harmless, already set above).
-	if err := t.SetAutoRespawnHook(sessionID); err != nil {
+	policy := tmux.RespawnPolicyForRole(opCfg, "deacon")
+	if err := t.SetAutoRespawnHook(sessionID, policy); err != nil {
 		// Non-fatal: Deacon still works, just won't auto-respawn on crash
 		// Daemon will still restart it, but with a delay
 		fmt.Printf("warning: failed to set auto-respawn hook for deacon: %v\n", err)
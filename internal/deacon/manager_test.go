@@ -60,11 +60,11 @@ func (m *mockTmux) WaitForCommand(_ string, _ []string, _ time.Duration) error {
 	return m.waitErr
 }
 
-func (m *mockTmux) SetAutoRespawnHook(_ string) error             { return nil }
-func (m *mockTmux) AcceptStartupDialogs(_ string) error           { return nil }
-func (m *mockTmux) AcceptWorkspaceTrustDialog(_ string) error     { return nil }
-func (m *mockTmux) AcceptBypassPermissionsWarning(_ string) error { return nil }
-func (m *mockTmux) SendKeysRaw(_, _ string) error                 { return m.sendKeysErr }
+func (m *mockTmux) SetAutoRespawnHook(_ string, _ tmux.RespawnPolicy) error { return nil }
+func (m *mockTmux) AcceptStartupDialogs(_ string) error                     { return nil }
+func (m *mockTmux) AcceptWorkspaceTrustDialog(_ string) error               { return nil }
+func (m *mockTmux) AcceptBypassPermissionsWarning(_ string) error           { return nil }
+func (m *mockTmux) SendKeysRaw(_, _ string) error                           { return m.sendKeysErr }
 func (m *mockTmux) GetSessionInfo(_ string) (*tmux.SessionInfo, error) {
 	return m.sessionInfo, m.sessionInfoErr
 }
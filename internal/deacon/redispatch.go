@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -214,13 +215,16 @@ func (s *BeadRedispatchState) RecordEscalation() {
 //   - beadID: the recovered bead to re-dispatch
 //   - sourceRig: the rig from which the bead was recovered (empty = auto-detect from prefix)
 //   - maxAttempts: max re-dispatches before escalating (0 = use default)
-//   - cooldown: min time between re-dispatches (0 = use default)
+//   - cooldown: min time between re-dispatches (0 = use configured cooldown plus jitter)
 func Redispatch(townRoot, beadID, sourceRig string, maxAttempts int, cooldown time.Duration) *RedispatchResult {
 	result := &RedispatchResult{BeadID: beadID}
 
 	if maxAttempts <= 0 {
 		maxAttempts = DefaultMaxRedispatches
 	}
+	if cooldown <= 0 {
+		cooldown = config.LoadOperationalConfigLayered(townRoot).GetDeaconConfig().RedispatchCooldownWithJitter()
+	}
 	if cooldown <= 0 {
 		cooldown = DefaultRedispatchCooldown
 	}
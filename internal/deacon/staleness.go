@@ -0,0 +1,125 @@
+package deacon
+
+import (
+	"time"
+)
+
+// StalenessGrade classifies a heartbeat by age.
+type StalenessGrade int
+
+const (
+	// GradeHealthy is a heartbeat younger than the stale threshold.
+	GradeHealthy StalenessGrade = iota
+	// GradeStale is a heartbeat between the stale and very-stale thresholds.
+	GradeStale
+	// GradeVeryStale is a heartbeat at or past the very-stale threshold.
+	GradeVeryStale
+)
+
+func (g StalenessGrade) String() string {
+	switch g {
+	case GradeStale:
+		return "stale"
+	case GradeVeryStale:
+		return "very_stale"
+	default:
+		return "healthy"
+	}
+}
+
+// StalenessThresholds are the heartbeat ages at which an agent becomes stale
+// and very stale (operational.deacon.heartbeat_stale_threshold and
+// heartbeat_very_stale_threshold).
+type StalenessThresholds struct {
+	Stale     time.Duration
+	VeryStale time.Duration
+}
+
+// Grade returns the grade of a heartbeat of the given age.
+func (t StalenessThresholds) Grade(age time.Duration) StalenessGrade {
+	switch {
+	case age >= t.VeryStale:
+		return GradeVeryStale
+	case age >= t.Stale:
+		return GradeStale
+	default:
+		return GradeHealthy
+	}
+}
+
+// threshold returns the threshold that bounds grade g from below.
+func (t StalenessThresholds) threshold(g StalenessGrade) time.Duration {
+	if g == GradeVeryStale {
+		return t.VeryStale
+	}
+	return t.Stale
+}
+
+// StalenessTransition is a monitored agent's heartbeat crossing a staleness
+// boundary.
+type StalenessTransition struct {
+	Agent     string
+	From      StalenessGrade
+	To        StalenessGrade
+	Age       time.Duration
+	Threshold time.Duration // the boundary crossed
+}
+
+// Worsened reports whether the heartbeat got staler.
+func (t StalenessTransition) Worsened() bool {
+	return t.To > t.From
+}
+
+// StalenessTracker grades heartbeat ages per agent and reports boundary
+// crossings. Agents start out healthy.
+//
+// To avoid spamming on a heartbeat flapping near a boundary, recoveries are
+// only reported once Debounce has passed since the agent's last reported
+// transition; until then the agent keeps its reported grade. Worsening is
+// always reported immediately. Not safe for concurrent use.
+type StalenessTracker struct {
+	Debounce time.Duration
+
+	agents map[string]*agentStaleness
+}
+
+type agentStaleness struct {
+	grade      StalenessGrade
+	reportedAt time.Time
+}
+
+// NewStalenessTracker returns a tracker with the given recovery debounce.
+func NewStalenessTracker(debounce time.Duration) *StalenessTracker {
+	return &StalenessTracker{Debounce: debounce, agents: make(map[string]*agentStaleness)}
+}
+
+// Observe records agent's heartbeat age at now and returns the transition to
+// report, or nil if the reported grade is unchanged.
+func (s *StalenessTracker) Observe(agent string, age time.Duration, now time.Time, thresholds StalenessThresholds) *StalenessTransition {
+	if s.agents == nil {
+		s.agents = make(map[string]*agentStaleness)
+	}
+	st, ok := s.agents[agent]
+	if !ok {
+		st = &agentStaleness{grade: GradeHealthy}
+		s.agents[agent] = st
+	}
+
+	grade := thresholds.Grade(age)
+	if grade == st.grade {
+		return nil
+	}
+	if grade < st.grade && !st.reportedAt.IsZero() && now.Sub(st.reportedAt) < s.Debounce {
+		return nil
+	}
+
+	tr := &StalenessTransition{Agent: agent, From: st.grade, To: grade, Age: age}
+	if tr.Worsened() {
+		tr.Threshold = thresholds.threshold(grade)
+	} else {
+		tr.Threshold = thresholds.threshold(st.grade)
+	}
+	st.grade = grade
+	st.reportedAt = now
+	return tr
+}
@@ -0,0 +1,76 @@
+package deacon
+
+import (
+	"testing"
+	"time"
+)
+
+var testThresholds = StalenessThresholds{Stale: 5 * time.Minute, VeryStale: 20 * time.Minute}
+
+func TestStalenessTracker_OneEventPerBoundary(t *testing.T) {
+	tracker := NewStalenessTracker(5 * time.Minute)
+	start := time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)
+
+	// Drive the heartbeat age from 0 to 25 minutes in 30s steps.
+	var got []StalenessTransition
+	for age := time.Duration(0); age <= 25*time.Minute; age += 30 * time.Second {
+		if tr := tracker.Observe("deacon", age, start.Add(age), testThresholds); tr != nil {
+			got = append(got, *tr)
+		}
+	}
+
+	want := []StalenessTransition{
+		{Agent: "deacon", From: GradeHealthy, To: GradeStale, Age: 5 * time.Minute, Threshold: 5 * time.Minute},
+		{Agent: "deacon", From: GradeStale, To: GradeVeryStale, Age: 20 * time.Minute, Threshold: 20 * time.Minute},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d transitions %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("transition %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// A fresh heartbeat well after the last report is a recovery.
+	tr := tracker.Observe("deacon", 10*time.Second, start.Add(26*time.Minute), testThresholds)
+	if tr == nil || tr.From != GradeVeryStale || tr.To != GradeHealthy || tr.Threshold != 20*time.Minute || tr.Worsened() {
+		t.Errorf("recovery = %+v, want very_stale -> healthy across 20m", tr)
+	}
+}
+
+func TestStalenessTracker_DebouncesFlapping(t *testing.T) {
+	tracker := NewStalenessTracker(5 * time.Minute)
+	now := time.Date(2026, 3, 28, 22, 0, 0, 0, time.UTC)
+
+	// Heartbeat flaps around the stale boundary every 30s.
+	events := 0
+	for i := 0; i < 8; i++ {
+		age := 4*time.Minute + 59*time.Second
+		if i%2 == 1 {
+			age = 5*time.Minute + time.Second
+		}
+		if tr := tracker.Observe("deacon", age, now.Add(time.Duration(i)*30*time.Second), testThresholds); tr != nil {
+			events++
+		}
+	}
+	if events != 1 {
+		t.Errorf("flapping heartbeat produced %d events, want 1", events)
+	}
+
+	// Once the debounce has passed, the recovery is reported.
+	if tr := tracker.Observe("deacon", time.Minute, now.Add(10*time.Minute), testThresholds); tr == nil || tr.To != GradeHealthy {
+		t.Errorf("recovery after debounce = %+v, want -> healthy", tr)
+	}
+}
+
+func TestStalenessTracker_AgentsAreIndependent(t *testing.T) {
+	tracker := NewStalenessTracker(time.Minute)
+	now := time.Now()
+	if tr := tracker.Observe("deacon", 6*time.Minute, now, testThresholds); tr == nil {
+		t.Fatal("deacon should go stale")
+	}
+	if tr := tracker.Observe("gastown/witness", 30*time.Minute, now, testThresholds); tr == nil || tr.From != GradeHealthy || tr.To != GradeVeryStale {
+		t.Errorf("witness = %+v, want healthy -> very_stale", tr)
+	}
+}
@@ -41,7 +41,7 @@ func DefaultStuckConfig() *StuckConfig {
 // back to compiled-in defaults. The townRoot parameter is used to locate
 // the settings/config.json file.
 func LoadStuckConfig(townRoot string) *StuckConfig {
-	opCfg := config.LoadOperationalConfig(townRoot)
+	opCfg := config.LoadOperationalConfigLayered(townRoot)
 	deaconCfg := opCfg.GetDeaconConfig()
 	return &StuckConfig{
 		PingTimeout:         deaconCfg.PingTimeoutD(),
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/templates"
 )
 
 func TestPrimingCheck_PolecatNewStructure(t *testing.T) {
@@ -439,6 +441,29 @@ func TestPrimingCheck_FixPreservesCustomerClaudeMd(t *testing.T) {
 	}
 }
 
+// TestPrimingCheck_FixCreatesManagedTownClaudeMd verifies that a missing
+// town-root CLAUDE.md is recreated with the managed region markers, matching
+// what gt install writes and gt upgrade maintains.
+func TestPrimingCheck_FixCreatesManagedTownClaudeMd(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	check := NewPrimingCheck()
+	ctx := &CheckContext{TownRoot: tmpDir}
+	_ = check.Run(ctx)
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("town-root CLAUDE.md not created: %v", err)
+	}
+	if got, want := string(data), templates.TownRootManagedRegion(); got != want {
+		t.Errorf("town-root CLAUDE.md = %q, want %q", got, want)
+	}
+}
+
 // TestPrimingCheck_FlagsStaleAgentLevelFiles verifies that CLAUDE.md/AGENTS.md
 // at agent level (e.g., refinery/CLAUDE.md) ARE flagged as stale files.
 // These are no longer created — only ~/gt/CLAUDE.md (town root) exists.
@@ -80,8 +80,8 @@ func (c *StalledPolecatCheck) Run(ctx *CheckContext) *CheckResult {
 			}
 
 			polecatGit := git.NewGit(clonePath)
-			branch, brErr := polecatGit.CurrentBranch()
-			if brErr != nil || branch == "" {
+			branch, onBranch, brErr := polecatGit.CurrentBranch()
+			if brErr != nil || !onBranch || branch == "" {
 				continue
 			}
 
@@ -598,7 +598,7 @@ func (m *Manager) cleanupStaleBranchesForRig(repoGit *git.Git, rigName string) (
 			if worktreePath, ok := dog.Worktrees[rigName]; ok {
 				// Get branch name for this worktree
 				worktreeGit := git.NewGit(worktreePath)
-				if branch, err := worktreeGit.CurrentBranch(); err == nil {
+				if branch, onBranch, err := worktreeGit.CurrentBranch(); err == nil && onBranch {
 					currentBranches[branch] = true
 				}
 			}
@@ -51,8 +51,28 @@ const (
 	TypeSessionEnd   = "session_end"
 
 	// Session death events (for crash investigation)
-	TypeSessionDeath = "session_death" // Feed-visible session termination
-	TypeMassDeath    = "mass_death"    // Multiple sessions died in short window
+	TypeSessionDeath   = "session_death"   // Feed-visible session termination
+	TypeMassDeath      = "mass_death"      // Multiple sessions died in short window
+	TypeRespawn        = "respawn"         // Pane restarted by the tmux auto-respawn hook
+	TypeRespawnPaused  = "respawn_paused"  // Auto-respawn hooks disabled after a mass death
+	TypeRespawnResumed = "respawn_resumed" // Auto-respawn hooks restored after the pause
+	TypeSessionHung    = "session_hung"    // Session idle with frozen pane output past the hung threshold
+
+	// GUPP events
+	TypeGUPPViolation = "gupp_violation" // Agent with hooked work stopped progressing past the GUPP timeout
+
+	// Reaper events
+	TypeWispAlert = "wisp_alert" // Open wisp count exceeded the reaper alert threshold
+
+	// Polecat namepool events
+	TypeNamepoolGrown = "namepool_grown" // Exhausted namepool grew by namepool_grow_increment
+
+	// Nudge events
+	TypeNudgeExpired        = "nudge_expired"         // Queued nudge dropped undelivered (dead-lettered)
+	TypeNudgeClaimReclaimed = "nudge_claim_reclaimed" // Orphaned nudge claim returned to the queue
+
+	// Heartbeat events
+	TypeHeartbeatStaleness = "heartbeat_staleness" // Agent heartbeat crossed a staleness threshold
 
 	// Witness patrol events
 	TypePatrolStarted   = "patrol_started"
@@ -323,6 +343,132 @@ func MassDeathPayload(count int, window string, sessions []string, possibleCause
 	return p
 }
 
+// RespawnPayload creates a payload for respawn events.
+// session: tmux target that was respawned (session or session:window)
+// trigger: what restarted it (e.g., "pane-died hook")
+func RespawnPayload(session, trigger string) map[string]interface{} {
+	return map[string]interface{}{
+		"session": session,
+		"trigger": trigger,
+	}
+}
+
+// RespawnPausePayload creates a payload for respawn_paused and
+// respawn_resumed events.
+// sessions: sessions whose auto-respawn hook was cleared or restored
+// pause: how long the hooks stay disabled (e.g., "5m0s")
+func RespawnPausePayload(sessions []string, pause string) map[string]interface{} {
+	return map[string]interface{}{
+		"sessions": sessions,
+		"count":    len(sessions),
+		"pause":    pause,
+	}
+}
+
+// WispAlertPayload creates a payload for wisp alert events.
+// open: open wisps remaining after the reap cycle
+// threshold: configured alert threshold that was exceeded
+// databases: number of databases scanned
+func WispAlertPayload(open, threshold, databases int) map[string]interface{} {
+	return map[string]interface{}{
+		"open":      open,
+		"threshold": threshold,
+		"databases": databases,
+	}
+}
+
+// NamepoolGrownPayload creates a payload for namepool growth events.
+// rig: rig whose polecat namepool grew
+// from, to: capacity before and after growing
+// limit: hard cap on growth (namepool_max_size)
+func NamepoolGrownPayload(rig string, from, to, limit int) map[string]interface{} {
+	return map[string]interface{}{
+		"rig":  rig,
+		"from": from,
+		"to":   to,
+		"max":  limit,
+	}
+}
+
+// NudgeExpiredPayload creates a payload for nudge expiry events.
+// session: recipient session whose queue held the nudge
+// sender: who sent the nudge
+// priority: nudge priority ("normal", "urgent")
+// reason: dead-letter reason (e.g., "expired")
+// expiresAt: when the nudge's TTL ran out
+func NudgeExpiredPayload(session, sender, priority, reason string, expiresAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"session":    session,
+		"sender":     sender,
+		"priority":   priority,
+		"reason":     reason,
+		"expired_at": expiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// NudgeClaimReclaimedPayload creates a payload for stale nudge claim reclamation.
+// session: session whose queue held the claim
+// claimID: claimed file name
+// holder: claim suffix of the drainer that abandoned it
+// age: how long the claim had been untouched
+// requeued: false if the claim was removed because it couldn't be requeued
+func NudgeClaimReclaimedPayload(session, claimID, holder string, age time.Duration, requeued bool) map[string]interface{} {
+	return map[string]interface{}{
+		"session":  session,
+		"claim_id": claimID,
+		"holder":   holder,
+		"age":      age.Round(time.Second).String(),
+		"requeued": requeued,
+	}
+}
+
+// SessionHungPayload creates a payload for hung session detections.
+// session: tmux session name
+// inactive: time since tmux last saw activity in the session
+// frozen: time the pane output has been unchanged
+// threshold: the hung session threshold
+func SessionHungPayload(session string, inactive, frozen, threshold time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"session":   session,
+		"inactive":  inactive.Round(time.Second).String(),
+		"frozen":    frozen.Round(time.Second).String(),
+		"threshold": threshold.String(),
+	}
+}
+
+// GUPPViolationPayload creates a payload for GUPP violation events.
+// session: tmux session of the offending agent
+// agent: agent bead ID
+// hookBead: the hooked work that isn't progressing
+// elapsed: time since the agent bead was last updated
+// timeout: the GUPP violation timeout
+// pane: the session's last pane output, empty if it could not be captured
+func GUPPViolationPayload(session, agent, hookBead string, elapsed, timeout time.Duration, pane string) map[string]interface{} {
+	return map[string]interface{}{
+		"session":   session,
+		"agent":     agent,
+		"hook_bead": hookBead,
+		"elapsed":   elapsed.Round(time.Second).String(),
+		"timeout":   timeout.String(),
+		"pane":      pane,
+	}
+}
+
+// HeartbeatStalenessPayload creates a payload for heartbeat staleness events.
+// agent: monitored agent (e.g., "deacon")
+// from, to: staleness grades ("healthy", "stale", "very_stale")
+// age: heartbeat age when the boundary was crossed
+// threshold: the threshold crossed
+func HeartbeatStalenessPayload(agent, from, to string, age, threshold time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"agent":     agent,
+		"from":      from,
+		"to":        to,
+		"age":       age.Round(time.Second).String(),
+		"threshold": threshold.String(),
+	}
+}
+
 // SessionPayload creates a payload for session start/end events.
 // sessionID: Claude Code session UUID
 // role: Gas Town role (e.g., "gastown/crew/joe", "deacon")
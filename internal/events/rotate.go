@@ -0,0 +1,85 @@
+package events
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gofrs/flock"
+)
+
+// rotatedEventsPath returns the path of the n-th rotated events file
+// (.events.jsonl.1 is the most recent).
+func rotatedEventsPath(townRoot string, n int) string {
+	return filepath.Join(townRoot, EventsFile+"."+strconv.Itoa(n))
+}
+
+// RotateEvents rotates .events.jsonl once it grows past maxBytes: the file
+// becomes .events.jsonl.1, older rotations shift up (.1 → .2, ...), at most
+// keep rotated files are kept, and an empty .events.jsonl is started.
+// It takes the same lock as event writers, so no event is lost mid-rotation.
+// A non-positive maxBytes disables rotation; keep is at least 1.
+func RotateEvents(townRoot string, maxBytes int64, keep int) error {
+	if maxBytes <= 0 {
+		return nil
+	}
+	if keep < 1 {
+		keep = 1
+	}
+	eventsPath := filepath.Join(townRoot, EventsFile)
+
+	fl := flock.New(eventsPath + ".lock")
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("acquiring events file lock: %w", err)
+	}
+	defer fl.Unlock() //nolint:errcheck // best-effort unlock
+
+	info, err := os.Stat(eventsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("stat events file: %w", err)
+	}
+	if info.Size() <= maxBytes {
+		return nil
+	}
+
+	if err := os.Remove(rotatedEventsPath(townRoot, keep)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing oldest rotated events file: %w", err)
+	}
+	for n := keep - 1; n >= 1; n-- {
+		if err := os.Rename(rotatedEventsPath(townRoot, n), rotatedEventsPath(townRoot, n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("shifting rotated events file: %w", err)
+		}
+	}
+	if err := os.Rename(eventsPath, rotatedEventsPath(townRoot, 1)); err != nil {
+		return fmt.Errorf("rotating events file: %w", err)
+	}
+	f, err := os.OpenFile(eventsPath, os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
+	if err != nil {
+		return fmt.Errorf("creating events file: %w", err)
+	}
+	return f.Close()
+}
+
+// EventsFiles returns the events log files under townRoot, oldest first:
+// the rotated files that exist (.events.jsonl.N down to .1), then
+// .events.jsonl itself. Rotated files are found by counting up from .1 and
+// stopping at the first gap.
+func EventsFiles(townRoot string) []string {
+	var rotated []string
+	for n := 1; ; n++ {
+		path := rotatedEventsPath(townRoot, n)
+		if _, err := os.Stat(path); err != nil {
+			break
+		}
+		rotated = append(rotated, path)
+	}
+	files := make([]string, 0, len(rotated)+1)
+	for i := len(rotated) - 1; i >= 0; i-- {
+		files = append(files, rotated[i])
+	}
+	return append(files, filepath.Join(townRoot, EventsFile))
+}
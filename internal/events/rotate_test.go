@@ -0,0 +1,97 @@
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeEventsFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readEventsFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestRotateEvents_BelowCapIsNoop(t *testing.T) {
+	dir := t.TempDir()
+	eventsPath := filepath.Join(dir, EventsFile)
+	writeEventsFile(t, eventsPath, "small\n")
+
+	if err := RotateEvents(dir, 1024, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := readEventsFile(t, eventsPath); got != "small\n" {
+		t.Errorf("events file = %q, want unchanged", got)
+	}
+	if _, err := os.Stat(eventsPath + ".1"); !os.IsNotExist(err) {
+		t.Error("no rotated file expected below the cap")
+	}
+}
+
+func TestRotateEvents_ShiftsAndKeeps(t *testing.T) {
+	dir := t.TempDir()
+	eventsPath := filepath.Join(dir, EventsFile)
+
+	// Four rotations with keep=2: only the two most recent generations survive.
+	for _, gen := range []string{"gen1", "gen2", "gen3", "gen4"} {
+		writeEventsFile(t, eventsPath, strings.Repeat(gen+"\n", 10))
+		if err := RotateEvents(dir, 10, 2); err != nil {
+			t.Fatalf("rotate %s: %v", gen, err)
+		}
+		if got := readEventsFile(t, eventsPath); got != "" {
+			t.Fatalf("after rotating %s, events file = %q, want empty", gen, got)
+		}
+	}
+
+	if got := readEventsFile(t, eventsPath+".1"); !strings.HasPrefix(got, "gen4") {
+		t.Errorf(".1 = %q, want gen4", got)
+	}
+	if got := readEventsFile(t, eventsPath+".2"); !strings.HasPrefix(got, "gen3") {
+		t.Errorf(".2 = %q, want gen3", got)
+	}
+	if _, err := os.Stat(eventsPath + ".3"); !os.IsNotExist(err) {
+		t.Error(".3 should not exist with keep=2")
+	}
+}
+
+func TestRotateEvents_MissingFileOrDisabled(t *testing.T) {
+	dir := t.TempDir()
+	if err := RotateEvents(dir, 10, 2); err != nil {
+		t.Errorf("missing events file: %v", err)
+	}
+
+	eventsPath := filepath.Join(dir, EventsFile)
+	writeEventsFile(t, eventsPath, strings.Repeat("x", 100))
+	if err := RotateEvents(dir, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(eventsPath + ".1"); !os.IsNotExist(err) {
+		t.Error("maxBytes=0 should disable rotation")
+	}
+}
+
+func TestEventsFiles_OldestFirst(t *testing.T) {
+	dir := t.TempDir()
+	eventsPath := filepath.Join(dir, EventsFile)
+	for _, p := range []string{eventsPath, eventsPath + ".1", eventsPath + ".2", eventsPath + ".4"} {
+		writeEventsFile(t, p, "")
+	}
+
+	got := EventsFiles(dir)
+	// .4 is past a gap, so it is not part of the chain.
+	want := []string{eventsPath + ".2", eventsPath + ".1", eventsPath}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("EventsFiles = %v, want %v", got, want)
+	}
+}
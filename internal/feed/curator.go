@@ -538,6 +538,14 @@ func (c *Curator) generateSummary(event *events.Event) string {
 		}
 		return "Multiple sessions died simultaneously"
 
+	case events.TypeWispAlert:
+		open, _ := event.Payload["open"].(float64) // JSON numbers are float64
+		threshold, _ := event.Payload["threshold"].(float64)
+		if open > 0 {
+			return fmt.Sprintf("WISP ALERT: %d open wisps exceed threshold %d", int(open), int(threshold))
+		}
+		return "Open wisps exceed alert threshold"
+
 	default:
 		return fmt.Sprintf("%s: %s", event.Actor, event.Type)
 	}
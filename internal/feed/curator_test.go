@@ -372,6 +372,14 @@ func TestCurator_GeneratesSummary(t *testing.T) {
 			},
 			expected: "gastown/witness handed off to fresh session",
 		},
+		{
+			event: &events.Event{
+				Type:    events.TypeWispAlert,
+				Actor:   "daemon",
+				Payload: map[string]interface{}{"open": float64(612), "threshold": float64(500), "databases": float64(3)},
+			},
+			expected: "WISP ALERT: 612 open wisps exceed threshold 500",
+		},
 	}
 
 	for _, tc := range tests {
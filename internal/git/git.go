@@ -71,6 +71,18 @@ type Git struct {
 // act on the Gas Town town-root repository or town-root runtime paths.
 var ErrUnsafeTownRootGitMutation = errors.New("unsafe git mutation targets Gas Town town root")
 
+// ErrRemoteNotFound is returned when an operation names a remote that is not
+// configured.
+var ErrRemoteNotFound = errors.New("remote not found")
+
+// ErrDirtyWorkingTree is returned when an operation would discard uncommitted
+// changes and the caller did not ask to force it.
+var ErrDirtyWorkingTree = errors.New("working tree has uncommitted changes")
+
+// ErrDetachedHead is returned by callers of CurrentBranch that need a branch
+// name when HEAD is detached.
+var ErrDetachedHead = errors.New("HEAD is detached")
+
 // NewGit creates a new Git wrapper for the given directory.
 func NewGit(workDir string) *Git {
 	return &Git{workDir: workDir}
@@ -96,6 +108,13 @@ func (g *Git) IsRepo() bool {
 
 // run executes a git command and returns stdout.
 func (g *Git) run(args ...string) (string, error) {
+	out, err := g.runRaw(args...)
+	return strings.TrimSpace(out), err
+}
+
+// runRaw is run without trimming stdout. Porcelain formats whose first
+// column may be a space (e.g. " M file" from status) need the raw output.
+func (g *Git) runRaw(args ...string) (string, error) {
 	if err := g.guardUnsafeTownRootMutation(args); err != nil {
 		return "", err
 	}
@@ -120,7 +139,7 @@ func (g *Git) run(args ...string) (string, error) {
 		return "", g.wrapError(err, stdout.String(), stderr.String(), args)
 	}
 
-	return strings.TrimSpace(stdout.String()), nil
+	return stdout.String(), nil
 }
 
 // pushTimeout is the maximum time a git push is allowed to run before being
@@ -1098,10 +1117,12 @@ type porcelainStatusEntry struct {
 
 // Status returns the current git status.
 func (g *Git) Status() (*GitStatus, error) {
-	out, err := g.run("status", "--porcelain", "-uall")
+	// runRaw keeps the leading space of an unstaged first entry (" M file").
+	raw, err := g.runRaw("status", "--porcelain", "-uall")
 	if err != nil {
 		return nil, err
 	}
+	out := strings.TrimRight(raw, "\n")
 
 	status := &GitStatus{Clean: true}
 	if out == "" {
@@ -1155,6 +1176,34 @@ func (g *Git) Status() (*GitStatus, error) {
 	return status, nil
 }
 
+// IsClean returns true if the working tree has no staged, unstaged,
+// untracked, or unmerged changes. Recovery code should refuse destructive
+// operations (reset, respawn, upstream merge) when this is false.
+func (g *Git) IsClean() (bool, error) {
+	status, err := g.Status()
+	if err != nil {
+		return false, err
+	}
+	return status.Clean, nil
+}
+
+// DirtyFiles returns the paths with uncommitted changes: staged, unstaged,
+// untracked, and unmerged. Renames and copies report both the source and
+// destination paths. Returns nil for a clean tree.
+func (g *Git) DirtyFiles() ([]string, error) {
+	status, err := g.Status()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	files = append(files, status.Modified...)
+	files = append(files, status.Added...)
+	files = append(files, status.Deleted...)
+	files = append(files, status.Untracked...)
+	files = append(files, status.Unmerged...)
+	return files, nil
+}
+
 func parsePorcelainStatusEntry(line string) (porcelainStatusEntry, bool) {
 	if len(line) < 3 {
 		return porcelainStatusEntry{}, false
@@ -1214,9 +1263,19 @@ func (g *Git) skipWorktreeFiles() map[string]bool {
 	return result
 }
 
-// CurrentBranch returns the current branch name.
-func (g *Git) CurrentBranch() (string, error) {
-	return g.run("rev-parse", "--abbrev-ref", "HEAD")
+// CurrentBranch returns the current branch name and true. When HEAD is
+// detached (e.g. after a bad rebase) it returns the short commit SHA and
+// false. In a fresh repository with no commits it returns the unborn branch
+// name and true.
+func (g *Git) CurrentBranch() (string, bool, error) {
+	if branch, err := g.run("symbolic-ref", "--short", "-q", "HEAD"); err == nil && branch != "" {
+		return branch, true, nil
+	}
+	sha, err := g.run("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "", false, err
+	}
+	return sha, false, nil
 }
 
 // DefaultBranch returns the default branch name (what HEAD points to).
@@ -1276,62 +1335,101 @@ func (g *Git) RemoteURL(remote string) (string, error) {
 	return g.run("remote", "get-url", remote)
 }
 
-// AddRemote adds a new remote with the given name and URL.
-func (g *Git) AddRemote(name, url string) (string, error) {
-	return g.run("remote", "add", name, url)
-}
-
-// SetRemoteURL updates the URL for an existing remote.
-func (g *Git) SetRemoteURL(name, url string) (string, error) {
-	return g.run("remote", "set-url", name, url)
+// HasRemote returns true if a remote with the given name is configured.
+func (g *Git) HasRemote(name string) (bool, error) {
+	_, err := g.RemoteURL(name)
+	if err != nil {
+		if strings.Contains(err.Error(), "No such remote") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
-// AddUpstreamRemote adds or updates the 'upstream' git remote.
+// AddRemote adds or updates the named git remote.
 // This is idempotent - if the remote already exists with the same URL, it's a no-op.
 // If the remote exists with a different URL, it's updated.
-func (g *Git) AddUpstreamRemote(upstreamURL string) error {
-	has, err := g.HasUpstreamRemote()
+func (g *Git) AddRemote(name, url string) error {
+	has, err := g.HasRemote(name)
 	if err != nil {
 		return err
 	}
 	if has {
-		current, err := g.GetUpstreamURL()
+		current, err := g.RemoteURL(name)
 		if err != nil {
 			return err
 		}
-		if current == upstreamURL {
+		if current == url {
 			return nil
 		}
-		_, err = g.run("remote", "set-url", "upstream", upstreamURL)
+		_, err = g.SetRemoteURL(name, url)
 		return err
 	}
-	_, err = g.run("remote", "add", "upstream", upstreamURL)
+	_, err = g.run("remote", "add", name, url)
 	return err
 }
 
+// SetRemoteURL updates the URL for an existing remote.
+func (g *Git) SetRemoteURL(name, url string) (string, error) {
+	return g.run("remote", "set-url", name, url)
+}
+
+// AddUpstreamRemote adds or updates the 'upstream' git remote.
+// See AddRemote for the idempotency rules.
+func (g *Git) AddUpstreamRemote(upstreamURL string) error {
+	return g.AddRemote("upstream", upstreamURL)
+}
+
 // GetUpstreamURL returns the URL of the upstream remote.
 // Returns empty string if upstream remote doesn't exist.
 func (g *Git) GetUpstreamURL() (string, error) {
-	out, err := g.run("remote", "get-url", "upstream")
+	out, err := g.RemoteURL("upstream")
 	if err != nil {
 		if strings.Contains(err.Error(), "No such remote") {
 			return "", nil
 		}
 		return "", err
 	}
-	return strings.TrimSpace(out), nil
+	return out, nil
 }
 
 // HasUpstreamRemote returns true if an upstream remote is configured.
 func (g *Git) HasUpstreamRemote() (bool, error) {
-	_, err := g.run("remote", "get-url", "upstream")
+	return g.HasRemote("upstream")
+}
+
+// PruneRemote deletes remote-tracking refs for branches that no longer exist
+// on the named remote, returning the pruned ref names (e.g. "upstream/old").
+// Returns an empty slice when nothing was stale, and an error wrapping
+// ErrRemoteNotFound when the remote is not configured.
+func (g *Git) PruneRemote(name string) ([]string, error) {
+	has, err := g.HasRemote(name)
 	if err != nil {
-		if strings.Contains(err.Error(), "No such remote") {
-			return false, nil
+		return nil, err
+	}
+	if !has {
+		return nil, fmt.Errorf("%w: %s", ErrRemoteNotFound, name)
+	}
+
+	out, err := g.run("remote", "prune", name)
+	if err != nil {
+		return nil, err
+	}
+	return parsePrunedRefs(out), nil
+}
+
+// parsePrunedRefs extracts ref names from `git remote prune` output, whose
+// pruned entries look like " * [pruned] upstream/old-branch".
+func parsePrunedRefs(out string) []string {
+	pruned := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		ref, ok := strings.CutPrefix(strings.TrimSpace(line), "* [pruned] ")
+		if ok && ref != "" {
+			pruned = append(pruned, strings.TrimSpace(ref))
 		}
-		return false, err
 	}
-	return true, nil
+	return pruned
 }
 
 // FetchUpstream fetches from the upstream remote.
@@ -1340,6 +1438,106 @@ func (g *Git) FetchUpstream() error {
 	return err
 }
 
+// AheadBehind compares the local branch with upstream/<branch>, returning how
+// many commits the local branch has that upstream lacks (ahead) and how many
+// upstream has that the local branch lacks (behind). Call FetchUpstream first
+// so the tracking ref is current.
+func (g *Git) AheadBehind(branch string) (ahead, behind int, err error) {
+	out, err := g.run("rev-list", "--left-right", "--count", branch+"...upstream/"+branch)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := fmt.Sscanf(out, "%d\t%d", &ahead, &behind); err != nil {
+		return 0, 0, fmt.Errorf("parsing ahead/behind counts %q: %w", out, err)
+	}
+	return ahead, behind, nil
+}
+
+// MergeStatus is the outcome of MergeUpstream.
+type MergeStatus string
+
+const (
+	// MergeMerged means upstream changes were merged cleanly.
+	MergeMerged MergeStatus = "merged"
+	// MergeUpToDate means upstream had nothing the current branch lacked.
+	MergeUpToDate MergeStatus = "up-to-date"
+	// MergeConflicted means the merge conflicted and was aborted.
+	MergeConflicted MergeStatus = "conflict"
+)
+
+// MergeResult describes the outcome of MergeUpstream.
+type MergeResult struct {
+	Status    MergeStatus
+	Conflicts []string // Conflicted paths; set only when Status is MergeConflicted
+}
+
+// MergeUpstream fetches the upstream remote and merges upstream/<branch> into
+// the current branch. A conflicted merge is aborted so the working tree is
+// left clean, and the conflicted paths are returned in the result with a nil
+// error so the caller can decide what to do. Other failures return an error.
+//
+// The caller must ensure the working directory is clean before calling this.
+func (g *Git) MergeUpstream(branch string) (MergeResult, error) {
+	if err := g.FetchUpstream(); err != nil {
+		return MergeResult{}, fmt.Errorf("fetching upstream: %w", err)
+	}
+
+	before, err := g.Rev("HEAD")
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	// ZFC: detect conflicts via diff --diff-filter=U rather than parsing merge output.
+	if _, mergeErr := g.runMergeCheck("merge", "--no-edit", "upstream/"+branch); mergeErr != nil {
+		conflicts, err := g.GetConflictingFiles()
+		if err == nil && len(conflicts) > 0 {
+			if err := g.AbortMerge(); err != nil {
+				return MergeResult{}, fmt.Errorf("aborting conflicted merge: %w", err)
+			}
+			return MergeResult{Status: MergeConflicted, Conflicts: conflicts}, nil
+		}
+		_ = g.AbortMerge()
+		return MergeResult{}, mergeErr
+	}
+
+	after, err := g.Rev("HEAD")
+	if err != nil {
+		return MergeResult{}, err
+	}
+	if after == before {
+		return MergeResult{Status: MergeUpToDate}, nil
+	}
+	return MergeResult{Status: MergeMerged}, nil
+}
+
+// ResetToUpstream hard-resets the current branch to upstream/<branch>, for
+// recovering a worktree that is beyond repair. Call FetchUpstream first so the
+// tracking ref is current. Unless force is set, a dirty working tree is left
+// untouched and ErrDirtyWorkingTree is returned. Untracked files survive the
+// reset either way; use CleanForce to remove them.
+func (g *Git) ResetToUpstream(branch string, force bool) error {
+	ref := "upstream/" + branch
+	exists, err := g.RefExists("refs/remotes/" + ref)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s does not exist (fetch upstream first?)", ref)
+	}
+
+	if !force {
+		clean, err := g.IsClean()
+		if err != nil {
+			return err
+		}
+		if !clean {
+			return fmt.Errorf("refusing to reset to %s: %w", ref, ErrDirtyWorkingTree)
+		}
+	}
+	return g.ResetHard(ref)
+}
+
 // Remotes returns the list of configured remote names.
 func (g *Git) Remotes() ([]string, error) {
 	out, err := g.run("remote")
@@ -2235,11 +2433,67 @@ func (g *Git) WorktreePrune() error {
 	return err
 }
 
+// PruneWorktrees runs git worktree prune and returns the paths of the
+// worktrees whose entries were removed. Locked worktrees are never pruned;
+// StaleLockedWorktrees reports the ones whose directories are gone. Unlike
+// WorktreePrune, which callers use for best-effort cleanup, it is meant for
+// patrols that log or escalate what they find.
+func (g *Git) PruneWorktrees() ([]string, error) {
+	before, err := g.WorktreeList()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.WorktreePrune(); err != nil {
+		return nil, err
+	}
+	after, err := g.WorktreeList()
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[string]bool, len(after))
+	for _, wt := range after {
+		remaining[wt.Path] = true
+	}
+	var pruned []string
+	for _, wt := range before {
+		if !remaining[wt.Path] {
+			pruned = append(pruned, wt.Path)
+		}
+	}
+	return pruned, nil
+}
+
+// StaleLockedWorktrees returns the paths of locked worktrees whose
+// directories no longer exist. git worktree prune leaves these in place; they
+// need git worktree unlock (or a human) before they can be pruned.
+func (g *Git) StaleLockedWorktrees() ([]string, error) {
+	worktrees, err := g.WorktreeList()
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, wt := range worktrees {
+		if !wt.Locked {
+			continue
+		}
+		if _, err := os.Stat(wt.Path); os.IsNotExist(err) {
+			stale = append(stale, wt.Path)
+		}
+	}
+	return stale, nil
+}
+
 // Worktree represents a git worktree.
 type Worktree struct {
 	Path   string
 	Branch string
 	Commit string
+	// Locked is set for worktrees locked with git worktree lock; git never
+	// prunes them.
+	Locked bool
+	// Prunable is set when git worktree prune would remove the entry,
+	// typically because its directory no longer exists.
+	Prunable bool
 }
 
 // WorktreeList returns all worktrees for this repository.
@@ -2268,6 +2522,10 @@ func (g *Git) WorktreeList() ([]Worktree, error) {
 			current.Commit = strings.TrimPrefix(line, "HEAD ")
 		case strings.HasPrefix(line, "branch "):
 			current.Branch = strings.TrimPrefix(line, "branch refs/heads/")
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.Locked = true
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.Prunable = true
 		}
 	}
 
@@ -2279,6 +2537,36 @@ func (g *Git) WorktreeList() ([]Worktree, error) {
 	return worktrees, nil
 }
 
+// IsWorktree reports whether the working directory is a linked worktree
+// (created by git worktree add) rather than the repository's main worktree.
+// A linked worktree has its own git dir under the common dir's worktrees/.
+func (g *Git) IsWorktree() (bool, error) {
+	out, err := g.run("rev-parse", "--path-format=absolute", "--git-dir", "--git-common-dir")
+	if err != nil {
+		return false, err
+	}
+	dirs := strings.Split(out, "\n")
+	if len(dirs) != 2 {
+		return false, fmt.Errorf("unexpected rev-parse output: %q", out)
+	}
+	return filepath.Clean(dirs[0]) != filepath.Clean(dirs[1]), nil
+}
+
+// MainWorktreePath returns the path of the repository's main worktree, the
+// first entry of git worktree list, from the main worktree or any linked
+// one. For a bare repository (such as the shared .repo.git) this is the
+// bare repository's directory, which has no checkout.
+func (g *Git) MainWorktreePath() (string, error) {
+	worktrees, err := g.WorktreeList()
+	if err != nil {
+		return "", err
+	}
+	if len(worktrees) == 0 {
+		return "", fmt.Errorf("git worktree list returned no worktrees")
+	}
+	return worktrees[0].Path, nil
+}
+
 // BranchCreatedDate returns the date when a branch was created.
 // This uses the committer date of the first commit on the branch.
 // Returns date in YYYY-MM-DD format.
@@ -2405,8 +2693,8 @@ func (g *Git) StashCount() (int, error) {
 	// Get current branch to filter stashes.
 	// If we can't determine the branch (detached HEAD, error), count all
 	// stashes as a safe fallback — better to over-count than silently lose work.
-	branch, branchErr := g.CurrentBranch()
-	filterByBranch := branchErr == nil && branch != "" && branch != "HEAD"
+	branch, onBranch, branchErr := g.CurrentBranch()
+	filterByBranch := branchErr == nil && onBranch && branch != ""
 
 	// Stash reflog lines have the format:
 	//   stash@{N}: WIP on <branch>: <hash> <message>
@@ -2473,8 +2761,8 @@ func (g *Git) StashListForBranch() ([]StashEntry, error) {
 		return nil, nil
 	}
 
-	branch, branchErr := g.CurrentBranch()
-	filterByBranch := branchErr == nil && branch != "" && branch != "HEAD"
+	branch, onBranch, branchErr := g.CurrentBranch()
+	filterByBranch := branchErr == nil && onBranch && branch != ""
 	wipPrefix := ": WIP on " + branch + ":"
 	onPrefix := ": On " + branch + ":"
 
@@ -2519,8 +2807,8 @@ func (g *Git) StashPop(ref string) error {
 // track origin/main while pushing work to origin/<current-branch>.
 // Returns 0 if there is no upstream or exact remote branch configured.
 func (g *Git) UnpushedCommits() (int, error) {
-	branch, branchErr := g.CurrentBranch()
-	if branchErr != nil || branch == "" || branch == "HEAD" {
+	branch, onBranch, branchErr := g.CurrentBranch()
+	if branchErr != nil || !onBranch {
 		branch = ""
 	}
 
@@ -2572,6 +2860,7 @@ type BranchPreservationStatus struct {
 // custody target for the branch. It prefers proof from the exact pushed source
 // branch, then explicit target branches, then upstream. It only falls back to the
 // remote default branch when no target/custody/upstream evidence exists.
+// Callers on a detached HEAD must pass an empty localBranch.
 func (g *Git) BranchPreservationStatus(localBranch, remote string, targets []string) (BranchPreservationStatus, error) {
 	return g.branchPreservationStatus(localBranch, remote, targets, true)
 }
@@ -2592,7 +2881,7 @@ func (g *Git) branchPreservationStatus(localBranch, remote string, targets []str
 	var candidates []string
 	hasEvidence := len(nonEmptyUnique(targets)) > 0
 
-	if includeExactBranch && localBranch != "" && localBranch != "HEAD" {
+	if includeExactBranch && localBranch != "" {
 		if remoteSHA, err := g.PushRemoteBranchTip(remote, localBranch); err == nil && remoteSHA != "" {
 			hasEvidence = true
 			result.ComparisonBase = remote + "/" + localBranch
@@ -2966,7 +3255,7 @@ func (g *Git) PruneStaleBranches(pattern string, dryRun bool) ([]PrunedBranch, e
 	}
 
 	// Get current branch to avoid deleting it
-	currentBranch, _ := g.CurrentBranch()
+	currentBranch, _, _ := g.CurrentBranch()
 	defaultBranch := g.RemoteDefaultBranch()
 
 	// List all local branches matching the pattern
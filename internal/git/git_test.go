@@ -100,7 +100,7 @@ func snapshotTownRootSafety(t *testing.T, root string) townRootSafetySnapshot {
 	if err != nil {
 		t.Fatalf("rev HEAD: %v", err)
 	}
-	branch, err := g.CurrentBranch()
+	branch, _, err := g.CurrentBranch()
 	if err != nil {
 		t.Fatalf("current branch: %v", err)
 	}
@@ -393,7 +393,7 @@ func TestCurrentBranch(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
 
-	branch, err := g.CurrentBranch()
+	branch, onBranch, err := g.CurrentBranch()
 	if err != nil {
 		t.Fatalf("CurrentBranch: %v", err)
 	}
@@ -402,6 +402,45 @@ func TestCurrentBranch(t *testing.T) {
 	if branch != "main" && branch != "master" {
 		t.Errorf("branch = %q, want main or master", branch)
 	}
+	if !onBranch {
+		t.Error("onBranch = false on a named branch")
+	}
+}
+
+func TestCurrentBranch_NoCommits(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--initial-branch", "trunk")
+
+	branch, onBranch, err := NewGit(dir).CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+	if branch != "trunk" || !onBranch {
+		t.Errorf("CurrentBranch = %q, %v; want trunk, true", branch, onBranch)
+	}
+}
+
+func TestCurrentBranch_Detached(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	sha, err := g.Rev("HEAD")
+	if err != nil {
+		t.Fatalf("Rev: %v", err)
+	}
+	if err := g.CheckoutDetach("HEAD"); err != nil {
+		t.Fatalf("CheckoutDetach: %v", err)
+	}
+
+	branch, onBranch, err := g.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+	if onBranch {
+		t.Error("onBranch = true on a detached HEAD")
+	}
+	if branch == "" || branch == "HEAD" || !strings.HasPrefix(sha, branch) {
+		t.Errorf("branch = %q, want a short SHA of %s", branch, sha)
+	}
 }
 
 func TestStatus(t *testing.T) {
@@ -490,6 +529,78 @@ func TestHasUncommittedChanges(t *testing.T) {
 	}
 }
 
+func TestIsCleanAndDirtyFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string)
+		want  []string
+	}{
+		{
+			name:  "clean",
+			setup: func(t *testing.T, dir string) {},
+		},
+		{
+			name: "untracked",
+			setup: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"new.txt"},
+		},
+		{
+			name: "unstaged modification",
+			setup: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("modified"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"README.md"},
+		},
+		{
+			name: "staged modification",
+			setup: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("staged"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				runGit(t, dir, "add", "README.md")
+			},
+			want: []string{"README.md"},
+		},
+		{
+			name: "staged rename",
+			setup: func(t *testing.T, dir string) {
+				runGit(t, dir, "mv", "README.md", "RENAMED.md")
+			},
+			want: []string{"README.md", "RENAMED.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := initTestRepo(t)
+			g := NewGit(dir)
+			tt.setup(t, dir)
+
+			clean, err := g.IsClean()
+			if err != nil {
+				t.Fatalf("IsClean: %v", err)
+			}
+			if clean != (len(tt.want) == 0) {
+				t.Errorf("IsClean = %v, want %v", clean, len(tt.want) == 0)
+			}
+
+			files, err := g.DirtyFiles()
+			if err != nil {
+				t.Fatalf("DirtyFiles: %v", err)
+			}
+			if strings.Join(files, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("DirtyFiles = %v, want %v", files, tt.want)
+			}
+		})
+	}
+}
+
 func TestCheckout(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
@@ -504,7 +615,7 @@ func TestCheckout(t *testing.T) {
 		t.Fatalf("Checkout: %v", err)
 	}
 
-	branch, _ := g.CurrentBranch()
+	branch, _, _ := g.CurrentBranch()
 	if branch != "feature" {
 		t.Errorf("branch = %q, want feature", branch)
 	}
@@ -514,7 +625,7 @@ func TestCheckoutDetachAllowsBranchCheckedOutInAnotherWorktree(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
 
-	mainBranch, err := g.CurrentBranch()
+	mainBranch, _, err := g.CurrentBranch()
 	if err != nil {
 		t.Fatalf("CurrentBranch: %v", err)
 	}
@@ -544,12 +655,12 @@ func TestCheckoutDetachAllowsBranchCheckedOutInAnotherWorktree(t *testing.T) {
 		t.Fatalf("CheckoutDetach(%s): %v", mainBranch, err)
 	}
 
-	branch, err := workerGit.CurrentBranch()
+	branch, onBranch, err := workerGit.CurrentBranch()
 	if err != nil {
 		t.Fatalf("CurrentBranch after detach: %v", err)
 	}
-	if branch != "HEAD" {
-		t.Fatalf("branch after detach = %q, want HEAD", branch)
+	if onBranch || !strings.HasPrefix(mainSHA, branch) {
+		t.Fatalf("CurrentBranch after detach = %q, %v; want short SHA of %s, false", branch, onBranch, mainSHA)
 	}
 	headSHA, err := workerGit.Rev("HEAD")
 	if err != nil {
@@ -575,7 +686,7 @@ func TestCheckoutNewBranch(t *testing.T) {
 		t.Fatalf("CheckoutNewBranch: %v", err)
 	}
 
-	branch, _ := g.CurrentBranch()
+	branch, _, _ := g.CurrentBranch()
 	if branch != "feature-new" {
 		t.Errorf("branch = %q, want feature-new", branch)
 	}
@@ -597,7 +708,7 @@ func TestNotARepo(t *testing.T) {
 	dir := t.TempDir() // Empty dir, not a git repo
 	g := NewGit(dir)
 
-	_, err := g.CurrentBranch()
+	_, _, err := g.CurrentBranch()
 	// ZFC: Check for GitError with raw stderr for agent observation.
 	// Agents decide what "not a git repository" means, not Go code.
 	gitErr, ok := err.(*GitError)
@@ -647,7 +758,7 @@ func TestFetchBranch(t *testing.T) {
 	}
 
 	// Push main branch
-	mainBranch, _ := g.CurrentBranch()
+	mainBranch, _, _ := g.CurrentBranch()
 	cmd = exec.Command("git", "push", "-u", "origin", mainBranch)
 	cmd.Dir = localDir
 	if err := cmd.Run(); err != nil {
@@ -663,7 +774,7 @@ func TestFetchBranch(t *testing.T) {
 func TestCheckConflicts_NoConflict(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
-	mainBranch, _ := g.CurrentBranch()
+	mainBranch, _, _ := g.CurrentBranch()
 
 	// Create feature branch with non-conflicting change
 	if err := g.CreateBranch("feature"); err != nil {
@@ -700,7 +811,7 @@ func TestCheckConflicts_NoConflict(t *testing.T) {
 	}
 
 	// Verify we're still on main and clean
-	branch, _ := g.CurrentBranch()
+	branch, _, _ := g.CurrentBranch()
 	if branch != mainBranch {
 		t.Errorf("branch = %q, want %q", branch, mainBranch)
 	}
@@ -713,7 +824,7 @@ func TestCheckConflicts_NoConflict(t *testing.T) {
 func TestCheckConflicts_WithConflict(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
-	mainBranch, _ := g.CurrentBranch()
+	mainBranch, _, _ := g.CurrentBranch()
 
 	// Create feature branch
 	if err := g.CreateBranch("feature"); err != nil {
@@ -770,7 +881,7 @@ func TestCheckConflicts_WithConflict(t *testing.T) {
 	}
 
 	// Verify we're still on main and clean
-	branch, _ := g.CurrentBranch()
+	branch, _, _ := g.CurrentBranch()
 	if branch != mainBranch {
 		t.Errorf("branch = %q, want %q", branch, mainBranch)
 	}
@@ -3218,3 +3329,112 @@ func TestBranchPushedToRemote_NoPushURL(t *testing.T) {
 		t.Errorf("BranchPushedToRemote unpushed = %d, want >= 1", unpushed)
 	}
 }
+
+func TestIsWorktreeAndMainWorktreePath(t *testing.T) {
+	main := initTestRepo(t)
+	linked := filepath.Join(t.TempDir(), "linked")
+	if err := NewGit(main).WorktreeAdd(linked, "feature"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+	sub := filepath.Join(linked, "sub")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	wantMain, err := filepath.EvalSymlinks(main)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		dir      string
+		worktree bool
+	}{
+		{main, false},
+		{linked, true},
+		{sub, true},
+	} {
+		g := NewGit(tc.dir)
+		got, err := g.IsWorktree()
+		if err != nil {
+			t.Fatalf("IsWorktree(%s): %v", tc.dir, err)
+		}
+		if got != tc.worktree {
+			t.Errorf("IsWorktree(%s) = %v, want %v", tc.dir, got, tc.worktree)
+		}
+
+		path, err := g.MainWorktreePath()
+		if err != nil {
+			t.Fatalf("MainWorktreePath(%s): %v", tc.dir, err)
+		}
+		if resolved, _ := filepath.EvalSymlinks(path); resolved != wantMain {
+			t.Errorf("MainWorktreePath(%s) = %s, want %s", tc.dir, path, wantMain)
+		}
+	}
+
+	if _, err := NewGit(t.TempDir()).IsWorktree(); err == nil {
+		t.Error("IsWorktree outside a repository: want error")
+	}
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	repo := initTestRepo(t)
+	g := NewGit(repo)
+	base := t.TempDir()
+	stale := filepath.Join(base, "stale")
+	locked := filepath.Join(base, "locked")
+	live := filepath.Join(base, "live")
+	for i, path := range []string{stale, locked, live} {
+		if err := g.WorktreeAdd(path, fmt.Sprintf("wt-%d", i)); err != nil {
+			t.Fatalf("WorktreeAdd(%s): %v", path, err)
+		}
+	}
+	cmd := exec.Command("git", "worktree", "lock", "--reason", "in use", locked)
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("worktree lock: %v\n%s", err, out)
+	}
+	for _, path := range []string{stale, locked} {
+		if err := os.RemoveAll(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	worktrees, err := g.WorktreeList()
+	if err != nil {
+		t.Fatalf("WorktreeList: %v", err)
+	}
+	byBase := make(map[string]Worktree)
+	for _, wt := range worktrees {
+		byBase[filepath.Base(wt.Path)] = wt
+	}
+	if wt := byBase["stale"]; !wt.Prunable || wt.Locked {
+		t.Errorf("stale worktree = %+v, want prunable and unlocked", wt)
+	}
+	if wt := byBase["locked"]; !wt.Locked || wt.Prunable {
+		t.Errorf("locked worktree = %+v, want locked and not prunable", wt)
+	}
+	if wt := byBase["live"]; wt.Locked || wt.Prunable || wt.Branch != "wt-2" {
+		t.Errorf("live worktree = %+v", wt)
+	}
+
+	pruned, err := g.PruneWorktrees()
+	if err != nil {
+		t.Fatalf("PruneWorktrees: %v", err)
+	}
+	if len(pruned) != 1 || filepath.Base(pruned[0]) != "stale" {
+		t.Errorf("pruned = %v, want only the stale worktree", pruned)
+	}
+
+	// The locked entry survives and is reported separately; a second prune
+	// has nothing to do.
+	lockedStale, err := g.StaleLockedWorktrees()
+	if err != nil {
+		t.Fatalf("StaleLockedWorktrees: %v", err)
+	}
+	if len(lockedStale) != 1 || filepath.Base(lockedStale[0]) != "locked" {
+		t.Errorf("StaleLockedWorktrees = %v, want only the locked worktree", lockedStale)
+	}
+	if pruned, err := g.PruneWorktrees(); err != nil || len(pruned) != 0 {
+		t.Errorf("second PruneWorktrees = %v, %v; want nothing pruned", pruned, err)
+	}
+}
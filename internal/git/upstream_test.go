@@ -1,6 +1,9 @@
 package git
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -81,3 +84,348 @@ func TestGit_UpstreamRemote(t *testing.T) {
 		}
 	})
 }
+
+func TestGit_NamedRemotes(t *testing.T) {
+	tmp := t.TempDir()
+	g := NewGit(tmp)
+	runGit(t, tmp, "init", "--initial-branch", "main")
+
+	vendorA1 := "https://example.com/vendor-a.git"
+	vendorB1 := "https://example.com/vendor-b.git"
+
+	assertURL := func(t *testing.T, name, want string) {
+		t.Helper()
+		url, err := g.RemoteURL(name)
+		if err != nil {
+			t.Fatalf("RemoteURL(%s): %v", name, err)
+		}
+		if url != want {
+			t.Errorf("RemoteURL(%s) = %q, want %q", name, url, want)
+		}
+	}
+
+	t.Run("initially absent", func(t *testing.T) {
+		for _, name := range []string{"vendor-a", "vendor-b"} {
+			has, err := g.HasRemote(name)
+			if err != nil {
+				t.Fatalf("HasRemote(%s): %v", name, err)
+			}
+			if has {
+				t.Fatalf("expected no %s remote initially", name)
+			}
+		}
+	})
+
+	t.Run("add both", func(t *testing.T) {
+		if err := g.AddRemote("vendor-a", vendorA1); err != nil {
+			t.Fatalf("AddRemote(vendor-a): %v", err)
+		}
+		if err := g.AddRemote("vendor-b", vendorB1); err != nil {
+			t.Fatalf("AddRemote(vendor-b): %v", err)
+		}
+		assertURL(t, "vendor-a", vendorA1)
+		assertURL(t, "vendor-b", vendorB1)
+	})
+
+	t.Run("idempotent same URL is true no-op", func(t *testing.T) {
+		if err := g.AddRemote("vendor-a", vendorA1); err != nil {
+			t.Fatalf("AddRemote(vendor-a): %v", err)
+		}
+		assertURL(t, "vendor-a", vendorA1)
+		assertURL(t, "vendor-b", vendorB1)
+	})
+
+	vendorA2 := "https://example.com/vendor-a-moved.git"
+
+	t.Run("update one leaves the other", func(t *testing.T) {
+		if err := g.AddRemote("vendor-a", vendorA2); err != nil {
+			t.Fatalf("AddRemote(vendor-a): %v", err)
+		}
+		assertURL(t, "vendor-a", vendorA2)
+		assertURL(t, "vendor-b", vendorB1)
+
+		remotes, err := g.Remotes()
+		if err != nil {
+			t.Fatalf("Remotes: %v", err)
+		}
+		if len(remotes) != 2 {
+			t.Errorf("Remotes = %v, want exactly vendor-a and vendor-b", remotes)
+		}
+	})
+
+	t.Run("upstream wrappers are independent", func(t *testing.T) {
+		if has, _ := g.HasUpstreamRemote(); has {
+			t.Fatal("named remotes should not create upstream")
+		}
+		if err := g.AddUpstreamRemote(vendorB1); err != nil {
+			t.Fatalf("AddUpstreamRemote: %v", err)
+		}
+		assertURL(t, "upstream", vendorB1)
+	})
+}
+
+// commitFile writes name with content in dir and commits it.
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", "update "+name)
+}
+
+// initUpstreamPair creates an upstream repo and a local clone of it whose
+// "upstream" remote points back at the first repo. Both start on main with
+// one shared commit.
+func initUpstreamPair(t *testing.T) (upstreamDir, localDir string) {
+	t.Helper()
+	upstreamDir = t.TempDir()
+	runGit(t, upstreamDir, "init", "--initial-branch", "main")
+	runGit(t, upstreamDir, "config", "user.email", "test@test.com")
+	runGit(t, upstreamDir, "config", "user.name", "Test User")
+	commitFile(t, upstreamDir, "README.md", "# Test\n")
+
+	localDir = filepath.Join(t.TempDir(), "local")
+	runGit(t, filepath.Dir(localDir), "clone", upstreamDir, localDir)
+	runGit(t, localDir, "config", "user.email", "test@test.com")
+	runGit(t, localDir, "config", "user.name", "Test User")
+	if err := NewGit(localDir).AddUpstreamRemote(upstreamDir); err != nil {
+		t.Fatalf("AddUpstreamRemote: %v", err)
+	}
+	return upstreamDir, localDir
+}
+
+func TestGit_FetchUpstreamAheadBehind(t *testing.T) {
+	upstreamDir, localDir := initUpstreamPair(t)
+	g := NewGit(localDir)
+
+	if err := g.FetchUpstream(); err != nil {
+		t.Fatalf("FetchUpstream: %v", err)
+	}
+	ahead, behind, err := g.AheadBehind("main")
+	if err != nil {
+		t.Fatalf("AheadBehind: %v", err)
+	}
+	if ahead != 0 || behind != 0 {
+		t.Errorf("in sync: ahead=%d behind=%d, want 0/0", ahead, behind)
+	}
+
+	commitFile(t, localDir, "local1.txt", "a")
+	commitFile(t, localDir, "local2.txt", "b")
+	commitFile(t, upstreamDir, "upstream1.txt", "c")
+	commitFile(t, upstreamDir, "upstream2.txt", "d")
+	commitFile(t, upstreamDir, "upstream3.txt", "e")
+
+	// Counts reflect the last fetch until upstream is fetched again.
+	if _, behind, _ := g.AheadBehind("main"); behind != 0 {
+		t.Errorf("before fetch: behind=%d, want 0", behind)
+	}
+
+	if err := g.FetchUpstream(); err != nil {
+		t.Fatalf("FetchUpstream: %v", err)
+	}
+	ahead, behind, err = g.AheadBehind("main")
+	if err != nil {
+		t.Fatalf("AheadBehind: %v", err)
+	}
+	if ahead != 2 || behind != 3 {
+		t.Errorf("diverged: ahead=%d behind=%d, want 2/3", ahead, behind)
+	}
+}
+
+func TestGit_AheadBehindMissingUpstreamBranch(t *testing.T) {
+	_, localDir := initUpstreamPair(t)
+	g := NewGit(localDir)
+	if err := g.FetchUpstream(); err != nil {
+		t.Fatalf("FetchUpstream: %v", err)
+	}
+	if _, _, err := g.AheadBehind("no-such-branch"); err == nil {
+		t.Error("expected error for a branch with no upstream counterpart")
+	}
+}
+
+func TestGit_MergeUpstream(t *testing.T) {
+	t.Run("up to date", func(t *testing.T) {
+		_, localDir := initUpstreamPair(t)
+		result, err := NewGit(localDir).MergeUpstream("main")
+		if err != nil {
+			t.Fatalf("MergeUpstream: %v", err)
+		}
+		if result.Status != MergeUpToDate {
+			t.Errorf("Status = %q, want %q", result.Status, MergeUpToDate)
+		}
+	})
+
+	t.Run("clean merge", func(t *testing.T) {
+		upstreamDir, localDir := initUpstreamPair(t)
+		commitFile(t, localDir, "local.txt", "local")
+		commitFile(t, upstreamDir, "upstream.txt", "upstream")
+
+		g := NewGit(localDir)
+		result, err := g.MergeUpstream("main")
+		if err != nil {
+			t.Fatalf("MergeUpstream: %v", err)
+		}
+		if result.Status != MergeMerged || len(result.Conflicts) != 0 {
+			t.Errorf("result = %+v, want clean merge", result)
+		}
+		if _, err := os.Stat(filepath.Join(localDir, "upstream.txt")); err != nil {
+			t.Errorf("upstream.txt not merged: %v", err)
+		}
+		if _, behind, _ := g.AheadBehind("main"); behind != 0 {
+			t.Errorf("behind = %d after merge, want 0", behind)
+		}
+	})
+
+	t.Run("conflict is aborted", func(t *testing.T) {
+		upstreamDir, localDir := initUpstreamPair(t)
+		commitFile(t, localDir, "README.md", "local change\n")
+		commitFile(t, upstreamDir, "README.md", "upstream change\n")
+
+		g := NewGit(localDir)
+		before, err := g.Rev("HEAD")
+		if err != nil {
+			t.Fatalf("Rev: %v", err)
+		}
+		result, err := g.MergeUpstream("main")
+		if err != nil {
+			t.Fatalf("MergeUpstream: %v", err)
+		}
+		if result.Status != MergeConflicted {
+			t.Fatalf("Status = %q, want %q", result.Status, MergeConflicted)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0] != "README.md" {
+			t.Errorf("Conflicts = %v, want [README.md]", result.Conflicts)
+		}
+
+		status, err := g.Status()
+		if err != nil {
+			t.Fatalf("Status: %v", err)
+		}
+		if !status.Clean {
+			t.Errorf("working tree not clean after abort: %+v", status)
+		}
+		if after, _ := g.Rev("HEAD"); after != before {
+			t.Errorf("HEAD moved from %s to %s", before, after)
+		}
+	})
+}
+
+func TestGit_ResetToUpstream(t *testing.T) {
+	t.Run("missing upstream ref", func(t *testing.T) {
+		_, localDir := initUpstreamPair(t)
+		if err := NewGit(localDir).ResetToUpstream("no-such-branch", true); err == nil {
+			t.Error("expected error for a branch with no upstream counterpart")
+		}
+	})
+
+	t.Run("refuses dirty tree", func(t *testing.T) {
+		upstreamDir, localDir := initUpstreamPair(t)
+		commitFile(t, localDir, "local.txt", "local")
+		commitFile(t, upstreamDir, "upstream.txt", "upstream")
+		if err := os.WriteFile(filepath.Join(localDir, "README.md"), []byte("unsaved work\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		g := NewGit(localDir)
+		if err := g.FetchUpstream(); err != nil {
+			t.Fatalf("FetchUpstream: %v", err)
+		}
+		before, _ := g.Rev("HEAD")
+		err := g.ResetToUpstream("main", false)
+		if !errors.Is(err, ErrDirtyWorkingTree) {
+			t.Fatalf("ResetToUpstream = %v, want ErrDirtyWorkingTree", err)
+		}
+		if after, _ := g.Rev("HEAD"); after != before {
+			t.Errorf("HEAD moved from %s to %s", before, after)
+		}
+		if data, _ := os.ReadFile(filepath.Join(localDir, "README.md")); string(data) != "unsaved work\n" {
+			t.Errorf("uncommitted change lost: %q", data)
+		}
+	})
+
+	t.Run("force resets dirty tree", func(t *testing.T) {
+		upstreamDir, localDir := initUpstreamPair(t)
+		commitFile(t, localDir, "local.txt", "local")
+		commitFile(t, upstreamDir, "upstream.txt", "upstream")
+		if err := os.WriteFile(filepath.Join(localDir, "README.md"), []byte("unsaved work\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		g := NewGit(localDir)
+		if err := g.FetchUpstream(); err != nil {
+			t.Fatalf("FetchUpstream: %v", err)
+		}
+		if err := g.ResetToUpstream("main", true); err != nil {
+			t.Fatalf("ResetToUpstream: %v", err)
+		}
+
+		head, _ := g.Rev("HEAD")
+		upstream, _ := g.Rev("upstream/main")
+		if head != upstream {
+			t.Errorf("HEAD = %s, want upstream/main %s", head, upstream)
+		}
+		if clean, err := g.IsClean(); err != nil || !clean {
+			t.Errorf("IsClean = %v, %v after forced reset", clean, err)
+		}
+		if _, err := os.Stat(filepath.Join(localDir, "local.txt")); !os.IsNotExist(err) {
+			t.Errorf("local-only commit survived the reset: %v", err)
+		}
+	})
+}
+
+func TestGit_PruneRemote(t *testing.T) {
+	upstreamDir, localDir := initUpstreamPair(t)
+	g := NewGit(localDir)
+
+	runGit(t, upstreamDir, "branch", "feature")
+	runGit(t, upstreamDir, "branch", "keep")
+	if err := g.FetchUpstream(); err != nil {
+		t.Fatalf("FetchUpstream: %v", err)
+	}
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		pruned, err := g.PruneRemote("upstream")
+		if err != nil {
+			t.Fatalf("PruneRemote: %v", err)
+		}
+		if pruned == nil || len(pruned) != 0 {
+			t.Errorf("pruned = %#v, want empty slice", pruned)
+		}
+	})
+
+	t.Run("deleted upstream branch", func(t *testing.T) {
+		runGit(t, upstreamDir, "branch", "-D", "feature")
+		pruned, err := g.PruneRemote("upstream")
+		if err != nil {
+			t.Fatalf("PruneRemote: %v", err)
+		}
+		if len(pruned) != 1 || pruned[0] != "upstream/feature" {
+			t.Errorf("pruned = %v, want [upstream/feature]", pruned)
+		}
+		if _, err := g.Rev("refs/remotes/upstream/feature"); err == nil {
+			t.Error("upstream/feature still exists after prune")
+		}
+		if _, err := g.Rev("refs/remotes/upstream/keep"); err != nil {
+			t.Errorf("upstream/keep was pruned: %v", err)
+		}
+	})
+
+	t.Run("nonexistent remote", func(t *testing.T) {
+		_, err := g.PruneRemote("no-such-remote")
+		if !errors.Is(err, ErrRemoteNotFound) {
+			t.Errorf("err = %v, want ErrRemoteNotFound", err)
+		}
+	})
+}
+
+func TestParsePrunedRefs(t *testing.T) {
+	out := "Pruning upstream\nURL: /tmp/upstream\n * [pruned] upstream/a\n * [pruned] upstream/feature/b"
+	got := parsePrunedRefs(out)
+	if len(got) != 2 || got[0] != "upstream/a" || got[1] != "upstream/feature/b" {
+		t.Errorf("parsePrunedRefs = %v", got)
+	}
+	if got := parsePrunedRefs(""); len(got) != 0 {
+		t.Errorf("parsePrunedRefs(\"\") = %v, want empty", got)
+	}
+}
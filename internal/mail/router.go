@@ -1797,7 +1797,7 @@ func (r *Router) enqueueReplyReminder(msg *Message, sessionID string) {
 	if msg.Type == TypeReply {
 		return // Already a reply — reminder would be redundant
 	}
-	delay := config.LoadOperationalConfig(r.townRoot).GetMailConfig().ReplyReminderDelayD()
+	delay := config.LoadOperationalConfigLayered(r.townRoot).GetMailConfig().ReplyReminderDelayD()
 	if delay <= 0 {
 		return // Disabled by config
 	}
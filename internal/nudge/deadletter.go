@@ -0,0 +1,87 @@
+package nudge
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Dead-letter reasons.
+const (
+	// DeadReasonExpired means the nudge's TTL passed while it sat in the
+	// queue, so Drain dropped it instead of delivering it.
+	DeadReasonExpired = "expired"
+	// DeadReasonExpiredOnRequeue means the nudge was drained but not
+	// delivered, and its TTL passed before it could be requeued.
+	DeadReasonExpiredOnRequeue = "expired_on_requeue"
+)
+
+// DeadNudge is one record in the dead-letter log.
+type DeadNudge struct {
+	Session string      `json:"session"`
+	Reason  string      `json:"reason"`
+	DeadAt  time.Time   `json:"dead_at"`
+	Nudge   QueuedNudge `json:"nudge"`
+}
+
+// DeadLetterFile returns the path of the town's dead-letter log, where
+// nudges dropped without delivery are appended as JSON lines.
+func DeadLetterFile(townRoot string) string {
+	return filepath.Join(townRoot, ".nudges-dead.jsonl")
+}
+
+// deadLetter appends n to the dead-letter log and emits a nudge_expired
+// event. Failures are reported to stderr: losing the record must not block
+// draining the queue.
+func deadLetter(townRoot, session string, n QueuedNudge, reason string, at time.Time) {
+	data, err := json.Marshal(DeadNudge{Session: session, Reason: reason, DeadAt: at, Nudge: n})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to encode dead nudge for %s: %v\n", session, err)
+		return
+	}
+	f, err := os.OpenFile(DeadLetterFile(townRoot), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open nudge dead-letter log: %v\n", err)
+	} else {
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write nudge dead-letter log: %v\n", err)
+		}
+		_ = f.Close()
+	}
+
+	_ = events.LogFeed(events.TypeNudgeExpired, session,
+		events.NudgeExpiredPayload(session, n.Sender, n.Priority, reason, n.ExpiresAt))
+}
+
+// ReadDeadLetters returns the records in the town's dead-letter log, oldest
+// first. Returns nil if the log doesn't exist; malformed lines are skipped.
+func ReadDeadLetters(townRoot string) ([]DeadNudge, error) {
+	f, err := os.Open(DeadLetterFile(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening nudge dead-letter log: %w", err)
+	}
+	defer f.Close()
+
+	var dead []DeadNudge
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var d DeadNudge
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			continue
+		}
+		dead = append(dead, d)
+	}
+	if err := scanner.Err(); err != nil {
+		return dead, fmt.Errorf("reading nudge dead-letter log: %w", err)
+	}
+	return dead, nil
+}
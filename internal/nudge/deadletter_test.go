@@ -0,0 +1,114 @@
+package nudge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDrainDeadLettersExpired(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-deadletter"
+
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	now := start
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	if err := Enqueue(townRoot, session, QueuedNudge{
+		Sender:   "mayor",
+		Message:  "check your hook",
+		Priority: PriorityUrgent,
+	}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	// Still within TTL: delivered normally, nothing dead-lettered.
+	nudges, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(nudges) != 1 {
+		t.Fatalf("Drain returned %d nudges, want 1", len(nudges))
+	}
+	if err := Requeue(townRoot, session, nudges); err != nil {
+		t.Fatalf("Requeue: %v", err)
+	}
+
+	// Move the clock past the TTL.
+	now = nudges[0].ExpiresAt.Add(time.Second)
+	nudges, err = Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(nudges) != 0 {
+		t.Fatalf("Drain returned %d nudges, want 0 (expired)", len(nudges))
+	}
+
+	dead, err := ReadDeadLetters(townRoot)
+	if err != nil {
+		t.Fatalf("ReadDeadLetters: %v", err)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(dead))
+	}
+	d := dead[0]
+	if d.Reason != DeadReasonExpired {
+		t.Errorf("Reason = %q, want %q", d.Reason, DeadReasonExpired)
+	}
+	if d.Session != session {
+		t.Errorf("Session = %q, want %q", d.Session, session)
+	}
+	if !d.DeadAt.Equal(now) {
+		t.Errorf("DeadAt = %v, want %v", d.DeadAt, now)
+	}
+	if d.Nudge.Sender != "mayor" || d.Nudge.Message != "check your hook" || d.Nudge.Priority != PriorityUrgent {
+		t.Errorf("original payload not preserved: %+v", d.Nudge)
+	}
+}
+
+func TestRequeueDeadLettersExpired(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-deadletter-requeue"
+
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	expired := QueuedNudge{
+		Sender:    "witness",
+		Message:   "too late",
+		Priority:  PriorityNormal,
+		Timestamp: now.Add(-time.Hour),
+		ExpiresAt: now.Add(-time.Minute),
+	}
+	if err := Requeue(townRoot, session, []QueuedNudge{expired}); err != nil {
+		t.Fatalf("Requeue: %v", err)
+	}
+	if pending, _ := Pending(townRoot, session); pending != 0 {
+		t.Errorf("Pending = %d, want 0 (expired nudge should not be requeued)", pending)
+	}
+
+	dead, err := ReadDeadLetters(townRoot)
+	if err != nil {
+		t.Fatalf("ReadDeadLetters: %v", err)
+	}
+	if len(dead) != 1 || dead[0].Reason != DeadReasonExpiredOnRequeue {
+		t.Fatalf("dead letters = %+v, want one with reason %q", dead, DeadReasonExpiredOnRequeue)
+	}
+	if !dead[0].Nudge.ExpiresAt.Equal(expired.ExpiresAt) {
+		t.Errorf("ExpiresAt = %v, want %v", dead[0].Nudge.ExpiresAt, expired.ExpiresAt)
+	}
+}
+
+func TestReadDeadLetters_Missing(t *testing.T) {
+	dead, err := ReadDeadLetters(t.TempDir())
+	if err != nil || dead != nil {
+		t.Errorf("ReadDeadLetters on missing log = %v, %v; want nil, nil", dead, err)
+	}
+}
+
+func TestDeadLetterFile(t *testing.T) {
+	if got := DeadLetterFile("/town"); got != "/town/.nudges-dead.jsonl" {
+		t.Errorf("DeadLetterFile = %q", got)
+	}
+}
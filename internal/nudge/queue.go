@@ -13,6 +13,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -48,11 +49,23 @@ const (
 	// staleClaimThreshold is how long a .claimed file must be untouched
 	// before Drain considers it orphaned (from a crashed drainer) and removes it.
 	staleClaimThreshold = 5 * time.Minute
+
+	// normalAgingThreshold is how long a normal nudge may wait before Drain
+	// delivers it alongside urgent ones. Without this boost a steady stream
+	// of urgents could starve an old normal until it expired.
+	normalAgingThreshold = 10 * time.Minute
 )
 
+// timeNow returns the current time. It can be overridden in tests.
+var timeNow = time.Now
+
+// ErrQueueFull is returned (wrapped) by Enqueue when the session's queue is
+// at its limit.
+var ErrQueueFull = errors.New("nudge queue is full")
+
 // nudgeConfig loads nudge-specific thresholds from town settings.
 func nudgeConfig(townRoot string) *config.NudgeThresholds {
-	return config.LoadOperationalConfig(townRoot).GetNudgeConfig()
+	return config.LoadOperationalConfigLayered(townRoot).GetNudgeConfig()
 }
 
 // QueuedNudge represents a nudge message stored in the queue.
@@ -88,7 +101,8 @@ func randomSuffix() string {
 
 // Enqueue writes a nudge to the queue for the given session.
 // The nudge will be picked up by the agent's hook at the next turn boundary.
-// Returns an error if the queue is full (MaxQueueDepth reached).
+// Returns an error wrapping ErrQueueFull if the queue is at max_queue_depth;
+// urgent nudges may exceed it by urgent_queue_headroom.
 func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	dir := queueDir(townRoot, session)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -96,14 +110,18 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	}
 
 	// Check queue depth before writing to prevent runaway senders.
-	maxDepth := nudgeConfig(townRoot).MaxQueueDepthV()
-	pending, _ := Pending(townRoot, session)
-	if pending >= maxDepth {
-		return fmt.Errorf("nudge queue for %s is full (%d/%d pending)", session, pending, maxDepth)
+	cfg := nudgeConfig(townRoot)
+	depth, _ := queueDepth(townRoot, session, cfg)
+	limit := depth.Limit
+	if nudge.Priority == PriorityUrgent {
+		limit = depth.UrgentLimit
+	}
+	if depth.Pending >= limit {
+		return fmt.Errorf("%w for %s (%d/%d pending)", ErrQueueFull, session, depth.Pending, limit)
 	}
 
 	if nudge.Timestamp.IsZero() {
-		nudge.Timestamp = time.Now()
+		nudge.Timestamp = timeNow()
 	}
 	if nudge.Priority == "" {
 		nudge.Priority = PriorityNormal
@@ -113,9 +131,9 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 	if nudge.ExpiresAt.IsZero() {
 		switch nudge.Priority {
 		case PriorityUrgent:
-			nudge.ExpiresAt = nudge.Timestamp.Add(DefaultUrgentTTL)
+			nudge.ExpiresAt = nudge.Timestamp.Add(cfg.UrgentTTLD())
 		default:
-			nudge.ExpiresAt = nudge.Timestamp.Add(DefaultNormalTTL)
+			nudge.ExpiresAt = nudge.Timestamp.Add(cfg.NormalTTLD())
 		}
 	}
 
@@ -139,10 +157,12 @@ func Enqueue(townRoot, session string, nudge QueuedNudge) error {
 
 // Requeue writes previously drained nudges back to the queue for later delivery.
 // Existing timestamps are preserved so FIFO ordering remains stable relative to
-// one another; only expired nudges are skipped.
+// one another; only expired nudges are skipped, and dead-lettered.
 func Requeue(townRoot, session string, nudges []QueuedNudge) error {
+	now := timeNow()
 	for _, n := range nudges {
-		if !n.ExpiresAt.IsZero() && time.Now().After(n.ExpiresAt) {
+		if !n.ExpiresAt.IsZero() && now.After(n.ExpiresAt) {
+			deadLetter(townRoot, session, n, DeadReasonExpiredOnRequeue, now)
 			continue
 		}
 		if err := Enqueue(townRoot, session, n); err != nil {
@@ -153,14 +173,20 @@ func Requeue(townRoot, session string, nudges []QueuedNudge) error {
 }
 
 // Drain reads and removes all queued nudges for a session, returning them
-// in FIFO order. This is called by the hook to pick up pending nudges.
+// in delivery order: urgent nudges first, then normal ones, each group in
+// FIFO order. Normal nudges that have waited longer than
+// normalAgingThreshold are promoted into the urgent group so they cannot be
+// starved by newer urgents. This is called by the hook to pick up pending
+// nudges.
 //
 // Uses rename-then-process to prevent concurrent Drain calls from delivering
 // the same nudge twice: each file is atomically renamed to a .claimed suffix
 // before reading, so only one caller can claim each nudge.
 //
-// Expired nudges (past ExpiresAt) are silently discarded during drain.
-// Orphaned .claimed files from crashed drainers are swept if older than 5 minutes.
+// Expired nudges (past ExpiresAt) are discarded during drain and recorded in
+// the dead-letter log (see DeadLetterFile).
+// Orphaned .claimed files from crashed drainers are requeued (see
+// ReclaimStaleClaims).
 func Drain(townRoot, session string) ([]QueuedNudge, error) {
 	dir := queueDir(townRoot, session)
 
@@ -172,33 +198,11 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 		return nil, fmt.Errorf("reading nudge queue: %w", err)
 	}
 
-	// Requeue orphaned .claimed files from crashed drainers.
-	// A .claimed file older than staleClaimThreshold is certainly orphaned —
-	// normal processing completes in milliseconds. We rename it back to .json
-	// so it gets picked up on this or a future Drain call, rather than deleting
-	// it (which would permanently drop the nudge).
-	staleThreshold := nudgeConfig(townRoot).StaleClaimThresholdD()
-	now := time.Now()
-	for _, entry := range entries {
-		if !strings.Contains(entry.Name(), ".claimed") {
-			continue
-		}
-		info, err := entry.Info()
-		if err != nil {
-			continue
-		}
-		if now.Sub(info.ModTime()) > staleThreshold {
-			orphanPath := filepath.Join(dir, entry.Name())
-			// Strip everything from ".claimed" onward to restore original .json filename
-			name := entry.Name()
-			claimedIdx := strings.Index(name, ".claimed")
-			restoredPath := filepath.Join(dir, name[:claimedIdx])
-			if err := os.Rename(orphanPath, restoredPath); err != nil {
-				// Rename failed — remove as last resort to prevent infinite accumulation
-				fmt.Fprintf(os.Stderr, "Warning: failed to requeue orphaned claim %s: %v\n", entry.Name(), err)
-				_ = os.Remove(orphanPath)
-			}
-		}
+	// Requeue orphaned .claimed files from crashed drainers. Reclaimed
+	// nudges are picked up by the next Drain call.
+	now := timeNow()
+	if _, err := reclaimStaleClaims(townRoot, session, entries, now); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
 
 	// Sort by name (timestamp-based) for FIFO ordering
@@ -253,6 +257,7 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 
 		// Skip expired nudges — stale messages create noise, not value.
 		if !n.ExpiresAt.IsZero() && now.After(n.ExpiresAt) {
+			deadLetter(townRoot, session, n, DeadReasonExpired, now)
 			if rmErr := os.Remove(claimPath); rmErr != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to remove expired nudge %s: %v\n", entry.Name(), rmErr)
 			}
@@ -275,9 +280,35 @@ func Drain(townRoot, session string) ([]QueuedNudge, error) {
 		}
 	}
 
+	sortForDelivery(nudges, now)
 	return nudges, nil
 }
 
+// sortForDelivery orders nudges by effective priority, then enqueue time.
+// Filenames already give FIFO order, but Requeue rewrites files under their
+// original timestamps, so sorting on Timestamp keeps the result stable either way.
+func sortForDelivery(nudges []QueuedNudge, now time.Time) {
+	sort.SliceStable(nudges, func(i, j int) bool {
+		ri, rj := deliveryRank(nudges[i], now), deliveryRank(nudges[j], now)
+		if ri != rj {
+			return ri < rj
+		}
+		return nudges[i].Timestamp.Before(nudges[j].Timestamp)
+	})
+}
+
+// deliveryRank returns 0 for nudges that should be delivered first (urgent,
+// or normal nudges that have aged past normalAgingThreshold) and 1 otherwise.
+func deliveryRank(n QueuedNudge, now time.Time) int {
+	if n.Priority == PriorityUrgent {
+		return 0
+	}
+	if !n.Timestamp.IsZero() && now.Sub(n.Timestamp) >= normalAgingThreshold {
+		return 0
+	}
+	return 1
+}
+
 // Pending returns the count of queued nudges for a session without draining.
 // This is an approximate count — it does not check expiry or read file contents.
 func Pending(townRoot, session string) (int, error) {
@@ -301,6 +332,32 @@ func Pending(townRoot, session string) (int, error) {
 	return count, nil
 }
 
+// Depth is a snapshot of a session's nudge queue against its limits.
+type Depth struct {
+	// Pending is the number of queued nudges (approximate, as for Pending).
+	Pending int `json:"pending"`
+	// Limit is max_queue_depth: normal nudges are rejected at this depth.
+	Limit int `json:"limit"`
+	// UrgentLimit is Limit plus urgent_queue_headroom.
+	UrgentLimit int `json:"urgent_limit"`
+}
+
+// Saturated reports whether normal nudges are currently being rejected.
+func (d Depth) Saturated() bool {
+	return d.Pending >= d.Limit
+}
+
+// QueueDepth returns the session's pending nudge count and queue limits.
+func QueueDepth(townRoot, session string) (Depth, error) {
+	return queueDepth(townRoot, session, nudgeConfig(townRoot))
+}
+
+func queueDepth(townRoot, session string, cfg *config.NudgeThresholds) (Depth, error) {
+	limit := cfg.MaxQueueDepthV()
+	pending, err := Pending(townRoot, session)
+	return Depth{Pending: pending, Limit: limit, UrgentLimit: limit + cfg.UrgentQueueHeadroomV()}, err
+}
+
 // QueueLen returns the number of pending nudges for a session without draining.
 // Returns 0 on error — callers use this for quick checks. Missing queue
 // directories are expected (no nudges yet) and silenced; other filesystem
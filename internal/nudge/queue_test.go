@@ -1,6 +1,7 @@
 package nudge
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -52,12 +53,12 @@ func TestEnqueueAndDrain(t *testing.T) {
 		t.Fatalf("Drain returned %d nudges, want 2", len(nudges))
 	}
 
-	// Verify FIFO order
-	if nudges[0].Sender != "mayor" {
-		t.Errorf("nudges[0].Sender = %q, want %q", nudges[0].Sender, "mayor")
+	// Urgent nudges are delivered before normal ones, even if enqueued later
+	if nudges[0].Sender != "gastown/witness" {
+		t.Errorf("nudges[0].Sender = %q, want %q", nudges[0].Sender, "gastown/witness")
 	}
-	if nudges[1].Sender != "gastown/witness" {
-		t.Errorf("nudges[1].Sender = %q, want %q", nudges[1].Sender, "gastown/witness")
+	if nudges[1].Sender != "mayor" {
+		t.Errorf("nudges[1].Sender = %q, want %q", nudges[1].Sender, "mayor")
 	}
 
 	// After drain, pending should be 0
@@ -245,6 +246,37 @@ func TestEnqueueUrgentTTL(t *testing.T) {
 	}
 }
 
+func TestEnqueueProfileTTL(t *testing.T) {
+	// A GT_PROFILE overlay in the town settings must reach the nudge queue,
+	// not just the daemon.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GT_PROFILE", "fast")
+	townRoot := t.TempDir()
+	settings := filepath.Join(townRoot, "settings", "config.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"operational": {"nudge": {"normal_ttl": "20m"}}, "profiles": {"fast": {"nudge": {"normal_ttl": "7m"}}}}`
+	if err := os.WriteFile(settings, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	session := "gt-test-profile-ttl"
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "test", Message: "hello"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	nudges, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(nudges) != 1 {
+		t.Fatalf("got %d nudges, want 1", len(nudges))
+	}
+	if got := nudges[0].ExpiresAt.Sub(nudges[0].Timestamp); got != 7*time.Minute {
+		t.Errorf("TTL = %v, want profile value 7m", got)
+	}
+}
+
 func TestEnqueueCustomExpiry(t *testing.T) {
 	townRoot := t.TempDir()
 	session := "gt-test-custom-expiry"
@@ -353,6 +385,9 @@ func TestEnqueueQueueDepthLimit(t *testing.T) {
 	if !strings.Contains(err.Error(), "is full") {
 		t.Errorf("got error %q, want to contain 'is full'", err.Error())
 	}
+	if !errors.Is(err, ErrQueueFull) {
+		t.Errorf("got error %v, want ErrQueueFull", err)
+	}
 
 	// Verify pending count is at max
 	pending, _ := Pending(townRoot, session)
@@ -791,3 +826,141 @@ func TestConcurrentDrainNoDoubleDeli(t *testing.T) {
 		t.Errorf("double delivery detected: got %d total nudges, want exactly %d", total, count)
 	}
 }
+
+func TestEnqueueUrgentHeadroom(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-urgent"
+
+	for i := 0; i < MaxQueueDepth; i++ {
+		if err := Enqueue(townRoot, session, QueuedNudge{Sender: "sender", Message: "msg"}); err != nil {
+			t.Fatalf("Enqueue %d: %v", i, err)
+		}
+	}
+
+	depth, err := QueueDepth(townRoot, session)
+	if err != nil {
+		t.Fatalf("QueueDepth: %v", err)
+	}
+	if depth.Pending != MaxQueueDepth || depth.Limit != MaxQueueDepth || !depth.Saturated() {
+		t.Errorf("QueueDepth = %+v, want saturated at %d", depth, MaxQueueDepth)
+	}
+	headroom := depth.UrgentLimit - depth.Limit
+	if headroom <= 0 {
+		t.Fatalf("UrgentLimit = %d, want headroom above Limit %d", depth.UrgentLimit, depth.Limit)
+	}
+
+	// A normal nudge is rejected at the cap.
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "sender", Message: "normal"}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("normal Enqueue at cap: got %v, want ErrQueueFull", err)
+	}
+
+	// Urgent nudges still get in, up to the headroom.
+	for i := 0; i < headroom; i++ {
+		if err := Enqueue(townRoot, session, QueuedNudge{Sender: "witness", Message: "urgent", Priority: PriorityUrgent}); err != nil {
+			t.Fatalf("urgent Enqueue %d within headroom: %v", i, err)
+		}
+	}
+	if err := Enqueue(townRoot, session, QueuedNudge{Sender: "witness", Message: "urgent", Priority: PriorityUrgent}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("urgent Enqueue past headroom: got %v, want ErrQueueFull", err)
+	}
+
+	if depth, _ := QueueDepth(townRoot, session); depth.Pending != depth.UrgentLimit {
+		t.Errorf("Pending = %d, want %d", depth.Pending, depth.UrgentLimit)
+	}
+}
+
+func TestQueueDepth_Empty(t *testing.T) {
+	depth, err := QueueDepth(t.TempDir(), "gt-none")
+	if err != nil {
+		t.Fatalf("QueueDepth: %v", err)
+	}
+	if depth.Pending != 0 || depth.Saturated() {
+		t.Errorf("QueueDepth = %+v, want empty and unsaturated", depth)
+	}
+}
+
+func TestDrainPriorityOrder(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-priority"
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	queued := []QueuedNudge{
+		{Sender: "normal-1", Priority: PriorityNormal, Timestamp: now.Add(-3 * time.Minute)},
+		{Sender: "urgent-1", Priority: PriorityUrgent, Timestamp: now.Add(-2 * time.Minute)},
+		{Sender: "normal-2", Priority: PriorityNormal, Timestamp: now.Add(-1 * time.Minute)},
+		{Sender: "urgent-2", Priority: PriorityUrgent, Timestamp: now.Add(-30 * time.Second)},
+		{Sender: "expired", Priority: PriorityUrgent, Timestamp: now.Add(-3 * time.Hour), ExpiresAt: now.Add(-time.Hour)},
+	}
+	for _, n := range queued {
+		if err := Enqueue(townRoot, session, n); err != nil {
+			t.Fatalf("Enqueue %s: %v", n.Sender, err)
+		}
+	}
+
+	nudges, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	want := []string{"urgent-1", "urgent-2", "normal-1", "normal-2"}
+	if got := senders(nudges); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Drain order = %v, want %v", got, want)
+	}
+}
+
+func TestDrainAgingBoost(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-aging"
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	queued := []QueuedNudge{
+		{Sender: "old-normal", Priority: PriorityNormal, Timestamp: now.Add(-normalAgingThreshold - time.Minute)},
+		{Sender: "fresh-normal", Priority: PriorityNormal, Timestamp: now.Add(-2 * time.Minute)},
+		{Sender: "fresh-urgent", Priority: PriorityUrgent, Timestamp: now.Add(-time.Minute)},
+	}
+	for _, n := range queued {
+		if err := Enqueue(townRoot, session, n); err != nil {
+			t.Fatalf("Enqueue %s: %v", n.Sender, err)
+		}
+	}
+
+	nudges, err := Drain(townRoot, session)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	want := []string{"old-normal", "fresh-urgent", "fresh-normal"}
+	if got := senders(nudges); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Drain order = %v, want %v", got, want)
+	}
+}
+
+func TestSortForDelivery_AgingBoundary(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	nudges := []QueuedNudge{
+		{Sender: "just-under", Priority: PriorityNormal, Timestamp: now.Add(-normalAgingThreshold + time.Second)},
+		{Sender: "urgent", Priority: PriorityUrgent, Timestamp: now},
+	}
+	sortForDelivery(nudges, now)
+	if got := senders(nudges); got[0] != "urgent" {
+		t.Errorf("order = %v, want urgent first while normal is under the aging threshold", got)
+	}
+
+	// Once the normal crosses the threshold it outranks the newer urgent.
+	sortForDelivery(nudges, now.Add(time.Second))
+	if got := senders(nudges); got[0] != "just-under" {
+		t.Errorf("order = %v, want aged normal first", got)
+	}
+}
+
+func senders(nudges []QueuedNudge) []string {
+	out := make([]string, len(nudges))
+	for i, n := range nudges {
+		out[i] = n.Sender
+	}
+	return out
+}
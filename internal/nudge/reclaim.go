@@ -0,0 +1,94 @@
+package nudge
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// claimMarker separates a queued nudge's filename from its drainer's claim
+// suffix: <name>.json.claimed.<holder>.
+const claimMarker = ".claimed"
+
+// ReclaimedClaim describes an orphaned claim that was returned to the queue.
+type ReclaimedClaim struct {
+	// ClaimID is the claimed file's name within the session's queue dir.
+	ClaimID string `json:"claim_id"`
+	// Holder is the random suffix of the drainer that claimed the nudge, or
+	// "" for claims made before suffixes were added.
+	Holder string `json:"holder"`
+	// Age is how long the claim had been untouched.
+	Age time.Duration `json:"age"`
+	// Requeued is false if the claim could not be renamed back and was
+	// removed instead, dropping the nudge.
+	Requeued bool `json:"requeued"`
+}
+
+// ReclaimStaleClaims returns orphaned claims in the session's queue to the
+// queue so the next Drain delivers them. A claim is stale when it has been
+// untouched for strictly longer than stale_claim_threshold at now; a claim
+// exactly at the threshold is left alone. Normal processing completes in
+// milliseconds, so a stale claim belongs to a drainer that crashed.
+//
+// Emits a nudge_claim_reclaimed event per reclaimed claim.
+func ReclaimStaleClaims(townRoot, session string, now time.Time) ([]ReclaimedClaim, error) {
+	entries, err := os.ReadDir(queueDir(townRoot, session))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading nudge queue: %w", err)
+	}
+	return reclaimStaleClaims(townRoot, session, entries, now)
+}
+
+// reclaimStaleClaims is ReclaimStaleClaims over an existing directory listing.
+// Rename failures are not fatal: the claim is removed as a last resort to
+// prevent infinite accumulation, and the first error is returned after
+// every claim has been handled.
+func reclaimStaleClaims(townRoot, session string, entries []os.DirEntry, now time.Time) ([]ReclaimedClaim, error) {
+	dir := queueDir(townRoot, session)
+	threshold := nudgeConfig(townRoot).StaleClaimThresholdD()
+
+	var reclaimed []ReclaimedClaim
+	var firstErr error
+	for _, entry := range entries {
+		name := entry.Name()
+		idx := strings.Index(name, claimMarker)
+		if idx < 0 {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		age := now.Sub(info.ModTime())
+		if age <= threshold {
+			continue
+		}
+
+		rc := ReclaimedClaim{
+			ClaimID:  name,
+			Holder:   strings.TrimPrefix(strings.TrimPrefix(name[idx:], claimMarker), "."),
+			Age:      age,
+			Requeued: true,
+		}
+		orphanPath := filepath.Join(dir, name)
+		if err := os.Rename(orphanPath, filepath.Join(dir, name[:idx])); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("requeueing orphaned claim %s: %w", name, err)
+			}
+			_ = os.Remove(orphanPath)
+			rc.Requeued = false
+		}
+		reclaimed = append(reclaimed, rc)
+
+		_ = events.LogFeed(events.TypeNudgeClaimReclaimed, session,
+			events.NudgeClaimReclaimedPayload(session, rc.ClaimID, rc.Holder, rc.Age, rc.Requeued))
+	}
+	return reclaimed, firstErr
+}
@@ -0,0 +1,102 @@
+package nudge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeClaim creates a claimed nudge file last modified at mtime.
+func writeClaim(t *testing.T, dir, name string, mtime time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(`{"sender":"ghost"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReclaimStaleClaims_Threshold(t *testing.T) {
+	threshold := nudgeConfig("").StaleClaimThresholdD()
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		age     time.Duration
+		reclaim bool
+	}{
+		{"under threshold", threshold - time.Second, false},
+		{"exactly at threshold", threshold, false},
+		{"just over threshold", threshold + time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			townRoot := t.TempDir()
+			session := "gt-test-reclaim"
+			dir := queueDir(townRoot, session)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			claimPath := writeClaim(t, dir, "100.json.claimed.deadbeef", now.Add(-tt.age))
+
+			reclaimed, err := ReclaimStaleClaims(townRoot, session, now)
+			if err != nil {
+				t.Fatalf("ReclaimStaleClaims: %v", err)
+			}
+
+			if !tt.reclaim {
+				if len(reclaimed) != 0 {
+					t.Errorf("reclaimed %+v, want none", reclaimed)
+				}
+				if _, err := os.Stat(claimPath); err != nil {
+					t.Errorf("claim should be left in place: %v", err)
+				}
+				return
+			}
+
+			if len(reclaimed) != 1 {
+				t.Fatalf("reclaimed %d claims, want 1", len(reclaimed))
+			}
+			rc := reclaimed[0]
+			if rc.ClaimID != "100.json.claimed.deadbeef" || rc.Holder != "deadbeef" || rc.Age != tt.age || !rc.Requeued {
+				t.Errorf("reclaimed = %+v", rc)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "100.json")); err != nil {
+				t.Errorf("claim should be requeued as 100.json: %v", err)
+			}
+		})
+	}
+}
+
+func TestReclaimStaleClaims_LegacyClaimHasNoHolder(t *testing.T) {
+	townRoot := t.TempDir()
+	session := "gt-test-reclaim-legacy"
+	dir := queueDir(townRoot, session)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	writeClaim(t, dir, "100.json.claimed", now.Add(-time.Hour))
+
+	reclaimed, err := ReclaimStaleClaims(townRoot, session, now)
+	if err != nil {
+		t.Fatalf("ReclaimStaleClaims: %v", err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].Holder != "" {
+		t.Fatalf("reclaimed = %+v, want one claim with no holder", reclaimed)
+	}
+	if n, _ := Pending(townRoot, session); n != 1 {
+		t.Errorf("Pending = %d, want 1", n)
+	}
+}
+
+func TestReclaimStaleClaims_NoQueue(t *testing.T) {
+	reclaimed, err := ReclaimStaleClaims(t.TempDir(), "gt-none", time.Now())
+	if err != nil || reclaimed != nil {
+		t.Errorf("ReclaimStaleClaims on missing queue = %v, %v; want nil, nil", reclaimed, err)
+	}
+}
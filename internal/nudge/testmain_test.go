@@ -0,0 +1,28 @@
+package nudge
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Dead-letter and reclaim events go to the town found from cwd, and from
+	// this package dir that search stops at internal/ because the
+	// internal/mayor package dir looks like a town marker. Run from an empty
+	// temp dir so the suite doesn't leave internal/.events.jsonl behind.
+	cwd, err := os.MkdirTemp("", "gt-nudge-test-cwd-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nudge TestMain: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		fmt.Fprintf(os.Stderr, "nudge TestMain: %v\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	_ = os.RemoveAll(cwd)
+	os.Exit(code)
+}
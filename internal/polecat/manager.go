@@ -145,6 +145,26 @@ type Manager struct {
 	namePool *NamePool
 	tmux     *tmux.Tmux
 	townRoot string // Computed once at construction; used by agentBeadID for deterministic IDs
+	clock    Clock  // Ages .pending reservation markers; nil means the real clock
+}
+
+// Clock reports the current time. The Manager reads it through this interface
+// so tests can age pending work deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the Clock used in production.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// now returns the current time from the manager's clock.
+func (m *Manager) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock.Now()
 }
 
 // NewManager creates a new polecat manager.
@@ -194,6 +214,13 @@ func NewManager(r *rig.Rig, g *git.Git, t *tmux.Tmux) *Manager {
 	// Set town root for custom theme resolution in getNames()
 	pool.SetTownRoot(townRoot)
 
+	// The pool is unlimited unless polecat.namepool_size is set explicitly;
+	// the accessor's default only applies once a limit has been asked for.
+	polecatCfg := config.LoadOperationalConfigLayered(townRoot).GetPolecatConfig()
+	if polecatCfg.NamepoolSize != nil {
+		pool.SetCapacity(polecatCfg.NamepoolSizeV(), polecatCfg.NamepoolGrowIncrementV(), polecatCfg.NamepoolMaxSizeV())
+	}
+
 	_ = pool.Load() // non-fatal: state file may not exist for new rigs
 
 	return &Manager{
@@ -203,6 +230,7 @@ func NewManager(r *rig.Rig, g *git.Git, t *tmux.Tmux) *Manager {
 		namePool: pool,
 		tmux:     t,
 		townRoot: townRoot,
+		clock:    realClock{},
 	}
 }
 
@@ -1238,7 +1266,7 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 	// any commits on the branch. The push is non-blocking: failures are warnings,
 	// not errors, so nuke still proceeds. See: disk-space-resilience.
 	polecatGit := git.NewGit(clonePath)
-	if branch, brErr := polecatGit.CurrentBranch(); brErr == nil && branch != "" {
+	if branch, onBranch, brErr := polecatGit.CurrentBranch(); brErr == nil && onBranch && branch != "" {
 		pushed, unpushedCount, checkErr := polecatGit.BranchPushedToRemote(branch, "origin")
 		if checkErr == nil && !pushed && unpushedCount > 0 {
 			if pushErr := polecatGit.Push("origin", branch, false); pushErr != nil {
@@ -1367,7 +1395,9 @@ func forceRemoveDir(dir string) error {
 
 // AllocateName allocates a name from the name pool.
 // Returns a themed pooled name (furiosa, nux, etc.) if available,
-// otherwise returns an overflow name (just a number like "51").
+// otherwise returns an overflow name (just a number like "51"). Returns an
+// error wrapping ErrNamepoolExhausted when polecat.namepool_size is set, that
+// many names are in use, and the pool can't grow (polecat.namepool_grow_increment).
 // The rig prefix is added by SessionName to create full session names like "gt-<rig>-51".
 // After allocation, kills any lingering tmux session for the name (gt-pqf9x)
 // to prevent "session already running" errors when reusing names from dead polecats.
@@ -1399,7 +1429,7 @@ func (m *Manager) AllocateName() (string, error) {
 	// name as available and reallocate it. The marker acts as a stand-in
 	// directory until AddWithOptions removes it after os.MkdirAll succeeds.
 	// Stale markers (process crashed before AddWithOptions) are cleaned up by
-	// cleanupOrphanPolecatState after pendingMaxAge.
+	// cleanupOrphanPolecatState after polecat.pending_max_age.
 	if err := os.MkdirAll(filepath.Join(m.rig.Path, "polecats"), 0755); err != nil {
 		return "", fmt.Errorf("creating polecats dir for reservation marker: %w", err)
 	}
@@ -1801,7 +1831,7 @@ func (m *Manager) ReuseIdlePolecat(name string, opts AddOptions) (*Polecat, erro
 	}
 
 	// Verify the worktree is actually on the expected branch
-	if actual, err := polecatGit.CurrentBranch(); err == nil && actual != branchName {
+	if actual, _, err := polecatGit.CurrentBranch(); err == nil && actual != branchName {
 		return nil, fmt.Errorf("branch mismatch after checkout: expected %s, got %s", branchName, actual)
 	}
 
@@ -2029,10 +2059,18 @@ func isSessionProcessDead(t *tmux.Tmux, sessionName string, townRoot string) boo
 }
 
 // pendingMaxAge is how long a .pending reservation marker may exist before
-// it is considered stale. gt sling completes in seconds, so 5 minutes is
-// a conservative bound that avoids false positives on slow machines.
+// it is considered stale. gt sling completes in seconds, so the 5 minute
+// default is a conservative bound that avoids false positives on slow machines.
 // Configurable via operational.polecat.pending_max_age in settings/config.json.
-const pendingMaxAge = 5 * time.Minute
+func (m *Manager) pendingMaxAge() time.Duration {
+	return config.LoadOperationalConfigLayered(m.townRoot).GetPolecatConfig().PendingMaxAgeD()
+}
+
+// isPendingStale reports whether a .pending marker last modified at modTime
+// has been abandoned at now. A marker exactly maxAge old is still live.
+func isPendingStale(modTime, now time.Time, maxAge time.Duration) bool {
+	return now.Sub(modTime) > maxAge
+}
 
 // cleanupOrphanPolecatState removes partial/broken polecat state during allocation.
 // This handles the race condition where worktree creation fails mid-way, leaving:
@@ -2048,6 +2086,8 @@ func (m *Manager) cleanupOrphanPolecatState() {
 		return // polecats dir doesn't exist, nothing to clean
 	}
 
+	now := m.now()
+	maxAge := m.pendingMaxAge()
 	for _, entry := range entries {
 		// Clean up stale allocation reservation markers.
 		// A .pending file older than pendingMaxAge means gt sling crashed after
@@ -2055,7 +2095,7 @@ func (m *Manager) cleanupOrphanPolecatState() {
 		// so the name can be reallocated on the next reconcile.
 		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".pending") {
 			info, err := entry.Info()
-			if err == nil && time.Since(info.ModTime()) > pendingMaxAge {
+			if err == nil && isPendingStale(info.ModTime(), now, maxAge) {
 				_ = os.Remove(filepath.Join(polecatsDir, entry.Name()))
 			}
 			continue
@@ -2210,8 +2250,11 @@ func (m *Manager) workstateInputForPolecat(name string, state State, issue strin
 
 	clonePath := m.clonePath(name)
 	g := git.NewGit(clonePath)
-	branch, branchErr := g.CurrentBranch()
-	if branchErr != nil {
+	// A detached HEAD has no branch to check against origin; fail the git
+	// check so the slot is not reused over unpreserved commits.
+	branch, onBranch, branchErr := g.CurrentBranch()
+	if branchErr != nil || !onBranch {
+		branch = ""
 		input.GitCheckFailed = true
 	} else {
 		input.Branch = branch
@@ -2590,7 +2633,11 @@ func (m *Manager) loadFromBeads(name string) (*Polecat, error) {
 
 	// Get actual branch from worktree (branches are now timestamped)
 	polecatGit := git.NewGit(clonePath)
-	branchName, err := polecatGit.CurrentBranch()
+	branchName, onBranch, err := polecatGit.CurrentBranch()
+	if err == nil && !onBranch {
+		style.PrintWarning("polecat %s: %v at %s; reporting branch polecat/%s", name, git.ErrDetachedHead, branchName, name)
+		err = git.ErrDetachedHead
+	}
 	if err != nil {
 		// Fall back to old format if we can't read the branch
 		branchName = fmt.Sprintf("polecat/%s", name)
@@ -1419,7 +1419,7 @@ func TestAddWithOptions_ResumeBranch(t *testing.T) {
 	}
 
 	worktreeGit := git.NewGit(polecat.ClonePath)
-	current, err := worktreeGit.CurrentBranch()
+	current, _, err := worktreeGit.CurrentBranch()
 	if err != nil {
 		t.Fatalf("CurrentBranch: %v", err)
 	}
@@ -1826,7 +1826,7 @@ func TestStalePendingMarkerIsCleanedUp(t *testing.T) {
 	}
 
 	// Backdate the file to simulate a stale marker (older than pendingMaxAge).
-	staleTime := time.Now().Add(-(pendingMaxAge + time.Minute))
+	staleTime := time.Now().Add(-(m.pendingMaxAge() + time.Minute))
 	if err := os.Chtimes(pendingPath, staleTime, staleTime); err != nil {
 		t.Fatal(err)
 	}
@@ -1839,6 +1839,60 @@ func TestStalePendingMarkerIsCleanedUp(t *testing.T) {
 	}
 }
 
+// fakeClock is a Clock fixed at t.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+// TestPendingMarkerAging verifies that .pending markers are classified against
+// polecat.pending_max_age using the manager's clock: a marker exactly at the
+// limit is kept, and only one older than it is removed.
+func TestPendingMarkerAging(t *testing.T) {
+	t.Parallel()
+
+	written := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		age       time.Duration // relative to pending_max_age
+		wantStale bool
+	}{
+		{"just under max age", -time.Second, false},
+		{"exactly at max age", 0, false},
+		{"just over max age", time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			m := NewManager(&rig.Rig{Name: "myrig", Path: tmpDir}, nil, nil)
+			maxAge := m.pendingMaxAge()
+			m.clock = &fakeClock{t: written.Add(maxAge + tt.age)}
+
+			if err := os.MkdirAll(filepath.Join(tmpDir, "polecats"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			pendingPath := m.pendingPath("furiosa")
+			if err := os.WriteFile(pendingPath, []byte("999"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(pendingPath, written, written); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := isPendingStale(written, m.now(), maxAge); got != tt.wantStale {
+				t.Errorf("isPendingStale = %v, want %v", got, tt.wantStale)
+			}
+
+			m.cleanupOrphanPolecatState()
+			_, err := os.Stat(pendingPath)
+			if removed := os.IsNotExist(err); removed != tt.wantStale {
+				t.Errorf("marker removed = %v, want %v", removed, tt.wantStale)
+			}
+		})
+	}
+}
+
 // TestAddWithOptions_RollbackReleasesName verifies that when AddWithOptions fails,
 // the allocated name is released back to the pool and the polecat directory is cleaned up.
 // Regression test for gt-2vs22: cleanupOnError previously only removed the directory,
@@ -3,6 +3,7 @@ package polecat
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/steveyegge/gastown/internal/atomicfile"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/lock"
 )
 
@@ -31,6 +33,10 @@ const (
 	MaxThemeNames = 2000
 )
 
+// ErrNamepoolExhausted is returned (wrapped) by Allocate when every slot in a
+// capacity-limited pool is in use and the pool cannot grow.
+var ErrNamepoolExhausted = errors.New("polecat namepool exhausted")
+
 // ReservedInfraAgentNames contains names reserved for infrastructure agents.
 // These names must never be allocated to polecats.
 var ReservedInfraAgentNames = map[string]bool{
@@ -115,6 +121,19 @@ type NamePool struct {
 	// MaxSize is the maximum number of themed names before overflow.
 	MaxSize int `json:"max_size"`
 
+	// Capacity is the maximum number of names (themed and overflow) in use
+	// at once; 0 means unlimited. Grows by growBy up to capacityCap when the
+	// pool is exhausted (see SetCapacity). Persisted so growth is kept.
+	Capacity int `json:"capacity,omitempty"`
+
+	// overflowInUse tracks overflow names currently in use, for Capacity.
+	// Transient like InUse.
+	overflowInUse map[string]bool
+
+	baseCapacity int
+	growBy       int
+	capacityCap  int
+
 	// stateFile is the path to persist pool state.
 	stateFile string
 
@@ -208,6 +227,7 @@ func (p *NamePool) Load() error {
 		if os.IsNotExist(err) {
 			// Initialize with empty state
 			p.InUse = make(map[string]bool)
+			p.overflowInUse = make(map[string]bool)
 			p.OverflowNext = p.MaxSize + 1
 			return nil
 		}
@@ -223,6 +243,7 @@ func (p *NamePool) Load() error {
 	}
 
 	p.InUse = make(map[string]bool)
+	p.overflowInUse = make(map[string]bool)
 
 	p.OverflowNext = loaded.OverflowNext
 	if p.OverflowNext < p.MaxSize+1 {
@@ -231,6 +252,10 @@ func (p *NamePool) Load() error {
 	if loaded.MaxSize > 0 {
 		p.MaxSize = loaded.MaxSize
 	}
+	// Keep earlier growth, within the current cap.
+	if p.Capacity > 0 && loaded.Capacity > p.Capacity {
+		p.Capacity = min(loaded.Capacity, max(p.capacityCap, p.Capacity))
+	}
 
 	return nil
 }
@@ -241,10 +266,11 @@ type namePoolState struct {
 	RigName      string `json:"rig_name"`
 	OverflowNext int    `json:"overflow_next"`
 	MaxSize      int    `json:"max_size"`
+	Capacity     int    `json:"capacity,omitempty"`
 }
 
 // Save persists the pool state to disk using atomic write.
-// Only runtime state (OverflowNext, MaxSize) is saved - configuration like
+// Only runtime state (OverflowNext, MaxSize, Capacity) is saved - configuration like
 // Theme and CustomNames come from settings/config.json and are not persisted here.
 func (p *NamePool) Save() error {
 	p.mu.RLock()
@@ -260,18 +286,41 @@ func (p *NamePool) Save() error {
 		RigName:      p.RigName,
 		OverflowNext: p.OverflowNext,
 		MaxSize:      p.MaxSize,
+		Capacity:     p.Capacity,
 	}
 
 	return atomicfile.WriteJSON(p.stateFile, state)
 }
 
+// SetCapacity limits the pool to size names in use at once. When the pool is
+// exhausted it grows by growBy slots at a time, up to hardCap; growBy <= 0
+// disables growth. size is honoured even below MaxSize: only the first size
+// themed names are then handed out until one is released. Without a call
+// to SetCapacity the pool is unlimited. Call before Load so persisted
+// growth is restored.
+func (p *NamePool) SetCapacity(size, growBy, hardCap int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.baseCapacity = size
+	p.Capacity = size
+	p.growBy = growBy
+	p.capacityCap = hardCap
+}
+
 // Allocate returns a name from the pool.
 // It prefers names in order from the theme list, and falls back to overflow names
-// when the pool is exhausted.
+// when the themed names run out. If the pool has a capacity (see SetCapacity)
+// and every slot is in use, it grows the pool if allowed, and otherwise returns
+// an error wrapping ErrNamepoolExhausted.
 func (p *NamePool) Allocate() (string, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.Capacity > 0 && p.inUseCountLocked() >= p.Capacity && !p.growLocked() {
+		return "", fmt.Errorf("%w: rig %s has %d/%d names in use", ErrNamepoolExhausted, p.RigName, p.inUseCountLocked(), p.Capacity)
+	}
+
 	names := p.getNames()
 
 	// Try to find first available name from the theme
@@ -283,16 +332,43 @@ func (p *NamePool) Allocate() (string, error) {
 		}
 	}
 
-	// Pool exhausted, use overflow naming
+	// Themed names exhausted, use overflow naming
 	name := p.formatOverflowName(p.OverflowNext)
 	p.OverflowNext++
+	p.markOverflowLocked(name)
 	return name, nil
 }
 
+// inUseCountLocked returns the number of themed and overflow names in use.
+func (p *NamePool) inUseCountLocked() int {
+	return len(p.InUse) + len(p.overflowInUse)
+}
+
+// markOverflowLocked records an overflow name as in use.
+func (p *NamePool) markOverflowLocked(name string) {
+	if p.overflowInUse == nil {
+		p.overflowInUse = make(map[string]bool)
+	}
+	p.overflowInUse[name] = true
+}
+
+// growLocked adds growBy slots to the pool, up to capacityCap, and emits a
+// namepool_grown event. Returns false if the pool cannot grow.
+func (p *NamePool) growLocked() bool {
+	if p.growBy <= 0 || p.Capacity >= p.capacityCap {
+		return false
+	}
+	from := p.Capacity
+	p.Capacity = min(p.Capacity+p.growBy, p.capacityCap)
+	_ = events.LogFeed(events.TypeNamepoolGrown, p.RigName+"/polecats",
+		events.NamepoolGrownPayload(p.RigName, from, p.Capacity, p.capacityCap))
+	return true
+}
+
 // Release returns a name slot to the available pool.
 // Called when a polecat is nuked - the name becomes available for new polecats.
 // NOTE: This releases the NAME, not the polecat. The polecat is gone (nuked).
-// For overflow names, this is a no-op (they are not reusable).
+// Overflow names free their slot but are not reused.
 func (p *NamePool) Release(name string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -300,8 +376,9 @@ func (p *NamePool) Release(name string) {
 	// Check if it's a themed name
 	if p.isThemedName(name) {
 		delete(p.InUse, name)
+		return
 	}
-	// Overflow names are not reusable, so we don't track them
+	delete(p.overflowInUse, name)
 }
 
 // isThemedName checks if a name is in the theme pool.
@@ -358,6 +435,7 @@ func (p *NamePool) Reconcile(existingPolecats []string) {
 
 	// Clear current state
 	p.InUse = make(map[string]bool)
+	p.overflowInUse = make(map[string]bool)
 
 	// Mark all existing polecats as in use
 	for _, name := range existingPolecats {
@@ -365,7 +443,12 @@ func (p *NamePool) Reconcile(existingPolecats []string) {
 			p.InUse[name] = true
 			continue
 		}
-		if seq, err := strconv.Atoi(name); err == nil && seq >= p.OverflowNext {
+		seq, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		p.overflowInUse[name] = true
+		if seq >= p.OverflowNext {
 			p.OverflowNext = seq + 1
 		}
 	}
@@ -774,5 +857,9 @@ func (p *NamePool) Reset() {
 	defer p.mu.Unlock()
 
 	p.InUse = make(map[string]bool)
+	p.overflowInUse = make(map[string]bool)
 	p.OverflowNext = p.MaxSize + 1
+	if p.Capacity > 0 {
+		p.Capacity = p.baseCapacity
+	}
 }
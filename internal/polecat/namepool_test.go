@@ -1,6 +1,7 @@
 package polecat
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -155,6 +156,153 @@ func TestNamePool_OverflowNotReusable(t *testing.T) {
 	}
 }
 
+func TestNamePool_CapacityExhausted(t *testing.T) {
+	pool := NewNamePoolWithConfig(t.TempDir(), "gastown", "mad-max", nil, 2)
+	pool.SetCapacity(3, 0, 10)
+
+	// Two themed names, then one overflow name fill the pool.
+	for _, want := range []string{"furiosa", "nux", "3"} {
+		name, err := pool.Allocate()
+		if err != nil {
+			t.Fatalf("Allocate: %v", err)
+		}
+		if name != want {
+			t.Errorf("Allocate = %s, want %s", name, want)
+		}
+	}
+
+	_, err := pool.Allocate()
+	if !errors.Is(err, ErrNamepoolExhausted) {
+		t.Fatalf("Allocate on full pool = %v, want ErrNamepoolExhausted", err)
+	}
+	if pool.Capacity != 3 {
+		t.Errorf("Capacity = %d, want 3 (growth disabled)", pool.Capacity)
+	}
+}
+
+func TestNamePool_UnlimitedWithoutCapacity(t *testing.T) {
+	pool := NewNamePool(t.TempDir(), "gastown")
+	for i := 0; i < DefaultPoolSize+10; i++ {
+		if _, err := pool.Allocate(); err != nil {
+			t.Fatalf("Allocate %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestNamePool_CapacityBelowMaxSize(t *testing.T) {
+	pool := NewNamePoolWithConfig(t.TempDir(), "gastown", "mad-max", nil, 5)
+	pool.SetCapacity(2, 0, 2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := pool.Allocate(); err != nil {
+			t.Fatalf("Allocate: %v", err)
+		}
+	}
+	if _, err := pool.Allocate(); !errors.Is(err, ErrNamepoolExhausted) {
+		t.Fatalf("expected exhaustion at capacity 2 with 5 themed names, got %v", err)
+	}
+}
+
+func TestNamePool_CapacityGrows(t *testing.T) {
+	pool := NewNamePoolWithConfig(t.TempDir(), "gastown", "mad-max", nil, 2)
+	pool.SetCapacity(2, 2, 5)
+
+	var names []string
+	for i := 0; i < 5; i++ {
+		name, err := pool.Allocate()
+		if err != nil {
+			t.Fatalf("Allocate %d: %v", i+1, err)
+		}
+		names = append(names, name)
+	}
+	if got := strings.Join(names, ","); got != "furiosa,nux,3,4,5" {
+		t.Errorf("allocated %s", got)
+	}
+	// 2 → 4 → 5: the last step is truncated at the hard cap.
+	if pool.Capacity != 5 {
+		t.Errorf("Capacity = %d, want 5", pool.Capacity)
+	}
+
+	if _, err := pool.Allocate(); !errors.Is(err, ErrNamepoolExhausted) {
+		t.Fatalf("Allocate past hard cap = %v, want ErrNamepoolExhausted", err)
+	}
+}
+
+func TestNamePool_CapacityRecycle(t *testing.T) {
+	pool := NewNamePoolWithConfig(t.TempDir(), "gastown", "mad-max", nil, 2)
+	pool.SetCapacity(3, 0, 3)
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.Allocate(); err != nil {
+			t.Fatalf("Allocate: %v", err)
+		}
+	}
+	if _, err := pool.Allocate(); !errors.Is(err, ErrNamepoolExhausted) {
+		t.Fatalf("expected exhaustion, got %v", err)
+	}
+
+	// A released themed name is handed out again.
+	pool.Release("nux")
+	name, err := pool.Allocate()
+	if err != nil {
+		t.Fatalf("Allocate after release: %v", err)
+	}
+	if name != "nux" {
+		t.Errorf("Allocate after release = %s, want nux", name)
+	}
+
+	// A released overflow name frees its slot, but the name isn't reused.
+	pool.Release("3")
+	name, err = pool.Allocate()
+	if err != nil {
+		t.Fatalf("Allocate after overflow release: %v", err)
+	}
+	if name != "4" {
+		t.Errorf("Allocate after overflow release = %s, want 4", name)
+	}
+}
+
+func TestNamePool_CapacityCountsReconciledOverflow(t *testing.T) {
+	pool := NewNamePoolWithConfig(t.TempDir(), "gastown", "mad-max", nil, 2)
+	pool.SetCapacity(3, 0, 3)
+	pool.Reconcile([]string{"furiosa", "7"})
+
+	if _, err := pool.Allocate(); err != nil {
+		t.Fatalf("Allocate: %v", err)
+	}
+	if _, err := pool.Allocate(); !errors.Is(err, ErrNamepoolExhausted) {
+		t.Fatalf("expected exhaustion with furiosa, nux, 7 in use, got %v", err)
+	}
+}
+
+func TestNamePool_CapacityGrowthPersists(t *testing.T) {
+	tmpDir := t.TempDir()
+	pool := NewNamePoolWithConfig(tmpDir, "gastown", "mad-max", nil, 1)
+	pool.SetCapacity(1, 1, 3)
+	for i := 0; i < 2; i++ {
+		if _, err := pool.Allocate(); err != nil {
+			t.Fatalf("Allocate: %v", err)
+		}
+	}
+	if err := pool.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	pool2 := NewNamePoolWithConfig(tmpDir, "gastown", "mad-max", nil, 1)
+	pool2.SetCapacity(1, 1, 3)
+	if err := pool2.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if pool2.Capacity != 2 {
+		t.Errorf("Capacity after Load = %d, want 2 (grown)", pool2.Capacity)
+	}
+
+	pool2.Reset()
+	if pool2.Capacity != 1 {
+		t.Errorf("Capacity after Reset = %d, want 1", pool2.Capacity)
+	}
+}
+
 func TestNamePool_SaveLoad(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "namepool-test-*")
 	if err != nil {
@@ -266,10 +266,16 @@ func shouldCreateFreshSessionBranch(currentBranch, issue, canonicalBranch string
 }
 
 func (m *SessionManager) ensureCanonicalSessionBranch(g *git.Git, polecat string, opts SessionStartOptions) string {
-	currentBranch, err := g.CurrentBranch()
+	currentBranch, onBranch, err := g.CurrentBranch()
 	if err != nil {
 		return ""
 	}
+	if !onBranch {
+		// Leave a detached HEAD alone: branching off the canonical base here
+		// would strand any commits made there, and the SHA is not a branch name.
+		debugSession("session worktree on detached HEAD", fmt.Errorf("at %s", currentBranch))
+		return ""
+	}
 
 	startPoint := m.canonicalSessionStartPoint(g)
 	if startPoint == "" {
@@ -527,8 +533,10 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	agentID := fmt.Sprintf("%s/%s", m.rig.Name, polecat)
 	debugSession("SetPaneDiedHook", m.tmux.SetPaneDiedHook(sessionID, agentID))
 
+	startTimeout := config.LoadOperationalConfigLayered(townRoot).SessionConfigForRole("polecat").ClaudeStartTimeoutD()
+
 	// Wait for Claude to start (non-fatal)
-	debugSession("WaitForCommand", m.tmux.WaitForCommand(sessionID, constants.SupportedShells, constants.ClaudeStartTimeout))
+	debugSession("WaitForCommand", m.tmux.WaitForCommand(sessionID, constants.SupportedShells, startTimeout))
 
 	// Accept startup dialogs (workspace trust + bypass permissions) if they appear
 	debugSession("AcceptStartupDialogs", m.tmux.AcceptStartupDialogs(sessionID))
@@ -540,7 +548,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// Wait for runtime to be fully ready at the prompt (not just started).
 	// Uses prompt-based polling for agents with ReadyPromptPrefix (e.g., Claude "❯ "),
 	// falling back to ReadyDelayMs sleep for agents without prompt detection.
-	debugSession("WaitForRuntimeReady", m.tmux.WaitForRuntimeReady(sessionID, runtimeConfig, constants.ClaudeStartTimeout))
+	debugSession("WaitForRuntimeReady", m.tmux.WaitForRuntimeReady(sessionID, runtimeConfig, startTimeout))
 	if err := m.tmux.CheckStartupBlocked(sessionID); err != nil {
 		_ = m.tmux.KillSessionWithProcesses(sessionID)
 		return fmt.Errorf("startup blocked: %w", err)
@@ -552,14 +560,14 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 		// Promptless runtimes need the full startup prompt delivered via nudge so
 		// the agent sees both the beacon and the initial work instructions.
 		debugSession("DeliverStartupPromptFallback",
-			runtime.DeliverStartupPromptFallback(m.tmux, sessionID, startupPromptFallback, runtimeConfig, constants.ClaudeStartTimeout))
+			runtime.DeliverStartupPromptFallback(m.tmux, sessionID, startupPromptFallback, runtimeConfig, startTimeout))
 	} else {
 		if fallbackInfo.StartupNudgeDelayMs > 0 {
 			// Wait for agent to finish processing the beacon + gt prime before sending
 			// work instructions. Prompt-capable runtimes already got the beacon as the
 			// initial CLI prompt, so they only need the delayed startup nudge here.
 			primeWaitRC := runtime.RuntimeConfigWithMinDelay(runtimeConfig, fallbackInfo.StartupNudgeDelayMs)
-			debugSession("WaitForPrimeReady", m.tmux.WaitForRuntimeReady(sessionID, primeWaitRC, constants.ClaudeStartTimeout))
+			debugSession("WaitForPrimeReady", m.tmux.WaitForRuntimeReady(sessionID, primeWaitRC, startTimeout))
 		}
 
 		if fallbackInfo.SendStartupNudge {
@@ -931,8 +939,8 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 	// via settings/config.json without rebuilding. Both fall back to compiled-in
 	// defaults when no config is present. (Re-wired after revert of #3100.)
 	townRoot := filepath.Dir(m.rig.Path)
-	opCfg := config.LoadOperationalConfig(townRoot)
-	sessionCfg := opCfg.GetSessionConfig()
+	opCfg := config.LoadOperationalConfigLayered(townRoot)
+	sessionCfg := opCfg.SessionConfigForRole("polecat")
 	verifyDelay := sessionCfg.StartupNudgeVerifyDelayD()
 	maxRetries := sessionCfg.StartupNudgeMaxRetriesV()
 
@@ -1,6 +1,7 @@
 package polecat
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -8,7 +9,23 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	// Namepool growth logs a namepool_grown feed event to the town found from
+	// cwd, and from this package dir that search stops at internal/ because
+	// the internal/mayor package dir looks like a town marker. Run from an
+	// empty temp dir so the suite doesn't leave internal/.events.jsonl behind.
+	cwd, err := os.MkdirTemp("", "gt-polecat-test-cwd-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "polecat TestMain: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		fmt.Fprintf(os.Stderr, "polecat TestMain: %v\n", err)
+		os.Exit(1)
+	}
+
 	code := m.Run()
+
+	_ = os.RemoveAll(cwd)
 	testutil.TerminateDoltContainer()
 	os.Exit(code)
 }
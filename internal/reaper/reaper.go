@@ -31,6 +31,33 @@ var DefaultDatabases = []string{"hq"}
 // testPollutionPrefixes are database name prefixes created by tests.
 var testPollutionPrefixes = []string{"testdb_", "beads_t", "beads_pt", "doctest_"}
 
+// DB is the subset of *sql.DB the reapers, purges and closers run their
+// statements through. The daemon passes a wrapper that adds its connection
+// pool's per-statement timeout and slow-query logging.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryRow runs a query that returns one row on db and scans it into dest.
+func queryRow(ctx context.Context, db DB, dest interface{}, query string, args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := rows.Scan(dest); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
 // isNothingToCommit returns true if the error is a Dolt "nothing to commit" error.
 func isNothingToCommit(err error) bool {
 	return err != nil && strings.Contains(strings.ToLower(err.Error()), "nothing to commit")
@@ -52,10 +79,23 @@ func isTableNotFound(err error) bool {
 // all production databases, filtering out system databases and test pollution.
 // Falls back to DefaultDatabases on any error.
 func DiscoverDatabases(host string, port int) []string {
+	names, err := showDatabases(host, port)
+	if err != nil {
+		return DefaultDatabases
+	}
+	databases := filterDatabases(names)
+	if len(databases) == 0 {
+		return DefaultDatabases
+	}
+	return databases
+}
+
+// showDatabases runs SHOW DATABASES on the Dolt server. Replaced in tests.
+var showDatabases = func(host string, port int) ([]string, error) {
 	dsn := fmt.Sprintf("root@tcp(%s:%d)/?parseTime=true&timeout=5s", host, port)
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
-		return DefaultDatabases
+		return nil, err
 	}
 	defer db.Close()
 
@@ -64,20 +104,33 @@ func DiscoverDatabases(host string, port int) []string {
 
 	rows, err := db.QueryContext(ctx, "SHOW DATABASES")
 	if err != nil {
-		return DefaultDatabases
+		return nil, err
 	}
 	defer rows.Close()
 
-	var databases []string
+	var names []string
 	for rows.Next() {
 		var name string
 		if err := rows.Scan(&name); err != nil {
 			continue
 		}
-		if name == "information_schema" || name == "mysql" {
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// filterDatabases drops system schemas (information_schema, mysql, dolt_*),
+// test pollution, and names that fail validDBName from a SHOW DATABASES list.
+func filterDatabases(names []string) []string {
+	var databases []string
+	for _, name := range names {
+		lower := strings.ToLower(name)
+		if lower == "information_schema" || lower == "mysql" || strings.HasPrefix(lower, "dolt_") {
+			continue
+		}
+		if !validDBName.MatchString(name) {
 			continue
 		}
-		lower := strings.ToLower(name)
 		skip := false
 		for _, prefix := range testPollutionPrefixes {
 			if strings.HasPrefix(lower, prefix) {
@@ -90,10 +143,6 @@ func DiscoverDatabases(host string, port int) []string {
 		}
 		databases = append(databases, name)
 	}
-
-	if len(databases) == 0 {
-		return DefaultDatabases
-	}
 	return databases
 }
 
@@ -113,6 +162,7 @@ type ReapResult struct {
 	Database   string    `json:"database"`
 	Reaped     int       `json:"reaped"`
 	OpenRemain int       `json:"open_remain"`
+	Status     string    `json:"status,omitempty"` // status stale wisps were set to
 	DryRun     bool      `json:"dry_run,omitempty"`
 	Anomalies  []Anomaly `json:"anomalies,omitempty"`
 }
@@ -161,6 +211,27 @@ const (
 	DefaultAlertThreshold = 800
 )
 
+// Statuses Reap can set on stale wisps.
+const (
+	// ReapStatusClosed closes stale wisps like any completed wisp (default).
+	ReapStatusClosed = "closed"
+	// ReapStatusReaped archives stale wisps as status='reaped' and stamps
+	// reaped_at, so force-closed wisps stay distinguishable from completed
+	// ones. Purge only deletes closed wisps, so reaped wisps are kept for
+	// audit or revival. Requires a reaped_at column on the wisps table.
+	ReapStatusReaped = "reaped"
+)
+
+// ValidateReapStatus returns an error unless status is "" (closed) or one of
+// the ReapStatus* constants.
+func ValidateReapStatus(status string) error {
+	switch status {
+	case "", ReapStatusClosed, ReapStatusReaped:
+		return nil
+	}
+	return fmt.Errorf("invalid reap status %q (want %q or %q)", status, ReapStatusClosed, ReapStatusReaped)
+}
+
 // ValidateDBName returns an error if the database name is unsafe.
 func ValidateDBName(dbName string) error {
 	if !validDBName.MatchString(dbName) {
@@ -215,13 +286,18 @@ func parentExcludeJoin(dbName string) (joinClause, whereCondition string) {
 // operations (wisps and issues). Returns false (no error) when tables are missing
 // — callers use this to skip databases that have incomplete beads schema (e.g.
 // partially initialized databases on the central Dolt server).
-func HasReaperSchema(db *sql.DB) (bool, error) {
+func HasReaperSchema(db DB) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	return HasReaperSchemaContext(ctx, db)
+}
 
+// HasReaperSchemaContext is HasReaperSchema bounded by ctx instead of its own
+// 5s timeout.
+func HasReaperSchemaContext(ctx context.Context, db DB) (bool, error) {
 	var count int
-	err := db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM information_schema.tables WHERE table_name IN ('wisps', 'issues') AND table_schema = DATABASE()").Scan(&count)
+	err := queryRow(ctx, db, &count,
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_name IN ('wisps', 'issues') AND table_schema = DATABASE()")
 	if err != nil {
 		return false, fmt.Errorf("check reaper schema: %w", err)
 	}
@@ -321,33 +397,160 @@ func Scan(db *sql.DB, dbName string, maxAge, purgeAge, mailDeleteAge, staleIssue
 // Reap closes stale wisps in a database whose parent molecule is already closed.
 // UPDATEs are batched to avoid holding a write lock for extended periods on large tables.
 func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapResult, error) {
+	return ReapAs(db, dbName, maxAge, ReapStatusClosed, dryRun)
+}
+
+// ReapAs is Reap with a choice of status for stale wisps (see ReapStatusClosed
+// and ReapStatusReaped). A database whose wisps table has no reaped_at column
+// falls back to closing, with an anomaly recorded in the result.
+func ReapAs(db *sql.DB, dbName string, maxAge time.Duration, status string, dryRun bool) (*ReapResult, error) {
 	// Use a longer timeout to accommodate batched processing across large tables.
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
+	return ReapAsContext(ctx, db, dbName, maxAge, status, dryRun)
+}
 
-	cutoff := time.Now().UTC().Add(-maxAge)
-	parentJoin, parentWhere := parentExcludeJoin(dbName)
-	// Exclude agent beads (issue_type='agent') from reaping — they have persistent
-	// identity and should not be closed by the wisp reaper regardless of age.
-	whereClause := fmt.Sprintf(
-		"w.status IN ('open', 'hooked', 'in_progress') AND w.created_at < ? AND w.issue_type != 'agent' AND %s", parentWhere)
+// ReapAsContext is ReapAs bounded by ctx instead of its own 2-minute timeout.
+func ReapAsContext(ctx context.Context, db DB, dbName string, maxAge time.Duration, status string, dryRun bool) (*ReapResult, error) {
+	if err := ValidateReapStatus(status); err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = ReapStatusClosed
+	}
 
+	cutoff := time.Now().UTC().Add(-maxAge)
 	result := &ReapResult{Database: dbName, DryRun: dryRun}
 
-	if dryRun {
-		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM wisps w %s WHERE %s", parentJoin, whereClause)
-		if err := db.QueryRowContext(ctx, countQuery, cutoff).Scan(&result.Reaped); err != nil {
-			return nil, fmt.Errorf("dry-run count: %w", err)
+	if status == ReapStatusReaped {
+		ok, err := hasColumn(ctx, db, "wisps", "reaped_at")
+		if err != nil {
+			return nil, err
 		}
-		openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
-		if err := db.QueryRowContext(ctx, openQuery).Scan(&result.OpenRemain); err != nil {
-			return nil, fmt.Errorf("count open: %w", err)
+		if !ok {
+			status = ReapStatusClosed
+			result.Anomalies = append(result.Anomalies, Anomaly{
+				Type:    "reap_status_fallback",
+				Message: "wisps table has no reaped_at column; closing stale wisps instead",
+			})
 		}
-		return result, nil
+	}
+	result.Status = status
+
+	reaped, err := reapStaleRows(ctx, db, dbName, wispQuery(dbName, status), cutoff, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("close stale wisps: %w", err)
+	}
+	result.Reaped = reaped
+
+	if !dryRun && reaped > 0 {
+		verb := "close"
+		if status == ReapStatusReaped {
+			verb = "archive"
+		}
+		if err := commitReap(ctx, db, fmt.Sprintf("reaper: %s %d stale wisps in %s", verb, reaped, dbName)); err != nil {
+			return result, err
+		}
+	}
+
+	openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
+	if err := queryRow(ctx, db, &result.OpenRemain, openQuery); err != nil {
+		return result, fmt.Errorf("count open: %w", err)
+	}
+
+	return result, nil
+}
+
+// staleRowQuery describes the rows a stale-row reaper closes: rows of Table
+// whose StatusCol is one of ActiveStatuses and whose TimeCol is older than
+// the cutoff, narrowed further by the optional Join and Where clauses.
+type staleRowQuery struct {
+	Table          string   // table to reap, e.g. "wisps"
+	Alias          string   // alias Table is given in the query, used by Join/Where
+	StatusCol      string   // status column, e.g. "status"
+	ActiveStatuses []string // statuses still considered live, bound as parameters
+	TimeCol        string   // timestamp compared against the cutoff
+	Join           string   // optional JOIN clause appended after the table
+	Where          string   // optional extra condition, ANDed in
+	Set            string   // SET clause applied to reaped rows
+}
+
+// validate rejects identifiers that can't be safely interpolated into SQL.
+func (q staleRowQuery) validate() error {
+	for _, ident := range []string{q.Table, q.Alias, q.StatusCol, q.TimeCol} {
+		if !validDBName.MatchString(ident) {
+			return fmt.Errorf("invalid identifier: %q", ident)
+		}
+	}
+	if len(q.ActiveStatuses) == 0 {
+		return fmt.Errorf("no active statuses for %s", q.Table)
+	}
+	if q.Set == "" {
+		return fmt.Errorf("no SET clause for %s", q.Table)
+	}
+	return nil
+}
+
+// where returns the shared WHERE clause and its arguments (the active
+// statuses, then the cutoff).
+func (q staleRowQuery) where(cutoff time.Time) (string, []interface{}) {
+	placeholders := make([]string, len(q.ActiveStatuses))
+	args := make([]interface{}, 0, len(q.ActiveStatuses)+1)
+	for i, s := range q.ActiveStatuses {
+		placeholders[i] = "?"
+		args = append(args, s)
+	}
+	args = append(args, cutoff)
+	clause := fmt.Sprintf("%s.%s IN (%s) AND %s.%s < ?",
+		q.Alias, q.StatusCol, strings.Join(placeholders, ","), q.Alias, q.TimeCol)
+	if q.Where != "" {
+		clause += " AND " + q.Where
+	}
+	return clause, args
+}
+
+// countQuery returns the query counting rows that would be reaped.
+func (q staleRowQuery) countQuery(cutoff time.Time) (string, []interface{}) {
+	where, args := q.where(cutoff)
+	return fmt.Sprintf("SELECT COUNT(*) FROM %s %s %s WHERE %s", q.Table, q.Alias, q.Join, where), args
+}
+
+// idQuery returns the query selecting the next batch of row IDs to reap.
+func (q staleRowQuery) idQuery(cutoff time.Time) (string, []interface{}) {
+	where, args := q.where(cutoff)
+	return fmt.Sprintf("SELECT %s.id FROM %s %s %s WHERE %s LIMIT %d",
+		q.Alias, q.Table, q.Alias, q.Join, where, DefaultBatchSize), args
+}
+
+// updateQuery returns the batch UPDATE applying Set to rows IN (inClause).
+func (q staleRowQuery) updateQuery(inClause string) string {
+	return fmt.Sprintf("UPDATE %s SET %s WHERE id IN (%s)", q.Table, q.Set, inClause)
+}
+
+// reapStaleRows applies q.Set to every row matching q older than cutoff and
+// returns how many rows it updated; in dry-run mode it only counts them.
+// UPDATEs are batched to avoid holding a write lock for extended periods on
+// large tables, and left uncommitted with autocommit disabled — callers flush
+// them with commitReap.
+func reapStaleRows(ctx context.Context, db DB, dbName string, q staleRowQuery, cutoff time.Time, dryRun bool) (int, error) {
+	if err := ValidateDBName(dbName); err != nil {
+		return 0, err
+	}
+	if err := q.validate(); err != nil {
+		return 0, err
+	}
+
+	if dryRun {
+		countQuery, args := q.countQuery(cutoff)
+		var n int
+		if err := queryRow(ctx, db, &n, countQuery, args...); err != nil {
+			return 0, fmt.Errorf("dry-run count: %w", err)
+		}
+		return n, nil
 	}
 
 	if _, err := db.ExecContext(ctx, "SET @@autocommit = 0"); err != nil {
-		return nil, fmt.Errorf("disable autocommit: %w", err)
+		return 0, fmt.Errorf("disable autocommit: %w", err)
 	}
 	defer func() {
 		_, _ = db.ExecContext(context.Background(), "SET @@autocommit = 1")
@@ -355,16 +558,12 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 
 	// Batch UPDATE: select IDs in chunks, update each chunk.
 	// This avoids holding a write lock on the entire table for minutes.
-	// Uses LEFT JOIN anti-pattern instead of correlated EXISTS to avoid O(n*m) cost (gt-jd1z).
-	idQuery := fmt.Sprintf(
-		"SELECT w.id FROM wisps w %s WHERE %s LIMIT %d",
-		parentJoin, whereClause, DefaultBatchSize)
-
-	totalReaped := 0
+	idQuery, idArgs := q.idQuery(cutoff)
+	total := 0
 	for {
-		rows, err := db.QueryContext(ctx, idQuery, cutoff)
+		rows, err := db.QueryContext(ctx, idQuery, idArgs...)
 		if err != nil {
-			return nil, fmt.Errorf("select reap batch: %w", err)
+			return total, fmt.Errorf("select reap batch: %w", err)
 		}
 
 		var ids []string
@@ -372,7 +571,7 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 			var id string
 			if err := rows.Scan(&id); err != nil {
 				rows.Close()
-				return nil, fmt.Errorf("scan wisp id: %w", err)
+				return total, fmt.Errorf("scan %s id: %w", q.Table, err)
 			}
 			ids = append(ids, id)
 		}
@@ -388,52 +587,146 @@ func Reap(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapRe
 			placeholders[i] = "?"
 			args[i] = id
 		}
-		inClause := strings.Join(placeholders, ",")
 
-		updateQuery := fmt.Sprintf(
-			"UPDATE wisps SET status='closed', closed_at=NOW() WHERE id IN (%s)",
-			inClause)
-		sqlResult, err := db.ExecContext(ctx, updateQuery, args...)
+		sqlResult, err := db.ExecContext(ctx, q.updateQuery(strings.Join(placeholders, ",")), args...)
 		if err != nil {
-			return nil, fmt.Errorf("close stale wisps batch: %w", err)
+			return total, fmt.Errorf("update batch: %w", err)
 		}
-
 		affected, _ := sqlResult.RowsAffected()
-		totalReaped += int(affected)
+		total += int(affected)
 	}
+	return total, nil
+}
 
-	result.Reaped = totalReaped
-
-	if totalReaped > 0 {
-		// Flush the SQL transaction to the Dolt working set before DOLT_COMMIT.
-		// With autocommit=0, UPDATE changes are in the SQL transaction buffer,
-		// not the Dolt working set. DOLT_COMMIT operates on the working set,
-		// so without this COMMIT it sees "nothing to commit".
-		if _, err := db.ExecContext(ctx, "COMMIT"); err != nil {
-			return result, fmt.Errorf("sql commit: %w", err)
+// commitReap flushes reapStaleRows' UPDATEs and records them as a Dolt commit.
+func commitReap(ctx context.Context, db DB, commitMsg string) error {
+	// Flush the SQL transaction to the Dolt working set before DOLT_COMMIT.
+	// With autocommit=0, UPDATE changes are in the SQL transaction buffer,
+	// not the Dolt working set. DOLT_COMMIT operates on the working set,
+	// so without this COMMIT it sees "nothing to commit".
+	if _, err := db.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("sql commit: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CALL DOLT_COMMIT('-Am', '%s')", commitMsg)); err != nil { //nolint:gosec // G201: commitMsg from safe values
+		// "nothing to commit" is expected when the reaper reverts dirty working
+		// set changes back to match HEAD. The wisps were set to "open" in the
+		// server's in-memory working set without being committed; closing them
+		// makes the working set match HEAD again, so DOLT_COMMIT sees no diff.
+		if !isNothingToCommit(err) {
+			return fmt.Errorf("dolt commit: %w", err)
 		}
-		commitMsg := fmt.Sprintf("reaper: close %d stale wisps in %s", totalReaped, dbName)
-		if _, err := db.ExecContext(ctx, fmt.Sprintf("CALL DOLT_COMMIT('-Am', '%s')", commitMsg)); err != nil { //nolint:gosec // G201: commitMsg from safe values
-			// "nothing to commit" is expected when the reaper reverts dirty working
-			// set changes back to match HEAD. The wisps were set to "open" in the
-			// server's in-memory working set without being committed; closing them
-			// makes the working set match HEAD again, so DOLT_COMMIT sees no diff.
-			if !isNothingToCommit(err) {
-				return result, fmt.Errorf("dolt commit: %w", err)
-			}
+	}
+	return nil
+}
+
+// moleculeLiveChildJoin returns a LEFT JOIN clause and WHERE condition that
+// exclude molecules with a live (open, hooked or in-progress) child step,
+// whether the step is an issue or a wisp. Same anti-join shape as
+// parentExcludeJoin.
+func moleculeLiveChildJoin() (joinClause, whereCondition string) {
+	joinClause = `LEFT JOIN (
+		SELECT d.depends_on_id AS mol_id
+		FROM dependencies d INNER JOIN issues c ON c.id = d.issue_id
+		WHERE d.type = 'parent-child' AND c.status IN ('open', 'hooked', 'in_progress')
+		UNION
+		SELECT wd.depends_on_id AS mol_id
+		FROM wisp_dependencies wd INNER JOIN wisps wc ON wc.id = wd.issue_id
+		WHERE wd.type = 'parent-child' AND wc.status IN ('open', 'hooked', 'in_progress')
+	) live_child ON live_child.mol_id = m.id`
+	whereCondition = "live_child.mol_id IS NULL"
+	return
+}
+
+// moleculeQuery is the staleRowQuery for abandoned molecules: molecule
+// issues that are still open but have not been updated since the cutoff and
+// have no live child steps.
+func moleculeQuery() staleRowQuery {
+	join, where := moleculeLiveChildJoin()
+	return staleRowQuery{
+		Table:          "issues",
+		Alias:          "m",
+		StatusCol:      "status",
+		ActiveStatuses: []string{"open", "hooked", "in_progress"},
+		TimeCol:        "updated_at",
+		Join:           join,
+		Where:          "m.issue_type = 'molecule' AND " + where,
+		Set:            "status='closed', closed_at=NOW()",
+	}
+}
+
+// ReapMolecules closes abandoned molecules in a database: open molecule
+// issues with no updates for maxAge and no live child steps. OpenRemain is
+// the number of molecules still open afterwards.
+func ReapMolecules(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	return ReapMoleculesContext(ctx, db, dbName, maxAge, dryRun)
+}
+
+// ReapMoleculesContext is ReapMolecules bounded by ctx instead of its own
+// 2-minute timeout.
+func ReapMoleculesContext(ctx context.Context, db DB, dbName string, maxAge time.Duration, dryRun bool) (*ReapResult, error) {
+	result := &ReapResult{Database: dbName, DryRun: dryRun, Status: ReapStatusClosed}
+	reaped, err := reapStaleRows(ctx, db, dbName, moleculeQuery(), time.Now().UTC().Add(-maxAge), dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("close stale molecules: %w", err)
+	}
+	result.Reaped = reaped
+
+	if !dryRun && reaped > 0 {
+		if err := commitReap(ctx, db, fmt.Sprintf("reaper: close %d stale molecules in %s", reaped, dbName)); err != nil {
+			return result, err
 		}
 	}
 
-	openQuery := "SELECT COUNT(*) FROM wisps WHERE status IN ('open', 'hooked', 'in_progress')"
-	if err := db.QueryRowContext(ctx, openQuery).Scan(&result.OpenRemain); err != nil {
+	openQuery := "SELECT COUNT(*) FROM issues WHERE issue_type = 'molecule' AND status IN ('open', 'hooked', 'in_progress')"
+	if err := queryRow(ctx, db, &result.OpenRemain, openQuery); err != nil {
 		return result, fmt.Errorf("count open: %w", err)
 	}
-
 	return result, nil
 }
 
+// reapSetClause returns the SET clause that marks a wisp with status.
+func reapSetClause(status string) string {
+	if status == ReapStatusReaped {
+		return "status='reaped', closed_at=NOW(), reaped_at=NOW()"
+	}
+	return "status='closed', closed_at=NOW()"
+}
+
+// wispQuery is the staleRowQuery for stale wisps: open wisps older than the
+// cutoff whose parent molecule is closed or gone, set to status.
+func wispQuery(dbName, status string) staleRowQuery {
+	parentJoin, parentWhere := parentExcludeJoin(dbName)
+	return staleRowQuery{
+		Table:          "wisps",
+		Alias:          "w",
+		StatusCol:      "status",
+		ActiveStatuses: []string{"open", "hooked", "in_progress"},
+		TimeCol:        "created_at",
+		// Uses LEFT JOIN anti-pattern instead of correlated EXISTS to avoid O(n*m) cost (gt-jd1z).
+		Join: parentJoin,
+		// Exclude agent beads (issue_type='agent') from reaping — they have persistent
+		// identity and should not be closed by the wisp reaper regardless of age.
+		Where: "w.issue_type != 'agent' AND " + parentWhere,
+		Set:   reapSetClause(status),
+	}
+}
+
+// hasColumn reports whether table in the current database has column.
+func hasColumn(ctx context.Context, db DB, table, column string) (bool, error) {
+	var count int
+	err := queryRow(ctx, db, &count,
+		"SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?",
+		table, column)
+	if err != nil {
+		return false, fmt.Errorf("check %s.%s column: %w", table, column, err)
+	}
+	return count > 0, nil
+}
+
 // Purge deletes old closed wisps and mail from a database.
-func Purge(db *sql.DB, dbName string, purgeAge, mailDeleteAge time.Duration, dryRun bool) (*PurgeResult, error) {
+func Purge(db DB, dbName string, purgeAge, mailDeleteAge time.Duration, dryRun bool) (*PurgeResult, error) {
 	result := &PurgeResult{Database: dbName, DryRun: dryRun}
 
 	// Purge closed wisps.
@@ -454,7 +747,7 @@ func Purge(db *sql.DB, dbName string, purgeAge, mailDeleteAge time.Duration, dry
 	return result, nil
 }
 
-func purgeClosedWisps(db *sql.DB, dbName string, purgeAge time.Duration, dryRun bool) (int, []Anomaly, error) {
+func purgeClosedWisps(db DB, dbName string, purgeAge time.Duration, dryRun bool) (int, []Anomaly, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
@@ -530,7 +823,7 @@ func purgeClosedWisps(db *sql.DB, dbName string, purgeAge time.Duration, dryRun
 	return totalDeleted, anomalies, nil
 }
 
-func purgeOldMail(db *sql.DB, dbName string, mailDeleteAge time.Duration, dryRun bool) (int, error) {
+func purgeOldMail(db DB, dbName string, mailDeleteAge time.Duration, dryRun bool) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
@@ -540,7 +833,7 @@ func purgeOldMail(db *sql.DB, dbName string, mailDeleteAge time.Duration, dryRun
 		"SELECT COUNT(*) FROM `%s`.issues WHERE status = 'closed' AND closed_at < ? AND id IN (SELECT issue_id FROM `%s`.labels WHERE label = 'gt:message')",
 		dbName, dbName)
 	var count int
-	if err := db.QueryRowContext(ctx, countQuery, mailCutoff).Scan(&count); err != nil {
+	if err := queryRow(ctx, db, &count, countQuery, mailCutoff); err != nil {
 		if isTableNotFound(err) {
 			return 0, nil // issues/labels not on this server
 		}
@@ -588,7 +881,7 @@ func purgeOldMail(db *sql.DB, dbName string, mailDeleteAge time.Duration, dryRun
 // AutoClose closes issues that have been open with no updates past staleAge.
 // Excludes P0/P1 priority, epics, hooked/pinned issues, standing-order labels,
 // and issues with active dependencies.
-func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (*AutoCloseResult, error) {
+func AutoClose(db DB, dbName string, staleAge time.Duration, dryRun bool) (*AutoCloseResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
 	defer cancel()
 
@@ -716,7 +1009,7 @@ func AutoClose(db *sql.DB, dbName string, staleAge time.Duration, dryRun bool) (
 }
 
 // batchDeleteRows deletes rows from a primary table and its auxiliary tables in batches.
-func batchDeleteRows(ctx context.Context, db *sql.DB, idQuery string, cutoffArg time.Time, primaryTable string, auxTables []string) (int, error) {
+func batchDeleteRows(ctx context.Context, db DB, idQuery string, cutoffArg time.Time, primaryTable string, auxTables []string) (int, error) {
 	totalDeleted := 0
 	for {
 		idRows, err := db.QueryContext(ctx, idQuery, cutoffArg)
@@ -785,7 +1078,7 @@ type ClosePluginReceiptResult struct {
 // plugins; they should be closed shortly after creation since they exist only
 // for audit/cooldown-gate purposes. The standard AutoClose path requires 7 days
 // of staleness, which lets plugin receipts accumulate into the hundreds.
-func ClosePluginReceipts(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
+func ClosePluginReceipts(db DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
 	defer cancel()
 
@@ -869,7 +1162,7 @@ func ClosePluginReceipts(db *sql.DB, dbName string, maxAge time.Duration, dryRun
 // + "from:daemon" with a title prefix "Plugin:" and are never closed after the
 // dog completes. Without this, they accumulate at ~288/day (one per 5-minute
 // stuck-agent-dog run) and are only caught by AutoClose after 7 days.
-func ClosePluginDispatches(db *sql.DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
+func ClosePluginDispatches(db DB, dbName string, maxAge time.Duration, dryRun bool) (*ClosePluginReceiptResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultQueryTimeout)
 	defer cancel()
 
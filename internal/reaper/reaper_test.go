@@ -1,10 +1,12 @@
 package reaper
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateDBName(t *testing.T) {
@@ -41,6 +43,53 @@ func TestDefaultDatabases(t *testing.T) {
 	}
 }
 
+// stubShowDatabases makes SHOW DATABASES return names (or fail with err)
+// for the duration of the test.
+func stubShowDatabases(t *testing.T, names []string, err error) {
+	t.Helper()
+	orig := showDatabases
+	showDatabases = func(string, int) ([]string, error) { return names, err }
+	t.Cleanup(func() { showDatabases = orig })
+}
+
+func TestDiscoverDatabases_UsesServerList(t *testing.T) {
+	stubShowDatabases(t, []string{
+		"information_schema",
+		"mysql",
+		"dolt_cluster",
+		"Dolt_Procedures",
+		"hq",
+		"gastown",
+		"new_rig",
+		"testdb_123",
+		"beads_t42",
+		"bad name",
+		"db`x",
+	}, nil)
+
+	got := DiscoverDatabases("127.0.0.1", 3307)
+	want := []string{"hq", "gastown", "new_rig"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("DiscoverDatabases() = %v, want %v", got, want)
+	}
+}
+
+func TestDiscoverDatabases_FallsBackOnError(t *testing.T) {
+	stubShowDatabases(t, nil, fmt.Errorf("connection refused"))
+	got := DiscoverDatabases("127.0.0.1", 3307)
+	if strings.Join(got, ",") != strings.Join(DefaultDatabases, ",") {
+		t.Errorf("DiscoverDatabases() on error = %v, want DefaultDatabases %v", got, DefaultDatabases)
+	}
+}
+
+func TestDiscoverDatabases_FallsBackWhenOnlySystemSchemas(t *testing.T) {
+	stubShowDatabases(t, []string{"information_schema", "mysql", "dolt_cluster"}, nil)
+	got := DiscoverDatabases("127.0.0.1", 3307)
+	if strings.Join(got, ",") != strings.Join(DefaultDatabases, ",") {
+		t.Errorf("DiscoverDatabases() = %v, want DefaultDatabases %v", got, DefaultDatabases)
+	}
+}
+
 func TestFormatJSON(t *testing.T) {
 	result := FormatJSON(map[string]int{"count": 42})
 	if result == "" {
@@ -130,6 +179,116 @@ func TestReapUpdateQueryNoDatabaseNameInjection(t *testing.T) {
 	}
 }
 
+func TestReapUpdateQuery_Status(t *testing.T) {
+	closed := wispQuery("gt", ReapStatusClosed).updateQuery("?,?")
+	if closed != "UPDATE wisps SET status='closed', closed_at=NOW() WHERE id IN (?,?)" {
+		t.Errorf("closed update query = %s", closed)
+	}
+
+	reaped := wispQuery("gt", ReapStatusReaped).updateQuery("?,?")
+	if !strings.Contains(reaped, "status='reaped'") || !strings.Contains(reaped, "reaped_at=NOW()") {
+		t.Errorf("reaped update query should set status='reaped' and reaped_at: %s", reaped)
+	}
+	if !strings.Contains(reaped, "IN (?,?)") {
+		t.Errorf("reaped update query should contain the IN clause: %s", reaped)
+	}
+}
+
+func TestStaleRowQuery_Wisps(t *testing.T) {
+	cutoff := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	q := wispQuery("gt", ReapStatusClosed)
+
+	idQuery, args := q.idQuery(cutoff)
+	for _, want := range []string{
+		"SELECT w.id FROM wisps w LEFT JOIN",
+		"w.status IN (?,?,?) AND w.created_at < ?",
+		"w.issue_type != 'agent'",
+		"open_parent.issue_id IS NULL",
+		fmt.Sprintf("LIMIT %d", DefaultBatchSize),
+	} {
+		if !strings.Contains(idQuery, want) {
+			t.Errorf("wisp idQuery missing %q: %s", want, idQuery)
+		}
+	}
+	wantArgs := []interface{}{"open", "hooked", "in_progress", cutoff}
+	if fmt.Sprint(args) != fmt.Sprint(wantArgs) {
+		t.Errorf("wisp idQuery args = %v, want %v", args, wantArgs)
+	}
+
+	countQuery, _ := q.countQuery(cutoff)
+	if !strings.HasPrefix(countQuery, "SELECT COUNT(*) FROM wisps w LEFT JOIN") {
+		t.Errorf("wisp countQuery = %s", countQuery)
+	}
+}
+
+func TestStaleRowQuery_Molecules(t *testing.T) {
+	cutoff := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	q := moleculeQuery()
+
+	idQuery, args := q.idQuery(cutoff)
+	for _, want := range []string{
+		"SELECT m.id FROM issues m LEFT JOIN",
+		"m.status IN (?,?,?) AND m.updated_at < ?",
+		"m.issue_type = 'molecule'",
+		"wisp_dependencies",
+		"live_child.mol_id IS NULL",
+	} {
+		if !strings.Contains(idQuery, want) {
+			t.Errorf("molecule idQuery missing %q: %s", want, idQuery)
+		}
+	}
+	if len(args) != 4 || args[3] != cutoff {
+		t.Errorf("molecule idQuery args = %v, want 3 statuses then the cutoff", args)
+	}
+	if got := q.updateQuery("?,?"); got != "UPDATE issues SET status='closed', closed_at=NOW() WHERE id IN (?,?)" {
+		t.Errorf("molecule updateQuery = %s", got)
+	}
+}
+
+func TestStaleRowQuery_ConfigurableStatuses(t *testing.T) {
+	q := staleRowQuery{Table: "t", Alias: "x", StatusCol: "state", ActiveStatuses: []string{"pending"}, TimeCol: "ts", Set: "state='done'"}
+	where, args := q.where(time.Time{})
+	if where != "x.state IN (?) AND x.ts < ?" {
+		t.Errorf("where = %q", where)
+	}
+	if len(args) != 2 || args[0] != "pending" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestReapStaleRows_RejectsUnsafeNames(t *testing.T) {
+	// Validation happens before any query, so a nil *sql.DB is never touched.
+	ctx := context.Background()
+	if _, err := reapStaleRows(ctx, nil, "bad;db", moleculeQuery(), time.Now(), true); err == nil {
+		t.Error("reapStaleRows accepted an invalid database name")
+	}
+
+	q := moleculeQuery()
+	q.Table = "issues; DROP TABLE issues"
+	if _, err := reapStaleRows(ctx, nil, "gt", q, time.Now(), true); err == nil {
+		t.Error("reapStaleRows accepted an invalid table name")
+	}
+
+	q = wispQuery("gt", ReapStatusClosed)
+	q.ActiveStatuses = nil
+	if _, err := reapStaleRows(ctx, nil, "gt", q, time.Now(), true); err == nil {
+		t.Error("reapStaleRows accepted an empty status filter")
+	}
+}
+
+func TestValidateReapStatus(t *testing.T) {
+	for _, s := range []string{"", ReapStatusClosed, ReapStatusReaped} {
+		if err := ValidateReapStatus(s); err != nil {
+			t.Errorf("ValidateReapStatus(%q) = %v, want nil", s, err)
+		}
+	}
+	for _, s := range []string{"deleted", "open", "reaped'; DROP TABLE wisps; --"} {
+		if err := ValidateReapStatus(s); err == nil {
+			t.Errorf("ValidateReapStatus(%q) should fail", s)
+		}
+	}
+}
+
 // TestPurgeDigestQueryNoDatabaseNameInjection verifies that the purge digest
 // query is a plain string with no Sprintf interpolation at all.
 func TestPurgeDigestQueryNoDatabaseNameInjection(t *testing.T) {
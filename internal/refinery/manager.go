@@ -240,9 +240,11 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	// Must be before WaitForRuntimeReady to avoid race where dialog blocks prompt detection.
 	_ = t.AcceptStartupDialogs(sessionID)
 
+	startTimeout := config.LoadOperationalConfigLayered(townRoot).SessionConfigForRole("refinery").ClaudeStartTimeoutD()
+
 	// Wait for Claude to start and show its prompt - fatal if Claude fails to launch
 	// WaitForRuntimeReady waits for the runtime to be ready
-	if err := t.WaitForRuntimeReady(sessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
+	if err := t.WaitForRuntimeReady(sessionID, runtimeConfig, startTimeout); err != nil {
 		// Kill the zombie session before returning error
 		_ = t.KillSessionWithProcesses(sessionID)
 		return fmt.Errorf("waiting for refinery to start: %w", err)
@@ -256,7 +258,7 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	}
 
 	_ = runtime.RunStartupFallback(t, sessionID, "refinery", runtimeConfig)
-	_ = runtime.DeliverStartupPromptFallback(t, sessionID, initialPrompt, runtimeConfig, constants.ClaudeStartTimeout)
+	_ = runtime.DeliverStartupPromptFallback(t, sessionID, initialPrompt, runtimeConfig, startTimeout)
 
 	// Track PID for defense-in-depth orphan cleanup (non-fatal)
 	if err := session.TrackSessionPID(townRoot, sessionID, t); err != nil {
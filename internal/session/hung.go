@@ -0,0 +1,137 @@
+package session
+
+import (
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// hungPaneLines is how many trailing pane lines are hashed to detect output
+// progress.
+const hungPaneLines = 20
+
+// hungSessionTmux is the subset of tmux.Tmux used by HungSessionDetector.
+// It is extracted to allow tests to avoid real tmux calls.
+type hungSessionTmux interface {
+	ListSessions() ([]string, error)
+	GetSessionActivity(session string) (time.Time, error)
+	CapturePane(session string, lines int) (string, error)
+}
+
+// HungSession is a Gas Town session that has been idle, with unchanged pane
+// output, for at least the hung-session threshold.
+type HungSession struct {
+	Session string
+	// Inactive is how long ago tmux last saw activity in the session.
+	Inactive time.Duration
+	// Frozen is how long the pane's output has been unchanged, measured from
+	// the first observation with the current output.
+	Frozen    time.Duration
+	Threshold time.Duration
+}
+
+// paneObservation is the last pane output seen for a session.
+type paneObservation struct {
+	hash      uint64
+	changedAt time.Time
+	reported  bool
+}
+
+// HungSessionDetector finds hung sessions by combining the hung-session
+// threshold (operational.session.hung_session_threshold) with pane output
+// progress: a session is hung only if tmux has seen no activity for the
+// threshold AND the last lines of its pane have not changed across calls
+// spanning the threshold. A session is never hung on first observation.
+// Not safe for concurrent use.
+type HungSessionDetector struct {
+	Threshold time.Duration
+
+	tmux  hungSessionTmux
+	panes map[string]*paneObservation
+}
+
+// NewHungSessionDetector returns a detector for sessions on t's socket.
+func NewHungSessionDetector(t *tmux.Tmux, threshold time.Duration) *HungSessionDetector {
+	return newHungSessionDetector(t, threshold)
+}
+
+func newHungSessionDetector(t hungSessionTmux, threshold time.Duration) *HungSessionDetector {
+	return &HungSessionDetector{
+		Threshold: threshold,
+		tmux:      t,
+		panes:     make(map[string]*paneObservation),
+	}
+}
+
+// DetectHungSessions samples every known Gas Town session at now and returns
+// those that are hung. A session_hung event is emitted the first time a
+// session is found hung; it is not repeated until the pane shows progress.
+// Sessions whose pane or activity cannot be read are skipped.
+func (d *HungSessionDetector) DetectHungSessions(now time.Time) ([]HungSession, error) {
+	sessions, err := d.tmux.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	var hung []HungSession
+	live := make(map[string]bool, len(sessions))
+	for _, sess := range sessions {
+		if !IsKnownSession(sess) {
+			continue
+		}
+		live[sess] = true
+
+		pane, err := d.tmux.CapturePane(sess, hungPaneLines)
+		if err != nil {
+			continue
+		}
+		hash := hashPane(pane)
+		obs, ok := d.panes[sess]
+		if !ok || obs.hash != hash {
+			d.panes[sess] = &paneObservation{hash: hash, changedAt: now}
+			continue
+		}
+
+		frozen := now.Sub(obs.changedAt)
+		if frozen < d.Threshold {
+			continue
+		}
+		activity, err := d.tmux.GetSessionActivity(sess)
+		if err != nil || activity.IsZero() {
+			continue
+		}
+		inactive := now.Sub(activity)
+		if inactive < d.Threshold {
+			continue
+		}
+
+		h := HungSession{Session: sess, Inactive: inactive, Frozen: frozen, Threshold: d.Threshold}
+		hung = append(hung, h)
+		if !obs.reported {
+			obs.reported = true
+			_ = events.LogFeed(events.TypeSessionHung, "daemon",
+				events.SessionHungPayload(sess, inactive, frozen, d.Threshold))
+		}
+	}
+
+	for sess := range d.panes {
+		if !live[sess] {
+			delete(d.panes, sess)
+		}
+	}
+	return hung, nil
+}
+
+// hashPane hashes pane output, ignoring trailing whitespace so a cursor
+// redraw of blank lines does not count as progress.
+func hashPane(pane string) uint64 {
+	h := fnv.New64a()
+	for _, line := range strings.Split(strings.TrimRight(pane, " \t\n"), "\n") {
+		_, _ = h.Write([]byte(strings.TrimRight(line, " \t")))
+		_, _ = h.Write([]byte{'\n'})
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,138 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// fakeHungTmux serves pane output and activity per session. A progressing
+// pane gets a new line on every capture; a frozen one never changes.
+type fakeHungTmux struct {
+	sessions    []string
+	progressing map[string]bool
+	activity    map[string]time.Time
+	captureErr  map[string]bool
+	captures    map[string]int
+}
+
+func (f *fakeHungTmux) ListSessions() ([]string, error) { return f.sessions, nil }
+
+func (f *fakeHungTmux) GetSessionActivity(session string) (time.Time, error) {
+	return f.activity[session], nil
+}
+
+func (f *fakeHungTmux) CapturePane(session string, lines int) (string, error) {
+	if f.captureErr[session] {
+		return "", errors.New("capture failed")
+	}
+	f.captures[session]++
+	if f.progressing[session] {
+		return strings.Repeat("working...\n", f.captures[session]), nil
+	}
+	return "waiting for input\n> \n\n", nil
+}
+
+func TestDetectHungSessions_ProgressingVsFrozen(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	threshold := 30 * time.Minute
+	idleSince := start.Add(-time.Hour)
+	fake := &fakeHungTmux{
+		sessions:    []string{"hq-frozen", "hq-busy", "hq-broken", "dotfiles"},
+		progressing: map[string]bool{"hq-busy": true},
+		activity:    map[string]time.Time{"hq-frozen": idleSince, "hq-busy": idleSince, "hq-broken": idleSince, "dotfiles": idleSince},
+		captureErr:  map[string]bool{"hq-broken": true},
+		captures:    map[string]int{},
+	}
+	d := newHungSessionDetector(fake, threshold)
+
+	// First observation and anything inside the window: never hung.
+	for _, at := range []time.Duration{0, 10 * time.Minute, 29 * time.Minute} {
+		hung, err := d.DetectHungSessions(start.Add(at))
+		if err != nil {
+			t.Fatalf("DetectHungSessions: %v", err)
+		}
+		if len(hung) != 0 {
+			t.Fatalf("at +%v: hung = %+v, want none inside the window", at, hung)
+		}
+	}
+
+	// Past the window only the frozen pane is hung; the busy session is
+	// just as idle by tmux activity but its output keeps changing.
+	for range 2 {
+		hung, err := d.DetectHungSessions(start.Add(threshold))
+		if err != nil {
+			t.Fatalf("DetectHungSessions: %v", err)
+		}
+		if len(hung) != 1 || hung[0].Session != "hq-frozen" {
+			t.Fatalf("hung = %+v, want only hq-frozen", hung)
+		}
+		if hung[0].Frozen != threshold || hung[0].Inactive != 90*time.Minute {
+			t.Errorf("hung[0] = %+v, want frozen %v, inactive 1h30m", hung[0], threshold)
+		}
+	}
+	if fake.captures["dotfiles"] != 0 {
+		t.Error("non-Gas Town session was captured")
+	}
+
+	// One event per detection, not per call.
+	data, err := os.ReadFile(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		t.Fatalf("reading events: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d events, want 1:\n%s", len(lines), data)
+	}
+	var ev events.Event
+	if err := json.Unmarshal([]byte(lines[0]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Type != events.TypeSessionHung || ev.Payload["session"] != "hq-frozen" || ev.Payload["frozen"] != "30m0s" {
+		t.Errorf("event = %+v", ev)
+	}
+}
+
+func TestDetectHungSessions_RecentActivityIsNotHung(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := &fakeHungTmux{
+		sessions: []string{"hq-quiet"},
+		activity: map[string]time.Time{"hq-quiet": start.Add(40 * time.Minute)},
+		captures: map[string]int{},
+	}
+	d := newHungSessionDetector(fake, 30*time.Minute)
+
+	// The pane output never changes, but tmux saw activity (e.g. input)
+	// within the threshold.
+	for _, at := range []time.Duration{0, 45 * time.Minute} {
+		if hung, _ := d.DetectHungSessions(start.Add(at)); len(hung) != 0 {
+			t.Fatalf("at +%v: hung = %+v, want none", at, hung)
+		}
+	}
+}
+
+func TestHashPane_IgnoresTrailingWhitespace(t *testing.T) {
+	if hashPane("a\nb\n") != hashPane("a  \nb\n\n\n") {
+		t.Error("trailing whitespace changed the hash")
+	}
+	if hashPane("a\nb") == hashPane("a\nc") {
+		t.Error("different output hashed equal")
+	}
+}
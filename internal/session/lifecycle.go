@@ -231,9 +231,12 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 		_ = t.ConfigureGasTownSession(cfg.SessionID, cfg.Theme, cfg.RigName, cfg.AgentName, cfg.Role)
 	}
 
+	opCfg := config.LoadOperationalConfigLayered(cfg.TownRoot)
+	sessionCfg := opCfg.SessionConfigForRole(cfg.Role)
+
 	// 8. Wait for agent to start.
 	if cfg.WaitForAgent {
-		if err := t.WaitForCommand(cfg.SessionID, constants.SupportedShells, constants.ClaudeStartTimeout); err != nil {
+		if err := t.WaitForCommand(cfg.SessionID, constants.SupportedShells, sessionCfg.ClaudeStartTimeoutD()); err != nil {
 			if cfg.WaitFatal {
 				_ = t.KillSessionWithProcesses(cfg.SessionID)
 				return nil, fmt.Errorf("waiting for %s to start: %w", cfg.Role, err)
@@ -243,7 +246,8 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 
 	// 9. Auto-respawn hook.
 	if cfg.AutoRespawn {
-		if err := t.SetAutoRespawnHook(cfg.SessionID); err != nil {
+		policy := tmux.RespawnPolicyForRole(opCfg, cfg.Role)
+		if err := t.SetAutoRespawnHook(cfg.SessionID, policy); err != nil {
 			fmt.Printf("warning: failed to set auto-respawn hook for %s: %v\n", cfg.Role, err)
 		}
 	}
@@ -261,7 +265,7 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 	// Uses prompt-based polling for agents with ReadyPromptPrefix,
 	// falling back to ReadyDelayMs sleep for agents without prompt detection.
 	if cfg.ReadyDelay {
-		if err := t.WaitForRuntimeReady(cfg.SessionID, runtimeConfig, constants.ClaudeStartTimeout); err != nil {
+		if err := t.WaitForRuntimeReady(cfg.SessionID, runtimeConfig, sessionCfg.ClaudeStartTimeoutD()); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s: %v\n", cfg.SessionID, err)
 		}
 	}
@@ -327,7 +331,7 @@ func RecordAgentInstantiateFromDir(ctx context.Context, runID, resolvedAgent, ro
 	}
 	branch, commit := "", ""
 	if g := git.NewGit(workDir); g != nil {
-		if b, err := g.CurrentBranch(); err == nil {
+		if b, _, err := g.CurrentBranch(); err == nil {
 			branch = b
 		}
 		if c, err := g.Rev("HEAD"); err == nil {
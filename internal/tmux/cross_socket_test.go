@@ -1,6 +1,7 @@
 package tmux
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -178,3 +179,188 @@ func TestSessionsOnMultipleSockets(t *testing.T) {
 		t.Errorf("cross socket has %d of %d expected sessions", crossCount, len(crossSessions))
 	}
 }
+
+func TestServerAlive_Transitions(t *testing.T) {
+	tm := newCrossTestSocket(t)
+	_ = tm.KillServer()
+
+	if tm.ServerAlive() {
+		t.Fatal("ServerAlive() = true before any server was started")
+	}
+
+	session := fmt.Sprintf("gt-test-alive-%d", os.Getpid())
+	if err := tm.NewSessionWithCommand(session, ".", "sleep 300"); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	if !tm.ServerAlive() {
+		t.Fatal("ServerAlive() = false with a session running")
+	}
+
+	// A live server with no sessions is still alive, even though
+	// has-session reports it the same way as no server at all.
+	if err := tm.SetExitEmpty(false); err != nil {
+		t.Fatalf("SetExitEmpty: %v", err)
+	}
+	if err := tm.KillSession(session); err != nil {
+		t.Fatalf("KillSession: %v", err)
+	}
+	if !tm.ServerAlive() {
+		t.Error("ServerAlive() = false for a live server with no sessions")
+	}
+
+	if err := tm.KillServer(); err != nil {
+		t.Fatalf("KillServer: %v", err)
+	}
+	if tm.ServerAlive() {
+		t.Error("ServerAlive() = true after KillServer")
+	}
+}
+
+func TestServerAlive_InvalidSocket(t *testing.T) {
+	if NewTmuxWithSocket("gt; rm -rf ~").ServerAlive() {
+		t.Error("ServerAlive() = true for an invalid socket name")
+	}
+}
+
+func TestEnsureServer(t *testing.T) {
+	tm := newCrossTestSocket(t)
+	_ = tm.KillServer()
+
+	if err := tm.EnsureServer(); err != nil {
+		t.Fatalf("EnsureServer: %v", err)
+	}
+	if !tm.ServerAlive() {
+		t.Fatal("server not alive after EnsureServer")
+	}
+	for _, opt := range []struct{ flags, name, want string }{
+		{"-gv", "exit-empty", "off"},
+		{"-gwv", "window-size", "latest"},
+		// remain-on-exit is per session, not town-wide.
+		{"-gwv", "remain-on-exit", "off"},
+	} {
+		got, err := tm.run("show-options", opt.flags, opt.name)
+		if err != nil {
+			t.Fatalf("show-options %s: %v", opt.name, err)
+		}
+		if got != opt.want {
+			t.Errorf("%s = %q, want %q", opt.name, got, opt.want)
+		}
+	}
+
+	// Sessions created afterwards are accepted and inherit the options,
+	// but keep remain-on-exit off unless they turn it on themselves.
+	session := fmt.Sprintf("gt-test-ensure-%d", os.Getpid())
+	if err := tm.NewSession(session, "."); err != nil {
+		t.Fatalf("NewSession on ensured server: %v", err)
+	}
+	for _, opt := range []struct{ name, want string }{
+		{"window-size", "latest"},
+		{"remain-on-exit", "off"},
+	} {
+		got, err := tm.run("show-options", "-wAv", "-t", session, opt.name)
+		if err != nil {
+			t.Fatalf("show-options %s on session: %v", opt.name, err)
+		}
+		if got != opt.want {
+			t.Errorf("session %s = %q, want %q", opt.name, got, opt.want)
+		}
+	}
+
+	// Idempotent on a running server.
+	if err := tm.EnsureServer(); err != nil {
+		t.Errorf("second EnsureServer: %v", err)
+	}
+}
+
+func TestEnsureServer_RefusesDefaultSocket(t *testing.T) {
+	for _, socket := range []string{"", "default", noTownSocket} {
+		if err := NewTmuxWithSocket(socket).EnsureServer(); !errors.Is(err, ErrNoTownSocket) {
+			t.Errorf("EnsureServer(%q) = %v, want ErrNoTownSocket", socket, err)
+		}
+	}
+}
+
+func TestRenameSession(t *testing.T) {
+	tm := newCrossTestSocket(t)
+
+	tmp := fmt.Sprintf("gt-test-tmp-%d", os.Getpid())
+	permanent := fmt.Sprintf("gt-test-perm-%d", os.Getpid())
+	other := fmt.Sprintf("gt-test-other-%d", os.Getpid())
+	for _, name := range []string{tmp, other} {
+		if err := tm.NewSessionWithCommand(name, ".", "sleep 300"); err != nil {
+			t.Fatalf("NewSessionWithCommand(%s): %v", name, err)
+		}
+	}
+	pid, err := tm.GetPanePID(tmp)
+	if err != nil {
+		t.Fatalf("GetPanePID: %v", err)
+	}
+
+	if err := tm.RenameSession(tmp, permanent); err != nil {
+		t.Fatalf("RenameSession: %v", err)
+	}
+	if has, _ := tm.HasSession(tmp); has {
+		t.Error("old name still resolves after rename")
+	}
+	if has, _ := tm.HasSession(permanent); !has {
+		t.Fatal("new name does not resolve after rename")
+	}
+	if got, _ := tm.GetPanePID(permanent); got != pid {
+		t.Errorf("pane pid after rename = %s, want %s (session restarted?)", got, pid)
+	}
+
+	// Renaming onto a live session must not clobber it.
+	if err := tm.RenameSession(permanent, other); !errors.Is(err, ErrSessionExists) {
+		t.Errorf("rename onto existing session: err = %v, want ErrSessionExists", err)
+	}
+	if has, _ := tm.HasSession(permanent); !has {
+		t.Error("source session lost after refused rename")
+	}
+
+	if err := tm.RenameSession(permanent, "bad.name"); !errors.Is(err, ErrInvalidSessionName) {
+		t.Errorf("rename to invalid name: err = %v, want ErrInvalidSessionName", err)
+	}
+	if err := tm.RenameSession(tmp, "gt-test-nowhere"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("rename of missing session: err = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestGetPaneCommandAndTitle_ActivePane(t *testing.T) {
+	tm := newCrossTestSocket(t)
+	session := fmt.Sprintf("gt-test-panecmd-%d", os.Getpid())
+	if err := tm.NewSessionWithCommand(session, ".", "sleep 300"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	if _, err := tm.run("select-pane", "-t", session+":^", "-T", "gt-agent"); err != nil {
+		t.Fatalf("select-pane -T: %v", err)
+	}
+
+	if cmd, err := tm.GetPaneCommand(session); err != nil || cmd != "sleep" {
+		t.Errorf("GetPaneCommand = %q, %v; want sleep", cmd, err)
+	}
+	if title, err := tm.GetPaneTitle(session); err != nil || title != "gt-agent" {
+		t.Errorf("GetPaneTitle = %q, %v; want gt-agent", title, err)
+	}
+
+	// Split the first window and make the new pane active: both report it.
+	if _, err := tm.run("split-window", "-t", session+":^", "cat"); err != nil {
+		t.Fatalf("split-window: %v", err)
+	}
+	if _, err := tm.run("select-pane", "-t", session+":^", "-T", "gt-helper"); err != nil {
+		t.Fatalf("select-pane -T: %v", err)
+	}
+	// A focused second window does not change what is reported.
+	if _, err := tm.run("new-window", "-t", session, "sleep 301"); err != nil {
+		t.Fatalf("new-window: %v", err)
+	}
+	if cmd, err := tm.GetPaneCommand(session); err != nil || cmd != "cat" {
+		t.Errorf("GetPaneCommand after split = %q, %v; want the active pane's cat", cmd, err)
+	}
+	if title, err := tm.GetPaneTitle(session); err != nil || title != "gt-helper" {
+		t.Errorf("GetPaneTitle after split = %q, %v; want gt-helper", title, err)
+	}
+
+	if _, err := tm.GetPaneTitle("gt-test-no-such-session"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("GetPaneTitle on a missing session: err = %v, want ErrSessionNotFound", err)
+	}
+}
@@ -3,7 +3,10 @@
 package tmux
 
 import (
+	"errors"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -55,3 +58,37 @@ func getProcessGroupMembers(pgid string) []string {
 	}
 	return members
 }
+
+// processExists reports whether a process with the given PID exists.
+// EPERM means the process exists but belongs to another user. A defunct
+// (zombie) process still answers signal 0, so on Linux its /proc state is
+// checked as well and a zombie counts as gone.
+func processExists(pid int) (bool, error) {
+	if pid <= 0 {
+		return false, nil
+	}
+	err := syscall.Kill(pid, 0)
+	if err == nil || errors.Is(err, syscall.EPERM) {
+		return !isZombieProcess(pid), nil
+	}
+	if errors.Is(err, syscall.ESRCH) {
+		return false, nil
+	}
+	return false, err
+}
+
+// isZombieProcess reports whether /proc says pid is defunct. Returns false
+// where /proc is unavailable (macOS).
+func isZombieProcess(pid int) bool {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return false
+	}
+	// Format: "pid (comm) state ..."; comm may contain spaces or parens.
+	stat := string(data)
+	idx := strings.LastIndexByte(stat, ')')
+	if idx < 0 || idx+2 >= len(stat) {
+		return false
+	}
+	return stat[idx+2] == 'Z'
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 // requireTestSocket returns a per-test socket name and skips the test if
@@ -74,10 +78,11 @@ func TestAutoRespawnHookCmd_Format(t *testing.T) {
 		{"error_suppression", "tmux -L gt", "hq-deacon", "|| true"},
 		{"socket_in_respawn", "tmux -L gt", "hq-deacon", "-L gt"},
 		{"bare_tmux_no_socket", "tmux", "hq-deacon", "tmux respawn-pane"},
+		{"respawn_event", "tmux -L gt", "hq-deacon", "gt log respawn --session 'hq-deacon'"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := buildAutoRespawnHookCmd(tt.tmuxCmd, tt.session)
+			cmd := buildAutoRespawnHookCmd(tt.tmuxCmd, tt.session, RespawnPolicy{})
 			if !strings.Contains(cmd, tt.wantFlag) {
 				t.Errorf("hook command missing %q:\n  %s", tt.wantFlag, cmd)
 			}
@@ -85,6 +90,282 @@ func TestAutoRespawnHookCmd_Format(t *testing.T) {
 	}
 }
 
+// TestAutoRespawnHookCmd_UncappedUnchanged pins the uncapped hook command so
+// the crash-loop cap never changes behavior for callers that don't opt in.
+func TestAutoRespawnHookCmd_UncappedUnchanged(t *testing.T) {
+	t.Parallel()
+	want := `run-shell -b "sleep 3 && tmux -L gt list-panes -t 'hq-deacon' -F '##{pane_dead}' 2>/dev/null | grep -q 1 && ` +
+		`tmux -L gt respawn-pane -k -t 'hq-deacon' && tmux -L gt set-option -t 'hq-deacon' remain-on-exit on && ` +
+		`(gt log respawn --session 'hq-deacon' >/dev/null 2>&1 || true) || true"`
+	for _, policy := range []RespawnPolicy{{}, {MaxRespawns: 3}, {Window: time.Minute}} {
+		if got := buildAutoRespawnHookCmd("tmux -L gt", "hq-deacon", policy); got != want {
+			t.Errorf("policy %+v:\n got  %s\n want %s", policy, got, want)
+		}
+	}
+}
+
+// TestAutoRespawnHookCmd_Delay verifies the configured delay is interpolated
+// into the hook's sleep while the dead-pane guard stays in place.
+func TestAutoRespawnHookCmd_Delay(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		delay time.Duration
+		want  string
+	}{
+		{0, "sleep 3 && "},
+		{10 * time.Second, "sleep 10 && "},
+		{1500 * time.Millisecond, "sleep 1.5 && "},
+	}
+	for _, tt := range tests {
+		cmd := buildAutoRespawnHookCmd("tmux -L gt", "hq-deacon", RespawnPolicy{Delay: tt.delay})
+		if !strings.Contains(cmd, tt.want) {
+			t.Errorf("delay %v: hook command missing %q:\n  %s", tt.delay, tt.want, cmd)
+		}
+		if !strings.Contains(cmd, "'##{pane_dead}' 2>/dev/null | grep -q 1 && ") {
+			t.Errorf("delay %v: dead-pane guard missing:\n  %s", tt.delay, cmd)
+		}
+	}
+}
+
+func TestRespawnPolicyFromConfig(t *testing.T) {
+	t.Parallel()
+	if got := RespawnPolicyFromConfig(nil); got.Delay != config.DefaultRespawnHookDelay ||
+		got.MaxRespawns != config.DefaultRespawnMaxAttempts || got.Window != config.DefaultRespawnWindow {
+		t.Errorf("RespawnPolicyFromConfig(nil) = %+v, want defaults", got)
+	}
+	if got := RespawnPolicyFromConfig(nil); got.BaseBackoff != config.DefaultRespawnBaseBackoff ||
+		got.BackoffMax != config.DefaultRespawnBackoffMax || got.StablePeriod != config.DefaultRespawnStablePeriod {
+		t.Errorf("RespawnPolicyFromConfig(nil) = %+v, want default backoff", got)
+	}
+	op := &config.OperationalConfig{Session: &config.SessionThresholds{RespawnHookDelay: "8s"}}
+	if got := RespawnPolicyFromConfig(op).Delay; got != 8*time.Second {
+		t.Errorf("Delay = %v, want 8s", got)
+	}
+}
+
+func TestRespawnPolicyForRole(t *testing.T) {
+	t.Parallel()
+	op := &config.OperationalConfig{Session: &config.SessionThresholds{
+		RespawnBaseBackoff: "5s",
+		PerRole: map[string]*config.SessionThresholds{
+			"deacon": {RespawnHookDelay: "1s", RespawnBaseBackoff: "10s"},
+		},
+	}}
+	if got := RespawnPolicyForRole(op, "deacon"); got.Delay != time.Second || got.BaseBackoff != 10*time.Second {
+		t.Errorf("deacon policy = %+v, want per-role delay 1s and base backoff 10s", got)
+	}
+	if got := RespawnPolicyForRole(op, "witness"); got.BaseBackoff != 5*time.Second {
+		t.Errorf("witness BaseBackoff = %v, want 5s", got.BaseBackoff)
+	}
+}
+
+// backoffHarness runs the auto-respawn backoff step against fake tmux, date
+// and sleep commands: session options are files, the clock is set by the
+// test, and sleeps are recorded instead of taken.
+type backoffHarness struct {
+	t      *testing.T
+	dir    string
+	script string
+	now    int64
+}
+
+func newBackoffHarness(t *testing.T, policy RespawnPolicy) *backoffHarness {
+	t.Helper()
+	dir := t.TempDir()
+	fakes := map[string]string{
+		"tmux": `dir=$(dirname "$0")
+case "$1" in
+show-options) for a; do opt=$a; done; cat "$dir/opt$opt" 2>/dev/null || true ;;
+set-option) for a; do opt=$prev; prev=$a; done; echo "$prev" > "$dir/opt$opt" ;;
+list-panes) echo 1 ;;
+esac
+`,
+		"date":  `cat "$(dirname "$0")/now"` + "\n",
+		"sleep": `echo "$1" >> "$(dirname "$0")/sleeps"` + "\n",
+	}
+	for name, body := range fakes {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &backoffHarness{
+		t:      t,
+		dir:    dir,
+		script: "true" + buildRespawnBackoffScript("tmux", "'hq-deacon'", "-t 'hq-deacon'", policy),
+		now:    1_700_000_000,
+	}
+}
+
+// respawn runs the backoff step once and returns the backoff it slept.
+func (h *backoffHarness) respawn() time.Duration {
+	h.t.Helper()
+	if err := os.WriteFile(filepath.Join(h.dir, "now"), []byte(strconv.FormatInt(h.now, 10)), 0644); err != nil {
+		h.t.Fatal(err)
+	}
+	_ = os.Remove(filepath.Join(h.dir, "sleeps"))
+	cmd := exec.Command("sh", "-c", h.script)
+	cmd.Env = append(os.Environ(), "PATH="+h.dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		h.t.Fatalf("backoff script failed: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(filepath.Join(h.dir, "sleeps"))
+	if err != nil {
+		h.t.Fatalf("backoff script did not sleep: %v", err)
+	}
+	secs, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		h.t.Fatalf("sleep arg %q: %v", data, err)
+	}
+	return time.Duration(secs) * time.Second
+}
+
+func (h *backoffHarness) advance(d time.Duration) { h.now += int64(d / time.Second) }
+
+func TestAutoRespawnBackoff_Progression(t *testing.T) {
+	t.Parallel()
+	h := newBackoffHarness(t, RespawnPolicy{BaseBackoff: 2 * time.Second, BackoffMax: 16 * time.Second, StablePeriod: 5 * time.Minute})
+
+	want := []time.Duration{0, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 16 * time.Second}
+	for i, w := range want {
+		if got := h.respawn(); got != w {
+			t.Errorf("respawn %d: backoff = %v, want %v", i+1, got, w)
+		}
+		// The pane crashes again shortly after each respawn.
+		h.advance(w + time.Second)
+	}
+}
+
+func TestAutoRespawnBackoff_ResetsAfterStablePeriod(t *testing.T) {
+	t.Parallel()
+	h := newBackoffHarness(t, RespawnPolicy{BaseBackoff: 2 * time.Second, BackoffMax: time.Minute, StablePeriod: 5 * time.Minute})
+
+	h.respawn() // 0
+	h.advance(time.Second)
+	h.respawn() // 2s
+	h.advance(3 * time.Second)
+	if got := h.respawn(); got != 4*time.Second {
+		t.Fatalf("third backoff = %v, want 4s", got)
+	}
+
+	// Alive for just under the stable period after the 4s-delayed respawn:
+	// still backing off.
+	h.advance(4*time.Second + 5*time.Minute - time.Second)
+	if got := h.respawn(); got != 8*time.Second {
+		t.Fatalf("backoff before stable period = %v, want 8s", got)
+	}
+
+	// Alive for the full stable period: the next crash starts over.
+	h.advance(8*time.Second + 5*time.Minute)
+	if got := h.respawn(); got != 0 {
+		t.Errorf("backoff after stable period = %v, want 0", got)
+	}
+	h.advance(time.Second)
+	if got := h.respawn(); got != 2*time.Second {
+		t.Errorf("backoff after reset = %v, want 2s", got)
+	}
+}
+
+// TestAutoRespawnHookCmd_Backoff verifies the backoff step is only added
+// when the policy asks for it, rounds to whole seconds, and is escaped for
+// run-shell.
+func TestAutoRespawnHookCmd_Backoff(t *testing.T) {
+	t.Parallel()
+	cmd := buildAutoRespawnHookCmd("tmux -L gt", "hq-deacon", RespawnPolicy{BaseBackoff: 1500 * time.Millisecond, BackoffMax: time.Minute, StablePeriod: 5 * time.Minute})
+	for _, want := range []string{
+		respawnAttemptsOption,
+		respawnLastOption,
+		`backoff=2; `,
+		`-ge 300 ]`,
+		`sleep \$backoff && tmux -L gt list-panes -t 'hq-deacon' -F '##{pane_dead}'`,
+	} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("backoff hook command missing %q:\n  %s", want, cmd)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(cmd, `\$`, ""), "$") {
+		t.Errorf("backoff hook command has unescaped $:\n  %s", cmd)
+	}
+	if plain := buildAutoRespawnHookCmd("tmux -L gt", "hq-deacon", RespawnPolicy{}); strings.Contains(plain, respawnAttemptsOption) {
+		t.Errorf("hook without backoff policy backs off:\n  %s", plain)
+	}
+}
+
+// TestAutoRespawnHookCmd_Capped verifies the capped hook counts respawns and
+// escapes shell syntax so tmux passes it through to run-shell untouched.
+func TestAutoRespawnHookCmd_Capped(t *testing.T) {
+	t.Parallel()
+	cmd := buildAutoRespawnHookCmd("tmux -L gt", "hq-deacon", RespawnPolicy{MaxRespawns: 4, Window: 2 * time.Minute})
+	for _, want := range []string{
+		respawnCountOption,
+		`[ \$count -le 4 ]`,
+		`-ge 120 ]`,
+		`\$(date +%s)`,
+		`'##{pane_dead}'`,
+		"|| true",
+	} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("capped hook command missing %q:\n  %s", want, cmd)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(cmd, `\$`, ""), "$") {
+		t.Errorf("capped hook command has unescaped $:\n  %s", cmd)
+	}
+}
+
+// TestAutoRespawnScript_GivesUpAfterCap runs the hook's shell pipeline directly
+// against a real pane whose command exits immediately, and verifies respawns
+// stop once the cap is exceeded within the window. Running the pipeline
+// directly (rather than waiting for pane-died) keeps the test deterministic;
+// TestAutoRespawnHook_RespawnWorks covers the hook wiring itself.
+func TestAutoRespawnScript_GivesUpAfterCap(t *testing.T) {
+	socket := requireTestSocket(t)
+	session := "test-respawn-cap"
+
+	testSession(t, socket, session, "sleep 300")
+	defer func() { _ = exec.Command("tmux", "-L", socket, "kill-session", "-t", session).Run() }()
+	if err := NewTmuxWithSocket(socket).SetOption(session, "remain-on-exit", "on"); err != nil {
+		t.Fatalf("SetOption: %v", err)
+	}
+	// Swap in a fast-exiting command; the script's respawn-pane reuses it.
+	_ = exec.Command("tmux", "-L", socket, "respawn-pane", "-k", "-t", session, "true").Run()
+
+	script := buildAutoRespawnScript("tmux -L "+socket, session, RespawnPolicy{MaxRespawns: 2, Window: time.Hour})
+	waitDead := func() bool {
+		deadline := time.Now().Add(3 * time.Second)
+		for time.Now().Before(deadline) {
+			if isPaneDead(socket, session) {
+				return true
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return false
+	}
+
+	var pids []string
+	for i := 0; i < 3; i++ {
+		if !waitDead() {
+			t.Fatalf("run %d: pane never died", i+1)
+		}
+		pids = append(pids, getPanePID(t, socket, session))
+		if out, err := exec.Command("sh", "-c", script).CombinedOutput(); err != nil {
+			t.Fatalf("run %d: script failed: %v\n%s", i+1, err, out)
+		}
+	}
+
+	// Runs 1 and 2 respawned (new PIDs); run 3 exceeded the cap and left the pane dead.
+	finalPID := getPanePID(t, socket, session)
+	if !isPaneDead(socket, session) || finalPID != pids[2] {
+		t.Errorf("pane respawned past the cap: pids %v, final %s, dead=%v", pids, finalPID, isPaneDead(socket, session))
+	}
+	if pids[0] == pids[1] || pids[1] == pids[2] {
+		t.Errorf("expected respawns below the cap to start new processes, got pids %v", pids)
+	}
+	out, _ := exec.Command("tmux", "-L", socket, "show-options", "-qv", "-t", session, respawnCountOption).Output()
+	if got := strings.TrimSpace(string(out)); got != "3" {
+		t.Errorf("%s = %q, want 3", respawnCountOption, got)
+	}
+}
+
 // TestAutoRespawnHook_RespawnWorks is the primary regression test: pane dies,
 // hook fires on the correct socket, pane comes back alive.
 func TestAutoRespawnHook_RespawnWorks(t *testing.T) {
@@ -104,7 +385,7 @@ func TestAutoRespawnHook_RespawnWorks(t *testing.T) {
 	logT("initial pane_dead=%v, pane_pid=%s", isPaneDead(socket, session), getPanePIDSafe(socket, session))
 
 	tmx := NewTmuxWithSocket(socket)
-	if err := tmx.SetAutoRespawnHook(session); err != nil {
+	if err := tmx.SetAutoRespawnHook(session, RespawnPolicy{}); err != nil {
 		t.Fatalf("SetAutoRespawnHook: %v", err)
 	}
 	logT("hook installed")
@@ -190,7 +471,7 @@ func TestAutoRespawnHook_SkipsAlreadyAlive(t *testing.T) {
 	defer func() { _ = exec.Command("tmux", "-L", socket, "kill-session", "-t", session).Run() }()
 
 	tmx := NewTmuxWithSocket(socket)
-	if err := tmx.SetAutoRespawnHook(session); err != nil {
+	if err := tmx.SetAutoRespawnHook(session, RespawnPolicy{}); err != nil {
 		t.Fatalf("SetAutoRespawnHook: %v", err)
 	}
 
@@ -212,3 +493,161 @@ func TestAutoRespawnHook_SkipsAlreadyAlive(t *testing.T) {
 		t.Errorf("hook killed daemon-respawned process: PID %s → %s (race condition)", pid1, pid2)
 	}
 }
+
+func TestHasAutoRespawnHook_ClearAndRestore(t *testing.T) {
+	socket := requireTestSocket(t)
+	session := "test-clear-hook"
+
+	testSession(t, socket, session, "sleep 300")
+	defer func() { _ = exec.Command("tmux", "-L", socket, "kill-session", "-t", session).Run() }()
+
+	tmx := NewTmuxWithSocket(socket)
+	check := func(want bool, when string) {
+		t.Helper()
+		got, err := tmx.HasAutoRespawnHook(session)
+		if err != nil {
+			t.Fatalf("HasAutoRespawnHook %s: %v", when, err)
+		}
+		if got != want {
+			t.Errorf("HasAutoRespawnHook %s = %v, want %v", when, got, want)
+		}
+	}
+
+	check(false, "with no hook")
+	if err := tmx.SetPaneDiedHook(session, "gastown/Toast"); err != nil {
+		t.Fatalf("SetPaneDiedHook: %v", err)
+	}
+	check(false, "with the crash-logging hook")
+
+	if err := tmx.SetAutoRespawnHook(session, RespawnPolicy{}); err != nil {
+		t.Fatalf("SetAutoRespawnHook: %v", err)
+	}
+	check(true, "after SetAutoRespawnHook")
+
+	if err := tmx.ClearPaneDiedHook(session); err != nil {
+		t.Fatalf("ClearPaneDiedHook: %v", err)
+	}
+	check(false, "after ClearPaneDiedHook")
+
+	if err := tmx.SetAutoRespawnHook(session, RespawnPolicy{}); err != nil {
+		t.Fatalf("SetAutoRespawnHook (restore): %v", err)
+	}
+	check(true, "after restore")
+}
+
+func TestSetOptionGetOption_RoundTrip(t *testing.T) {
+	socket := requireTestSocket(t)
+	session := "test-options"
+
+	testSession(t, socket, session, "sleep 300")
+	defer func() { _ = exec.Command("tmux", "-L", socket, "kill-session", "-t", session).Run() }()
+
+	tmx := NewTmuxWithSocket(socket)
+	for _, tc := range []struct{ name, value string }{
+		{"remain-on-exit", "on"},
+		{"remain-on-exit", "off"},
+		{"status-left-length", "42"},
+		{"@gt_test_option", "hello world"},
+	} {
+		if err := tmx.SetOption(session, tc.name, tc.value); err != nil {
+			t.Fatalf("SetOption(%s, %q): %v", tc.name, tc.value, err)
+		}
+		got, err := tmx.GetOption(session, tc.name)
+		if err != nil {
+			t.Fatalf("GetOption(%s): %v", tc.name, err)
+		}
+		if got != tc.value {
+			t.Errorf("GetOption(%s) = %q, want %q", tc.name, got, tc.value)
+		}
+	}
+
+	// The option was set on this socket's server, not the default one.
+	out, err := exec.Command("tmux", "-L", socket, "show-options", "-qv", "-t", session, "@gt_test_option").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "hello world" {
+		t.Errorf("option not found on socket %s: %q, %v", socket, out, err)
+	}
+
+	if got, err := tmx.GetOption(session, "@gt_unset_option"); err != nil || got != "" {
+		t.Errorf("GetOption(unset) = %q, %v; want empty", got, err)
+	}
+	if err := tmx.SetOption("no-such-session", "remain-on-exit", "on"); err == nil {
+		t.Error("SetOption on a missing session should fail")
+	}
+}
+
+func TestAutoRespawnHookCmd_WindowTarget(t *testing.T) {
+	cmd := buildAutoRespawnHookCmd("tmux -L gt", "gt-crew:agent-2", RespawnPolicy{MaxRespawns: 3, Window: time.Minute})
+	for _, want := range []string{
+		`list-panes -t 'gt-crew:agent-2'`,
+		`respawn-pane -k -t 'gt-crew:agent-2'`,
+		`set-option -t 'gt-crew:agent-2' remain-on-exit on`,
+		`show-options -qv -w -t 'gt-crew:agent-2' ` + respawnCountOption,
+		`set-option -w -t 'gt-crew:agent-2' ` + respawnCountOption,
+	} {
+		if !strings.Contains(cmd, want) {
+			t.Errorf("window hook command missing %q:\n  %s", want, cmd)
+		}
+	}
+
+	// Session-scoped hooks keep session-level counters.
+	cmd = buildAutoRespawnHookCmd("tmux -L gt", "gt-crew", RespawnPolicy{MaxRespawns: 3, Window: time.Minute})
+	if strings.Contains(cmd, "-w -t") {
+		t.Errorf("session hook command uses window-scoped options:\n  %s", cmd)
+	}
+}
+
+func TestSetAutoRespawnHookForWindow_RejectsBadNames(t *testing.T) {
+	tmx := NewTmuxWithSocket("gt-test-unused")
+	for _, tc := range []struct{ session, window string }{
+		{"gt-crew", ""},
+		{"gt-crew", "1;rm -rf /"},
+		{"gt-crew", "a:b"},
+		{"gt crew", "1"},
+	} {
+		if err := tmx.SetAutoRespawnHookForWindow(tc.session, tc.window, RespawnPolicy{}); err == nil {
+			t.Errorf("SetAutoRespawnHookForWindow(%q, %q) should fail", tc.session, tc.window)
+		}
+	}
+}
+
+// TestAutoRespawnHookForWindow_RespawnsOnlyThatWindow kills the process in a
+// hooked window and observes that the hook respawns that window while a
+// sibling window in the same session is left alone.
+func TestAutoRespawnHookForWindow_RespawnsOnlyThatWindow(t *testing.T) {
+	socket := requireTestSocket(t)
+	session := "test-window-respawn"
+
+	testSession(t, socket, session, "sleep 300")
+	defer func() { _ = exec.Command("tmux", "-L", socket, "kill-session", "-t", session).Run() }()
+	if out, err := exec.Command("tmux", "-L", socket, "new-window", "-t", session+":1", "sleep 300").CombinedOutput(); err != nil {
+		t.Fatalf("new-window: %v\n%s", err, out)
+	}
+	target := session + ":1"
+
+	tmx := NewTmuxWithSocket(socket)
+	if err := tmx.SetAutoRespawnHookForWindow(session, "1", RespawnPolicy{Delay: 200 * time.Millisecond}); err != nil {
+		t.Fatalf("SetAutoRespawnHookForWindow: %v", err)
+	}
+
+	siblingPID := getPanePID(t, socket, session+":0")
+	oldPID := getPanePID(t, socket, target)
+	if err := exec.Command("kill", oldPID).Run(); err != nil {
+		t.Fatalf("kill %s: %v", oldPID, err)
+	}
+
+	respawned := false
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if pid := getPanePIDSafe(socket, target); pid != oldPID && !isPaneDead(socket, target) {
+			respawned = true
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !respawned {
+		t.Fatalf("window %s was not respawned (pane_dead=%v, pid=%s)", target, isPaneDead(socket, target), getPanePIDSafe(socket, target))
+	}
+	if pid := getPanePID(t, socket, session+":0"); pid != siblingPID {
+		t.Errorf("sibling window was respawned: PID %s → %s", siblingPID, pid)
+	}
+}
@@ -1,6 +1,9 @@
 package tmux
 
 import (
+	"errors"
+	"os/exec"
+	"strings"
 	"testing"
 )
 
@@ -39,6 +42,83 @@ func TestNewTmuxWithSocket(t *testing.T) {
 	}
 }
 
+var maliciousNames = []string{
+	"gt; rm -rf ~",
+	"gt$(touch /tmp/pwned)",
+	"gt`id`",
+	"gt && reboot",
+	"gt|nc evil 1",
+	"gt\nid",
+	"gt'quote",
+	`gt"dq`,
+	"gt#{pane_pid}",
+	"../gt",
+}
+
+func TestValidateSocketName(t *testing.T) {
+	for _, name := range []string{"", "default", "gt-a1b2c3", "gt_test_42"} {
+		if err := ValidateSocketName(name); err != nil {
+			t.Errorf("ValidateSocketName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range maliciousNames {
+		if err := ValidateSocketName(name); !errors.Is(err, ErrInvalidSocketName) {
+			t.Errorf("ValidateSocketName(%q) = %v, want ErrInvalidSocketName", name, err)
+		}
+	}
+}
+
+func TestNewTmuxWithSocket_AcceptsDottedName(t *testing.T) {
+	// SocketFromEnv returns the basename of $TMUX, which may contain dots.
+	tmx := NewTmuxWithSocket("gt.town-1")
+	if _, err := tmx.ListSessions(); errors.Is(err, ErrInvalidSocketName) {
+		t.Errorf("ListSessions on dotted socket = %v, want no socket-name error", err)
+	}
+}
+
+func TestQuoteHookSocket_ShellRoundTrip(t *testing.T) {
+	if got := quoteHookSocket("gt-a1b2c3"); got != "gt-a1b2c3" {
+		t.Errorf("quoteHookSocket(safe) = %q, want unchanged", got)
+	}
+	for _, name := range append([]string{"gt.town-1", "default.sock"}, maliciousNames...) {
+		out, err := exec.Command("sh", "-c", "printf %s "+quoteHookSocket(name)).Output()
+		if err != nil {
+			t.Fatalf("sh for %q: %v", name, err)
+		}
+		if string(out) != name {
+			t.Errorf("quoteHookSocket(%q) reached the shell as %q", name, out)
+		}
+	}
+}
+
+func TestSetAutoRespawnHook_RejectsMaliciousSession(t *testing.T) {
+	tmx := NewTmuxWithSocket("gt-test-unused")
+	for _, name := range maliciousNames {
+		if err := tmx.SetAutoRespawnHook(name, RespawnPolicy{}); !errors.Is(err, ErrInvalidSessionName) {
+			t.Errorf("SetAutoRespawnHook(%q) = %v, want ErrInvalidSessionName", name, err)
+		}
+		if err := tmx.SetAutoRespawnHookForWindow("gt-crew", name, RespawnPolicy{}); err == nil {
+			t.Errorf("SetAutoRespawnHookForWindow(window %q) should fail", name)
+		}
+	}
+}
+
+func TestAutoRespawnHookCmd_NoInjectionFromValidNames(t *testing.T) {
+	// Every name that passes validation yields a hook command free of shell
+	// metacharacters beyond the fixed pipeline the builder itself emits.
+	for _, name := range append([]string{"gt-a1b2c3", "gt_test"}, maliciousNames...) {
+		if ValidateSocketName(name) != nil || validateSessionName(name) != nil {
+			continue
+		}
+		cmd := buildAutoRespawnHookCmd("tmux -L "+name, name, RespawnPolicy{})
+		for _, bad := range []string{";", "`", "$(", "\n"} {
+			if strings.Contains(cmd, bad) {
+				t.Errorf("hook command for %q contains %q:\n  %s", name, bad, cmd)
+			}
+		}
+	}
+}
+
 func TestBuildCommandNoSocket(t *testing.T) {
 	orig := defaultSocket
 	defer func() { defaultSocket = orig }()
@@ -45,6 +45,7 @@ var (
 	ErrSessionNotFound    = errors.New("session not found")
 	ErrSessionRunning     = errors.New("session already running with healthy agent")
 	ErrInvalidSessionName = errors.New("invalid session name")
+	ErrInvalidSocketName  = errors.New("invalid socket name")
 	ErrIdleTimeout        = errors.New("agent not idle before timeout")
 )
 
@@ -58,6 +59,18 @@ func validateSessionName(name string) error {
 	return nil
 }
 
+// ValidateSocketName checks that a tmux socket name contains only
+// [A-Za-z0-9_-]. Commands run on a socket pass its name to tmux as a plain
+// argument, so any name works there; this check is for callers that splice
+// the name unquoted into a shell command, such as the GT_TOWN_SOCKET prefix
+// of the key bindings. An empty name (the default server) is valid.
+func ValidateSocketName(name string) error {
+	if name != "" && !validSessionNameRe.MatchString(name) {
+		return fmt.Errorf("%w %q: must match %s", ErrInvalidSocketName, name, validSessionNameRe.String())
+	}
+	return nil
+}
+
 // validateCommandBinary extracts the binary path from a tmux session command
 // and verifies it exists on disk. Handles common patterns:
 //   - "exec env VAR=val /path/to/binary --args"
@@ -213,6 +226,9 @@ func NewTmux() *Tmux {
 // This creates/connects to an isolated tmux server, separate from the user's
 // default server. Primarily used in tests to prevent session name collisions
 // and keystroke leaks (e.g. Escape from NudgeSession hitting the user's prefix table).
+//
+// Any socket name tmux accepts is allowed, including the dotted names
+// SocketFromEnv can return; hook commands shell-quote it where needed.
 func NewTmuxWithSocket(socket string) *Tmux {
 	return &Tmux{socketName: socket}
 }
@@ -377,7 +393,8 @@ func (t *Tmux) NewSessionWithCommand(name, workDir, command string) error {
 //
 // The command should still use 'exec env' for WaitForCommand detection compatibility,
 // but -e provides defense-in-depth for the initial shell environment.
-// Requires tmux >= 3.2.
+// On tmux < 3.2, which has no -e, the variables are set on the session
+// after it is created, so only the respawned command sees them.
 func (t *Tmux) NewSessionWithCommandAndEnv(name, workDir, command string, env map[string]string) error {
 	if err := validateSessionName(name); err != nil {
 		return err
@@ -413,12 +430,26 @@ func (t *Tmux) NewSessionWithCommandAndEnv(name, workDir, command string, env ma
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
-	for _, k := range keys {
-		args = append(args, "-e", fmt.Sprintf("%s=%s", k, env[k]))
+	envFlags := t.SupportsFeature(FeatureNewSessionEnv)
+	if envFlags {
+		for _, k := range keys {
+			args = append(args, "-e", fmt.Sprintf("%s=%s", k, env[k]))
+		}
 	}
 	if _, err := t.run(args...); err != nil {
 		return err
 	}
+	if !envFlags {
+		// tmux < 3.2 has no new-session -e. The initial shell misses the
+		// variables, but the respawned command below inherits them from
+		// the session environment.
+		for _, k := range keys {
+			if _, err := t.run("set-environment", "-t", name, k, env[k]); err != nil {
+				_ = t.KillSession(name)
+				return fmt.Errorf("setting %s in session %q: %w", k, name, err)
+			}
+		}
+	}
 	// tmux 3.3+ sets window-size=manual on detached sessions (no client present),
 	// which locks the window at 80x24 even after a client attaches. Override to
 	// "latest" so the window auto-resizes to the attaching client's terminal size.
@@ -701,6 +732,15 @@ func (t *Tmux) KillSessionWithProcesses(name string) error {
 	return err
 }
 
+// KillSessionTree kills session and every process it started, so no
+// orphaned subprocess (e.g. a Claude child) outlives it: the pane process's
+// descendants and reparented process-group members get SIGTERM, then
+// SIGKILL after a grace period, before kill-session runs on t's socket.
+// It is KillSessionWithProcesses under the name callers look for.
+func (t *Tmux) KillSessionTree(session string) error {
+	return t.KillSessionWithProcesses(session)
+}
+
 // KillSessionWithProcessesExcluding is like KillSessionWithProcesses but excludes
 // specified PIDs from being killed. This is essential for self-kill scenarios where
 // the calling process (e.g., gt done) is running inside the session it's terminating.
@@ -1008,6 +1048,48 @@ func (t *Tmux) KillServer() error {
 	return err
 }
 
+// ServerAlive reports whether a tmux server is listening on t's socket.
+// A live server with no sessions (exit-empty off) counts as alive. Unlike
+// HasSession, this never confuses "no server" with "no such session":
+// list-sessions succeeds on any live server, while has-session on an empty
+// server fails with "no current target", which wrapError reports as
+// ErrNoServer. Recovery code can use this to decide whether to start a server.
+func (t *Tmux) ServerAlive() bool {
+	_, err := t.run("list-sessions", "-F", "#{session_name}")
+	return err == nil
+}
+
+// ErrNoTownSocket is returned by EnsureServer when t does not target a
+// per-town socket.
+var ErrNoTownSocket = errors.New("no town tmux socket configured")
+
+// EnsureServer starts the per-town tmux server if it is not running and
+// applies the town-wide options that sessions inherit:
+//   - exit-empty off: the server outlives its last session (without it a
+//     server started with no sessions exits immediately)
+//   - window-size latest: see NewSessionWithCommand
+//
+// remain-on-exit stays a per-session choice: session constructors and
+// SetAutoRespawnHook turn it on where a dead pane should be kept.
+//
+// The server is started and configured in a single tmux invocation. Safe to
+// call on a running server; the options are simply re-applied. Refuses to
+// touch the user's default server (ErrNoTownSocket).
+func (t *Tmux) EnsureServer() error {
+	if t.socketName == "" || t.socketName == "default" || t.socketName == noTownSocket {
+		return ErrNoTownSocket
+	}
+	_, err := t.run(
+		"start-server", ";",
+		"set-option", "-g", "exit-empty", "off", ";",
+		"set-option", "-gw", "window-size", "latest",
+	)
+	if err != nil {
+		return fmt.Errorf("starting tmux server on socket %s: %w", t.socketName, err)
+	}
+	return nil
+}
+
 // SetExitEmpty controls the tmux exit-empty server option.
 // When on (default), the server exits when there are no sessions.
 // When off, the server stays running even with no sessions.
@@ -1215,7 +1297,7 @@ func (t *Tmux) SendKeys(session, keys string) error {
 func (t *Tmux) SendKeysDebounced(session, keys string, debounceMs int) (retErr error) {
 	defer func() { telemetry.RecordPromptSend(context.Background(), session, keys, debounceMs, retErr) }()
 	// Send text using literal mode (-l) to handle special chars
-	if _, err := t.run("send-keys", "-t", session, "-l", keys); err != nil {
+	if _, err := t.run("send-keys", "-t", session, "-l", "--", escapeSendKeysLiteral(keys)); err != nil {
 		return err
 	}
 	// Wait for paste to be processed
@@ -1233,6 +1315,38 @@ func (t *Tmux) SendKeysRaw(session, keys string) error {
 	return err
 }
 
+// SendKeysLiteral types keys into a session verbatim and, if enter is set,
+// presses Enter afterwards. Unlike SendKeysRaw, key names such as "C-c" are
+// not interpreted, so it is safe for arbitrary recovery commands like "/clear".
+func (t *Tmux) SendKeysLiteral(session, keys string, enter bool) error {
+	if keys != "" {
+		if _, err := t.run("send-keys", "-t", session, "-l", "--", escapeSendKeysLiteral(keys)); err != nil {
+			return err
+		}
+	}
+	if !enter {
+		return nil
+	}
+	if keys != "" {
+		time.Sleep(time.Duration(constants.DefaultDebounceMs) * time.Millisecond)
+	}
+	_, err := t.run("send-keys", "-t", session, "Enter")
+	return err
+}
+
+// escapeSendKeysLiteral protects a send-keys argument from tmux's command
+// parser. Even when passed as a separate argv entry, an argument ending in ";"
+// is taken as a command separator (the ";" is dropped, and a lone ";" sends
+// nothing), while a trailing "\;" is unescaped to ";". Inserting a backslash
+// before a trailing ";" makes tmux deliver the text unchanged in both cases.
+// Quotes and other shell metacharacters need no escaping: no shell is involved.
+func escapeSendKeysLiteral(keys string) string {
+	if strings.HasSuffix(keys, ";") {
+		return keys[:len(keys)-1] + `\;`
+	}
+	return keys
+}
+
 // SendKeysReplace sends keystrokes, clearing any pending input first.
 // This is useful for "replaceable" notifications where only the latest matters.
 // Uses Ctrl-U to clear the input line before sending the new message.
@@ -2134,6 +2248,25 @@ func (t *Tmux) GetPaneCommand(session string) (string, error) {
 	return result, nil
 }
 
+// GetPaneTitle returns the title of the active pane in a session's first
+// window, the pane GetPaneCommand reports on. Programs set it with the
+// terminal title escape sequence (Claude shows its current task there), so
+// it can be empty.
+func (t *Tmux) GetPaneTitle(session string) (string, error) {
+	// Same first-window targeting as GetPaneCommand; see there. An unknown
+	// target is not an error for display-message, it just expands to
+	// nothing, so the session name is included to tell the cases apart.
+	out, err := t.run("display-message", "-t", session+":^", "-p", "#{session_name}\t#{pane_title}")
+	if err != nil {
+		return "", err
+	}
+	name, title, _ := strings.Cut(out, "\t")
+	if name == "" {
+		return "", fmt.Errorf("%w: %s", ErrSessionNotFound, session)
+	}
+	return title, nil
+}
+
 // FindAgentPane finds the pane running an agent process within a session.
 // In multi-window/multi-pane sessions, send-keys -t <session> targets the
 // active/focused pane, which may not be the agent pane. This method returns
@@ -2255,6 +2388,51 @@ func (t *Tmux) GetPanePID(target string) (string, error) {
 	return result, nil
 }
 
+// IsPaneProcessAlive reports whether the pane's main process really exists at
+// the OS level. It catches zombie panes where tmux still reports pane_dead=0
+// but the child is gone, which the respawn hook (keyed on pane-died) misses.
+//
+// A bare PID check is fooled when the PID has been recycled by an unrelated
+// process, so the process must also still be a child of this tmux server.
+// tmux exposes no pane start time to compare against, but parentage is a
+// stricter identity check: every pane process is forked by the server.
+// Where the parent PID is unavailable (Windows), only existence is checked.
+func (t *Tmux) IsPaneProcessAlive(session string) (bool, error) {
+	tmuxTarget := session
+	if !strings.HasPrefix(session, "%") {
+		tmuxTarget = session + ":^"
+	}
+	out, err := t.run("display-message", "-t", tmuxTarget, "-p", "#{pane_pid}|#{pane_dead}|#{pid}")
+	if err != nil {
+		return false, err
+	}
+	parts := strings.Split(strings.TrimSpace(out), "|")
+	if len(parts) != 3 || parts[0] == "" {
+		return false, fmt.Errorf("empty PID for target %s (session may not exist)", session)
+	}
+	if parts[1] == "1" {
+		return false, nil
+	}
+	pid, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, fmt.Errorf("parsing pane PID %q: %w", parts[0], err)
+	}
+	return panePIDAlive(pid, parts[2])
+}
+
+// panePIDAlive reports whether pid exists and, when its parent is known,
+// is a child of serverPID.
+func panePIDAlive(pid int, serverPID string) (bool, error) {
+	exists, err := processExists(pid)
+	if err != nil || !exists {
+		return false, err
+	}
+	if ppid := getParentPID(strconv.Itoa(pid)); ppid != "" && serverPID != "" && ppid != serverPID {
+		return false, nil // PID recycled by an unrelated process
+	}
+	return true, nil
+}
+
 // GetSessionActivity returns the last activity time for a session.
 // This is updated whenever there's any activity in the session (input/output).
 func (t *Tmux) GetSessionActivity(session string) (time.Time, error) {
@@ -2462,9 +2640,19 @@ func (t *Tmux) FindSessionByWorkDir(targetDir string, processNames []string) ([]
 	return matches, nil
 }
 
-// CapturePane captures the visible content of a pane.
+// CapturePane captures the last N lines of a session's pane, including
+// scrollback above the visible area. Returns ErrSessionNotFound when the
+// target does not exist (tmux reports a missing pane, or no server at all).
 func (t *Tmux) CapturePane(session string, lines int) (string, error) {
-	return t.run("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
+	out, err := t.run("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
+	if err != nil {
+		if errors.Is(err, ErrNoServer) || strings.Contains(err.Error(), "can't find pane") ||
+			strings.Contains(err.Error(), "can't find window") {
+			return "", ErrSessionNotFound
+		}
+		return "", err
+	}
+	return out, nil
 }
 
 // CapturePaneAll captures all scrollback history.
@@ -2630,12 +2818,32 @@ func (t *Tmux) GetAllEnvironment(session string) (map[string]string, error) {
 	return env, nil
 }
 
-// RenameSession renames a session.
+// RenameSession renames session oldName to newName without restarting it,
+// e.g. to promote a temporary polecat session to its permanent name.
+// newName must pass the same validation as NewSession. Returns
+// ErrSessionExists if newName is already taken and ErrSessionNotFound if
+// oldName does not exist.
 func (t *Tmux) RenameSession(oldName, newName string) error {
 	if err := validateSessionName(newName); err != nil {
 		return err
 	}
-	_, err := t.run("rename-session", "-t", oldName, newName)
+	// Check explicitly so an existing session is never clobbered, whatever
+	// the tmux version does with duplicate names.
+	exists, err := t.HasSession(newName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrSessionExists, newName)
+	}
+	target := "=" + oldName
+	if runtime.GOOS == "windows" {
+		target = oldName
+	}
+	_, err = t.run("rename-session", "-t", target, newName)
+	if errors.Is(err, ErrNoServer) {
+		return ErrSessionNotFound
+	}
 	return err
 }
 
@@ -2647,6 +2855,8 @@ type SessionInfo struct {
 	Attached     bool
 	Activity     string // Last activity time
 	LastAttached string // Last time the session was attached
+	PanePID      int    // PID of the active pane's process (0 if unknown)
+	PaneDead     bool   // Active pane's process has exited (remain-on-exit)
 }
 
 // DisplayMessage shows a message in the tmux status line.
@@ -3213,20 +3423,52 @@ func (t *Tmux) IsIdle(session string) bool {
 	return false
 }
 
+// sessionInfoFormat is the list-sessions -F format parsed by parseSessionInfo.
+// pane_pid and pane_dead describe the session's active pane.
+const sessionInfoFormat = "#{session_name}|#{session_windows}|#{session_created}|#{session_attached}|#{session_activity}|#{session_last_attached}|#{pane_pid}|#{pane_dead}"
+
 // GetSessionInfo returns detailed information about a session.
 func (t *Tmux) GetSessionInfo(name string) (*SessionInfo, error) {
-	format := "#{session_name}|#{session_windows}|#{session_created}|#{session_attached}|#{session_activity}|#{session_last_attached}"
-	out, err := t.run("list-sessions", "-F", format, "-f", fmt.Sprintf("#{==:#{session_name},%s}", name))
+	out, err := t.listSession(name, sessionInfoFormat)
 	if err != nil {
 		return nil, err
 	}
 	if out == "" {
 		return nil, ErrSessionNotFound
 	}
+	return parseSessionInfo(out)
+}
+
+// ListSessionsInfo returns structured metadata for every session on the
+// socket, in tmux's listing order. No server means no sessions.
+func (t *Tmux) ListSessionsInfo() ([]SessionInfo, error) {
+	out, err := t.run("list-sessions", "-F", sessionInfoFormat)
+	if err != nil {
+		if errors.Is(err, ErrNoServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-	parts := strings.Split(out, "|")
+	var sessions []SessionInfo
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		info, err := parseSessionInfo(line)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *info)
+	}
+	return sessions, nil
+}
+
+// parseSessionInfo parses one line of sessionInfoFormat output.
+func parseSessionInfo(line string) (*SessionInfo, error) {
+	parts := strings.Split(line, "|")
 	if len(parts) < 4 {
-		return nil, fmt.Errorf("unexpected session info format: %s", out)
+		return nil, fmt.Errorf("unexpected session info format: %s", line)
 	}
 
 	windows := 0
@@ -3253,14 +3495,37 @@ func (t *Tmux) GetSessionInfo(name string) (*SessionInfo, error) {
 	if len(parts) > 5 {
 		info.LastAttached = parts[5]
 	}
+	if len(parts) > 7 {
+		_, _ = fmt.Sscanf(parts[6], "%d", &info.PanePID)
+		info.PaneDead = parts[7] == "1"
+	}
 
 	return info, nil
 }
 
+// listSession returns format expanded for the session called name, or ""
+// if there is no such session. tmux 3.2+ filters with list-sessions -f;
+// older versions list every session and the match is picked out here.
+func (t *Tmux) listSession(name, format string) (string, error) {
+	if t.SupportsFeature(FeatureFormatFilter) {
+		return t.run("list-sessions", "-F", format, "-f", fmt.Sprintf("#{==:#{session_name},%s}", name))
+	}
+	out, err := t.run("list-sessions", "-F", "#{session_name}|"+format)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if n, rest, ok := strings.Cut(line, "|"); ok && n == name {
+			return rest, nil
+		}
+	}
+	return "", nil
+}
+
 // GetSessionCreatedTime returns the creation time of a tmux session.
 // Uses #{session_created} (Unix timestamp) from tmux list-sessions.
 func (t *Tmux) GetSessionCreatedTime(name string) (time.Time, error) {
-	out, err := t.run("list-sessions", "-F", "#{session_created}", "-f", fmt.Sprintf("#{==:#{session_name},%s}", name))
+	out, err := t.listSession(name, "#{session_created}")
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -3455,7 +3720,12 @@ func IsInsideTmux() bool {
 // a registered rig prefix or "hq-"). In non-GT sessions, the user's original
 // MouseDown1StatusRight binding (if any) is preserved.
 // See: https://github.com/steveyegge/gastown/issues/1548
+//
+// The popup needs tmux 3.2+; on older versions no binding is added.
 func (t *Tmux) SetMailClickBinding(session string) error {
+	if !t.SupportsFeature(FeatureDisplayPopup) {
+		return nil
+	}
 	// Skip if already configured — preserves user's original fallback from first call
 	if t.isGTBinding("root", "MouseDown1StatusRight") {
 		return nil
@@ -3537,8 +3807,52 @@ func (t *Tmux) SetRemainOnExit(pane string, on bool) error {
 	if !on {
 		value = "off"
 	}
-	_, err := t.run("set-option", "-t", pane, "remain-on-exit", value)
-	return err
+	return t.SetOption(pane, "remain-on-exit", value)
+}
+
+// SetOption sets tmux option name to value on session (or a session:window
+// or pane target), on this Tmux's socket. tmux infers the option's scope
+// from its name, so window options such as remain-on-exit apply to the
+// target's window. User options (@name) are set on the session.
+func (t *Tmux) SetOption(session, name, value string) error {
+	if _, err := t.run("set-option", "-t", session, name, value); err != nil {
+		return fmt.Errorf("setting option %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetOption returns the effective value of tmux option name for session,
+// including values inherited from the global options. An unset user option
+// (@name) returns "".
+func (t *Tmux) GetOption(session, name string) (string, error) {
+	return t.run("show-options", "-Aqv", "-t", session, name)
+}
+
+// displayBatchSep separates the fields of a DisplayMessageBatch format. The
+// ASCII unit separator is passed through verbatim by tmux and does not occur
+// in session names, titles, paths, or numeric formats.
+const displayBatchSep = "\x1f"
+
+// DisplayMessageBatch expands several format strings (e.g. "#{pane_pid}",
+// "#{pane_dead}") against target in a single display-message call and returns
+// the results in the order of formats. Use it instead of one display-message
+// per field when inspecting many properties of many sessions. As with a
+// single display-message, an unknown target is not an error; include
+// "#{session_name}" to detect it.
+func (t *Tmux) DisplayMessageBatch(target string, formats []string) ([]string, error) {
+	if len(formats) == 0 {
+		return nil, nil
+	}
+	// The trailing separator keeps a final empty field from being trimmed away.
+	out, err := t.run("display-message", "-p", "-t", target, strings.Join(formats, displayBatchSep)+displayBatchSep)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(out, displayBatchSep)
+	if len(fields) != len(formats)+1 {
+		return nil, fmt.Errorf("display-message for %s: got %d fields, want %d", target, len(fields)-1, len(formats))
+	}
+	return fields[:len(formats)], nil
 }
 
 // SwitchClient switches the current tmux client to a different session.
@@ -3871,6 +4185,9 @@ func (t *Tmux) SetRigMenuBinding(session string) error {
 //
 // Safe to call multiple times; skips if bindings already exist.
 func EnsureBindingsOnSocket(socket, townSocket string) error {
+	if err := ValidateSocketName(townSocket); err != nil {
+		return err
+	}
 	t := NewTmuxWithSocket(socket)
 
 	// Build the command strings, optionally prefixed with GT_TOWN_SOCKET so
@@ -4045,31 +4362,135 @@ func (t *Tmux) SetPaneDiedHook(session, agentID string) error {
 	return err
 }
 
+// RespawnPolicy bounds how often the auto-respawn hook restarts a session,
+// so a command that crashes immediately on startup can't spin in a tight loop.
+type RespawnPolicy struct {
+	// MaxRespawns is the most hook-driven respawns allowed within Window.
+	// Zero or negative means unlimited.
+	MaxRespawns int
+	// Window is the period over which respawns are counted. The counter
+	// resets once a respawn happens more than Window after the first one.
+	Window time.Duration
+	// Delay is how long the hook waits after the pane dies before respawning.
+	// Zero or negative uses config.DefaultRespawnHookDelay.
+	Delay time.Duration
+	// BaseBackoff, when positive, makes repeated respawns back off
+	// exponentially on top of Delay: the first respawn waits no longer, then
+	// BaseBackoff, 2*BaseBackoff, ... up to BackoffMax. A pane that stays
+	// alive for StablePeriod after its last respawn starts over. Durations
+	// are rounded up to whole seconds.
+	BaseBackoff  time.Duration
+	BackoffMax   time.Duration
+	StablePeriod time.Duration
+}
+
+// capped reports whether the policy limits respawns at all.
+func (p RespawnPolicy) capped() bool {
+	return p.MaxRespawns > 0 && p.Window > 0
+}
+
+// backoff reports whether the policy backs off repeated respawns.
+func (p RespawnPolicy) backoff() bool {
+	return p.BaseBackoff > 0
+}
+
+// delay returns the hook's pre-respawn delay, defaulting when unset.
+func (p RespawnPolicy) delay() time.Duration {
+	if p.Delay <= 0 {
+		return config.DefaultRespawnHookDelay
+	}
+	return p.Delay
+}
+
+// RespawnPolicyFromConfig builds a RespawnPolicy from the daemon thresholds
+// (respawn_max_attempts, respawn_window) and the session respawn_hook_delay
+// and backoff thresholds (respawn_base_backoff, respawn_backoff_max,
+// respawn_stable_period). A nil config yields the defaults.
+func RespawnPolicyFromConfig(op *config.OperationalConfig) RespawnPolicy {
+	return respawnPolicy(op.GetDaemonConfig(), op.GetSessionConfig())
+}
+
+// RespawnPolicyForRole is RespawnPolicyFromConfig with the session thresholds
+// overridden by role's session.per_role entry.
+func RespawnPolicyForRole(op *config.OperationalConfig, role string) RespawnPolicy {
+	return respawnPolicy(op.GetDaemonConfig(), op.SessionConfigForRole(role))
+}
+
+func respawnPolicy(daemonCfg *config.DaemonThresholds, sessionCfg *config.SessionThresholds) RespawnPolicy {
+	return RespawnPolicy{
+		MaxRespawns:  daemonCfg.RespawnMaxAttemptsV(),
+		Window:       daemonCfg.RespawnWindowD(),
+		Delay:        sessionCfg.RespawnHookDelayD(),
+		BaseBackoff:  sessionCfg.RespawnBaseBackoffD(),
+		BackoffMax:   sessionCfg.RespawnBackoffMaxD(),
+		StablePeriod: sessionCfg.RespawnStablePeriodD(),
+	}
+}
+
+// Session user options used by the auto-respawn hook to count respawns and
+// to back them off.
+const (
+	respawnCountOption    = "@gt_respawn_count"
+	respawnStartOption    = "@gt_respawn_start"
+	respawnAttemptsOption = "@gt_respawn_attempts"
+	respawnLastOption     = "@gt_respawn_last"
+)
+
 // SetAutoRespawnHook configures a session to automatically respawn when the pane dies.
 // This is used for persistent agents like Deacon that should never exit.
 // PATCH-010: Fixes Deacon crash loop by respawning at tmux level.
 //
 // The hook:
-// 1. Waits 3 seconds (debounce rapid crashes)
-// 2. Checks if pane is still dead (daemon may have already restarted it)
-// 3. Respawns the pane with its original command
-// 4. Re-enables remain-on-exit (respawn-pane resets it to off!)
+//  1. Waits policy.Delay (default 3s) to debounce rapid crashes
+//  2. Checks if pane is still dead (daemon may have already restarted it)
+//  3. Counts the respawn against policy and gives up (leaving the pane dead)
+//     once more than policy.MaxRespawns happen within policy.Window
+//  4. Backs off repeated respawns per policy.BaseBackoff, then checks the
+//     pane is still dead
+//  5. Respawns the pane with its original command
+//  6. Re-enables remain-on-exit (respawn-pane resets it to off!)
+//  7. Records a "respawn" feed event via the hidden gt log respawn command
+//     (best-effort: a missing gt binary or town never blocks the respawn)
 //
 // The hook uses run-shell -b (background) to prevent output from leaking to
 // the user's active tmux pane, and includes || true to suppress error display.
 //
 // Requires remain-on-exit to be set first (called automatically by this function).
-func (t *Tmux) SetAutoRespawnHook(session string) error {
+func (t *Tmux) SetAutoRespawnHook(session string, policy RespawnPolicy) error {
+	if err := validateSessionName(session); err != nil {
+		return err
+	}
+	return t.setAutoRespawnHook(session, policy)
+}
+
+// SetAutoRespawnHookForWindow is SetAutoRespawnHook scoped to a single window
+// (session:window), for towns that run several agents as separate windows of
+// one session. The hook is a window hook, so each window can carry its own,
+// and the generated command respawns that window's pane. Respawn counts for a
+// capped policy are kept per window.
+//
+// window is a window index or name and must match the same strict pattern as
+// session names.
+func (t *Tmux) SetAutoRespawnHookForWindow(session, window string, policy RespawnPolicy) error {
 	if err := validateSessionName(session); err != nil {
 		return err
 	}
+	if window == "" || !validSessionNameRe.MatchString(window) {
+		return fmt.Errorf("invalid window name %q: must match %s", window, validSessionNameRe.String())
+	}
+	return t.setAutoRespawnHook(session+":"+window, policy)
+}
+
+// setAutoRespawnHook installs the auto-respawn pane-died hook on target, a
+// validated session name or session:window.
+func (t *Tmux) setAutoRespawnHook(target string, policy RespawnPolicy) error {
 	// First, enable remain-on-exit so the pane stays after process exit
-	if err := t.SetRemainOnExit(session, true); err != nil {
+	if err := t.SetOption(target, "remain-on-exit", "on"); err != nil {
 		return fmt.Errorf("setting remain-on-exit: %w", err)
 	}
 
-	// Sanitize session name for shell safety
-	safeSession := strings.ReplaceAll(session, "'", "'\\''")
+	// Sanitize target for shell safety
+	safeTarget := strings.ReplaceAll(target, "'", "'\\''")
 
 	// Build the tmux command prefix, including socket flag when configured.
 	// When a socket is configured, the embedded tmux commands MUST include
@@ -4077,27 +4498,74 @@ func (t *Tmux) SetAutoRespawnHook(session string) error {
 	// would otherwise connect to the default server instead of the town socket.
 	tmuxCmd := "tmux"
 	if t.socketName != "" {
-		tmuxCmd = fmt.Sprintf("tmux -L %s", t.socketName)
+		tmuxCmd = "tmux -L " + quoteHookSocket(t.socketName)
 	}
 
-	hookCmd := buildAutoRespawnHookCmd(tmuxCmd, safeSession)
+	hookCmd := buildAutoRespawnHookCmd(tmuxCmd, safeTarget, policy)
 
-	// Set the hook on this specific session.
+	// Set the hook on this specific session (or window).
 	// Note: this OVERWRITES any existing pane-died hook (e.g., SetPaneDiedHook).
 	// tmux only allows one hook per event per session.
-	_, err := t.run("set-hook", "-t", session, "pane-died", hookCmd)
-	if err != nil {
+	args := []string{"set-hook", "-t", target, "pane-died", hookCmd}
+	if strings.Contains(target, ":") {
+		args = []string{"set-hook", "-w", "-t", target, "pane-died", hookCmd}
+	}
+	if _, err := t.run(args...); err != nil {
 		return fmt.Errorf("setting pane-died hook: %w", err)
 	}
 
 	return nil
 }
 
+// quoteHookSocket returns socket ready to splice into the auto-respawn hook's
+// shell command. Names made only of [A-Za-z0-9_-] are returned unchanged;
+// anything else (e.g. a dotted name from SocketFromEnv) is single-quoted so
+// the shell never interprets it.
+func quoteHookSocket(socket string) string {
+	if validSessionNameRe.MatchString(socket) {
+		return socket
+	}
+	return "'" + strings.ReplaceAll(socket, "'", `'\''`) + "'"
+}
+
+// HasAutoRespawnHook reports whether the session's pane-died hook is the
+// auto-respawn hook installed by SetAutoRespawnHook (as opposed to no hook,
+// or the crash-logging hook from SetPaneDiedHook).
+func (t *Tmux) HasAutoRespawnHook(session string) (bool, error) {
+	if err := validateSessionName(session); err != nil {
+		return false, err
+	}
+	// show-hooks -t stopped reporting session hooks in tmux 3.4; hooks are
+	// options there, so read it as one.
+	cmd := "show-options"
+	if t.SupportsFeature(FeatureShowHooksSession) {
+		cmd = "show-hooks"
+	}
+	out, err := t.run(cmd, "-t", session, "pane-died")
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(out, "respawn-pane -k"), nil
+}
+
+// ClearPaneDiedHook removes the session's pane-died hook, disarming
+// auto-respawn until SetAutoRespawnHook is called again.
+func (t *Tmux) ClearPaneDiedHook(session string) error {
+	if err := validateSessionName(session); err != nil {
+		return err
+	}
+	if _, err := t.run("set-hook", "-t", session, "-u", "pane-died"); err != nil {
+		return fmt.Errorf("clearing pane-died hook: %w", err)
+	}
+	return nil
+}
+
 // buildAutoRespawnHookCmd builds the pane-died hook command string for auto-respawn.
 // The tmuxCmd parameter is the tmux binary invocation (e.g., "tmux -L gt" or "tmux").
-// The session parameter is the already-sanitized session name.
+// The session parameter is the already-sanitized session name, or session:window
+// for a window-scoped hook (the respawn counters are then window options).
 //
-// The command has three safety measures:
+// The command has four safety measures:
 //
 //  1. run-shell -b: Runs in background so output/errors never leak to the
 //     user's active tmux pane. Without -b, run-shell displays failures
@@ -4105,27 +4573,98 @@ func (t *Tmux) SetAutoRespawnHook(session string) error {
 //     which can take over an unrelated session the user is viewing.
 //
 //  2. Dead-pane guard: Checks #{pane_dead} before respawning. The daemon's
-//     heartbeat may have already restarted the session during the 3-second
-//     sleep window. Without this guard, the hook blindly runs respawn-pane -k
+//     heartbeat may have already restarted the session during the delay
+//     window. Without this guard, the hook blindly runs respawn-pane -k
 //     which kills the daemon's freshly-started agent.
 //
-//  3. || true: Ensures the overall command always exits 0, suppressing any
+//  3. Crash-loop cap: when policy is capped, respawns are counted in session
+//     user options and the hook stops respawning once the cap is exceeded.
+//
+//  4. || true: Ensures the overall command always exits 0, suppressing any
 //     error display from tmux even if the session was killed entirely.
-func buildAutoRespawnHookCmd(tmuxCmd, session string) string {
-	// The shell pipeline:
-	//   sleep 3                              -- debounce rapid crashes
-	//   list-panes ... #{pane_dead} | grep   -- guard: only proceed if pane is still dead
-	//   respawn-pane -k                      -- restart with original command
-	//   set-option remain-on-exit on         -- re-enable (respawn-pane resets it to off!)
-	//   || true                              -- suppress errors unconditionally
-	//
-	// IMPORTANT: run-shell expands format variables (#{...}) at hook fire time,
-	// not at shell execution time. We need the pane_dead check to run 3 seconds
-	// AFTER the pane dies (to detect if the daemon already restarted it).
-	// Using ##{pane_dead} escapes the first expansion (## -> #), so the shell
-	// receives #{pane_dead} and passes it to the nested `tmux list-panes` call
-	// which evaluates it at query time -- giving us the CURRENT pane state.
-	return fmt.Sprintf(
-		`run-shell -b "sleep 3 && %s list-panes -t '%s' -F '##{pane_dead}' 2>/dev/null | grep -q 1 && %s respawn-pane -k -t '%s' && %s set-option -t '%s' remain-on-exit on || true"`,
-		tmuxCmd, session, tmuxCmd, session, tmuxCmd, session)
+func buildAutoRespawnHookCmd(tmuxCmd, session string, policy RespawnPolicy) string {
+	return `run-shell -b "` + escapeRunShellArg(buildAutoRespawnScript(tmuxCmd, session, policy)) + `"`
+}
+
+// buildAutoRespawnScript builds the shell pipeline run by the auto-respawn hook:
+//
+//	sleep <delay>                        -- debounce rapid crashes
+//	list-panes ... #{pane_dead} | grep   -- guard: only proceed if pane is still dead
+//	(capped policy only)                 -- bump the respawn counter, resetting it once
+//	                                        the window has elapsed; stop if over the cap
+//	(backoff policy only)                -- sleep the backoff for this attempt, then
+//	                                        re-check the pane is still dead
+//	respawn-pane -k                      -- restart with original command
+//	set-option remain-on-exit on         -- re-enable (respawn-pane resets it to off!)
+//	gt log respawn                       -- record a feed event; failures are ignored
+//	|| true                              -- suppress errors unconditionally
+//
+// The pane_dead check must run the full delay AFTER the pane dies (to detect if the
+// daemon already restarted it), so it queries tmux at execution time rather
+// than relying on the hook's own format expansion.
+func buildAutoRespawnScript(tmuxCmd, session string, policy RespawnPolicy) string {
+	target := "'" + session + "'"
+	// Session names cannot contain ':', so one marks a session:window target.
+	optTarget := "-t " + target
+	if strings.Contains(session, ":") {
+		optTarget = "-w -t " + target
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "sleep %s && %s list-panes -t %s -F '#{pane_dead}' 2>/dev/null | grep -q 1",
+		strconv.FormatFloat(policy.delay().Seconds(), 'f', -1, 64), tmuxCmd, target)
+	if policy.capped() {
+		fmt.Fprintf(&b, " && now=$(date +%%s)")
+		fmt.Fprintf(&b, " && start=$(%s show-options -qv %s %s)", tmuxCmd, optTarget, respawnStartOption)
+		fmt.Fprintf(&b, " && count=$(%s show-options -qv %s %s)", tmuxCmd, optTarget, respawnCountOption)
+		fmt.Fprintf(&b, ` && if [ -z "$start" ] || [ $((now - start)) -ge %d ]; then start=$now; count=0; fi`,
+			int64(policy.Window/time.Second))
+		b.WriteString(" && count=$((count + 1))")
+		fmt.Fprintf(&b, " && %s set-option %s %s $start", tmuxCmd, optTarget, respawnStartOption)
+		fmt.Fprintf(&b, " && %s set-option %s %s $count", tmuxCmd, optTarget, respawnCountOption)
+		fmt.Fprintf(&b, " && [ $count -le %d ]", policy.MaxRespawns)
+	}
+	if policy.backoff() {
+		b.WriteString(buildRespawnBackoffScript(tmuxCmd, target, optTarget, policy))
+	}
+	fmt.Fprintf(&b, " && %s respawn-pane -k -t %s && %s set-option -t %s remain-on-exit on", tmuxCmd, target, tmuxCmd, target)
+	fmt.Fprintf(&b, " && (gt log respawn --session %s >/dev/null 2>&1 || true) || true", target)
+	return b.String()
+}
+
+// buildRespawnBackoffScript builds the backoff step of the auto-respawn
+// script: the attempt count and the time of the last respawn live in session
+// options, so they survive between hook runs. The count resets once the last
+// respawn is at least StablePeriod ago; attempt n (from 0) then waits 0,
+// BaseBackoff, 2*BaseBackoff, ... capped at BackoffMax, and the pane must
+// still be dead afterwards.
+func buildRespawnBackoffScript(tmuxCmd, target, optTarget string, policy RespawnPolicy) string {
+	base := ceilSeconds(policy.BaseBackoff)
+	limit := max(ceilSeconds(policy.BackoffMax), base)
+	stable := ceilSeconds(policy.StablePeriod)
+	var b strings.Builder
+	b.WriteString(" && now=$(date +%s)")
+	fmt.Fprintf(&b, " && attempts=$(%s show-options -qv %s %s)", tmuxCmd, optTarget, respawnAttemptsOption)
+	fmt.Fprintf(&b, " && last=$(%s show-options -qv %s %s)", tmuxCmd, optTarget, respawnLastOption)
+	fmt.Fprintf(&b, ` && if [ -z "$attempts" ] || [ -z "$last" ] || [ $((now - last)) -ge %d ]; then attempts=0; fi`, stable)
+	fmt.Fprintf(&b, ` && backoff=0 && if [ $attempts -gt 0 ]; then backoff=%d; i=1; while [ $i -lt $attempts ] && [ $backoff -lt %d ]; do backoff=$((backoff * 2)); i=$((i + 1)); done; fi`, base, limit)
+	fmt.Fprintf(&b, " && if [ $backoff -gt %d ]; then backoff=%d; fi", limit, limit)
+	fmt.Fprintf(&b, " && %s set-option %s %s $((attempts + 1))", tmuxCmd, optTarget, respawnAttemptsOption)
+	fmt.Fprintf(&b, " && %s set-option %s %s $((now + backoff))", tmuxCmd, optTarget, respawnLastOption)
+	fmt.Fprintf(&b, " && sleep $backoff && %s list-panes -t %s -F '#{pane_dead}' 2>/dev/null | grep -q 1", tmuxCmd, target)
+	return b.String()
+}
+
+// ceilSeconds returns d in whole seconds, rounded up.
+func ceilSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
+
+// escapeRunShellArg escapes a shell command for use inside a double-quoted
+// tmux command argument. run-shell expands #{...} formats before the shell
+// sees the command, so # is doubled (## -> #) to keep format queries for the
+// nested tmux calls, which then evaluate them at execution time. $, " and \
+// are escaped so tmux passes them to the shell untouched.
+func escapeRunShellArg(script string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, `#`, `##`)
+	return r.Replace(script)
 }
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -157,6 +159,152 @@ func TestSendKeysAndCapture(t *testing.T) {
 	}
 }
 
+func TestCapturePane_LastLines(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-capture-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	if err := tm.NewSessionWithCommand(sessionName, "", "seq 1 100; sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	var output string
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		out, err := tm.CapturePane(sessionName, 200)
+		if err != nil {
+			t.Fatalf("CapturePane: %v", err)
+		}
+		if strings.Contains(out, "100") {
+			output = out
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if output == "" {
+		t.Fatal("timed out waiting for command output")
+	}
+	if !strings.Contains(output, "\n1\n") && !strings.HasPrefix(output, "1\n") {
+		t.Errorf("expected scrollback to include line 1, got:\n%s", output)
+	}
+}
+
+func TestCapturePane_MissingSession(t *testing.T) {
+	tm := newTestTmux(t)
+	keep := "gt-test-capture-keep-" + t.Name()
+	_ = tm.KillSession(keep)
+	if err := tm.NewSession(keep, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(keep) }()
+
+	_, err := tm.CapturePane("gt-test-capture-nonexistent", 10)
+	if !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("CapturePane(missing) = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestCapturePane_NoServer(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxWithSocket(fmt.Sprintf("gt-test-noserver-%d", os.Getpid()))
+	_, err := tm.CapturePane("anything", 10)
+	if !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("CapturePane(no server) = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSendKeysLiteral(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-sendkeys-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	// cat echoes each line back once Enter is pressed, so every delivered
+	// line shows up twice: as typed and as output.
+	if err := tm.NewSessionWithCommand(sessionName, "", "cat"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	for i := 0; i < 50; i++ {
+		if cmd, _ := tm.GetPaneCommand(sessionName); cmd == "cat" {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// waitForCount polls the pane until line appears want times.
+	waitForCount := func(line string, want int) (int, string) {
+		var n int
+		var out string
+		for deadline := time.Now().Add(3 * time.Second); time.Now().Before(deadline); {
+			var err error
+			out, err = tm.CapturePane(sessionName, 100)
+			if err != nil {
+				t.Fatalf("CapturePane: %v", err)
+			}
+			n = 0
+			for _, l := range strings.Split(out, "\n") {
+				if strings.TrimRight(l, " ") == line {
+					n++
+				}
+			}
+			if n >= want {
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		return n, out
+	}
+
+	inputs := []string{
+		"/clear",
+		`it's a "quoted" line`,
+		"first; second",
+		"trailing semicolon;",
+		";",
+		`escaped\;`,
+		"-n not a flag",
+		"C-c",
+		"$HOME `id`",
+	}
+	for _, in := range inputs {
+		if err := tm.SendKeysLiteral(sessionName, in, true); err != nil {
+			t.Fatalf("SendKeysLiteral(%q): %v", in, err)
+		}
+		// Wait for cat's echo before the next line so output doesn't interleave.
+		if n, out := waitForCount(in, 2); n != 2 {
+			t.Errorf("%q appears %d times in pane, want 2 (typed and echoed)\npane:\n%s", in, n, out)
+		}
+	}
+
+	// Without enter the text stays on the input line and is not echoed.
+	if err := tm.SendKeysLiteral(sessionName, "pending input", false); err != nil {
+		t.Fatalf("SendKeysLiteral(enter=false): %v", err)
+	}
+	if n, out := waitForCount("pending input", 1); n != 1 {
+		t.Errorf("pending input appears %d times, want 1 (typed, not submitted)\npane:\n%s", n, out)
+	}
+}
+
+func TestEscapeSendKeysLiteral(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"/clear", "/clear"},
+		{"a; b", "a; b"},
+		{"a;", `a\;`},
+		{";", `\;`},
+		{`a\;`, `a\\;`},
+		{`it's "q"`, `it's "q"`},
+	}
+	for _, tt := range tests {
+		if got := escapeSendKeysLiteral(tt.in); got != tt.want {
+			t.Errorf("escapeSendKeysLiteral(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
 func TestGetSessionInfo(t *testing.T) {
 	tm := newTestTmux(t)
 	sessionName := "gt-test-info-" + t.Name()
@@ -183,6 +331,272 @@ func TestGetSessionInfo(t *testing.T) {
 	}
 }
 
+func TestListSessionsInfo(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pane_dead requires remain-on-exit, unsupported on psmux")
+	}
+	tm := newTestTmux(t)
+	alive := "gt-test-lsinfo-alive"
+	dead := "gt-test-lsinfo-dead"
+	for _, name := range []string{alive, dead} {
+		_ = tm.KillSession(name)
+		if err := tm.NewSessionWithCommand(name, "", "sleep 30"); err != nil {
+			t.Fatalf("NewSessionWithCommand(%s): %v", name, err)
+		}
+		defer func(name string) { _ = tm.KillSession(name) }(name)
+	}
+
+	// Kill the dead session's process; remain-on-exit keeps the pane around.
+	// respawn-pane resets remain-on-exit, so set it again explicitly.
+	if err := tm.SetRemainOnExit(dead, true); err != nil {
+		t.Fatalf("SetRemainOnExit: %v", err)
+	}
+	info, err := tm.GetSessionInfo(dead)
+	if err != nil {
+		t.Fatalf("GetSessionInfo: %v", err)
+	}
+	if info.PanePID <= 0 {
+		t.Fatalf("PanePID = %d, want > 0", info.PanePID)
+	}
+	proc, err := os.FindProcess(info.PanePID)
+	if err != nil {
+		t.Fatalf("FindProcess: %v", err)
+	}
+	if err := proc.Kill(); err != nil {
+		t.Fatalf("kill pane process: %v", err)
+	}
+
+	var got map[string]SessionInfo
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		sessions, err := tm.ListSessionsInfo()
+		if err != nil {
+			t.Fatalf("ListSessionsInfo: %v", err)
+		}
+		got = make(map[string]SessionInfo, len(sessions))
+		for _, s := range sessions {
+			got[s.Name] = s
+		}
+		if got[dead].PaneDead || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	a, ok := got[alive]
+	if !ok {
+		t.Fatalf("session %s missing from %v", alive, got)
+	}
+	if a.PaneDead {
+		t.Errorf("%s: PaneDead = true, want false", alive)
+	}
+	if a.PanePID <= 0 {
+		t.Errorf("%s: PanePID = %d, want > 0", alive, a.PanePID)
+	}
+	if a.Created == "" {
+		t.Errorf("%s: Created is empty", alive)
+	}
+	d, ok := got[dead]
+	if !ok {
+		t.Fatalf("session %s missing from %v", dead, got)
+	}
+	if !d.PaneDead {
+		t.Errorf("%s: PaneDead = false, want true", dead)
+	}
+}
+
+func TestListSessionsInfo_NoServer(t *testing.T) {
+	if !hasTmux() {
+		t.Skip("tmux not installed")
+	}
+	tm := NewTmuxWithSocket(fmt.Sprintf("gt-test-noserver-%d", os.Getpid()))
+	sessions, err := tm.ListSessionsInfo()
+	if err != nil {
+		t.Fatalf("ListSessionsInfo: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("sessions = %v, want none", sessions)
+	}
+}
+
+// batchFormats mixes session, window, and pane fields with a literal and a
+// format that expands to nothing, to exercise ordering and empty fields.
+var batchFormats = []string{
+	"#{pane_dead}",
+	"#{session_name}",
+	"literal",
+	"#{@gt_unset_option}",
+	"#{window_index}",
+	"#{pane_pid}",
+	"#{session_windows}",
+}
+
+func TestDisplayMessageBatch(t *testing.T) {
+	tm := newTestTmux(t)
+	session := "gt-test-batch"
+	_ = tm.KillSession(session)
+	if err := tm.NewSessionWithCommand(session, "", "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(session) }()
+
+	got, err := tm.DisplayMessageBatch(session, batchFormats)
+	if err != nil {
+		t.Fatalf("DisplayMessageBatch: %v", err)
+	}
+	if len(got) != len(batchFormats) {
+		t.Fatalf("got %d fields, want %d: %q", len(got), len(batchFormats), got)
+	}
+	for i, format := range batchFormats {
+		want, err := tm.run("display-message", "-p", "-t", session, format)
+		if err != nil {
+			t.Fatalf("display-message %s: %v", format, err)
+		}
+		if got[i] != want {
+			t.Errorf("field %d (%s) = %q, want %q", i, format, got[i], want)
+		}
+	}
+	if got[1] != session || got[2] != "literal" || got[3] != "" {
+		t.Errorf("fields out of order: %q", got)
+	}
+
+	if got, err := tm.DisplayMessageBatch(session, nil); err != nil || got != nil {
+		t.Errorf("no formats: got %q, %v; want nil, nil", got, err)
+	}
+}
+
+// benchmarkSession starts a session for the DisplayMessage benchmarks.
+func benchmarkSession(b *testing.B) (*Tmux, string) {
+	b.Helper()
+	if !hasTmux() {
+		b.Skip("tmux not installed")
+	}
+	tm := NewTmux()
+	session := "gt-bench-batch"
+	_ = tm.KillSession(session)
+	if err := tm.NewSessionWithCommand(session, "", "sleep 300"); err != nil {
+		b.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	b.Cleanup(func() { _ = tm.KillSession(session) })
+	return tm, session
+}
+
+func BenchmarkDisplayMessage_PerField(b *testing.B) {
+	tm, session := benchmarkSession(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, format := range batchFormats {
+			if _, err := tm.run("display-message", "-p", "-t", session, format); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkDisplayMessage_Batched(b *testing.B) {
+	tm, session := benchmarkSession(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tm.DisplayMessageBatch(session, batchFormats); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseSessionInfo(t *testing.T) {
+	info, err := parseSessionInfo("gt-a|2|0|1|||4242|1")
+	if err != nil {
+		t.Fatalf("parseSessionInfo: %v", err)
+	}
+	if info.Name != "gt-a" || info.Windows != 2 || !info.Attached || info.PanePID != 4242 || !info.PaneDead {
+		t.Errorf("parseSessionInfo = %+v", info)
+	}
+
+	// Older formats without pane fields still parse.
+	info, err = parseSessionInfo("gt-b|1|0|0")
+	if err != nil {
+		t.Fatalf("parseSessionInfo(short): %v", err)
+	}
+	if info.PanePID != 0 || info.PaneDead {
+		t.Errorf("parseSessionInfo(short) = %+v, want no pane info", info)
+	}
+
+	if _, err := parseSessionInfo("garbage"); err == nil {
+		t.Error("parseSessionInfo(garbage) should fail")
+	}
+}
+
+func TestIsPaneProcessAlive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pane_dead requires remain-on-exit, unsupported on psmux")
+	}
+	tm := newTestTmux(t)
+	session := "gt-test-pane-alive"
+	_ = tm.KillSession(session)
+	if err := tm.NewSessionWithCommand(session, "", "sleep 30"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(session) }()
+
+	alive, err := tm.IsPaneProcessAlive(session)
+	if err != nil {
+		t.Fatalf("IsPaneProcessAlive: %v", err)
+	}
+	if !alive {
+		t.Fatal("IsPaneProcessAlive = false for a running pane")
+	}
+
+	if err := tm.SetRemainOnExit(session, true); err != nil {
+		t.Fatalf("SetRemainOnExit: %v", err)
+	}
+	pid, err := tm.GetPanePID(session)
+	if err != nil {
+		t.Fatalf("GetPanePID: %v", err)
+	}
+	if err := exec.Command("kill", "-9", pid).Run(); err != nil {
+		t.Fatalf("kill pane process: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		alive, err = tm.IsPaneProcessAlive(session)
+		if err != nil {
+			t.Fatalf("IsPaneProcessAlive after kill: %v", err)
+		}
+		if !alive || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if alive {
+		t.Error("IsPaneProcessAlive = true after the pane process was killed")
+	}
+
+	if _, err := tm.IsPaneProcessAlive("gt-test-pane-alive-missing"); err == nil {
+		t.Error("IsPaneProcessAlive(missing session) should fail")
+	}
+}
+
+func TestPanePIDAlive_RecycledPID(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("parent PID is not available on Windows")
+	}
+	pid := os.Getpid()
+	parent := strconv.Itoa(os.Getppid())
+
+	// Our own process, checked against its real parent, is alive.
+	alive, err := panePIDAlive(pid, parent)
+	if err != nil || !alive {
+		t.Errorf("panePIDAlive(self, real parent) = %v, %v; want true", alive, err)
+	}
+
+	// The same PID claimed by a different "server" looks recycled.
+	alive, err = panePIDAlive(pid, "1")
+	if err != nil || alive {
+		t.Errorf("panePIDAlive(self, wrong parent) = %v, %v; want false", alive, err)
+	}
+}
+
 func TestWrapError(t *testing.T) {
 	tm := newTestTmux(t)
 
@@ -925,6 +1339,57 @@ func TestKillSessionWithProcesses_KillsProcessGroup(t *testing.T) {
 	}
 }
 
+// TestKillSessionTree_ReapsForkedChild verifies that a child forked by the
+// pane's command (the shape of a Claude subprocess) does not outlive the
+// session.
+func TestKillSessionTree_ReapsForkedChild(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process tree walk is Unix-only")
+	}
+	tm := newTestTmux(t)
+	sessionName := "gt-test-killtree-" + t.Name()
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	_ = tm.KillSession(sessionName)
+
+	cmd := fmt.Sprintf(`sh -c 'sleep 300 & echo $! > %s; wait'`, pidFile)
+	if err := tm.NewSessionWithCommand(sessionName, "", cmd); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	var childPID int
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if data, err := os.ReadFile(pidFile); err == nil {
+			if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+				childPID = pid
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if childPID == 0 {
+		t.Fatal("child never reported its PID")
+	}
+	if alive, _ := processExists(childPID); !alive {
+		t.Fatalf("child %d not running before kill", childPID)
+	}
+
+	if err := tm.KillSessionTree(sessionName); err != nil {
+		t.Fatalf("KillSessionTree: %v", err)
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if alive, _ := processExists(childPID); !alive {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	_ = exec.Command("kill", "-KILL", strconv.Itoa(childPID)).Run()
+	t.Errorf("forked child %d survived KillSessionTree", childPID)
+}
+
 func TestSessionSet(t *testing.T) {
 	tm := newTestTmux(t)
 	sessionName := "gt-test-sessionset-" + t.Name()
@@ -0,0 +1,106 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Features that SupportsFeature can gate on. Each maps to the minimum tmux
+// version that behaves as Gas Town expects.
+const (
+	// FeatureNewSessionEnv is new-session -e VAR=value (tmux 3.2).
+	FeatureNewSessionEnv = "new-session-env"
+	// FeatureFormatFilter is list-sessions/list-panes -f <filter> (tmux 3.2).
+	FeatureFormatFilter = "format-filter"
+	// FeatureDisplayPopup is display-popup (tmux 3.2).
+	FeatureDisplayPopup = "display-popup"
+	// FeatureShowHooksSession is a show-hooks -t <session> that reports
+	// session-level hooks. It is broken from tmux 3.4 on, so the check is
+	// inverted: supported only below 3.4.
+	FeatureShowHooksSession = "show-hooks-session"
+)
+
+// featureMinVersion is the minimum {major, minor} for each version-gated feature.
+var featureMinVersion = map[string][2]int{
+	FeatureNewSessionEnv: {3, 2},
+	FeatureFormatFilter:  {3, 2},
+	FeatureDisplayPopup:  {3, 2},
+}
+
+// tmuxVersionOutput runs `tmux -V`. Replaced in tests.
+var tmuxVersionOutput = func() (string, error) {
+	cmd := exec.Command("tmux", "-V")
+	hideConsoleWindow(cmd)
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// versionCache holds the parsed `tmux -V` result; the installed binary does
+// not change while gt runs, so it is detected once per process.
+var versionCache struct {
+	once         sync.Once
+	major, minor int
+	err          error
+}
+
+// tmuxVersionRe matches the first "<major>.<minor>" in `tmux -V` output,
+// e.g. "tmux 3.3a", "tmux 3.4", "tmux next-3.5".
+var tmuxVersionRe = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// Version returns the installed tmux version as parsed from `tmux -V`.
+// Suffixes such as the "a" in 3.3a and prefixes such as "next-" are ignored.
+// The result is cached for the life of the process.
+func (t *Tmux) Version() (major, minor int, err error) {
+	versionCache.once.Do(func() {
+		out, err := tmuxVersionOutput()
+		if err != nil {
+			versionCache.major, versionCache.minor = 0, 0
+			versionCache.err = fmt.Errorf("running tmux -V: %w", err)
+			return
+		}
+		versionCache.major, versionCache.minor, versionCache.err = parseTmuxVersion(out)
+	})
+	return versionCache.major, versionCache.minor, versionCache.err
+}
+
+// parseTmuxVersion extracts major and minor from `tmux -V` output.
+func parseTmuxVersion(out string) (major, minor int, err error) {
+	out = strings.TrimSpace(out)
+	m := tmuxVersionRe.FindStringSubmatch(out)
+	if m == nil {
+		return 0, 0, fmt.Errorf("unrecognized tmux version %q", out)
+	}
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+	return major, minor, nil
+}
+
+// SupportsFeature reports whether the installed tmux supports feat (one of
+// the Feature* constants). Unknown features and an undetectable version
+// report false, so callers fall back to their conservative code path.
+func (t *Tmux) SupportsFeature(feat string) bool {
+	major, minor, err := t.Version()
+	if err != nil {
+		return false
+	}
+	if feat == FeatureShowHooksSession {
+		return !versionAtLeast(major, minor, 3, 4)
+	}
+	want, ok := featureMinVersion[feat]
+	if !ok {
+		return false
+	}
+	return versionAtLeast(major, minor, want[0], want[1])
+}
+
+// versionAtLeast reports whether major.minor >= wantMajor.wantMinor.
+func versionAtLeast(major, minor, wantMajor, wantMinor int) bool {
+	if major != wantMajor {
+		return major > wantMajor
+	}
+	return minor >= wantMinor
+}
@@ -0,0 +1,157 @@
+package tmux
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockTmuxVersion makes `tmux -V` report out (or fail with err) and resets
+// the version cache, restoring both when the test ends.
+func mockTmuxVersion(t *testing.T, out string, err error) {
+	t.Helper()
+	orig := tmuxVersionOutput
+	tmuxVersionOutput = func() (string, error) { return out, err }
+	versionCache.once = sync.Once{}
+	t.Cleanup(func() {
+		tmuxVersionOutput = orig
+		versionCache.once = sync.Once{}
+	})
+}
+
+func TestParseTmuxVersion(t *testing.T) {
+	tests := []struct {
+		out          string
+		major, minor int
+	}{
+		{"tmux 3.3a\n", 3, 3},
+		{"tmux 3.4", 3, 4},
+		{"tmux next-3.5", 3, 5},
+		{"tmux 2.9", 2, 9},
+		{"tmux 3.2-rc3", 3, 2},
+	}
+	for _, tt := range tests {
+		major, minor, err := parseTmuxVersion(tt.out)
+		if err != nil {
+			t.Errorf("parseTmuxVersion(%q): %v", tt.out, err)
+			continue
+		}
+		if major != tt.major || minor != tt.minor {
+			t.Errorf("parseTmuxVersion(%q) = %d.%d, want %d.%d", tt.out, major, minor, tt.major, tt.minor)
+		}
+	}
+
+	for _, out := range []string{"", "tmux master", "not tmux"} {
+		if _, _, err := parseTmuxVersion(out); err == nil {
+			t.Errorf("parseTmuxVersion(%q) should fail", out)
+		}
+	}
+}
+
+func TestSupportsFeature(t *testing.T) {
+	tests := []struct {
+		version string
+		feat    string
+		want    bool
+	}{
+		{"tmux 3.3a", FeatureShowHooksSession, true},
+		{"tmux 3.4", FeatureShowHooksSession, false},
+		{"tmux next-3.5", FeatureShowHooksSession, false},
+		{"tmux 3.3a", FeatureNewSessionEnv, true},
+		{"tmux 3.1c", FeatureNewSessionEnv, false},
+		{"tmux next-3.5", FeatureFormatFilter, true},
+		{"tmux 2.9", FeatureDisplayPopup, false},
+		{"tmux 3.4", "no-such-feature", false},
+	}
+	tmx := NewTmux()
+	for _, tt := range tests {
+		mockTmuxVersion(t, tt.version, nil)
+		if got := tmx.SupportsFeature(tt.feat); got != tt.want {
+			t.Errorf("%s: SupportsFeature(%q) = %v, want %v", tt.version, tt.feat, got, tt.want)
+		}
+	}
+}
+
+func TestVersion_Cached(t *testing.T) {
+	calls := 0
+	mockTmuxVersion(t, "", nil)
+	tmuxVersionOutput = func() (string, error) {
+		calls++
+		return "tmux 3.4", nil
+	}
+
+	tmx := NewTmux()
+	for i := 0; i < 3; i++ {
+		major, minor, err := tmx.Version()
+		if err != nil || major != 3 || minor != 4 {
+			t.Fatalf("Version() = %d.%d, %v; want 3.4", major, minor, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("tmux -V ran %d times, want 1", calls)
+	}
+}
+
+func TestVersion_Unavailable(t *testing.T) {
+	mockTmuxVersion(t, "", errors.New("exec: \"tmux\": executable file not found"))
+
+	tmx := NewTmux()
+	if _, _, err := tmx.Version(); err == nil {
+		t.Error("Version() should fail when tmux -V fails")
+	}
+	if tmx.SupportsFeature(FeatureNewSessionEnv) {
+		t.Error("SupportsFeature should be false when the version is unknown")
+	}
+}
+
+// TestOldTmuxFallbacks runs the session calls gated on tmux 3.2 features
+// down their pre-3.2 paths against the installed tmux.
+func TestOldTmuxFallbacks(t *testing.T) {
+	tm := newTestTmux(t)
+	mockTmuxVersion(t, "tmux 3.1c", nil)
+	session := "gt-test-oldtmux"
+	_ = tm.KillSession(session)
+
+	err := tm.NewSessionWithCommandAndEnv(session, "", "sleep 300", map[string]string{"GT_ROLE": "witness"})
+	if err != nil {
+		t.Fatalf("NewSessionWithCommandAndEnv: %v", err)
+	}
+	defer func() { _ = tm.KillSession(session) }()
+
+	if got, err := tm.GetEnvironment(session, "GT_ROLE"); err != nil || got != "witness" {
+		t.Errorf("GT_ROLE = %q, %v; want witness", got, err)
+	}
+	info, err := tm.GetSessionInfo(session)
+	if err != nil || info.Name != session {
+		t.Errorf("GetSessionInfo = %+v, %v", info, err)
+	}
+	if created, err := tm.GetSessionCreatedTime(session); err != nil || time.Since(created) > time.Minute {
+		t.Errorf("GetSessionCreatedTime = %v, %v", created, err)
+	}
+	if _, err := tm.GetSessionInfo("gt-test-oldtmux-missing"); err != ErrSessionNotFound {
+		t.Errorf("GetSessionInfo(missing) err = %v, want ErrSessionNotFound", err)
+	}
+}
+
+// TestHasAutoRespawnHook_Tmux34 reads the hook the way tmux 3.4+ needs.
+func TestHasAutoRespawnHook_Tmux34(t *testing.T) {
+	tm := newTestTmux(t)
+	mockTmuxVersion(t, "tmux 3.4", nil)
+	session := "gt-test-hooks34"
+	_ = tm.KillSession(session)
+	if err := tm.NewSessionWithCommand(session, "", "sleep 300"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(session) }()
+
+	if has, err := tm.HasAutoRespawnHook(session); err != nil || has {
+		t.Fatalf("before hook: HasAutoRespawnHook = %v, %v", has, err)
+	}
+	if err := tm.SetAutoRespawnHook(session, RespawnPolicy{}); err != nil {
+		t.Fatalf("SetAutoRespawnHook: %v", err)
+	}
+	if has, err := tm.HasAutoRespawnHook(session); err != nil || !has {
+		t.Errorf("after hook: HasAutoRespawnHook = %v, %v", has, err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -342,7 +343,11 @@ func (s *GtEventsSource) Close() error {
 	return s.file.Close()
 }
 
-// parseGtEventLine parses a line from .events.jsonl
+// parseGtEventLine parses a line from .events.jsonl. Two shapes are
+// accepted: the GtEvent form written by the events package ("ts", "payload",
+// "visibility"), and an Event-shaped form ("time", "message", optional
+// "payload") such as gt feed --json output. Both normalize to an Event;
+// lines matching neither are skipped.
 func parseGtEventLine(line string) *Event {
 	if strings.TrimSpace(line) == "" {
 		return nil
@@ -353,6 +358,10 @@ func parseGtEventLine(line string) *Event {
 		return nil
 	}
 
+	if ge.Timestamp == "" && ge.Visibility == "" {
+		return parseEventFormLine(line)
+	}
+
 	// Only show feed-visible events
 	if ge.Visibility != "feed" && ge.Visibility != "both" {
 		return nil
@@ -363,25 +372,77 @@ func parseGtEventLine(line string) *Event {
 		t = time.Now()
 	}
 
-	// Extract rig from payload or actor
-	rig := ""
-	if ge.Payload != nil {
-		if r, ok := ge.Payload["rig"].(string); ok {
-			rig = r
+	// Build message from event type and payload
+	message := buildEventMessage(ge.Type, ge.Payload)
+
+	return &Event{
+		Time:    t,
+		Type:    ge.Type,
+		Actor:   ge.Actor,
+		Target:  getPayloadString(ge.Payload, "bead"),
+		Message: message,
+		Rig:     eventRig(ge.Actor, ge.Payload),
+		Role:    actorRole(ge.Actor),
+		Raw:     line,
+	}
+}
+
+// parseEventFormLine parses an Event-shaped line. It has no visibility, so
+// it is always shown, but it must carry a time and a type. When it has a
+// payload and no message, the message is built from the payload as for
+// GtEvent lines.
+func parseEventFormLine(line string) *Event {
+	var ev struct {
+		Event
+		Payload map[string]interface{} `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(line), &ev); err != nil {
+		return nil
+	}
+	if ev.Time.IsZero() || ev.Type == "" {
+		return nil
+	}
+
+	event := ev.Event
+	if event.Message == "" && ev.Payload != nil {
+		event.Message = buildEventMessage(event.Type, ev.Payload)
+	}
+	if event.Target == "" {
+		event.Target = getPayloadString(ev.Payload, "bead")
+	}
+	if event.Rig == "" {
+		event.Rig = eventRig(event.Actor, ev.Payload)
+	}
+	if event.Role == "" {
+		event.Role = actorRole(event.Actor)
+	}
+	event.Raw = line
+	return &event
+}
+
+// eventRig returns the event's rig: the payload's "rig", else the first
+// part of an actor like "gastown/witness" (town-level actors have none).
+func eventRig(actor string, payload map[string]interface{}) string {
+	if payload != nil {
+		if r, ok := payload["rig"].(string); ok && r != "" {
+			return r
 		}
 	}
-	if rig == "" && ge.Actor != "" {
+	if actor != "" {
 		// Extract rig from actor like "gastown/witness"
-		parts := strings.Split(ge.Actor, "/")
+		parts := strings.Split(actor, "/")
 		if len(parts) > 0 && parts[0] != constants.RoleMayor && parts[0] != constants.RoleDeacon {
-			rig = parts[0]
+			return parts[0]
 		}
 	}
+	return ""
+}
 
-	// Extract role from actor
+// actorRole extracts the role from an actor path.
+func actorRole(actor string) string {
 	role := ""
-	if ge.Actor != "" {
-		parts := strings.Split(ge.Actor, "/")
+	if actor != "" {
+		parts := strings.Split(actor, "/")
 		if len(parts) >= 2 {
 			role = parts[len(parts)-1]
 			// Check for known roles
@@ -403,20 +464,7 @@ func parseGtEventLine(line string) *Event {
 			role = parts[0]
 		}
 	}
-
-	// Build message from event type and payload
-	message := buildEventMessage(ge.Type, ge.Payload)
-
-	return &Event{
-		Time:    t,
-		Type:    ge.Type,
-		Actor:   ge.Actor,
-		Target:  getPayloadString(ge.Payload, "bead"),
-		Message: message,
-		Rig:     rig,
-		Role:    role,
-		Raw:     line,
-	}
+	return role
 }
 
 // buildEventMessage creates a human-readable message from event type and payload
@@ -530,6 +578,140 @@ func buildEventMessage(eventType string, payload map[string]interface{}) string
 		}
 		return "merge failed"
 
+	case "respawn":
+		session := getPayloadString(payload, "session")
+		trigger := getPayloadString(payload, "trigger")
+		if session != "" && trigger != "" {
+			return fmt.Sprintf("respawned %s (%s)", session, trigger)
+		}
+		if session != "" {
+			return fmt.Sprintf("respawned %s", session)
+		}
+		return "session respawned"
+
+	case "respawn_paused":
+		count := getPayloadInt(payload, "count")
+		pause := getPayloadString(payload, "pause")
+		if pause != "" {
+			return fmt.Sprintf("auto-respawn paused for %d session(s) for %s", count, pause)
+		}
+		return fmt.Sprintf("auto-respawn paused for %d session(s)", count)
+
+	case "respawn_resumed":
+		return fmt.Sprintf("auto-respawn resumed for %d session(s)", getPayloadInt(payload, "count"))
+
+	case "session_hung":
+		session := getPayloadString(payload, "session")
+		frozen := getPayloadString(payload, "frozen")
+		if session == "" {
+			return "session hung"
+		}
+		if frozen != "" {
+			return fmt.Sprintf("%s hung (no output for %s)", session, frozen)
+		}
+		return session + " hung"
+
+	case "gupp_violation":
+		agent := getPayloadString(payload, "agent")
+		if agent == "" {
+			agent = getPayloadString(payload, "session")
+		}
+		elapsed := getPayloadString(payload, "elapsed")
+		if agent == "" {
+			return "GUPP violation"
+		}
+		msg := "GUPP violation: " + agent
+		if hook := getPayloadString(payload, "hook_bead"); hook != "" {
+			msg += " not progressing on " + hook
+		}
+		if elapsed != "" {
+			msg += " for " + elapsed
+		}
+		return msg
+
+	case "wisp_alert":
+		open := getPayloadInt(payload, "open")
+		threshold := getPayloadInt(payload, "threshold")
+		if open > 0 {
+			return fmt.Sprintf("%d open wisps exceed threshold %d", open, threshold)
+		}
+		return "open wisps exceed threshold"
+
+	case "namepool_grown":
+		rig := getPayloadString(payload, "rig")
+		from := getPayloadInt(payload, "from")
+		to := getPayloadInt(payload, "to")
+		msg := fmt.Sprintf("polecat namepool grew %d → %d", from, to)
+		if rig != "" {
+			msg = fmt.Sprintf("%s polecat namepool grew %d → %d", rig, from, to)
+		}
+		if limit := getPayloadInt(payload, "max"); limit > 0 && to >= limit {
+			msg += " (at cap)"
+		}
+		return msg
+
+	case "nudge_expired":
+		session := getPayloadString(payload, "session")
+		sender := getPayloadString(payload, "sender")
+		reason := getPayloadString(payload, "reason")
+		if session == "" {
+			return "nudge expired undelivered"
+		}
+		msg := "nudge to " + session
+		if sender != "" {
+			msg = fmt.Sprintf("nudge from %s to %s", sender, session)
+		}
+		if reason != "" {
+			return fmt.Sprintf("%s dropped (%s)", msg, strings.ReplaceAll(reason, "_", " "))
+		}
+		return msg + " dropped"
+
+	case "nudge_claim_reclaimed":
+		session := getPayloadString(payload, "session")
+		age := getPayloadString(payload, "age")
+		msg := "stale nudge claim"
+		if age != "" {
+			msg = fmt.Sprintf("nudge claim stale for %s", age)
+		}
+		if session != "" {
+			msg += " in " + session
+		}
+		if requeued, ok := payload["requeued"].(bool); ok && !requeued {
+			return msg + " removed (requeue failed)"
+		}
+		return msg + " requeued"
+
+	case "heartbeat_staleness":
+		agent := getPayloadString(payload, "agent")
+		to := strings.ReplaceAll(getPayloadString(payload, "to"), "_", " ")
+		age := getPayloadString(payload, "age")
+		threshold := getPayloadString(payload, "threshold")
+		if agent == "" || to == "" {
+			return "heartbeat staleness changed"
+		}
+		if getPayloadString(payload, "from") != "healthy" && to == "healthy" {
+			return fmt.Sprintf("%s heartbeat recovered (age %s, below %s)", agent, age, threshold)
+		}
+		return fmt.Sprintf("%s heartbeat %s (age %s, threshold %s)", agent, to, age, threshold)
+
+	case "mass_death":
+		count := getPayloadInt(payload, "count")
+		window := getPayloadString(payload, "window")
+		msg := fmt.Sprintf("%d sessions died within %s", count, window)
+		if roles, ok := payload["roles"].(map[string]interface{}); ok && len(roles) > 0 {
+			names := make([]string, 0, len(roles))
+			for role := range roles {
+				names = append(names, role)
+			}
+			sort.Strings(names)
+			parts := make([]string, 0, len(names))
+			for _, role := range names {
+				parts = append(parts, fmt.Sprintf("%d %s", getPayloadInt(roles, role), role))
+			}
+			msg += " (" + strings.Join(parts, ", ") + ")"
+		}
+		return msg
+
 	default:
 		if msg := getPayloadString(payload, "message"); msg != "" {
 			return msg
@@ -2,124 +2,411 @@ package feed
 
 import (
 	"bufio"
+	"container/heap"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
+
+	gtevents "github.com/steveyegge/gastown/internal/events"
+)
+
+// Output formats for PrintGtEvents.
+const (
+	// FormatPlain prints "[15:04:05] symbol actor message" lines (default).
+	FormatPlain = "plain"
+	// FormatJSON prints one JSON object per event (see jsonEvent).
+	FormatJSON = "json"
 )
 
 // PrintOptions controls filtering and behavior for PrintGtEvents.
 type PrintOptions struct {
 	Limit  int
 	Follow bool
-	Since  string // duration string like "5m", "1h"
-	Mol    string // molecule/issue ID prefix filter
-	Type   string // event type filter
-	Rig    string // rig name filter (matches event's Rig field)
+	Since  time.Time       // only events at or after this time (zero: no lower bound)
+	Until  time.Time       // only events at or before this time (zero: no upper bound)
+	Mol    string          // molecule/issue ID prefix filter
+	Type   string          // event type filter
+	Types  []string        // event types to include (any of); empty matches all
+	Actors []string        // actors to include (any of); empty matches all
+	Rig    string          // rig name filter (matches event's Rig field)
+	Format string          // FormatPlain (default) or FormatJSON
+	Color  bool            // colorize plain output by event category (callers check for a TTY/NO_COLOR)
+	Theme  SymbolTheme     // event symbols; nil uses the default emoji symbols
 	Ctx    context.Context // optional: controls follow-mode lifecycle; nil uses signal.NotifyContext
+	Out    io.Writer       // optional: where events are printed; nil means stdout
+
+	// IncludeRotated also reads rotated logs (.events.jsonl.1, .2, ...; see
+	// events.RotateEvents) so the limit can reach past the last rotation.
+	IncludeRotated bool
+
+	// Collapse coalesces runs of consecutive identical events (same type,
+	// actor and message) in the initial batch of plain output into one line
+	// with an "(xN, first-last)" suffix. Follow-mode events and JSON output
+	// are printed individually.
+	Collapse bool
+
+	// Spans pairs each patrol_started event in the initial batch of plain
+	// output with its patrol_complete (see patrolSpans) and prints the pair
+	// as one line with the elapsed time. Cannot be combined with Collapse.
+	Spans bool
+}
+
+// defaultFollowBacklog is how many recent events FollowGtEvents prints
+// before tailing (the gt feed --limit default).
+const defaultFollowBacklog = 100
+
+// followPollInterval is how often follow mode checks the events file.
+const followPollInterval = 200 * time.Millisecond
+
+// FollowGtEvents prints the most recent events from .events.jsonl to out,
+// then keeps printing events as they are appended, like tail -f, until ctx
+// is canceled. It survives the file being truncated or replaced (rotation).
+func FollowGtEvents(ctx context.Context, townRoot string, out io.Writer) error {
+	return PrintGtEvents(townRoot, PrintOptions{Limit: defaultFollowBacklog, Follow: true, Ctx: ctx, Out: out})
 }
 
-// PrintGtEvents reads .events.jsonl and prints events to stdout.
+// PrintGtEvents reads .events.jsonl and prints events to opts.Out (stdout).
 // When opts.Follow is true, it tails the file for new events after printing
 // the initial batch, polling every 200ms. Canceled via opts.Ctx or SIGINT.
 func PrintGtEvents(townRoot string, opts PrintOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
 	eventsPath := filepath.Join(townRoot, ".events.jsonl")
 	file, err := os.Open(eventsPath)
 	if err != nil {
 		return fmt.Errorf("no events file found at %s: %w", eventsPath, err)
 	}
-	defer file.Close()
+	tail := &eventTail{path: eventsPath, file: file}
+	defer func() { tail.file.Close() }()
 
-	// Parse --since into a cutoff time
-	var sinceTime time.Time
-	if opts.Since != "" {
-		dur, err := time.ParseDuration(opts.Since)
-		if err != nil {
-			return fmt.Errorf("invalid --since duration %q: %w", opts.Since, err)
+	var r io.Reader = file
+	if opts.IncludeRotated {
+		var readers []io.Reader
+		files := gtevents.EventsFiles(townRoot)
+		for _, path := range files[:len(files)-1] { // oldest first; the last is eventsPath
+			f, err := os.Open(path)
+			if os.IsNotExist(err) {
+				continue // rotated away since it was listed
+			}
+			if err != nil {
+				return fmt.Errorf("reading events: %w", err)
+			}
+			defer f.Close()
+			// Terminate each file so a missing final newline cannot join
+			// its last line to the next file's first.
+			readers = append(readers, f, strings.NewReader("\n"))
 		}
-		sinceTime = time.Now().Add(-dur)
+		r = io.MultiReader(append(readers, file)...)
 	}
 
-	var events []Event
-	scanner := bufio.NewScanner(file)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	if !opts.Follow {
+		return PrintGtEventsFromReader(r, opts)
+	}
+	if err := opts.printBatch(r); err != nil {
+		return err
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if event := parseGtEventLine(line); event != nil {
-			if matchesFilters(event, sinceTime, opts.Mol, opts.Type, opts.Rig) {
-				events = append(events, *event)
-			}
-		}
+	// Tail mode: poll for appended lines from where the scan above stopped.
+	ctx := opts.Ctx
+	if ctx == nil {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
 	}
 
-	if err := scanner.Err(); err != nil {
+	// printBatch read file to EOF.
+	if tail.offset, err = file.Seek(0, io.SeekCurrent); err != nil {
 		return fmt.Errorf("reading events: %w", err)
 	}
 
+	ticker := time.NewTicker(followPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			tail.poll(func(line string) {
+				if event := parseGtEventLine(line); event != nil {
+					if opts.matches(event) {
+						opts.print(*event)
+					}
+				}
+			})
+		}
+	}
+}
+
+// PrintGtEventsFromReader prints the events in r, which holds .events.jsonl
+// lines (e.g. a rotated log or another tool's output on stdin), to opts.Out
+// (stdout). Malformed lines are skipped as in PrintGtEvents. Follow is not
+// supported: a reader has no file to tail.
+func PrintGtEventsFromReader(r io.Reader, opts PrintOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if opts.Follow {
+		return fmt.Errorf("follow mode needs an events file, not a reader")
+	}
+	return opts.printBatch(r)
+}
+
+// validate checks the options and fills in defaults.
+func (opts *PrintOptions) validate() error {
+	switch opts.Format {
+	case "", FormatPlain, FormatJSON:
+	default:
+		return fmt.Errorf("invalid format %q (want %q or %q)", opts.Format, FormatPlain, FormatJSON)
+	}
+	if opts.Spans && opts.Collapse {
+		return fmt.Errorf("spans and collapse cannot be combined")
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		return fmt.Errorf("--until %s is before --since %s", opts.Until.Format(time.RFC3339), opts.Since.Format(time.RFC3339))
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	return nil
+}
+
+// printBatch prints the most recent matching events in r, oldest first,
+// reading r to EOF.
+func (opts PrintOptions) printBatch(r io.Reader) error {
+	// Filters are applied during the scan, and with a limit only the most
+	// recent matching events are kept (see recentEvents), so memory stays
+	// bounded by the limit rather than the input size.
+	recent := newRecentEvents(opts.Limit)
+	if err := scanGtEvents(r, func(event *Event) {
+		if opts.matches(event) {
+			recent.add(*event)
+		}
+	}); err != nil {
+		return err
+	}
+
+	events := recent.chronological()
+
+	if len(events) == 0 && !opts.Follow {
+		if opts.Format != FormatJSON {
+			fmt.Fprintln(opts.Out, "No events found")
+		}
+		return nil
+	}
+
+	if opts.Spans && opts.Format != FormatJSON {
+		for _, line := range patrolSpans(events) {
+			printEventLine(opts.Out, line.Event, line.suffix(), opts.Color, opts.Theme)
+		}
+		return nil
+	}
+	if opts.Collapse && opts.Format != FormatJSON {
+		for _, run := range collapseEvents(events) {
+			printEventLine(opts.Out, run.Event, run.suffix(), opts.Color, opts.Theme)
+		}
+		return nil
+	}
+	for _, event := range events {
+		opts.print(event)
+	}
+	return nil
+}
+
+// eventTail reads lines appended to the events file. It reopens the file
+// when it has been replaced (rotation) and rewinds when it has been
+// truncated, and holds back a trailing partial line until its newline
+// arrives.
+type eventTail struct {
+	path    string
+	file    *os.File
+	offset  int64  // bytes of file consumed so far
+	partial string // unterminated tail of the last read
+}
+
+// poll calls fn for each complete line appended since the last poll.
+func (t *eventTail) poll(fn func(line string)) {
+	t.read(fn)
+
+	st, err := os.Stat(t.path)
+	if err != nil {
+		return // mid-rotation; try again next tick
+	}
+	if fi, err := t.file.Stat(); err == nil && !os.SameFile(fi, st) {
+		// Rotated: the old file was drained above, continue with the new one.
+		f, err := os.Open(t.path)
+		if err != nil {
+			return
+		}
+		t.file.Close()
+		t.file, t.offset, t.partial = f, 0, ""
+		t.read(fn)
+		return
+	}
+	if st.Size() < t.offset {
+		// Truncated in place: start over from the beginning.
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+		t.offset, t.partial = 0, ""
+		t.read(fn)
+	}
+}
+
+// read consumes everything from the current offset to EOF.
+func (t *eventTail) read(fn func(line string)) {
+	data, _ := io.ReadAll(t.file) // a read error means no new data this tick
+	if len(data) == 0 {
+		return
+	}
+	t.offset += int64(len(data))
+	lines := strings.Split(t.partial+string(data), "\n")
+	t.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		fn(line)
+	}
+}
+
+// recentEvents collects the most recent limit events in O(limit) memory,
+// using a min-heap on event time that evicts the oldest event once full.
+// With limit <= 0 every event is kept — memory then grows with the input,
+// which is the price of an unbounded listing.
+type recentEvents struct {
+	limit int
+	seq   int
+	heap  eventHeap
+	all   []Event // limit <= 0 only
+}
+
+func newRecentEvents(limit int) *recentEvents {
+	return &recentEvents{limit: limit}
+}
+
+// add offers an event. Among events with equal times, later ones win.
+func (r *recentEvents) add(event Event) {
+	if r.limit <= 0 {
+		r.all = append(r.all, event)
+		return
+	}
+	r.seq++
+	item := seqEvent{Event: event, seq: r.seq}
+	if len(r.heap) < r.limit {
+		heap.Push(&r.heap, item)
+		return
+	}
+	if r.heap.less(r.heap[0], item) {
+		r.heap[0] = item
+		heap.Fix(&r.heap, 0)
+	}
+}
+
+// chronological returns the collected events oldest first.
+func (r *recentEvents) chronological() []Event {
+	if r.limit <= 0 {
+		return sortRecentEvents(r.all, 0)
+	}
+	events := make([]Event, len(r.heap))
+	for i := range events {
+		events[i] = heap.Pop(&r.heap).(seqEvent).Event
+	}
+	return events
+}
+
+// sortRecentEvents returns the limit most recent events (all if limit <= 0),
+// oldest first, by sorting the whole slice.
+func sortRecentEvents(events []Event, limit int) []Event {
 	// Sort by time descending (most recent first)
 	sort.Slice(events, func(i, j int) bool {
 		return events[i].Time.After(events[j].Time)
 	})
 
 	// Apply limit
-	if opts.Limit > 0 && len(events) > opts.Limit {
-		events = events[:opts.Limit]
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
 	}
 
 	// Reverse to show oldest first (chronological)
 	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
 		events[i], events[j] = events[j], events[i]
 	}
+	return events
+}
 
-	if len(events) == 0 && !opts.Follow {
-		fmt.Println("No events found in .events.jsonl")
-		return nil
-	}
+// seqEvent is an event with its arrival order, to break time ties.
+type seqEvent struct {
+	Event
+	seq int
+}
 
-	for _, event := range events {
-		printEvent(event)
-	}
+// eventHeap is a container/heap min-heap of events ordered by (time, seq).
+type eventHeap []seqEvent
 
-	if !opts.Follow {
-		return nil
+func (h eventHeap) less(a, b seqEvent) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.Before(b.Time)
 	}
+	return a.seq < b.seq
+}
 
-	// Tail mode: poll for new lines using a fresh scanner each tick.
-	// bufio.Scanner sets an internal 'done' flag after EOF and won't retry,
-	// so we must create a new scanner each poll cycle while preserving the
-	// file offset (os.File tracks position across scanner instances).
-	ctx := opts.Ctx
-	if ctx == nil {
-		var stop context.CancelFunc
-		ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt)
-		defer stop()
+func (h eventHeap) Len() int           { return len(h) }
+func (h eventHeap) Less(i, j int) bool { return h.less(h[i], h[j]) }
+func (h eventHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *eventHeap) Push(x any)        { *h = append(*h, x.(seqEvent)) }
+func (h *eventHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// scanGtEvents calls fn for each feed-visible event in r. Malformed and
+// non-feed lines are skipped (see parseGtEventLine).
+func scanGtEvents(r io.Reader, fn func(*Event)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		if event := parseGtEventLine(scanner.Text()); event != nil {
+			fn(event)
+		}
 	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+	return nil
+}
 
-	ticker := time.NewTicker(200 * time.Millisecond)
-	defer ticker.Stop()
+// matches reports whether an event passes all of the options' filters.
+// Filtering happens before the limit, so Limit keeps the N most recent
+// matching events.
+func (opts PrintOptions) matches(event *Event) bool {
+	if !opts.Until.IsZero() && event.Time.After(opts.Until) {
+		return false
+	}
+	return matchesFilters(event, opts.Since, opts.Mol, opts.Type, opts.Rig) &&
+		matchesAny(event.Type, opts.Types) &&
+		matchesAny(event.Actor, opts.Actors)
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-ticker.C:
-			s := bufio.NewScanner(file)
-			s.Buffer(make([]byte, 1024*1024), 1024*1024)
-			for s.Scan() {
-				line := s.Text()
-				if event := parseGtEventLine(line); event != nil {
-					if matchesFilters(event, sinceTime, opts.Mol, opts.Type, opts.Rig) {
-						printEvent(*event)
-					}
-				}
-			}
+// matchesAny reports whether value is one of allowed. An empty list matches everything.
+func matchesAny(value string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if value == a {
+			return true
 		}
 	}
+	return false
 }
 
 // matchesFilters checks whether an event passes the --since, --mol, --type, and --rig filters.
@@ -139,44 +426,350 @@ func matchesFilters(event *Event, sinceTime time.Time, mol, eventType, rig strin
 	return true
 }
 
-// printEvent formats and prints a single event line.
-func printEvent(event Event) {
-	symbol := typeSymbol(event.Type)
+// print prints a single event in the configured format.
+func (opts PrintOptions) print(event Event) {
+	if opts.Format == FormatJSON {
+		printEventJSON(opts.Out, event)
+		return
+	}
+	printEvent(opts.Out, event, opts.Color, opts.Theme)
+}
+
+// jsonEvent is the FormatJSON line format: the parsed event's normalized
+// fields plus the raw payload from .events.jsonl.
+type jsonEvent struct {
+	Time    string                 `json:"time"` // RFC3339
+	Type    string                 `json:"type"`
+	Actor   string                 `json:"actor"`
+	Target  string                 `json:"target,omitempty"`
+	Rig     string                 `json:"rig,omitempty"`
+	Message string                 `json:"message"`
+	Payload map[string]interface{} `json:"payload,omitempty"`
+}
+
+// eventPayload recovers event's payload from event.Raw; both line shapes
+// parseGtEventLine accepts keep it under "payload".
+func eventPayload(event Event) map[string]interface{} {
+	var ge GtEvent
+	_ = json.Unmarshal([]byte(event.Raw), &ge)
+	return ge.Payload
+}
+
+// newJSONEvent builds the JSON form of event.
+func newJSONEvent(event Event) jsonEvent {
+	return jsonEvent{
+		Time:    event.Time.Format(time.RFC3339),
+		Type:    event.Type,
+		Actor:   event.Actor,
+		Target:  event.Target,
+		Rig:     event.Rig,
+		Message: event.Message,
+		Payload: eventPayload(event),
+	}
+}
+
+// payloadDetailFields lists, for event types whose details matter at a
+// glance, the payload fields plain output appends to the message.
+var payloadDetailFields = map[string][]string{
+	"merge_started": {"branch"},
+	"merged":        {"branch"},
+	"merge_failed":  {"branch", "reason"},
+	"merge_skipped": {"branch", "reason"},
+	"sling":         {"target"},
+}
+
+// payloadDetail returns a compact " (key=value, ...)" suffix with event's
+// detail fields, skipping empty values and values the message already
+// shows. Other event types get no suffix.
+func payloadDetail(event Event) string {
+	fields := payloadDetailFields[event.Type]
+	if len(fields) == 0 {
+		return ""
+	}
+	payload := eventPayload(event)
+	var parts []string
+	for _, key := range fields {
+		if v := getPayloadString(payload, key); v != "" && !strings.Contains(event.Message, v) {
+			parts = append(parts, key+"="+v)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// printEventJSON prints a single event as one line of JSON.
+func printEventJSON(w io.Writer, event Event) {
+	data, err := json.Marshal(newJSONEvent(event))
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+// ANSI colors for event categories in plain output.
+const (
+	ansiReset  = "\033[0m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+// printEvent formats and prints a single event line, with a payload detail
+// suffix for the types in payloadDetailFields. With color, the symbol
+// and message are wrapped in the event category's color; the actor column
+// stays uncolored so its padding (and the line layout) is unchanged.
+func printEvent(w io.Writer, event Event, color bool, theme SymbolTheme) {
+	printEventLine(w, event, "", color, theme)
+}
+
+// printEventLine is printEvent with suffix appended to the message.
+func printEventLine(w io.Writer, event Event, suffix string, color bool, theme SymbolTheme) {
+	symbol, ansi := theme.symbol(event.Type), typeColor(event.Type)
 	ts := event.Time.Local().Format("15:04:05")
 	actor := event.Actor
 	if actor == "" {
 		actor = "system"
 	}
-	fmt.Printf("[%s] %s %-25s %s\n", ts, symbol, actor, event.Message)
+	message := event.Message + payloadDetail(event) + suffix
+	if color && ansi != "" {
+		symbol = ansi + symbol + ansiReset
+		message = ansi + message + ansiReset
+	}
+	fmt.Fprintf(w, "[%s] %s %-25s %s\n", ts, symbol, actor, message)
+}
+
+// eventRun is a run of consecutive identical events, see collapseEvents.
+type eventRun struct {
+	Event           // the first event of the run
+	count int       // events in the run
+	last  time.Time // time of the last event of the run
+}
+
+// suffix returns the " (xN, 15:04:05-15:06:10)" marker for runs of more
+// than one event, and "" otherwise.
+func (r eventRun) suffix() string {
+	if r.count < 2 {
+		return ""
+	}
+	return fmt.Sprintf(" (x%d, %s-%s)", r.count,
+		r.Time.Local().Format("15:04:05"), r.last.Local().Format("15:04:05"))
+}
+
+// collapseEvents groups consecutive events with the same type, actor and
+// message into runs. events must already be in chronological order.
+func collapseEvents(events []Event) []eventRun {
+	var runs []eventRun
+	for _, event := range events {
+		if n := len(runs); n > 0 {
+			prev := &runs[n-1]
+			if prev.Type == event.Type && prev.Actor == event.Actor && prev.Message == event.Message {
+				prev.count++
+				prev.last = event.Time
+				continue
+			}
+		}
+		runs = append(runs, eventRun{Event: event, count: 1, last: event.Time})
+	}
+	return runs
 }
 
 func typeSymbol(eventType string) string {
+	symbol, _ := typeStyle(eventType)
+	return symbol
+}
+
+func typeColor(eventType string) string {
+	_, color := typeStyle(eventType)
+	return color
+}
+
+// SymbolTheme maps event types to the symbols printed for them, replacing
+// the default emoji (which some terminals render as boxes). Types missing
+// from a theme use its "*" entry, or the default arrow.
+type SymbolTheme map[string]string
+
+// ASCIISymbolTheme uses two-character ASCII symbols, keeping columns aligned
+// in any terminal.
+var ASCIISymbolTheme = SymbolTheme{
+	"patrol_started":        "~>",
+	"patrol_complete":       "~.",
+	"polecat_nudged":        "!>",
+	"sling":                 "=>",
+	"handoff":               "<>",
+	"done":                  "ok",
+	"merged":                "ok",
+	"merge_failed":          "!!",
+	"create":                "+ ",
+	"complete":              "ok",
+	"fail":                  "!!",
+	"delete":                "- ",
+	"respawn":               "^^",
+	"respawn_paused":        "^|",
+	"respawn_resumed":       "^>",
+	"session_hung":          "..",
+	"gupp_violation":        "!h",
+	"wisp_alert":            "/!",
+	"mass_death":            "XX",
+	"heartbeat_staleness":   "<3",
+	"namepool_grown":        "^+",
+	"nudge_expired":         "x>",
+	"nudge_claim_reclaimed": "<-",
+	"*":                     "->",
+}
+
+// SymbolThemeNames lists the themes accepted by LookupSymbolTheme.
+var SymbolThemeNames = []string{"emoji", "ascii"}
+
+// LookupSymbolTheme returns the named symbol theme ("emoji" or "" for the
+// default, "ascii").
+func LookupSymbolTheme(name string) (SymbolTheme, error) {
+	switch name {
+	case "", "emoji":
+		return nil, nil
+	case "ascii":
+		return ASCIISymbolTheme, nil
+	}
+	return nil, fmt.Errorf("unknown theme %q (want one of %s)", name, strings.Join(SymbolThemeNames, ", "))
+}
+
+// symbol returns the theme's symbol for eventType. A nil theme is the
+// default emoji theme (typeSymbol).
+func (t SymbolTheme) symbol(eventType string) string {
+	if t == nil {
+		return typeSymbol(eventType)
+	}
+	if s, ok := t[eventType]; ok {
+		return s
+	}
+	if s, ok := t["*"]; ok {
+		return s
+	}
+	return typeSymbol("")
+}
+
+// typeStyle returns the symbol and ANSI color for an event type. Colors group
+// event categories: failures red, warnings yellow, completions green,
+// patrols cyan; everything else is uncolored.
+func typeStyle(eventType string) (symbol, color string) {
 	switch eventType {
 	case "patrol_started":
-		return "\U0001F989" // owl
+		return "\U0001F989", ansiCyan // owl
 	case "patrol_complete":
-		return "\U0001F989" // owl
+		return "\U0001F989", ansiCyan // owl
 	case "polecat_nudged":
-		return "\u26A1" // lightning
+		return "\u26A1", "" // lightning
 	case "sling":
-		return "\U0001F3AF" // target
+		return "\U0001F3AF", "" // target
 	case "handoff":
-		return "\U0001F91D" // handshake
+		return "\U0001F91D", "" // handshake
 	case "done":
-		return "\u2713" // checkmark
+		return "\u2713", ansiGreen // checkmark
 	case "merged":
-		return "\u2713"
+		return "\u2713", ansiGreen
 	case "merge_failed":
-		return "\u2717" // x
+		return "\u2717", ansiRed // x
 	case "create":
-		return "+"
+		return "+", ""
 	case "complete":
-		return "\u2713"
+		return "\u2713", ansiGreen
 	case "fail":
-		return "\u2717"
+		return "\u2717", ansiRed
 	case "delete":
-		return "\u2298" // circled minus
+		return "\u2298", "" // circled minus
+	case "respawn":
+		return "\u21BB", ansiYellow // clockwise open circle arrow
+	case "respawn_paused":
+		return "\u23F9", ansiRed // stop
+	case "respawn_resumed":
+		return "\u25B6", ansiGreen // play
+	case "session_hung":
+		return "\u23F8", ansiYellow // pause
+	case "gupp_violation":
+		return "\u2693", ansiRed // anchor
+	case "wisp_alert":
+		return "\u26A0", ansiYellow // warning sign
+	case "mass_death":
+		return "\u2620", ansiRed // skull and crossbones
+	case "heartbeat_staleness":
+		return "\u2665", ansiYellow // heart
+	case "namepool_grown":
+		return "\u2197", ansiYellow // north east arrow
+	case "nudge_expired":
+		return "\u231B", ansiYellow // hourglass
+	case "nudge_claim_reclaimed":
+		return "\u21BA", ansiYellow // anticlockwise arrow
 	default:
-		return "\u2192" // arrow
+		return "\u2192", "" // arrow
+	}
+}
+
+// patrolSpanKey returns the key pairing a patrol_started event with its
+// patrol_complete: the actor plus the payload's patrol_id, or the rig for
+// events emitted without one.
+func patrolSpanKey(event Event) string {
+	payload := eventPayload(event)
+	id := getPayloadString(payload, "patrol_id")
+	if id == "" {
+		id = "rig=" + getPayloadString(payload, "rig")
+	}
+	return event.Actor + "\x00" + id
+}
+
+// spanLine is one line of span output: a patrol_started event with its
+// matching patrol_complete (end), a patrol_started with none (open), or any
+// other event, printed as-is.
+type spanLine struct {
+	Event
+	end  *Event
+	open bool
+}
+
+// suffix returns " → <complete message> (elapsed)" for a paired span,
+// " (incomplete)" for an open one, and "" otherwise.
+func (l spanLine) suffix() string {
+	if l.open {
+		return " (incomplete)"
+	}
+	if l.end == nil {
+		return ""
+	}
+	var msg string
+	if l.end.Message != "" && l.end.Message != l.Message {
+		msg = " \u2192 " + l.end.Message
+	}
+	return fmt.Sprintf("%s (%s)", msg, l.end.Time.Sub(l.Time).Round(time.Second))
+}
+
+// patrolSpans folds each patrol_complete into the line of the
+// patrol_started it closes, matched by patrolSpanKey. Patrols of different
+// actors may interleave freely; when one key has several patrols open, a
+// complete closes the most recent (nested spans close inside out). A
+// complete with no open start (e.g. its start fell outside the limit) is
+// kept as its own line. events must already be in chronological order.
+func patrolSpans(events []Event) []spanLine {
+	lines := make([]spanLine, 0, len(events))
+	open := make(map[string][]int) // key -> indexes of open starts in lines
+	for _, event := range events {
+		switch event.Type {
+		case "patrol_started":
+			key := patrolSpanKey(event)
+			open[key] = append(open[key], len(lines))
+			lines = append(lines, spanLine{Event: event, open: true})
+			continue
+		case "patrol_complete":
+			key := patrolSpanKey(event)
+			if stack := open[key]; len(stack) > 0 {
+				i := stack[len(stack)-1]
+				open[key] = stack[:len(stack)-1]
+				end := event
+				lines[i].end, lines[i].open = &end, false
+				continue
+			}
+		}
+		lines = append(lines, spanLine{Event: event})
 	}
+	return lines
 }
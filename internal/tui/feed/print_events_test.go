@@ -1,8 +1,12 @@
 package feed
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
@@ -122,7 +126,7 @@ func TestPrintGtEvents_SinceFilter(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := PrintGtEvents(townRoot, PrintOptions{Limit: 100, Since: "5m"})
+	err := PrintGtEvents(townRoot, PrintOptions{Limit: 100, Since: now.Add(-5 * time.Minute)})
 
 	w.Close()
 	os.Stdout = oldStdout
@@ -175,6 +179,71 @@ func TestPrintGtEvents_TypeFilter(t *testing.T) {
 	}
 }
 
+func TestPrintGtEvents_Respawn(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "respawn", Actor: "hq-deacon", Visibility: "feed",
+			Payload: map[string]interface{}{"session": "hq-deacon", "trigger": "pane-died hook"}},
+	})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := PrintGtEvents(townRoot, PrintOptions{Limit: 10})
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("PrintGtEvents returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, _ := r.Read(buf)
+	output := string(buf[:n])
+
+	if !strings.Contains(output, typeSymbol("respawn")) {
+		t.Errorf("output missing respawn symbol: %q", output)
+	}
+	if !strings.Contains(output, "respawned hq-deacon (pane-died hook)") {
+		t.Errorf("output missing respawn message: %q", output)
+	}
+	if typeSymbol("respawn") == typeSymbol("unknown") {
+		t.Error("respawn should have its own symbol, not the default arrow")
+	}
+}
+
+func TestPrintGtEvents_WispAlert(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "wisp_alert", Actor: "daemon", Visibility: "feed",
+			Payload: map[string]interface{}{"open": float64(612), "threshold": float64(500), "databases": float64(3)}},
+	})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := PrintGtEvents(townRoot, PrintOptions{Limit: 10})
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("PrintGtEvents returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, _ := r.Read(buf)
+	output := string(buf[:n])
+
+	if !strings.Contains(output, typeSymbol("wisp_alert")) {
+		t.Errorf("output missing wisp_alert symbol: %q", output)
+	}
+	if !strings.Contains(output, "612 open wisps exceed threshold 500") {
+		t.Errorf("output missing wisp_alert message: %q", output)
+	}
+}
+
 func TestPrintGtEvents_NoEventsFile(t *testing.T) {
 	dir := t.TempDir() // no .events.jsonl
 	err := PrintGtEvents(dir, PrintOptions{Limit: 10})
@@ -217,19 +286,59 @@ func TestPrintGtEvents_VisibilityFiltering(t *testing.T) {
 	}
 }
 
-func TestPrintGtEvents_InvalidSinceDuration(t *testing.T) {
+func TestPrintGtEvents_UntilBeforeSince(t *testing.T) {
 	now := time.Now()
 	townRoot := writeTestEvents(t, []GtEvent{
 		{Timestamp: now.Format(time.RFC3339), Source: "test", Type: "create", Actor: "a", Visibility: "feed", Payload: map[string]interface{}{"message": "event"}},
 	})
 
-	err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Since: "notaduration"})
+	err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Since: now, Until: now.Add(-time.Hour)})
 	if err == nil {
-		t.Fatal("expected error for invalid --since duration")
+		t.Fatal("expected error for --until before --since")
 	}
-	if !strings.Contains(err.Error(), "invalid --since duration") {
-		t.Errorf("unexpected error: %v", err)
+}
+
+func TestPrintGtEvents_TimeWindow(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	ev := func(offset time.Duration, msg string) GtEvent {
+		return GtEvent{Timestamp: base.Add(offset).Format(time.RFC3339), Source: "test", Type: "create", Actor: "a", Visibility: "feed", Payload: map[string]interface{}{"message": msg}}
 	}
+	townRoot := writeTestEvents(t, []GtEvent{
+		ev(-time.Minute, "before"),
+		ev(0, "at-since"),
+		ev(30*time.Minute, "inside"),
+		ev(time.Hour, "at-until"),
+		ev(time.Hour+time.Second, "after"),
+	})
+
+	t.Run("bounds are inclusive", func(t *testing.T) {
+		lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 100, Since: base, Until: base.Add(time.Hour)})
+		got := strings.Join(lines, "\n")
+		for _, want := range []string{"at-since", "inside", "at-until"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("missing %q in window output:\n%s", want, got)
+			}
+		}
+		for _, unwanted := range []string{"before", "after"} {
+			if strings.Contains(got, unwanted) {
+				t.Errorf("%q is outside the window:\n%s", unwanted, got)
+			}
+		}
+	})
+
+	t.Run("until only", func(t *testing.T) {
+		lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 100, Until: base})
+		if len(lines) != 2 || !strings.Contains(lines[0], "before") || !strings.Contains(lines[1], "at-since") {
+			t.Errorf("expected before and at-since, got %q", lines)
+		}
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 100, Since: base.Add(10 * time.Minute), Until: base.Add(20 * time.Minute)})
+		if len(lines) != 1 || !strings.Contains(lines[0], "No events found") {
+			t.Errorf("expected no events, got %q", lines)
+		}
+	})
 }
 
 func TestPrintGtEvents_FollowStreamsAppended(t *testing.T) {
@@ -386,3 +495,849 @@ func TestMatchesFilters(t *testing.T) {
 		})
 	}
 }
+
+// capturePrintGtEvents runs PrintGtEvents and returns its non-empty output lines.
+func capturePrintGtEvents(t *testing.T, townRoot string, opts PrintOptions) []string {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := PrintGtEvents(townRoot, opts)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("PrintGtEvents returned error: %v", err)
+	}
+	buf := make([]byte, 8192)
+	n, _ := r.Read(buf)
+	return strings.Split(strings.TrimSpace(string(buf[:n])), "\n")
+}
+
+func TestPrintGtEvents_TypesAndActorsFilter(t *testing.T) {
+	now := time.Now()
+	ev := func(ago time.Duration, typ, actor, msg string) GtEvent {
+		return GtEvent{Timestamp: now.Add(-ago).Format(time.RFC3339), Source: "test", Type: typ, Actor: actor, Visibility: "feed", Payload: map[string]interface{}{"message": msg}}
+	}
+	townRoot := writeTestEvents(t, []GtEvent{
+		ev(6*time.Minute, "patrol_complete", "hq-deacon", "deacon patrol 1"),
+		ev(5*time.Minute, "patrol_complete", "gastown/witness", "witness patrol"),
+		ev(4*time.Minute, "sling", "hq-deacon", "deacon sling"),
+		ev(3*time.Minute, "patrol_complete", "hq-deacon", "deacon patrol 2"),
+		ev(2*time.Minute, "create", "hq-deacon", "deacon create"),
+		ev(1*time.Minute, "patrol_complete", "hq-deacon", "deacon patrol 3"),
+	})
+
+	t.Run("empty filters match all", func(t *testing.T) {
+		if lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 100}); len(lines) != 6 {
+			t.Errorf("expected 6 events, got %d: %q", len(lines), lines)
+		}
+	})
+
+	t.Run("multiple types", func(t *testing.T) {
+		lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 100, Types: []string{"sling", "create"}})
+		if len(lines) != 2 || !strings.Contains(lines[0], "slung") || !strings.Contains(lines[1], "deacon create") {
+			t.Errorf("expected sling then create, got %q", lines)
+		}
+	})
+
+	t.Run("type and actor", func(t *testing.T) {
+		lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 100, Types: []string{"patrol_complete"}, Actors: []string{"hq-deacon"}})
+		if len(lines) != 3 {
+			t.Fatalf("expected 3 deacon patrols, got %d: %q", len(lines), lines)
+		}
+		for _, line := range lines {
+			if strings.Contains(line, "witness") {
+				t.Errorf("actor filter should exclude witness, got: %s", line)
+			}
+		}
+	})
+
+	t.Run("limit applies after filtering", func(t *testing.T) {
+		lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 2, Types: []string{"patrol_complete"}, Actors: []string{"hq-deacon"}})
+		// The 2 most recent matching events, still oldest first.
+		if len(lines) != 2 || !strings.Contains(lines[0], "deacon patrol 2") || !strings.Contains(lines[1], "deacon patrol 3") {
+			t.Errorf("expected deacon patrols 2 and 3, got %q", lines)
+		}
+	})
+}
+
+func TestMatchesAny(t *testing.T) {
+	if !matchesAny("sling", nil) {
+		t.Error("empty list should match everything")
+	}
+	if !matchesAny("sling", []string{"create", "sling"}) {
+		t.Error("expected match")
+	}
+	if matchesAny("done", []string{"create", "sling"}) {
+		t.Error("unexpected match")
+	}
+}
+
+func TestPrintGtEvents_JSONFormat(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: ts.Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "gastown/crew/joe", Visibility: "feed", Payload: map[string]interface{}{"bead": "gt-abc", "target": "polecat-1"}},
+		{Timestamp: ts.Format(time.RFC3339), Source: "gt", Type: "audit", Actor: "x", Visibility: "audit"},
+	})
+	// Malformed lines are skipped, as in plain mode.
+	f, err := os.OpenFile(filepath.Join(townRoot, ".events.jsonl"), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{not json\n")
+	f.Close()
+
+	lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 10, Format: FormatJSON})
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 JSON line, got %d: %q", len(lines), lines)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("output is not JSON: %v: %s", err, lines[0])
+	}
+	if got["time"] != "2026-03-04T05:06:07Z" || got["type"] != "sling" || got["actor"] != "gastown/crew/joe" {
+		t.Errorf("unexpected fields: %v", got)
+	}
+	if msg, _ := got["message"].(string); msg == "" {
+		t.Errorf("message should be set: %v", got)
+	}
+	payload, _ := got["payload"].(map[string]interface{})
+	if payload["bead"] != "gt-abc" || payload["target"] != "polecat-1" {
+		t.Errorf("payload = %v, want raw payload", got["payload"])
+	}
+}
+
+func TestPrintGtEvents_JSONFormatNoEvents(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "a", Visibility: "feed"},
+	})
+	lines := capturePrintGtEvents(t, townRoot, PrintOptions{Limit: 10, Format: FormatJSON, Types: []string{"done"}})
+	if len(lines) != 1 || lines[0] != "" {
+		t.Errorf("expected no output, got %q", lines)
+	}
+}
+
+func TestPrintGtEvents_InvalidFormat(t *testing.T) {
+	townRoot := writeTestEvents(t, nil)
+	if err := PrintGtEvents(townRoot, PrintOptions{Format: "xml"}); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
+
+// syncBuffer is a strings.Builder safe for concurrent writes and reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitForOutput polls out until it contains want or the deadline passes.
+func waitForOutput(t *testing.T, out *syncBuffer, want string) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if strings.Contains(out.String(), want) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %q in output:\n%s", want, out.String())
+}
+
+func feedEventLine(t *testing.T, msg string) string {
+	t.Helper()
+	b, err := json.Marshal(GtEvent{
+		Timestamp: time.Now().Format(time.RFC3339), Source: "test", Type: "create",
+		Actor: "a", Visibility: "feed", Payload: map[string]interface{}{"message": msg},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b) + "\n"
+}
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFollowGtEvents_AppendTruncateRotate(t *testing.T) {
+	dir := t.TempDir()
+	eventsPath := filepath.Join(dir, ".events.jsonl")
+	if err := os.WriteFile(eventsPath, []byte(feedEventLine(t, "backlog")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() { done <- FollowGtEvents(ctx, dir, out) }()
+
+	waitForOutput(t, out, "backlog")
+
+	// Appended lines, including one written in two pieces.
+	appendFile(t, eventsPath, feedEventLine(t, "appended"))
+	waitForOutput(t, out, "appended")
+	split := feedEventLine(t, "split-write")
+	appendFile(t, eventsPath, split[:10])
+	time.Sleep(3 * followPollInterval)
+	appendFile(t, eventsPath, split[10:])
+	waitForOutput(t, out, "split-write")
+
+	// Truncated in place, then rewritten.
+	if err := os.WriteFile(eventsPath, []byte(feedEventLine(t, "after-truncate")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitForOutput(t, out, "after-truncate")
+
+	// Rotated: renamed away and replaced by a new file.
+	if err := os.Rename(eventsPath, eventsPath+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, eventsPath, feedEventLine(t, "after-rotate"))
+	waitForOutput(t, out, "after-rotate")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("FollowGtEvents returned %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("FollowGtEvents did not stop after cancel")
+	}
+
+	for _, msg := range []string{"backlog", "appended", "split-write", "after-truncate", "after-rotate"} {
+		if n := strings.Count(out.String(), msg); n != 1 {
+			t.Errorf("%q printed %d times, want 1:\n%s", msg, n, out.String())
+		}
+	}
+}
+
+func TestPrintGtEvents_Color(t *testing.T) {
+	ts := time.Now()
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: ts.Format(time.RFC3339), Source: "gt", Type: "merge_failed", Actor: "gastown/refinery", Visibility: "feed", Payload: map[string]interface{}{"message": "conflict"}},
+		{Timestamp: ts.Format(time.RFC3339), Source: "gt", Type: "patrol_complete", Actor: "hq-deacon", Visibility: "feed", Payload: map[string]interface{}{"message": "patrol done"}},
+		{Timestamp: ts.Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "mayor", Visibility: "feed", Payload: map[string]interface{}{"bead": "gt-1", "target": "p1"}},
+	})
+
+	// Without Color (what the CLI passes for non-TTY output), no escape codes leak.
+	var plain strings.Builder
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &plain}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(plain.String(), "\033[") {
+		t.Errorf("uncolored output contains ANSI escapes: %q", plain.String())
+	}
+
+	var colored strings.Builder
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &colored, Color: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := colored.String()
+	if !strings.Contains(out, ansiRed+"\u2717"+ansiReset) {
+		t.Errorf("merge_failed should be red: %q", out)
+	}
+	if !strings.Contains(out, ansiCyan+"patrol done"+ansiReset) {
+		t.Errorf("patrol_complete should be cyan: %q", out)
+	}
+
+	// Stripping the escapes gives back the exact uncolored layout.
+	stripped := out
+	for _, code := range []string{ansiReset, ansiRed, ansiGreen, ansiYellow, ansiCyan} {
+		stripped = strings.ReplaceAll(stripped, code, "")
+	}
+	if stripped != plain.String() {
+		t.Errorf("colored output layout differs:\ngot  %q\nwant %q", stripped, plain.String())
+	}
+}
+
+func TestPrintGtEvents_IncludeRotated(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	line := func(offset time.Duration, msg string) string {
+		b, _ := json.Marshal(GtEvent{Timestamp: base.Add(offset).Format(time.RFC3339), Source: "test", Type: "create", Actor: "a", Visibility: "feed", Payload: map[string]interface{}{"message": msg}})
+		return string(b) + "\n"
+	}
+	dir := t.TempDir()
+	eventsPath := filepath.Join(dir, ".events.jsonl")
+	os.WriteFile(eventsPath+".2", []byte(line(0, "oldest")+line(time.Minute, "older")), 0644)
+	os.WriteFile(eventsPath+".1", []byte(line(2*time.Minute, "middle")), 0644)
+	os.WriteFile(eventsPath, []byte(line(3*time.Minute, "newest")), 0644)
+
+	// By default only the current file is read.
+	if lines := capturePrintGtEvents(t, dir, PrintOptions{Limit: 10}); len(lines) != 1 || !strings.Contains(lines[0], "newest") {
+		t.Errorf("default: got %q, want only the current file", lines)
+	}
+
+	lines := capturePrintGtEvents(t, dir, PrintOptions{Limit: 10, IncludeRotated: true})
+	want := []string{"oldest", "older", "middle", "newest"}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(want), lines)
+	}
+	for i, w := range want {
+		if !strings.Contains(lines[i], w) {
+			t.Errorf("line %d = %q, want %q", i, lines[i], w)
+		}
+	}
+
+	// The limit reaches across files: the 3 most recent events.
+	lines = capturePrintGtEvents(t, dir, PrintOptions{Limit: 3, IncludeRotated: true})
+	if len(lines) != 3 || !strings.Contains(lines[0], "older") || !strings.Contains(lines[2], "newest") {
+		t.Errorf("limit 3: got %q", lines)
+	}
+}
+
+func TestPrintGtEvents_MixedSchemas(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	gtLine := func(offset time.Duration, msg string) string {
+		b, _ := json.Marshal(GtEvent{Timestamp: base.Add(offset).Format(time.RFC3339), Source: "test", Type: "create", Actor: "gastown/witness", Visibility: "feed", Payload: map[string]interface{}{"message": msg}})
+		return string(b)
+	}
+	at := func(offset time.Duration) string { return base.Add(offset).Format(time.RFC3339) }
+	lines := []string{
+		gtLine(3*time.Minute, "gt-form-late"),
+		// Event form with a message, as written by gt feed --json.
+		`{"time":"` + at(time.Minute) + `","type":"create","actor":"gastown/witness","message":"event-form-early"}`,
+		gtLine(0, "gt-form-first"),
+		// Event form with only a payload: the message is built from it.
+		`{"Time":"` + at(2*time.Minute) + `","Type":"create","Actor":"gastown/witness","payload":{"message":"event-form-payload"}}`,
+		// Neither schema: skipped.
+		`{"foo":"bar"}`,
+		`{"type":"create","message":"no time"}`,
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".events.jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("write events file: %v", err)
+	}
+
+	got := capturePrintGtEvents(t, dir, PrintOptions{Limit: 10})
+	want := []string{"gt-form-first", "event-form-early", "event-form-payload", "gt-form-late"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d: %q", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if !strings.Contains(got[i], w) {
+			t.Errorf("line %d = %q, want %q", i, got[i], w)
+		}
+	}
+}
+
+func TestParseGtEventLine_EventForm(t *testing.T) {
+	event := parseGtEventLine(`{"time":"2026-05-01T12:00:00Z","type":"sling","actor":"gastown/crew/joe","payload":{"bead":"gt-abc","target":"gastown/polecats/Toast"}}`)
+	if event == nil {
+		t.Fatal("parseGtEventLine returned nil for an Event-form line")
+	}
+	if event.Message != buildEventMessage("sling", map[string]interface{}{"bead": "gt-abc", "target": "gastown/polecats/Toast"}) {
+		t.Errorf("Message = %q, want it built from the payload", event.Message)
+	}
+	if event.Target != "gt-abc" || event.Rig != "gastown" || event.Role != "crew" {
+		t.Errorf("got Target=%q Rig=%q Role=%q, want gt-abc/gastown/crew", event.Target, event.Rig, event.Role)
+	}
+
+	// GtEvent lines keep their visibility filtering.
+	if parseGtEventLine(`{"ts":"2026-05-01T12:00:00Z","type":"create","visibility":"audit"}`) != nil {
+		t.Error("audit-only GtEvent line should be skipped")
+	}
+}
+
+func TestRecentEvents_MatchesFullSort(t *testing.T) {
+	base := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	var events []Event
+	for i := 0; i < 500; i++ {
+		events = append(events, Event{Time: base.Add(time.Duration(i) * time.Second), Message: fmt.Sprintf("e%d", i)})
+	}
+	rng := rand.New(rand.NewSource(1))
+	rng.Shuffle(len(events), func(i, j int) { events[i], events[j] = events[j], events[i] })
+
+	for _, limit := range []int{1, 7, 100, 499, 500, 1000, 0, -1} {
+		recent := newRecentEvents(limit)
+		for _, e := range events {
+			recent.add(e)
+		}
+		got := recent.chronological()
+		want := sortRecentEvents(append([]Event(nil), events...), limit)
+
+		if len(got) != len(want) {
+			t.Fatalf("limit %d: got %d events, want %d", limit, len(got), len(want))
+		}
+		for i := range want {
+			if got[i].Message != want[i].Message {
+				t.Fatalf("limit %d: event %d = %s, want %s", limit, i, got[i].Message, want[i].Message)
+			}
+		}
+	}
+}
+
+func TestRecentEvents_TiesKeepLatest(t *testing.T) {
+	ts := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	recent := newRecentEvents(2)
+	for _, msg := range []string{"a", "b", "c"} {
+		recent.add(Event{Time: ts, Message: msg})
+	}
+	got := recent.chronological()
+	if len(got) != 2 || got[0].Message != "b" || got[1].Message != "c" {
+		t.Errorf("got %v, want the last two of equal-time events in file order", got)
+	}
+}
+
+func benchmarkEventsFile(b *testing.B, n int) string {
+	b.Helper()
+	dir := b.TempDir()
+	f, err := os.Create(filepath.Join(dir, ".events.jsonl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	base := time.Now().Add(-time.Duration(n) * time.Second)
+	for i := 0; i < n; i++ {
+		line, _ := json.Marshal(GtEvent{Timestamp: base.Add(time.Duration(i) * time.Second).Format(time.RFC3339), Source: "bench", Type: "create", Actor: "a", Visibility: "feed", Payload: map[string]interface{}{"message": "event"}})
+		f.Write(append(line, '\n'))
+	}
+	f.Close()
+	return dir
+}
+
+func BenchmarkPrintGtEvents_Limit100(b *testing.B) {
+	dir := benchmarkEventsFile(b, 50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := PrintGtEvents(dir, PrintOptions{Limit: 100, Out: io.Discard}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPrintGtEvents_Unbounded(b *testing.B) {
+	dir := benchmarkEventsFile(b, 50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := PrintGtEvents(dir, PrintOptions{Out: io.Discard}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSymbolTheme(t *testing.T) {
+	// nil is the default emoji theme.
+	var emoji SymbolTheme
+	if got := emoji.symbol("done"); got != typeSymbol("done") {
+		t.Errorf("default done = %q, want %q", got, typeSymbol("done"))
+	}
+
+	ascii, err := LookupSymbolTheme("ascii")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for typ, want := range map[string]string{"done": "ok", "fail": "!!", "sling": "=>"} {
+		if got := ascii.symbol(typ); got != want {
+			t.Errorf("ascii %s = %q, want %q", typ, got, want)
+		}
+	}
+	for typ := range ASCIISymbolTheme {
+		if len(ASCIISymbolTheme[typ]) != 2 {
+			t.Errorf("ascii %s symbol %q should be 2 columns wide", typ, ASCIISymbolTheme[typ])
+		}
+	}
+
+	// Unknown types fall through to the theme's default, then the arrow.
+	if got := ascii.symbol("brand_new_type"); got != "->" {
+		t.Errorf("ascii unknown = %q, want ->", got)
+	}
+	if got := (SymbolTheme{"done": "D"}).symbol("brand_new_type"); got != "→" {
+		t.Errorf("partial theme unknown = %q, want default arrow", got)
+	}
+
+	if _, err := LookupSymbolTheme("klingon"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
+func TestPrintGtEvents_ASCIITheme(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "done", Actor: "gastown/crew/joe", Visibility: "feed", Payload: map[string]interface{}{"bead": "gt-1"}},
+	})
+	var out strings.Builder
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &out, Theme: ASCIISymbolTheme}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "] ok gastown/crew/joe") {
+		t.Errorf("expected ascii symbol in output: %q", out.String())
+	}
+	for _, r := range out.String() {
+		if r > 127 {
+			t.Errorf("non-ASCII rune %q in ascii-themed output: %q", r, out.String())
+			break
+		}
+	}
+}
+
+func TestPrintGtEvents_MassDeath(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "mass_death", Actor: "daemon", Visibility: "feed",
+			Payload: map[string]interface{}{
+				"count":    float64(3),
+				"window":   "30s",
+				"sessions": []interface{}{"gt-furiosa", "gt-nux", "gt-witness"},
+				"roles":    map[string]interface{}{"witness": float64(1), "polecat": float64(2)},
+			}},
+	})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := PrintGtEvents(townRoot, PrintOptions{Limit: 10})
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("PrintGtEvents returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, _ := r.Read(buf)
+	output := string(buf[:n])
+
+	if !strings.Contains(output, typeSymbol("mass_death")) || typeSymbol("mass_death") == typeSymbol("unknown") {
+		t.Errorf("output missing mass_death symbol: %q", output)
+	}
+	if !strings.Contains(output, "3 sessions died within 30s (2 polecat, 1 witness)") {
+		t.Errorf("output missing mass_death message: %q", output)
+	}
+}
+
+func TestPrintGtEvents_RespawnPause(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "respawn_paused", Actor: "daemon", Visibility: "feed",
+			Payload: map[string]interface{}{
+				"sessions": []interface{}{"gt-furiosa", "gt-nux"},
+				"count":    float64(2),
+				"pause":    "5m0s",
+			}},
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "respawn_resumed", Actor: "daemon", Visibility: "feed",
+			Payload: map[string]interface{}{
+				"sessions": []interface{}{"gt-furiosa"},
+				"count":    float64(1),
+			}},
+	})
+
+	var out bytes.Buffer
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &out}); err != nil {
+		t.Fatalf("PrintGtEvents returned error: %v", err)
+	}
+	output := out.String()
+
+	for _, typ := range []string{"respawn_paused", "respawn_resumed"} {
+		if !strings.Contains(output, typeSymbol(typ)) || typeSymbol(typ) == typeSymbol("unknown") {
+			t.Errorf("output missing %s symbol: %q", typ, output)
+		}
+	}
+	if !strings.Contains(output, "auto-respawn paused for 2 session(s) for 5m0s") {
+		t.Errorf("output missing respawn_paused message: %q", output)
+	}
+	if !strings.Contains(output, "auto-respawn resumed for 1 session(s)") {
+		t.Errorf("output missing respawn_resumed message: %q", output)
+	}
+}
+
+func TestPrintGtEvents_NudgeExpired(t *testing.T) {
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: time.Now().Format(time.RFC3339), Source: "gt", Type: "nudge_expired", Actor: "gt-nux", Visibility: "feed",
+			Payload: map[string]interface{}{
+				"session":  "gt-nux",
+				"sender":   "mayor",
+				"priority": "normal",
+				"reason":   "expired_on_requeue",
+			}},
+	})
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := PrintGtEvents(townRoot, PrintOptions{Limit: 10})
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("PrintGtEvents returned error: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, _ := r.Read(buf)
+	output := string(buf[:n])
+
+	if !strings.Contains(output, typeSymbol("nudge_expired")) || typeSymbol("nudge_expired") == typeSymbol("unknown") {
+		t.Errorf("output missing nudge_expired symbol: %q", output)
+	}
+	if !strings.Contains(output, "nudge from mayor to gt-nux dropped (expired on requeue)") {
+		t.Errorf("output missing nudge_expired message: %q", output)
+	}
+}
+
+func TestBuildEventMessage_NudgeClaimReclaimed(t *testing.T) {
+	tests := []struct {
+		payload map[string]interface{}
+		want    string
+	}{
+		{map[string]interface{}{"session": "gt-nux", "age": "6m0s", "requeued": true}, "nudge claim stale for 6m0s in gt-nux requeued"},
+		{map[string]interface{}{"session": "gt-nux", "age": "6m0s", "requeued": false}, "nudge claim stale for 6m0s in gt-nux removed (requeue failed)"},
+	}
+	for _, tt := range tests {
+		if got := buildEventMessage("nudge_claim_reclaimed", tt.payload); got != tt.want {
+			t.Errorf("buildEventMessage = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestBuildEventMessage_SessionHung(t *testing.T) {
+	payload := map[string]interface{}{"session": "gt-nux", "inactive": "45m0s", "frozen": "31m0s", "threshold": "30m0s"}
+	if got, want := buildEventMessage("session_hung", payload), "gt-nux hung (no output for 31m0s)"; got != want {
+		t.Errorf("buildEventMessage = %q, want %q", got, want)
+	}
+	if typeSymbol("session_hung") == typeSymbol("unknown") {
+		t.Error("session_hung has no symbol")
+	}
+}
+
+func TestBuildEventMessage_GUPPViolation(t *testing.T) {
+	payload := map[string]interface{}{"session": "gt-Toast", "agent": "gt-gastown-polecat-Toast", "hook_bead": "gt-abc", "elapsed": "42m0s", "pane": "> "}
+	if got, want := buildEventMessage("gupp_violation", payload), "GUPP violation: gt-gastown-polecat-Toast not progressing on gt-abc for 42m0s"; got != want {
+		t.Errorf("buildEventMessage = %q, want %q", got, want)
+	}
+	if typeSymbol("gupp_violation") == typeSymbol("unknown") {
+		t.Error("gupp_violation has no symbol")
+	}
+}
+
+func TestBuildEventMessage_NamepoolGrown(t *testing.T) {
+	tests := []struct {
+		payload map[string]interface{}
+		want    string
+	}{
+		{map[string]interface{}{"rig": "gastown", "from": float64(50), "to": float64(60), "max": float64(200)}, "gastown polecat namepool grew 50 → 60"},
+		{map[string]interface{}{"rig": "gastown", "from": float64(190), "to": float64(200), "max": float64(200)}, "gastown polecat namepool grew 190 → 200 (at cap)"},
+	}
+	for _, tt := range tests {
+		if got := buildEventMessage("namepool_grown", tt.payload); got != tt.want {
+			t.Errorf("buildEventMessage = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestPrintGtEvents_PayloadDetail(t *testing.T) {
+	now := time.Now()
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: now.Add(-2 * time.Minute).Format(time.RFC3339), Source: "gt", Type: "merge_failed", Actor: "gastown/refinery", Visibility: "feed",
+			Payload: map[string]interface{}{"mr": "gt-mr1", "worker": "nux", "branch": "polecat/nux", "reason": "conflict in go.mod"}},
+		{Timestamp: now.Add(-1 * time.Minute).Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "mayor", Visibility: "feed",
+			Payload: map[string]interface{}{"target": "gastown/polecats"}},
+		{Timestamp: now.Format(time.RFC3339), Source: "gt", Type: "done", Actor: "gastown/polecats/nux", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-abc", "branch": "polecat/nux"}},
+	})
+
+	var out bytes.Buffer
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &out}); err != nil {
+		t.Fatalf("PrintGtEvents: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), out.String())
+	}
+
+	// The reason is already in the message, so only the branch is added.
+	if !strings.HasSuffix(lines[0], "merge failed: conflict in go.mod (branch=polecat/nux)") {
+		t.Errorf("merge_failed line = %q, want branch suffix", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "work slung (target=gastown/polecats)") {
+		t.Errorf("sling line = %q, want target suffix", lines[1])
+	}
+	// Types without detail fields print just the message, as before.
+	if !strings.HasSuffix(lines[2], "done: gt-abc") || strings.Contains(lines[2], "(") {
+		t.Errorf("done line = %q, want the plain message", lines[2])
+	}
+}
+
+func TestPrintGtEvents_Collapse(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	nudge := func(at time.Duration) GtEvent {
+		return GtEvent{Timestamp: base.Add(at).Format(time.RFC3339), Source: "gt", Type: "nudge", Actor: "gastown/witness", Visibility: "feed",
+			Payload: map[string]interface{}{"target": "gastown/polecats/nux", "reason": "idle"}}
+	}
+	// Written out of order: the run only exists once sorted.
+	townRoot := writeTestEvents(t, []GtEvent{
+		nudge(2 * time.Minute),
+		{Timestamp: base.Add(3 * time.Minute).Format(time.RFC3339), Source: "gt", Type: "done", Actor: "gastown/polecats/nux", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-abc"}},
+		nudge(0),
+		nudge(time.Minute),
+	})
+
+	var plain, collapsed bytes.Buffer
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &plain}); err != nil {
+		t.Fatalf("PrintGtEvents: %v", err)
+	}
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &collapsed, Collapse: true}); err != nil {
+		t.Fatalf("PrintGtEvents (collapse): %v", err)
+	}
+
+	plainLines := strings.Split(strings.TrimSpace(plain.String()), "\n")
+	lines := strings.Split(strings.TrimSpace(collapsed.String()), "\n")
+	if len(plainLines) != 4 || len(lines) != 2 {
+		t.Fatalf("got %d plain and %d collapsed lines, want 4 and 2:\n%s\n%s", len(plainLines), len(lines), plain.String(), collapsed.String())
+	}
+	want := plainLines[0] + " (x3, 12:00:00-12:02:00)"
+	if lines[0] != want {
+		t.Errorf("collapsed line = %q, want %q", lines[0], want)
+	}
+	if lines[1] != plainLines[3] {
+		t.Errorf("single event line = %q, want unchanged %q", lines[1], plainLines[3])
+	}
+}
+
+func TestPrintGtEvents_CollapseWithoutDuplicates(t *testing.T) {
+	now := time.Now()
+	townRoot := writeTestEvents(t, []GtEvent{
+		{Timestamp: now.Add(-time.Minute).Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "mayor", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-abc", "target": "gastown/polecats"}},
+		{Timestamp: now.Format(time.RFC3339), Source: "gt", Type: "done", Actor: "gastown/polecats/nux", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-abc"}},
+	})
+
+	var plain, collapsed bytes.Buffer
+	_ = PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &plain})
+	_ = PrintGtEvents(townRoot, PrintOptions{Limit: 10, Out: &collapsed, Collapse: true})
+	if plain.String() != collapsed.String() {
+		t.Errorf("collapse changed output without duplicates:\n%s\nvs\n%s", plain.String(), collapsed.String())
+	}
+}
+
+func TestPrintGtEventsFromReader(t *testing.T) {
+	now := time.Now()
+	line := func(ev GtEvent) string {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	input := strings.Join([]string{
+		line(GtEvent{Timestamp: now.Add(-2 * time.Minute).Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "mayor", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-abc", "target": "gastown/polecats"}}),
+		`{"ts": "truncated`,
+		"not json at all",
+		"",
+		// Audit-only events are skipped like in the events file.
+		line(GtEvent{Timestamp: now.Add(-90 * time.Second).Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "mayor", Visibility: "audit"}),
+		// Longer than bufio.Scanner's 64KB default buffer.
+		line(GtEvent{Timestamp: now.Add(-time.Minute).Format(time.RFC3339), Source: "gt", Type: "done", Actor: "gastown/polecats/nux", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-big", "notes": strings.Repeat("x", 200*1024)}}),
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	if err := PrintGtEventsFromReader(strings.NewReader(input), PrintOptions{Limit: 10, Out: &out}); err != nil {
+		t.Fatalf("PrintGtEventsFromReader: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2 valid feed events:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[0], "gt-abc") || !strings.Contains(lines[1], "done: gt-big") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	if err := PrintGtEventsFromReader(strings.NewReader(input), PrintOptions{Follow: true, Out: &out}); err == nil {
+		t.Error("Follow on a reader: want error")
+	}
+}
+
+func TestPrintGtEvents_Spans(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	patrol := func(typ, actor, id, rig string, offset time.Duration, message string) GtEvent {
+		payload := map[string]interface{}{"rig": rig}
+		if id != "" {
+			payload["patrol_id"] = id
+		}
+		if message != "" {
+			payload["message"] = message
+		}
+		return GtEvent{Timestamp: base.Add(offset).Format(time.RFC3339), Source: "gt", Type: typ, Actor: actor, Visibility: "feed", Payload: payload}
+	}
+	townRoot := writeTestEvents(t, []GtEvent{
+		// Two witnesses patrol at once, interleaved.
+		patrol("patrol_started", "gastown/witness", "w1", "gastown", 0, ""),
+		patrol("patrol_started", "beads/witness", "b1", "beads", 10*time.Second, ""),
+		// The same actor overlaps two patrols, told apart by patrol_id.
+		patrol("patrol_started", "gastown/witness", "w2", "gastown", 20*time.Second, ""),
+		patrol("patrol_complete", "gastown/witness", "w1", "gastown", 90*time.Second, "All polecats healthy"),
+		{Timestamp: base.Add(100 * time.Second).Format(time.RFC3339), Source: "gt", Type: "sling", Actor: "mayor", Visibility: "feed",
+			Payload: map[string]interface{}{"bead": "gt-abc", "target": "gastown/polecats"}},
+		patrol("patrol_complete", "beads/witness", "b1", "beads", 2*time.Minute, ""),
+		// No patrol_id: nested spans on one rig pair inside out.
+		patrol("patrol_started", "hq-deacon", "", "hq", 3*time.Minute, "outer"),
+		patrol("patrol_started", "hq-deacon", "", "hq", 3*time.Minute+5*time.Second, "inner"),
+		patrol("patrol_complete", "hq-deacon", "", "hq", 3*time.Minute+15*time.Second, "inner done"),
+		patrol("patrol_complete", "hq-deacon", "", "hq", 4*time.Minute, "outer done"),
+		// A complete whose start is not in view stays on its own line.
+		patrol("patrol_complete", "refinery", "r0", "gastown", 5*time.Minute, "stray"),
+	})
+
+	var out bytes.Buffer
+	if err := PrintGtEvents(townRoot, PrintOptions{Limit: 20, Out: &out, Spans: true}); err != nil {
+		t.Fatalf("PrintGtEvents: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+
+	want := []string{
+		"gastown/witness           patrol started \u2192 All polecats healthy (1m30s)",
+		"beads/witness             patrol started \u2192 patrol complete (1m50s)",
+		"gastown/witness           patrol started (incomplete)",
+		"mayor",
+		"hq-deacon                 outer \u2192 outer done (1m0s)",
+		"hq-deacon                 inner \u2192 inner done (10s)",
+		"refinery                  stray",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i, w := range want {
+		if !strings.Contains(lines[i], w) {
+			t.Errorf("line %d = %q, want it to contain %q", i, lines[i], w)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "[12:00:00] "+typeSymbol("patrol_started")) {
+		t.Errorf("span line should carry the start's time and symbol: %q", lines[0])
+	}
+}
+
+func TestPrintGtEvents_SpansRejectsCollapse(t *testing.T) {
+	err := PrintGtEventsFromReader(strings.NewReader(""), PrintOptions{Out: io.Discard, Spans: true, Collapse: true})
+	if err == nil {
+		t.Error("expected an error combining Spans and Collapse")
+	}
+}
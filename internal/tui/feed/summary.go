@@ -0,0 +1,54 @@
+package feed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SummaryOptions filters the events SummarizeGtEvents counts. Zero values
+// mean no filtering, as in PrintOptions.
+type SummaryOptions struct {
+	Since  time.Time // only events at or after this time
+	Until  time.Time // only events at or before this time
+	Types  []string  // event types to include (any of)
+	Actors []string  // actors to include (any of)
+	Rig    string    // rig name filter
+}
+
+// SummarizeGtEvents counts the feed-visible events in .events.jsonl that
+// match opts, keyed by event type and then actor. Events without an actor
+// are counted under "system", matching the feed display.
+func SummarizeGtEvents(townRoot string, opts SummaryOptions) (map[string]map[string]int, error) {
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+		return nil, fmt.Errorf("--until %s is before --since %s", opts.Until.Format(time.RFC3339), opts.Since.Format(time.RFC3339))
+	}
+
+	eventsPath := filepath.Join(townRoot, ".events.jsonl")
+	file, err := os.Open(eventsPath)
+	if err != nil {
+		return nil, fmt.Errorf("no events file found at %s: %w", eventsPath, err)
+	}
+	defer file.Close()
+
+	filter := PrintOptions{Since: opts.Since, Until: opts.Until, Types: opts.Types, Actors: opts.Actors, Rig: opts.Rig}
+	counts := make(map[string]map[string]int)
+	err = scanGtEvents(file, func(event *Event) {
+		if !filter.matches(event) {
+			return
+		}
+		actor := event.Actor
+		if actor == "" {
+			actor = "system"
+		}
+		if counts[event.Type] == nil {
+			counts[event.Type] = make(map[string]int)
+		}
+		counts[event.Type][actor]++
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
@@ -0,0 +1,56 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeGtEvents(t *testing.T) {
+	now := time.Now()
+	ev := func(ago time.Duration, typ, actor string) GtEvent {
+		return GtEvent{Timestamp: now.Add(-ago).Format(time.RFC3339), Source: "test", Type: typ, Actor: actor, Visibility: "feed"}
+	}
+	townRoot := writeTestEvents(t, []GtEvent{
+		ev(3*time.Hour, "merge_failed", "gastown/refinery"),
+		ev(30*time.Minute, "merge_failed", "gastown/refinery"),
+		ev(20*time.Minute, "merge_failed", "gastown/refinery"),
+		ev(10*time.Minute, "merge_failed", ""), // no actor → "system"
+		ev(5*time.Minute, "custom_thing", "hq-deacon"),
+		{Timestamp: now.Format(time.RFC3339), Source: "test", Type: "merge_failed", Actor: "x", Visibility: "audit"},
+	})
+
+	counts, err := SummarizeGtEvents(townRoot, SummaryOptions{Since: now.Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := counts["merge_failed"]["gastown/refinery"]; got != 2 {
+		t.Errorf("merge_failed/refinery = %d, want 2 (3h-old event is outside the window)", got)
+	}
+	if got := counts["merge_failed"]["system"]; got != 1 {
+		t.Errorf("merge_failed/system = %d, want 1", got)
+	}
+	if _, ok := counts["merge_failed"]["x"]; ok {
+		t.Error("audit-only events should not be counted")
+	}
+	// Types without a typeSymbol entry are counted like any other.
+	if typeSymbol("custom_thing") != typeSymbol("") {
+		t.Fatal("test assumes custom_thing has no dedicated symbol")
+	}
+	if got := counts["custom_thing"]["hq-deacon"]; got != 1 {
+		t.Errorf("custom_thing/hq-deacon = %d, want 1", got)
+	}
+
+	counts, err = SummarizeGtEvents(townRoot, SummaryOptions{Types: []string{"custom_thing"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts["custom_thing"]["hq-deacon"] != 1 {
+		t.Errorf("type filter: counts = %v", counts)
+	}
+}
+
+func TestSummarizeGtEvents_NoFile(t *testing.T) {
+	if _, err := SummarizeGtEvents(t.TempDir(), SummaryOptions{}); err == nil {
+		t.Error("expected error for missing events file")
+	}
+}
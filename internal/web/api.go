@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -20,6 +21,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"golang.org/x/time/rate"
 )
 
 // CommandRequest is the JSON request body for /api/run.
@@ -62,6 +64,10 @@ type APIHandler struct {
 	optionsCacheMu   sync.RWMutex
 	// cmdSem limits concurrent command executions to prevent resource exhaustion.
 	cmdSem chan struct{}
+	// runLimiters holds a *rate.Limiter per client identity for /api/run.
+	// commandsPerMinute <= 0 disables rate limiting.
+	runLimiters       sync.Map
+	commandsPerMinute int
 	// csrfToken is validated on POST requests to prevent cross-site request forgery.
 	csrfToken string
 }
@@ -86,10 +92,44 @@ func NewAPIHandler(defaultRunTimeout, maxRunTimeout time.Duration, csrfToken str
 		defaultRunTimeout: defaultRunTimeout,
 		maxRunTimeout:     maxRunTimeout,
 		cmdSem:            make(chan struct{}, maxConcurrentCommands),
+		commandsPerMinute: config.DefaultWebMaxCommandsPerMinute,
 		csrfToken:         csrfToken,
 	}
 }
 
+// SetMaxCommandsPerMinute sets the per-client /api/run rate limit.
+// A value <= 0 disables rate limiting. Must be called before serving requests.
+func (h *APIHandler) SetMaxCommandsPerMinute(n int) {
+	h.commandsPerMinute = n
+}
+
+// allowRun reports whether the client identified by r may run another command,
+// consuming a token from its bucket. Each client may burst up to the full
+// per-minute allowance, refilling evenly over the minute.
+func (h *APIHandler) allowRun(r *http.Request) bool {
+	if h.commandsPerMinute <= 0 {
+		return true
+	}
+	key := clientIdentity(r)
+	if v, ok := h.runLimiters.Load(key); ok {
+		return v.(*rate.Limiter).Allow()
+	}
+	l := rate.NewLimiter(rate.Limit(float64(h.commandsPerMinute)/60), h.commandsPerMinute)
+	v, _ := h.runLimiters.LoadOrStore(key, l)
+	return v.(*rate.Limiter).Allow()
+}
+
+// clientIdentity returns the key used for per-client rate limiting: the
+// remote host without its ephemeral port, so every tab from one browser
+// shares a bucket.
+func clientIdentity(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // ServeHTTP routes API requests to the appropriate handler.
 func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// No CORS headers — the dashboard is served from the same origin.
@@ -151,6 +191,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // handleRun executes a gt command and returns the result.
 func (h *APIHandler) handleRun(w http.ResponseWriter, r *http.Request) {
+	if !h.allowRun(r) {
+		h.sendError(w, "Rate limit exceeded, try again shortly", http.StatusTooManyRequests)
+		return
+	}
+
 	var req CommandRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.sendError(w, "Invalid request body", http.StatusBadRequest)
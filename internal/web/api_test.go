@@ -338,6 +338,50 @@ func TestAPIHandler_Run_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestAPIHandler_Run_RateLimited(t *testing.T) {
+	handler := NewAPIHandler(30*time.Second, 60*time.Second, "test-token")
+	handler.SetMaxCommandsPerMinute(2)
+
+	run := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/run", bytes.NewBufferString(`{invalid json}`))
+		req.Header.Set("X-Dashboard-Token", "test-token")
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Burst allowance is consumed even by requests that fail later.
+	for i := 0; i < 2; i++ {
+		if code := run("10.0.0.1:5000"); code != http.StatusBadRequest {
+			t.Fatalf("request %d status = %d, want %d", i, code, http.StatusBadRequest)
+		}
+	}
+	// Same host on a different port shares the bucket.
+	if code := run("10.0.0.1:5001"); code != http.StatusTooManyRequests {
+		t.Errorf("over-limit status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+	// Other clients are unaffected.
+	if code := run("10.0.0.2:5000"); code != http.StatusBadRequest {
+		t.Errorf("other client status = %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestAPIHandler_Run_RateLimitDisabled(t *testing.T) {
+	handler := NewAPIHandler(30*time.Second, 60*time.Second, "test-token")
+	handler.SetMaxCommandsPerMinute(0)
+
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/run", bytes.NewBufferString(`{invalid json}`))
+		req.Header.Set("X-Dashboard-Token", "test-token")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("request %d status = %d, want %d", i, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
 func TestAPIHandler_Run_EmptyCommand(t *testing.T) {
 	handler := NewAPIHandler(30*time.Second, 60*time.Second, "test-token")
 
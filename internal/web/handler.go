@@ -455,8 +455,8 @@ func generateCSRFToken() string {
 }
 
 // NewDashboardMux creates an HTTP handler that serves both the dashboard and API.
-// webCfg may be nil, in which case defaults are used.
-func NewDashboardMux(fetcher ConvoyFetcher, webCfg *config.WebTimeoutsConfig) (http.Handler, error) {
+// webCfg and thresholds may be nil, in which case defaults are used.
+func NewDashboardMux(fetcher ConvoyFetcher, webCfg *config.WebTimeoutsConfig, thresholds *config.WebThresholds) (http.Handler, error) {
 	if webCfg == nil {
 		webCfg = config.DefaultWebTimeoutsConfig()
 	}
@@ -472,6 +472,7 @@ func NewDashboardMux(fetcher ConvoyFetcher, webCfg *config.WebTimeoutsConfig) (h
 	defaultRunTimeout := config.ParseDurationOrDefault(webCfg.DefaultRunTimeout, 30*time.Second)
 	maxRunTimeout := config.ParseDurationOrDefault(webCfg.MaxRunTimeout, 60*time.Second)
 	apiHandler := NewAPIHandler(defaultRunTimeout, maxRunTimeout, csrfToken)
+	apiHandler.SetMaxCommandsPerMinute(thresholds.MaxCommandsPerMinuteV())
 
 	// Create static file server from embedded files
 	staticFS, err := fs.Sub(staticFiles, "static")
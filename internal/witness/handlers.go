@@ -868,7 +868,9 @@ func slotOpenDecision(workDir, townRoot, rigName, polecatName, exitType string)
 	clonePath := filepath.Join(townRoot, rigName, "polecats", polecatName, rigName)
 	g := git.NewGit(clonePath)
 	targetRefs := witnessRecoveryTargetRefs(beads.New(beads.ResolveBeadsDir(workDir)), fields)
-	if branch, err := g.CurrentBranch(); err == nil {
+	// A detached HEAD has no branch to check against origin; treat it like a
+	// failed git check so the slot is not reused over unpreserved commits.
+	if branch, onBranch, err := g.CurrentBranch(); err == nil && onBranch {
 		input.Branch = branch
 		if status, err := g.CheckUncommittedWork(); err == nil {
 			input.GitDirty = !status.CleanExcludingRuntime()
@@ -1497,7 +1499,7 @@ func DetectZombiePolecats(bd *BdCli, workDir, rigName string, router *mail.Route
 	initRegistryFromTownRoot(townRoot)
 
 	// Load witness thresholds from config (fallback to compiled-in defaults).
-	witCfg := config.LoadOperationalConfig(townRoot).GetWitnessConfig()
+	witCfg := config.LoadOperationalConfigLayered(townRoot).GetWitnessConfig()
 
 	polecatsDir := filepath.Join(townRoot, rigName, "polecats")
 	entries, err := os.ReadDir(polecatsDir)
@@ -2122,7 +2124,7 @@ func DetectStalledPolecats(workDir, rigName string) *DetectStalledPolecatsResult
 	initRegistryFromTownRoot(townRoot)
 
 	// Load witness thresholds from config (fallback to compiled-in defaults).
-	witCfg := config.LoadOperationalConfig(townRoot).GetWitnessConfig()
+	witCfg := config.LoadOperationalConfigLayered(townRoot).GetWitnessConfig()
 	stallThreshold := witCfg.StartupStallThresholdD()
 	activityGrace := witCfg.StartupActivityGraceD()
 
@@ -2629,7 +2631,7 @@ func resetAbandonedBead(bd *BdCli, workDir, rigName, hookBead, polecatName strin
 	if trErr != nil || trRoot == "" {
 		trRoot = workDir
 	}
-	maxRespawns := config.LoadOperationalConfig(trRoot).GetWitnessConfig().MaxBeadRespawnsV()
+	maxRespawns := config.LoadOperationalConfigLayered(trRoot).GetWitnessConfig().MaxBeadRespawnsV()
 
 	// Guard: if the polecat's commit is already on the default branch,
 	// the work is done — close the bead instead of resetting for re-dispatch.
@@ -3330,8 +3332,8 @@ func activeMRGitSafe(workDir, rigName, polecatName string) bool {
 	}
 	clonePath := filepath.Join(townRoot, rigName, "polecats", polecatName, rigName)
 	g := git.NewGit(clonePath)
-	branch, err := g.CurrentBranch()
-	if err != nil || branch == "" {
+	branch, onBranch, err := g.CurrentBranch()
+	if err != nil || !onBranch || branch == "" {
 		return false
 	}
 	status, err := g.CheckUncommittedWork()
@@ -76,7 +76,7 @@ func ShouldBlockRespawn(workDir, beadID string) bool {
 	if err != nil || townRoot == "" {
 		townRoot = workDir
 	}
-	maxRespawns := config.LoadOperationalConfig(townRoot).GetWitnessConfig().MaxBeadRespawnsV()
+	maxRespawns := config.LoadOperationalConfigLayered(townRoot).GetWitnessConfig().MaxBeadRespawnsV()
 
 	// Cross-process flock to serialize with other witness instances.
 	unlock, flockErr := lock.FlockAcquire(beadRespawnStateFile(townRoot) + ".flock")